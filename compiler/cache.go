@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// buildCache maps each file and target which was generated to a hash of its
+// inputs.
+type buildCache struct {
+	mu     sync.Mutex
+	hashes map[string]string
+}
+
+// loadBuildCache reads the cache file, returning an empty cache if the file
+// is not set or does not exist.
+func loadBuildCache(file string) (*buildCache, error) {
+	cache := &buildCache{hashes: make(map[string]string)}
+	if file == "" {
+		return cache, nil
+	}
+	contents, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &cache.hashes); err != nil {
+		return nil, err
+	}
+	return cache, nil
+}
+
+func (c *buildCache) get(key string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hashes[key]
+}
+
+func (c *buildCache) set(key, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hashes[key] = hash
+}
+
+// save writes the cache to the file.
+func (c *buildCache) save(file string) error {
+	c.mu.Lock()
+	contents, err := json.MarshalIndent(c.hashes, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0777); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(contents, '\n'), 0644)
+}
+
+// cacheKey identifies a file generated for a target in the cache.
+func cacheKey(file string, target Target) string {
+	return file + "|" + target.Gen + "|" + target.Out
+}
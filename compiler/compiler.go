@@ -14,10 +14,17 @@
 package compiler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Workiva/frugal/compiler/generator"
 	"github.com/Workiva/frugal/compiler/generator/dartlang"
@@ -38,89 +45,238 @@ type Options struct {
 	DryRun  bool   // Do not generate code
 	Recurse bool   // Generate includes
 	Verbose bool   // Verbose mode
+
+	// IncludePaths are directories searched for includes, in addition to
+	// those listed by parser.IncludePathEnv.
+	IncludePaths []string
+}
+
+// Target is a language to generate and where to write it.
+type Target struct {
+	Gen string `yaml:"gen"` // Language to generate
+	Out string `yaml:"out"` // Output location for generated code
+}
+
+// BuildOptions contains compiler options for generating code for a set of
+// files and targets.
+type BuildOptions struct {
+	Files   []string // Frugal files to generate
+	Targets []Target // Languages to generate each file in
+	Delim   string   // Token delimiter for scope topics
+	DryRun  bool     // Do not generate code
+	Recurse bool     // Generate includes
+	Verbose bool     // Verbose mode
+
+	// IncludePaths are directories searched for includes, in addition to
+	// those listed by parser.IncludePathEnv.
+	IncludePaths []string
+
+	// Parallelism is the maximum number of files generated at once. Defaults
+	// to the number of CPUs.
+	Parallelism int
+
+	// CacheFile records a hash of the inputs of each file and target which
+	// was generated. Files whose inputs are unchanged since the last build
+	// are skipped. No caching is done if empty.
+	CacheFile string
+
+	// Now is the time of generation. Defaults to the current time.
+	Now time.Time
+}
+
+// BuildResult is the outcome of generating a file for a target.
+type BuildResult struct {
+	File    string // Frugal file which was generated
+	Gen     string // Language it was generated in
+	Skipped bool   // Inputs were unchanged since the last build
+	Err     error  // Error generating the file, if any
 }
 
 // Compile parses the Frugal IDL and generates code for it, returning an error
 // if something failed.
 func Compile(options Options) error {
-	var err error
-	defer globals.Reset()
-	globals.TopicDelimiter = options.Delim
-	globals.Gen = options.Gen
-	globals.Out = options.Out
-	globals.DryRun = options.DryRun
-	globals.Recurse = options.Recurse
-	globals.Verbose = options.Verbose
-	globals.FileDir = filepath.Dir(options.File)
-
-	absFile, err := filepath.Abs(options.File)
+	results, err := Build(BuildOptions{
+		Files:        []string{options.File},
+		Targets:      []Target{{Gen: options.Gen, Out: options.Out}},
+		Delim:        options.Delim,
+		DryRun:       options.DryRun,
+		Recurse:      options.Recurse,
+		Verbose:      options.Verbose,
+		IncludePaths: options.IncludePaths,
+		Parallelism:  1,
+		Now:          globals.Now,
+	})
 	if err != nil {
 		return err
 	}
+	return results[0].Err
+}
+
+// Build generates code for each of the files in each of the targets, in
+// parallel. Results are returned in file then target order. The returned
+// error is only set if the build cache could not be read or written.
+func Build(options BuildOptions) ([]*BuildResult, error) {
+	if options.Delim == "" {
+		options.Delim = "."
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	parallelism := options.Parallelism
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
 
-	frugal, err := parseFrugal(absFile)
+	cache, err := loadBuildCache(options.CacheFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return generateFrugal(frugal)
-}
+	b := &builder{
+		options: options,
+		cache:   cache,
+		parsed:  make(map[string]*parsedFile, len(options.Files)),
+		claimed: make(map[Target]map[string]bool, len(options.Targets)),
+	}
+	for _, file := range options.Files {
+		b.parsed[file] = &parsedFile{}
+	}
+	for _, target := range options.Targets {
+		b.claimed[target] = make(map[string]bool)
+	}
 
-// parseFrugal parses a frugal file.
-func parseFrugal(file string) (*parser.Frugal, error) {
-	if !exists(file) {
-		return nil, fmt.Errorf("Frugal file not found: %s\n", file)
+	results := make([]*BuildResult, 0, len(options.Files)*len(options.Targets))
+	for _, file := range options.Files {
+		for _, target := range options.Targets {
+			results = append(results, &BuildResult{File: file, Gen: target.Gen})
+		}
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i, result := range results {
+		target := options.Targets[i%len(options.Targets)]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(result *BuildResult, target Target) {
+			defer func() {
+				if r := recover(); r != nil {
+					result.Err = fmt.Errorf("%v", r)
+				}
+				<-sem
+				wg.Done()
+			}()
+			result.Skipped, result.Err = b.build(result.File, target)
+		}(result, target)
+	}
+	wg.Wait()
+
+	if options.CacheFile == "" || options.DryRun {
+		return results, nil
 	}
-	logv(fmt.Sprintf("Parsing %s", file))
-	return parser.ParseFrugal(file)
+	return results, b.cache.save(options.CacheFile)
+}
+
+// builder holds the state shared by the jobs of a build.
+type builder struct {
+	options BuildOptions
+	cache   *buildCache
+	parsed  map[string]*parsedFile
+
+	mu      sync.Mutex
+	claimed map[Target]map[string]bool
+}
+
+// parsedFile is a file parsed once and shared by each target generating it.
+// Generators must not modify the parse tree, since targets may generate it
+// concurrently.
+type parsedFile struct {
+	once   sync.Once
+	frugal *parser.Frugal
+	err    error
+}
+
+// parse returns the parse tree of the file, parsing it on first use.
+func (b *builder) parse(file string) (*parser.Frugal, error) {
+	parsed := b.parsed[file]
+	parsed.once.Do(func() {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			parsed.err = err
+			return
+		}
+		includePaths := append(append([]string{}, b.options.IncludePaths...), parser.EnvIncludePaths()...)
+		parsed.frugal, parsed.err = parseFrugal(absFile, includePaths, b.options.Verbose)
+	})
+	return parsed.frugal, parsed.err
 }
 
-// generateFrugal generates code for a frugal struct.
-func generateFrugal(f *parser.Frugal) error {
-	var gen = globals.Gen
+// build generates code for a file in a target, returning true if it was
+// skipped because its inputs are unchanged.
+func (b *builder) build(file string, target Target) (bool, error) {
+	frugal, err := b.parse(file)
+	if err != nil {
+		return false, err
+	}
 
-	lang, options, err := cleanGenParam(gen)
+	lang, langOptions, err := cleanGenParam(target.Gen)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	// Resolve Frugal generator.
-	g, err := getProgramGenerator(lang, options)
+	g, err := getProgramGenerator(lang, langOptions, generator.Config{
+		TopicDelimiter: b.options.Delim,
+		Out:            target.Out,
+		Now:            b.options.Now,
+	})
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	var key, hash string
+	if b.options.CacheFile != "" && !b.options.DryRun {
+		key = cacheKey(frugal.Path, target)
+		if hash, err = b.hashInputs(frugal, target); err != nil {
+			return false, err
+		}
+		if b.cache.get(key) == hash && exists(g.GetOutputDir(b.outDir(g, target), frugal)) {
+			logv(b.options.Verbose, fmt.Sprintf("Skipping unchanged %s", frugal.File))
+			return true, nil
+		}
 	}
 
 	// The parsed frugal contains everything needed to generate
-	if err := generateFrugalRec(f, g, true, lang); err != nil {
-		return err
+	if err := b.generateFrugalRec(frugal, g, target, true, lang); err != nil {
+		return false, err
 	}
 
-	return nil
+	if key != "" {
+		b.cache.set(key, hash)
+	}
+	return false, nil
 }
 
 // generateFrugalRec generates code for a frugal struct, recursively generating
 // code for includes
-func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate bool, lang string) error {
-	if _, ok := globals.CompiledFiles[f.File]; ok {
-		// Already generated this file
-		return nil
-	}
-	globals.CompiledFiles[f.File] = f
-
-	out := globals.Out
-	if out == "" {
-		out = g.DefaultOutputDir()
-	}
+func (b *builder) generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, target Target, generate bool, lang string) error {
+	out := b.outDir(g, target)
 	fullOut := g.GetOutputDir(out, f)
 	if err := os.MkdirAll(out, 0777); err != nil {
 		return err
 	}
 
-	logv(fmt.Sprintf("Generating \"%s\" Frugal code for %s", lang, f.File))
-	if globals.DryRun || !generate {
+	if b.options.DryRun || !generate {
+		logv(b.options.Verbose, fmt.Sprintf("Generating \"%s\" Frugal code for %s", lang, f.File))
+		return nil
+	}
+
+	if !b.claim(target, f.File) {
+		// Already generated this file
 		return nil
 	}
 
+	logv(b.options.Verbose, fmt.Sprintf("Generating \"%s\" Frugal code for %s", lang, f.File))
 	if err := g.Generate(f, fullOut); err != nil {
 		return err
 	}
@@ -133,7 +289,7 @@ func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate
 			continue
 		}
 		inclFrugal := f.ParsedIncludes[include.Name]
-		if err := generateFrugalRec(inclFrugal, g, globals.Recurse, lang); err != nil {
+		if err := b.generateFrugalRec(inclFrugal, g, target, b.options.Recurse, lang); err != nil {
 			return err
 		}
 	}
@@ -141,13 +297,79 @@ func generateFrugalRec(f *parser.Frugal, g generator.ProgramGenerator, generate
 	return nil
 }
 
+// claim marks the file as generated for the target, returning false if it
+// already was.
+func (b *builder) claim(target Target, file string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.claimed[target][file] {
+		return false
+	}
+	b.claimed[target][file] = true
+	return true
+}
+
+// outDir returns the root output directory for the target.
+func (b *builder) outDir(g generator.ProgramGenerator, target Target) string {
+	if target.Out == "" {
+		return g.DefaultOutputDir()
+	}
+	return target.Out
+}
+
+// hashInputs returns a hash of everything which affects the code generated
+// for the frugal in the target: the compiler version, the generation options
+// and the contents of the file and everything it includes.
+func (b *builder) hashInputs(f *parser.Frugal, target Target) (string, error) {
+	files := make(map[string]bool)
+	var collect func(*parser.Frugal)
+	collect = func(f *parser.Frugal) {
+		if files[f.Path] {
+			return
+		}
+		files[f.Path] = true
+		for _, include := range f.ParsedIncludes {
+			collect(include)
+		}
+	}
+	collect(f)
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%t\x00", globals.Version, target.Gen, target.Out, b.options.Delim, b.options.Recurse)
+	for _, path := range paths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(contents))
+		h.Write(contents)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// parseFrugal parses a frugal file, searching the given include paths for its
+// includes.
+func parseFrugal(file string, includePaths []string, verbose bool) (*parser.Frugal, error) {
+	if !exists(file) {
+		return nil, fmt.Errorf("Frugal file not found: %s\n", file)
+	}
+	logv(verbose, fmt.Sprintf("Parsing %s", file))
+	return parser.ParseFrugalWithOptions(file, parser.ParseOptions{IncludePaths: includePaths})
+}
+
 // getProgramGenerator resolves the ProgramGenerator for the given language. It
 // returns an error if the language is not supported.
-func getProgramGenerator(lang string, options map[string]string) (generator.ProgramGenerator, error) {
+func getProgramGenerator(lang string, options map[string]string, config generator.Config) (generator.ProgramGenerator, error) {
 	var g generator.ProgramGenerator
 	switch lang {
 	case "dart":
-		g = generator.NewProgramGenerator(dartlang.NewGenerator(options), false)
+		g = newProgramGenerator(dartlang.NewGenerator(options), config, false)
 	case "go":
 		// Make sure the package prefix ends with a "/"
 		if package_prefix, ok := options["package_prefix"]; ok {
@@ -156,11 +378,11 @@ func getProgramGenerator(lang string, options map[string]string) (generator.Prog
 			}
 		}
 
-		g = generator.NewProgramGenerator(golang.NewGenerator(options), false)
+		g = newProgramGenerator(golang.NewGenerator(options), config, false)
 	case "java":
-		g = generator.NewProgramGenerator(java.NewGenerator(options), true)
+		g = newProgramGenerator(java.NewGenerator(options), config, true)
 	case "py":
-		g = generator.NewProgramGenerator(python.NewGenerator(options), true)
+		g = newProgramGenerator(python.NewGenerator(options), config, true)
 	case "html":
 		g = html.NewGenerator(options)
 	default:
@@ -169,6 +391,13 @@ func getProgramGenerator(lang string, options map[string]string) (generator.Prog
 	return g, nil
 }
 
+// newProgramGenerator configures the language generator and wraps it in a
+// ProgramGenerator.
+func newProgramGenerator(g generator.LanguageGenerator, config generator.Config, splitPublisherSubscriber bool) generator.ProgramGenerator {
+	g.SetConfig(config)
+	return generator.NewProgramGenerator(g, splitPublisherSubscriber)
+}
+
 // exists determines if the file at the given path exists.
 func exists(path string) bool {
 	_, err := os.Stat(path)
@@ -206,8 +435,8 @@ func cleanGenParam(gen string) (lang string, options map[string]string, err erro
 }
 
 // logv prints the message if in verbose mode.
-func logv(msg string) {
-	if globals.Verbose {
+func logv(verbose bool, msg string) {
+	if verbose {
 		fmt.Println(msg)
 	}
 }
@@ -0,0 +1,274 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package format prints Frugal IDL in a canonical form.
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const indentation = "    "
+
+// Format parses the given IDL source and returns it in canonical form.
+// Comments and doc strings are preserved. Includes are not resolved, so the
+// source only needs to be syntactically valid.
+func Format(filePath string, contents []byte) ([]byte, error) {
+	frugal, err := parser.ParseSource(filePath, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPrinter(contents, frugal.Comments)
+	nodes := definitions(frugal)
+	for i, n := range nodes {
+		end := len(p.src)
+		if i+1 < len(nodes) {
+			end = p.start(nodes[i+1].pos, nodes[i+1].doc)
+		}
+		forceBlank := i > 0 && (n.kind != nodes[i-1].kind || n.block)
+		n.print(p, end, forceBlank)
+	}
+	p.flushComments(len(p.src), 0, false)
+	return p.bytes(), nil
+}
+
+// definition is a top-level IDL definition.
+type definition struct {
+	kind  string
+	block bool
+	pos   parser.Pos
+	doc   []string
+	print func(p *printer, end int, forceBlank bool)
+}
+
+// definitions returns the top-level definitions of the Frugal in source
+// order.
+func definitions(f *parser.Frugal) []*definition {
+	defs := []*definition{}
+	add := func(kind string, pos parser.Pos, doc []string, line func() string) {
+		defs = append(defs, &definition{kind: kind, pos: pos, doc: doc,
+			print: func(p *printer, end int, forceBlank bool) {
+				p.node(pos, doc, 0, forceBlank, line())
+			},
+		})
+	}
+	addBlock := func(kind string, pos parser.Pos, doc []string, header, footer string, members []member) {
+		defs = append(defs, &definition{kind: kind, block: true, pos: pos, doc: doc,
+			print: func(p *printer, end int, forceBlank bool) {
+				p.block(pos, doc, forceBlank, header, footer, members, p.blockEnd(pos, end))
+			},
+		})
+	}
+
+	for _, include := range f.Includes {
+		include := include
+		add("include", include.Pos, include.Comment, func() string {
+			return "include " + strconv.Quote(include.Value) + annotations(include.Annotations)
+		})
+	}
+	for _, namespace := range f.Namespaces {
+		namespace := namespace
+		add("namespace", namespace.Pos, namespace.Comment, func() string {
+			return fmt.Sprintf("namespace %s %s%s", namespace.Scope, namespace.Value, annotations(namespace.Annotations))
+		})
+	}
+	for _, typedef := range f.Typedefs {
+		typedef := typedef
+		add("typedef", typedef.Pos, typedef.Comment, func() string {
+			return fmt.Sprintf("typedef %s %s%s", typeName(typedef.Type), typedef.Name, annotations(typedef.Annotations))
+		})
+	}
+	for _, constant := range f.Constants {
+		constant := constant
+		add("const", constant.Pos, constant.Comment, func() string {
+			return fmt.Sprintf("const %s %s = %s%s", typeName(constant.Type), constant.Name,
+				value(constant.Value), annotations(constant.Annotations))
+		})
+	}
+	for _, enum := range f.Enums {
+		members := make([]member, len(enum.Values))
+		for i, v := range enum.Values {
+			members[i] = member{v.Pos, v.Comment,
+				fmt.Sprintf("%s = %d%s,", v.Name, v.Value, annotations(v.Annotations))}
+		}
+		addBlock("enum", enum.Pos, enum.Comment, "enum "+enum.Name+" {", "}"+annotations(enum.Annotations), members)
+	}
+	for _, s := range append(append(append([]*parser.Struct{}, f.Structs...), f.Exceptions...), f.Unions...) {
+		members := make([]member, len(s.Fields))
+		for i, field := range s.Fields {
+			members[i] = member{field.Pos, field.Comment, fieldString(field, s.Type != parser.StructTypeUnion) + ","}
+		}
+		header := fmt.Sprintf("%s %s {", s.Type, s.Name)
+		addBlock(s.Type.String(), s.Pos, s.Comment, header, "}"+annotations(s.Annotations), members)
+	}
+	for _, service := range f.Services {
+		members := make([]member, len(service.Methods))
+		for i, method := range service.Methods {
+			members[i] = member{method.Pos, method.Comment, methodString(method)}
+		}
+		header := "service " + service.Name
+		if service.Extends != "" {
+			header += " extends " + service.Extends
+		}
+		addBlock("service", service.Pos, service.Comment, header+" {", "}"+annotations(service.Annotations), members)
+	}
+	for _, scope := range f.Scopes {
+		members := make([]member, len(scope.Operations))
+		for i, op := range scope.Operations {
+			members[i] = member{op.Pos, op.Comment,
+				fmt.Sprintf("%s: %s%s", op.Name, typeName(op.Type), annotations(op.Annotations))}
+		}
+		header := "scope " + scope.Name
+		if scope.Prefix != nil && scope.Prefix.String != "" {
+			header += " prefix " + scope.Prefix.String
+		}
+		addBlock("scope", scope.Pos, scope.Comment, header+" {", "}"+annotations(scope.Annotations), members)
+	}
+
+	sort.SliceStable(defs, func(i, j int) bool {
+		return before(defs[i].pos, defs[j].pos)
+	})
+	return defs
+}
+
+// member is a single-line definition within a block, such as a field.
+type member struct {
+	pos  parser.Pos
+	doc  []string
+	line string
+}
+
+func before(a, b parser.Pos) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Col < b.Col)
+}
+
+func fieldString(field *parser.Field, withModifier bool) string {
+	modifier := ""
+	if withModifier {
+		switch field.Modifier {
+		case parser.Required:
+			modifier = "required "
+		case parser.Optional:
+			modifier = "optional "
+		}
+	}
+	def := ""
+	if field.Default != nil {
+		def = " = " + value(field.Default)
+	}
+	return fmt.Sprintf("%d: %s%s %s%s%s", field.ID, modifier, typeName(field.Type), field.Name, def,
+		annotations(field.Annotations))
+}
+
+func methodString(method *parser.Method) string {
+	var buf bytes.Buffer
+	if method.Oneway {
+		buf.WriteString("oneway ")
+	}
+	returnType := "void"
+	if method.ReturnType != nil {
+		returnType = typeName(method.ReturnType)
+	}
+	fmt.Fprintf(&buf, "%s %s(%s)", returnType, method.Name, fieldList(method.Arguments, true))
+	if len(method.Exceptions) > 0 {
+		// Exceptions are always optional, so the modifier is omitted.
+		fmt.Fprintf(&buf, " throws (%s)", fieldList(method.Exceptions, false))
+	}
+	buf.WriteString(annotations(method.Annotations))
+	return buf.String()
+}
+
+func fieldList(fields []*parser.Field, withModifier bool) string {
+	strs := make([]string, len(fields))
+	for i, field := range fields {
+		strs[i] = fieldString(field, withModifier)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func typeName(t *parser.Type) string {
+	var name string
+	switch t.Name {
+	case "map":
+		name = fmt.Sprintf("map<%s, %s>", typeName(t.KeyType), typeName(t.ValueType))
+	case "list":
+		name = fmt.Sprintf("list<%s>", typeName(t.ValueType))
+	case "set":
+		name = fmt.Sprintf("set<%s>", typeName(t.ValueType))
+	default:
+		name = t.Name
+	}
+	return name + annotations(t.Annotations)
+}
+
+func annotations(anns parser.Annotations) string {
+	if len(anns) == 0 {
+		return ""
+	}
+	strs := make([]string, len(anns))
+	for i, ann := range anns {
+		strs[i] = ann.Name
+		if ann.Value != "" {
+			strs[i] += "=" + strconv.Quote(ann.Value)
+		}
+	}
+	return " (" + strings.Join(strs, ", ") + ")"
+}
+
+func value(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case parser.Identifier:
+		return string(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		// Doubles must contain a decimal point to parse as such.
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.Contains(s, ".") {
+			if i := strings.IndexAny(s, "e"); i >= 0 {
+				s = s[:i] + ".0" + s[i:]
+			} else {
+				s += ".0"
+			}
+		}
+		return s
+	case []interface{}:
+		strs := make([]string, len(v))
+		for i, elem := range v {
+			strs[i] = value(elem)
+		}
+		return "[" + strings.Join(strs, ", ") + "]"
+	case []parser.KeyValue:
+		strs := make([]string, len(v))
+		for i, kv := range v {
+			strs[i] = value(kv.Key) + ": " + value(kv.Value)
+		}
+		return "{" + strings.Join(strs, ", ") + "}"
+	case nil:
+		// The parser represents empty maps as nil.
+		return "{}"
+	default:
+		panic(fmt.Sprintf("format: unexpected constant value %#v", v))
+	}
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// printer writes formatted lines, interleaving the source comments which
+// precede each definition.
+type printer struct {
+	src        []rune
+	lineStarts []int
+	comments   []*parser.SourceComment
+	lines      []string
+	opened     bool // The last line opened a block
+	commented  bool // The last line ends in a line comment
+}
+
+func newPrinter(contents []byte, comments []*parser.SourceComment) *printer {
+	p := &printer{src: []rune(string(contents)), lineStarts: []int{0}, comments: comments}
+	for i, r := range p.src {
+		if r == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+	return p
+}
+
+func (p *printer) bytes() []byte {
+	if len(p.lines) == 0 {
+		return []byte{}
+	}
+	return []byte(strings.Join(p.lines, "\n") + "\n")
+}
+
+// offset returns the index in the source of the given position.
+func (p *printer) offset(pos parser.Pos) int {
+	if pos.Line < 1 || pos.Line > len(p.lineStarts) {
+		return len(p.src)
+	}
+	return p.lineStarts[pos.Line-1] + pos.Col - 1
+}
+
+// start returns the offset at which a definition begins, including its doc
+// string.
+func (p *printer) start(pos parser.Pos, doc []string) int {
+	off := p.offset(pos)
+	if len(doc) > 0 {
+		if i := strings.LastIndex(string(p.src[:off]), "/**@"); i >= 0 {
+			return len([]rune(string(p.src[:off])[:i]))
+		}
+	}
+	return off
+}
+
+// line returns the 1-based line number of the given offset.
+func (p *printer) line(off int) int {
+	for i := len(p.lineStarts) - 1; i >= 0; i-- {
+		if p.lineStarts[i] <= off {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// blankBefore indicates if the source line preceding the given offset is
+// blank.
+func (p *printer) blankBefore(off int) bool {
+	line := p.line(off)
+	if line < 2 {
+		return false
+	}
+	prev := p.src[p.lineStarts[line-2] : p.lineStarts[line-1]-1]
+	return strings.TrimSpace(string(prev)) == ""
+}
+
+// blockEnd returns the offset of the closing brace of the block definition at
+// pos, which is the last brace outside of comments and strings before end.
+func (p *printer) blockEnd(pos parser.Pos, end int) int {
+	closing := end
+	comment := 0
+	for i := p.offset(pos); i < end; i++ {
+		for comment < len(p.comments) && p.offset(p.comments[comment].Pos) < i {
+			comment++
+		}
+		if comment < len(p.comments) && p.offset(p.comments[comment].Pos) == i {
+			i += len([]rune(p.comments[comment].Text)) - 1
+			continue
+		}
+		if strings.HasPrefix(string(p.src[i:min(i+4, end)]), "/**@") {
+			for i+1 < end && !(p.src[i] == '*' && p.src[i+1] == '/') {
+				i++
+			}
+			i++
+			continue
+		}
+		switch p.src[i] {
+		case '"', '\'':
+			quote := p.src[i]
+			for i++; i < end && p.src[i] != quote; i++ {
+				if p.src[i] == '\\' {
+					i++
+				}
+			}
+		case '}':
+			closing = i
+		}
+	}
+	return closing
+}
+
+// emit writes a line at the given indentation level, preceded by a blank line
+// if requested and the line does not start a block.
+func (p *printer) emit(indent int, text string, blank bool) {
+	if blank && len(p.lines) > 0 && !p.opened {
+		p.lines = append(p.lines, "")
+	}
+	p.lines = append(p.lines, strings.Repeat(indentation, indent)+text)
+	p.opened = false
+	p.commented = false
+}
+
+// flushComments writes the comments which begin before the given offset.
+// Trailing comments are appended to the previous line unless it already ends
+// in a line comment, as when a definition spanning several source lines is
+// written on one, in which case they are written like leading comments.
+// Returns true if any comments were written on their own line.
+func (p *printer) flushComments(off, indent int, forceBlank bool) bool {
+	wrote := false
+	for len(p.comments) > 0 && p.offset(p.comments[0].Pos) < off {
+		comment := p.comments[0]
+		p.comments = p.comments[1:]
+		lines := strings.Split(comment.Text, "\n")
+		switch {
+		case comment.Trailing && len(p.lines) > 0 && !p.commented:
+			p.lines[len(p.lines)-1] += " " + lines[0]
+		case comment.Trailing:
+			p.emit(indent, lines[0], forceBlank && !wrote)
+			wrote = true
+		default:
+			p.emit(indent, lines[0], (forceBlank && !wrote) || p.blankBefore(p.offset(comment.Pos)))
+			wrote = true
+		}
+		p.commented = len(lines) == 1 && !strings.HasPrefix(lines[0], "/*")
+		// Continuation lines of block comments are kept verbatim.
+		for _, line := range lines[1:] {
+			p.lines = append(p.lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	return wrote
+}
+
+// node writes the leading comments and doc string of a definition followed by
+// its first line.
+func (p *printer) node(pos parser.Pos, doc []string, indent int, forceBlank bool, line string) {
+	start := p.start(pos, doc)
+	wroteComments := p.flushComments(start, indent, forceBlank)
+	blank := (forceBlank && !wroteComments) || p.blankBefore(start)
+	if len(doc) > 0 {
+		p.docString(doc, indent, blank)
+		blank = false
+	}
+	p.emit(indent, line, blank)
+}
+
+func (p *printer) docString(doc []string, indent int, blank bool) {
+	lines := make([]string, len(doc))
+	for i, line := range doc {
+		lines[i] = strings.TrimRight(strings.TrimLeft(line, "\t* "), " \t\r")
+	}
+	if len(lines) == 1 {
+		p.emit(indent, "/**@ "+lines[0]+" */", blank)
+		return
+	}
+	p.emit(indent, "/**@", blank)
+	for _, line := range lines {
+		p.emit(indent, strings.TrimRight(" * "+line, " "), false)
+	}
+	p.emit(indent, " */", false)
+}
+
+// block writes a definition containing members, such as a struct.
+func (p *printer) block(pos parser.Pos, doc []string, forceBlank bool, header, footer string,
+	members []member, end int) {
+	p.node(pos, doc, 0, forceBlank, header)
+	p.opened = true
+	for _, m := range members {
+		p.node(m.pos, m.doc, 1, false, m.line)
+	}
+	p.flushComments(end, 1, false)
+	p.lines = append(p.lines, footer)
+	p.opened = false
+	p.commented = false
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
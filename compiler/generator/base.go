@@ -23,6 +23,7 @@ import (
 // BaseGenerator contains base generator logic which language generators can
 // extend.
 type BaseGenerator struct {
+	Config
 	Options map[string]string
 	Frugal  *parser.Frugal
 	elemNum int
@@ -69,6 +70,11 @@ func (b *BaseGenerator) GenerateBlockComment(comment []string, indent string) st
 	return block
 }
 
+// SetConfig sets the compilation settings for this generator.
+func (b *BaseGenerator) SetConfig(config Config) {
+	b.Config = config
+}
+
 // SetFrugal sets the Frugal parse tree for this generator.
 func (b *BaseGenerator) SetFrugal(f *parser.Frugal) {
 	b.Frugal = f
@@ -83,16 +89,19 @@ func (b *BaseGenerator) GetElem() string {
 func (b *BaseGenerator) GetServiceMethodTypes(service *parser.Service) []*parser.Struct {
 	structs := []*parser.Struct{}
 	for _, method := range service.Methods {
+		// Copy the fields rather than modifying the parse tree, which may be
+		// shared with other generators.
 		arg := &parser.Struct{
 			Name:   fmt.Sprintf("%s_args", method.Name),
-			Fields: method.Arguments,
+			Fields: make([]*parser.Field, len(method.Arguments)),
 			Type:   parser.StructTypeStruct,
 		}
-
-		for _, field := range arg.Fields {
-			if field.Modifier == parser.Optional {
-				field.Modifier = parser.Default
+		for i, field := range method.Arguments {
+			argField := *field
+			if argField.Modifier == parser.Optional {
+				argField.Modifier = parser.Default
 			}
+			arg.Fields[i] = &argField
 		}
 		structs = append(structs, arg)
 
@@ -106,7 +115,10 @@ func (b *BaseGenerator) GetServiceMethodTypes(service *parser.Service) []*parser
 			if numReturns == 1 {
 				fields[0] = parser.FieldFromType(method.ReturnType, "success")
 			}
-			copy(fields[numReturns:], method.Exceptions)
+			for i, field := range method.Exceptions {
+				exception := *field
+				fields[numReturns+i] = &exception
+			}
 			for _, field := range fields {
 				field.Modifier = parser.Optional
 			}
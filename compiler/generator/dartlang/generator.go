@@ -201,7 +201,8 @@ func (g *Generator) addToPubspec(dir string) error {
 		},
 	}
 
-	if g.Frugal.ContainsFrugalDefinitions() {
+	// Generated canonical JSON methods use frugal.CanonicalJson.
+	if g.Frugal.ContainsFrugalDefinitions() || g.generateCanonicalJSONOption() {
 		deps["frugal"] = dep{
 			Hosted:  hostedDep{Name: "frugal", URL: "https://pub.workiva.org"},
 			Version: fmt.Sprintf("^%s", globals.Version),
@@ -603,7 +604,13 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 		return err
 	}
 
-	contents := g.generateStruct(s)
+	if g.generateCanonicalJSONOption() {
+		if _, err = file.WriteString("import 'package:frugal/frugal.dart' as frugal;\n\n"); err != nil {
+			return err
+		}
+	}
+
+	contents := g.generateStruct(s, false)
 	_, err = file.WriteString(contents)
 	return err
 }
@@ -623,12 +630,12 @@ func (g *Generator) GenerateException(s *parser.Struct) error {
 func (g *Generator) generateServiceArgsResults(service *parser.Service) string {
 	contents := ""
 	for _, s := range g.GetServiceMethodTypes(service) {
-		contents += g.generateStruct(s)
+		contents += g.generateStruct(s, true)
 	}
 	return contents
 }
 
-func (g *Generator) generateStruct(s *parser.Struct) string {
+func (g *Generator) generateStruct(s *parser.Struct, isArgOrResult bool) string {
 	contents := ""
 
 	// Class declaration
@@ -702,6 +709,11 @@ func (g *Generator) generateStruct(s *parser.Struct) string {
 	// validate
 	contents += g.generateValidate(s)
 
+	// canonical JSON
+	if !isArgOrResult {
+		contents += g.generateCanonicalJSON(s)
+	}
+
 	contents += "}\n"
 	return contents
 }
@@ -1095,7 +1107,7 @@ func (g *Generator) generateToString(s *parser.Struct) string {
 			contents += tabtab + ind + "ret.write(\"BINARY\");\n"
 		} else if g.Frugal.IsEnum(underlyingType) {
 			contents += fmt.Sprintf(tabtab+ind+"String %s_name = %s.VALUES_TO_NAMES[this.%s];\n",
-				fName, g.qualifiedTypeName(field.Type), fName)
+				fName, g.qualifiedTypeName(underlyingType), fName)
 			contents += fmt.Sprintf(tabtab+ind+"if(%s_name != null) {\n", fName)
 			contents += fmt.Sprintf(tabtabtab+ind+"ret.write(%s_name);\n", fName)
 			contents += tabtabtab + ind + "ret.write(\" (\");\n"
@@ -1344,7 +1356,7 @@ func (g *Generator) GenerateScopeImports(file *os.File, s *parser.Scope) error {
 
 // GenerateConstants generates any static constants.
 func (g *Generator) GenerateConstants(file *os.File, name string) error {
-	constants := fmt.Sprintf("const String delimiter = '%s';", globals.TopicDelimiter)
+	constants := fmt.Sprintf("const String delimiter = '%s';", g.TopicDelimiter)
 	_, err := file.WriteString(constants)
 	return err
 }
@@ -1409,7 +1421,7 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 		}
 
 		publishers += tabtab + fmt.Sprintf("var op = \"%s\";\n", op.Name)
-		publishers += tabtab + fmt.Sprintf("var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+		publishers += tabtab + fmt.Sprintf("var prefix = \"%s\";\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 		publishers += tabtab + "var topic = \"${prefix}" + strings.Title(scope.Name) + "${delimiter}${op}\";\n"
 		publishers += tabtab + "var memoryBuffer = new frugal.TMemoryOutputBuffer(transport.publishSizeLimit);\n"
 		publishers += tabtab + "var oprot = protocolFactory.getProtocol(memoryBuffer);\n"
@@ -1428,13 +1440,13 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	return err
 }
 
-func generatePrefixStringTemplate(scope *parser.Scope) string {
+func generatePrefixStringTemplate(scope *parser.Scope, delimiter string) string {
 	if scope.Prefix.String == "" {
 		return ""
 	}
 	template := ""
 	template += scope.Prefix.Template("%s")
-	template += globals.TopicDelimiter
+	template += delimiter
 	if len(scope.Prefix.Variables) == 0 {
 		return template
 	}
@@ -1477,7 +1489,7 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 		subscribers += fmt.Sprintf(tab+"Future<frugal.FSubscription> subscribe%s(%sdynamic on%s(frugal.FContext ctx, %s req)) async {\n",
 			op.Name, args, op.Type.ParamName(), g.getDartTypeFromThriftType(op.Type))
 		subscribers += fmt.Sprintf(tabtab+"var op = \"%s\";\n", op.Name)
-		subscribers += fmt.Sprintf(tabtab+"var prefix = \"%s\";\n", generatePrefixStringTemplate(scope))
+		subscribers += fmt.Sprintf(tabtab+"var prefix = \"%s\";\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 		subscribers += tabtab + "var topic = \"${prefix}" + strings.Title(scope.Name) + "${delimiter}${op}\";\n"
 		subscribers += tabtab + "var transport = provider.subscriberTransportFactory.getTransport();\n"
 		subscribers += fmt.Sprintf(tabtab+"await transport.subscribe(topic, _recv%s(op, provider.protocolFactory, on%s));\n",
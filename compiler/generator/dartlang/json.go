@@ -0,0 +1,246 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dartlang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const canonicalJSONOption = "canonical_json"
+
+func (g *Generator) generateCanonicalJSONOption() bool {
+	_, ok := g.Options[canonicalJSONOption]
+	return ok
+}
+
+// generateCanonicalJSON generates the toJsonValue and fromJsonValue methods
+// used with frugal.CanonicalJson when the canonical_json option is set. See
+// documentation/json.md for the format they implement.
+func (g *Generator) generateCanonicalJSON(s *parser.Struct) string {
+	if !g.generateCanonicalJSONOption() {
+		return ""
+	}
+	return g.generateToJSONValue(s) + g.generateFromJSONValue(s)
+}
+
+// isJSONObjectKey returns true if maps with keys of the given type are
+// written as JSON objects rather than as lists of entries.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "string":
+		return true
+	}
+	return false
+}
+
+// jsonZeroValue returns the JSON value a field of the given type is written
+// as when it is null.
+func (g *Generator) jsonZeroValue(t *parser.Type) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		if enum := g.Frugal.FindEnum(underlyingType); enum != nil {
+			for _, value := range enum.Values {
+				if value.Value == 0 {
+					return fmt.Sprintf("'%s'", value.Name)
+				}
+			}
+		}
+		return "0"
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return "false"
+	case "i64":
+		return "'0'"
+	case "string", "binary":
+		return "''"
+	case "list", "set":
+		return "[]"
+	case "map":
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			return "{}"
+		}
+		return "[]"
+	}
+	return "0"
+}
+
+func (g *Generator) generateToJSONValue(s *parser.Struct) string {
+	contents := "\n"
+	contents += tab + "Map<String, Object> toJsonValue() {\n"
+	contents += tabtab + "Map<String, Object> value = {};\n"
+	for _, field := range s.Fields {
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		fName := toFieldName(field.Name)
+		titleName := strings.Title(field.Name)
+		src := "this." + fName
+		expr := g.generateToJSONExpr(field.Type, src)
+		switch {
+		case field.Modifier == parser.Optional && field.Default != nil &&
+			(underlyingType.IsPrimitive() || g.Frugal.IsEnum(underlyingType)):
+			// Optional fields equal to their default are not set.
+			def := g.generateConstantValue(field.Type, field.Default, tab)
+			if underlyingType.Name == "binary" {
+				contents += fmt.Sprintf(tabtab+"if(isSet%s() && %s != frugal.CanonicalJson.binaryValue(%s)) {\n",
+					titleName, expr, def)
+			} else {
+				contents += fmt.Sprintf(tabtab+"if(isSet%s() && %s != %s) {\n", titleName, src, def)
+			}
+		case field.Modifier == parser.Optional || s.Type == parser.StructTypeUnion || g.Frugal.IsStruct(underlyingType):
+			contents += fmt.Sprintf(tabtab+"if(isSet%s()) {\n", titleName)
+		default:
+			contents += fmt.Sprintf(tabtab+"value['%s'] = %s != null ? %s : %s;\n",
+				field.Name, src, expr, g.jsonZeroValue(field.Type))
+			continue
+		}
+		contents += fmt.Sprintf(tabtabtab+"value['%s'] = %s;\n", field.Name, expr)
+		contents += tabtab + "}\n"
+	}
+	contents += tabtab + "return value;\n"
+	contents += tab + "}\n"
+	return contents
+}
+
+// generateToJSONExpr returns an expression for the JSON value of the source
+// value of the given type.
+func (g *Generator) generateToJSONExpr(t *parser.Type, src string) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return src + ".toJsonValue()"
+	case g.Frugal.IsEnum(underlyingType):
+		if g.useEnums() {
+			return fmt.Sprintf("frugal.CanonicalJson.enumNameValue(%s)", src)
+		}
+		return fmt.Sprintf("frugal.CanonicalJson.enumValue(%s, %s.VALUES_TO_NAMES)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		elem := g.GetElem()
+		list := fmt.Sprintf("%s.toList()", src)
+		if expr := g.generateToJSONExpr(underlyingType.ValueType, elem); expr != elem {
+			list = fmt.Sprintf("%s.map((%s) => %s).toList()", src, elem, expr)
+		}
+		if underlyingType.Name == "set" {
+			return fmt.Sprintf("frugal.CanonicalJson.sorted(%s)", list)
+		}
+		return list
+	case underlyingType.Name == "map":
+		key, value := g.GetElem(), g.GetElem()
+		keyExpr := g.generateToJSONExpr(underlyingType.KeyType, key)
+		valueExpr := g.generateToJSONExpr(underlyingType.ValueType, value)
+		helper := "entriesValue"
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			helper = "mapValue"
+		}
+		return fmt.Sprintf("frugal.CanonicalJson.%s(%s, (%s) => %s, (%s) => %s)",
+			helper, src, key, keyExpr, value, valueExpr)
+	}
+	switch underlyingType.Name {
+	case "i64":
+		return fmt.Sprintf("%s.toString()", src)
+	case "double":
+		return fmt.Sprintf("frugal.CanonicalJson.doubleValue(%s)", src)
+	case "binary":
+		return fmt.Sprintf("frugal.CanonicalJson.binaryValue(%s)", src)
+	}
+	return src
+}
+
+func (g *Generator) generateFromJSONValue(s *parser.Struct) string {
+	contents := "\n"
+	contents += tab + "fromJsonValue(Object value) {\n"
+	contents += tabtab + "Map<String, Object> object = frugal.CanonicalJson.parseObject(value);\n"
+	if s.Type == parser.StructTypeUnion {
+		contents += tabtab + "int setFields = 0;\n"
+	}
+	for _, field := range s.Fields {
+		elem := g.GetElem()
+		contents += fmt.Sprintf(tabtab+"Object %s = object['%s'];\n", elem, field.Name)
+		contents += fmt.Sprintf(tabtab+"if(%s != null) {\n", elem)
+		contents += fmt.Sprintf(tabtabtab+"this.%s = %s;\n", toFieldName(field.Name), g.generateFromJSONExpr(field.Type, elem))
+		if s.Type == parser.StructTypeUnion {
+			contents += tabtabtab + "setFields++;\n"
+		}
+		if field.Modifier == parser.Required {
+			contents += tabtab + "} else {\n"
+			contents += fmt.Sprintf(tabtabtab+"throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, \"Required field '%s' is not present in struct '%s'\");\n",
+				field.Name, s.Name)
+		}
+		contents += tabtab + "}\n"
+	}
+	if s.Type == parser.StructTypeUnion {
+		// An unset union is written as {}, so only reject several fields.
+		contents += tabtab + "if(setFields > 1) {\n"
+		contents += tabtabtab + "throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, \"The union had more than one field set, $setFields were set\");\n"
+		contents += tabtab + "}\n"
+	}
+	contents += tab + "}\n"
+	return contents
+}
+
+// generateFromJSONExpr returns an expression for the value of the given type
+// held by the source JSON value.
+func (g *Generator) generateFromJSONExpr(t *parser.Type, src string) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return fmt.Sprintf("(new %s()..fromJsonValue(%s))", g.qualifiedTypeName(underlyingType), src)
+	case g.Frugal.IsEnum(underlyingType):
+		if g.useEnums() {
+			return fmt.Sprintf("frugal.CanonicalJson.parseEnumName(%s, %s.values, %s.deserialize%s)",
+				src, g.qualifiedTypeName(underlyingType), g.includeQualifier(underlyingType), underlyingType.Name)
+		}
+		return fmt.Sprintf("frugal.CanonicalJson.parseEnum(%s, %s.VALUES_TO_NAMES)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		elem := g.GetElem()
+		return fmt.Sprintf("new %s.from(frugal.CanonicalJson.parseList(%s).map((%s) => %s))",
+			g.getDartTypeFromThriftType(underlyingType), src, elem, g.generateFromJSONExpr(underlyingType.ValueType, elem))
+	case underlyingType.Name == "map":
+		key, value := g.GetElem(), g.GetElem()
+		keyExpr := g.generateFromJSONExpr(underlyingType.KeyType, key)
+		valueExpr := g.generateFromJSONExpr(underlyingType.ValueType, value)
+		helper := "parseEntries"
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			helper = "parseMap"
+			if g.Frugal.UnderlyingType(underlyingType.KeyType).Name == "bool" {
+				keyExpr = fmt.Sprintf("frugal.CanonicalJson.parseBoolKey(%s)", key)
+			}
+		}
+		return fmt.Sprintf("new %s.from(frugal.CanonicalJson.%s(%s, (%s) => %s, (%s) => %s))",
+			g.getDartTypeFromThriftType(underlyingType), helper, src, key, keyExpr, value, valueExpr)
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return fmt.Sprintf("frugal.CanonicalJson.parseBool(%s)", src)
+	case "byte", "i8":
+		return fmt.Sprintf("frugal.CanonicalJson.parseInt(%s, 8)", src)
+	case "i16":
+		return fmt.Sprintf("frugal.CanonicalJson.parseInt(%s, 16)", src)
+	case "i32":
+		return fmt.Sprintf("frugal.CanonicalJson.parseInt(%s, 32)", src)
+	case "i64":
+		return fmt.Sprintf("frugal.CanonicalJson.parseInt(%s, 64)", src)
+	case "double":
+		return fmt.Sprintf("frugal.CanonicalJson.parseDouble(%s)", src)
+	case "binary":
+		return fmt.Sprintf("frugal.CanonicalJson.parseBinary(%s)", src)
+	}
+	return fmt.Sprintf("frugal.CanonicalJson.parseString(%s)", src)
+}
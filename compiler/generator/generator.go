@@ -16,6 +16,7 @@ package generator
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Workiva/frugal/compiler/parser"
 )
@@ -38,6 +39,14 @@ const (
 	ObjectFile             FileType = "object"
 )
 
+// Config contains settings which apply to every file generated in a
+// compilation.
+type Config struct {
+	TopicDelimiter string    // Token delimiter for scope topics
+	Out            string    // Root output location, empty for the default
+	Now            time.Time // Time of generation
+}
+
 // Options contains language generator options. The map key is the option name,
 // and the value is the option description.
 type Options map[string]string
@@ -55,6 +64,13 @@ var Languages = LanguageOptions{
 		"async":          "Generate async client code using channels",
 		"use_vendor":     "Use specified import references for vendored includes and do not generate code for them",
 		"slim":           "Generate slim type definitions (WARNING: code generated by this may break code consumers, protocol logic should not change)",
+		"context":        "Generate service interfaces which take a context.Context instead of a frugal.FContext",
+		"equals":         "Generate an Equals method for structs, unions and exceptions (included types must use the same option)",
+		"deep_copy":      "Generate a DeepCopy method for structs, unions and exceptions (included types must use the same option)",
+		"hash":           "Generate a stable Hash method for structs, unions and exceptions (included types must use the same option)",
+		"mocks":          "Generate a programmable mock of each service interface and an in-memory fake of each scope's publisher and subscriber (extended services must use the same option)",
+		"strict_enums":   "Reject unknown enum values when reading, or read them as the value named by the enum's unknown_value annotation. Enums from vendored includes are not checked with use_vendor",
+		"canonical_json": "Generate MarshalJSON and UnmarshalJSON methods for structs, unions and exceptions using canonical JSON (included types must use the same option)",
 	},
 	"java": Options{
 		"generated_annotations": "[undated|suppress] " +
@@ -63,17 +79,20 @@ var Languages = LanguageOptions{
 		"async":            "Generate async client code using futures",
 		"boxed_primitives": "Generate primitives as the boxed equivalents",
 		"use_vendor":       "Use specified import references for vendored includes and do not generate code for them",
+		"canonical_json":   "Generate toJsonValue and fromJsonValue methods for structs, unions and exceptions for use with com.workiva.frugal.util.CanonicalJson (included types must use the same option)",
 	},
 	"dart": Options{
 		"library_prefix": "Generate code that can be used within an existing library. " +
 			"Use a dot-separated string, e.g. \"my_parent_lib.src.gen\"",
-		"use_enums":  "Generate enums as enums rather than a class with numerical constants",
-		"use_vendor": "Use specified import references for vendored includes and do not generate code for them",
+		"use_enums":      "Generate enums as enums rather than a class with numerical constants",
+		"use_vendor":     "Use specified import references for vendored includes and do not generate code for them",
+		"canonical_json": "Generate toJsonValue and fromJsonValue methods for structs, unions and exceptions for use with frugal.CanonicalJson (included types must use the same option)",
 	},
 	"py": Options{
 		"tornado":        "Generate code for use with Tornado (compatible with Python 2.7)",
 		"asyncio":        "Generate code for use with asyncio (compatible with Python 3.5 or above)",
 		"package_prefix": "Package prefix for generated files",
+		"canonical_json": "Generate to_json_value and from_json_value methods for structs, unions and exceptions for use with frugal.util.canonical_json (included types must use the same option)",
 	},
 	"html": Options{
 		"standalone": "Self-contained mode, includes all CSS in the HTML files. Generates no style.css file, but HTML files will be larger",
@@ -111,6 +130,7 @@ type ProgramGenerator interface {
 // languages.
 type LanguageGenerator interface {
 	// Generic methods
+	SetConfig(Config)
 	SetFrugal(*parser.Frugal)
 	SetupGenerator(outputDir string) error
 	TeardownGenerator() error
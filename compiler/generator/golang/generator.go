@@ -40,6 +40,13 @@ const (
 	asyncOption         = "async"
 	useVendorOption     = "use_vendor"
 	slimOption          = "slim"
+	contextOption       = "context"
+	equalsOption        = "equals"
+	deepCopyOption      = "deep_copy"
+	hashOption          = "hash"
+	mocksOption         = "mocks"
+	strictEnumsOption   = "strict_enums"
+	canonicalJSONOption = "canonical_json"
 )
 
 // Generator implements the LanguageGenerator interface for Go.
@@ -346,6 +353,35 @@ func (g *Generator) GenerateEnum(enum *parser.Enum) error {
 	contents += "\treturn \"<UNSET>\"\n"
 	contents += "}\n\n"
 
+	contents += fmt.Sprintf("// %sValues returns all values of %s in declaration order.\n", eName, eName)
+	contents += fmt.Sprintf("func %sValues() []%s {\n", eName, eName)
+	contents += fmt.Sprintf("\treturn []%s{\n", eName)
+	for _, field := range enum.Values {
+		contents += fmt.Sprintf("\t\t%s_%s,\n", eName, field.Name)
+	}
+	contents += "\t}\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// %sNames returns the names of all values of %s in declaration order.\n", eName, eName)
+	contents += fmt.Sprintf("func %sNames() []string {\n", eName)
+	contents += "\treturn []string{\n"
+	for _, field := range enum.Values {
+		contents += fmt.Sprintf("\t\t\"%s\",\n", field.Name)
+	}
+	contents += "\t}\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// IsValid returns true if p is a declared value of %s.\n", eName)
+	contents += fmt.Sprintf("func (p %s) IsValid() bool {\n", eName)
+	contents += "\tswitch p {\n"
+	for _, field := range enum.Values {
+		contents += fmt.Sprintf("\tcase %s_%s:\n", eName, field.Name)
+		contents += "\t\treturn true\n"
+	}
+	contents += "\t}\n"
+	contents += "\treturn false\n"
+	contents += "}\n\n"
+
 	contents += fmt.Sprintf("func %sFromString(s string) (%s, error) {\n", eName, eName)
 	contents += "\tswitch s {\n"
 	for _, field := range enum.Values {
@@ -389,9 +425,38 @@ func (g *Generator) GenerateEnum(enum *parser.Enum) error {
 	return err
 }
 
+// generateEnumCheck generates the check of an enum value read into temp,
+// which either returns an error or replaces the value with the enum's
+// unknown_value. Vendored enums are not checked since their code may have been
+// generated by a compiler without IsValid.
+func (g *Generator) generateEnumCheck(enumType *parser.Type, goOrigType, goEnumType string) string {
+	if g.isVendoredType(enumType) {
+		return ""
+	}
+	check := "temp"
+	if goOrigType != goEnumType {
+		check = fmt.Sprintf("%s(temp)", goEnumType)
+	}
+	contents := fmt.Sprintf("\t\tif !%s.IsValid() {\n", check)
+	enum := g.Frugal.FindEnum(enumType)
+	if unknown, ok := enum.Annotations.UnknownValue(); ok {
+		value := goEnumType + "_" + unknown
+		if goOrigType != goEnumType {
+			value = fmt.Sprintf("%s(%s)", goOrigType, value)
+		}
+		contents += fmt.Sprintf("\t\t\ttemp = %s\n", value)
+	} else {
+		contents += fmt.Sprintf("\t\t\treturn thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, "+
+			"fmt.Errorf(\"invalid value %%d for enum %s\", v))\n", title(enum.Name))
+	}
+	contents += "\t\t}\n"
+	return contents
+}
+
 // GenerateStruct generates the given struct.
 func (g *Generator) GenerateStruct(s *parser.Struct) error {
 	contents := g.generateStruct(s, "")
+	contents += g.generateValueMethods(s)
 	_, err := g.typesFile.WriteString(contents)
 	return err
 }
@@ -399,6 +464,7 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 // GenerateUnion generates the given union.
 func (g *Generator) GenerateUnion(union *parser.Struct) error {
 	contents := g.generateStruct(union, "")
+	contents += g.generateValueMethods(union)
 	_, err := g.typesFile.WriteString(contents)
 	return err
 }
@@ -406,6 +472,7 @@ func (g *Generator) GenerateUnion(union *parser.Struct) error {
 // GenerateException generates the given exception.
 func (g *Generator) GenerateException(exception *parser.Struct) error {
 	contents := g.generateStruct(exception, "")
+	contents += g.generateValueMethods(exception)
 	contents += fmt.Sprintf("func (p *%s) Error() string {\n", title(exception.Name))
 	contents += "\treturn p.String()\n"
 	contents += "}\n"
@@ -437,6 +504,7 @@ func (g *Generator) generateStruct(s *parser.Struct, serviceName string) string
 	contents += g.generateRead(s, sName)
 	contents += g.generateWrite(s, sName)
 	contents += g.generateToString(s, sName)
+	contents += g.generateValidate(s, sName)
 
 	return contents
 }
@@ -793,6 +861,9 @@ func (g *Generator) generateReadFieldRec(field *parser.Field, first bool) string
 			contents += fmt.Sprintf("\t\t%s%s = %sv\n", prefix, fName, maybeAddress)
 		} else {
 			contents += fmt.Sprintf("\t\ttemp := %s(v)\n", cast)
+			if isEnum && g.generateStrictEnums() {
+				contents += g.generateEnumCheck(underlyingType, goOrigType, goUnderlyingType)
+			}
 			contents += fmt.Sprintf("\t\t%s%s = %stemp\n", prefix, fName, maybeAddress)
 		}
 
@@ -1086,6 +1157,24 @@ func (g *Generator) GenerateTypesImports(file *os.File) error {
 		contents += "\t\"database/sql/driver\"\n"
 		contents += "\t\"errors\"\n"
 	}
+	if g.typesUsePatterns() {
+		contents += "\t\"regexp\"\n"
+	}
+	if g.generateHashOption() {
+		contents += "\t\"encoding/binary\"\n"
+		contents += "\t\"hash\"\n"
+		contents += "\t\"hash/fnv\"\n"
+		contents += "\t\"io\"\n"
+	}
+	if g.generateHashOption() || g.generateCanonicalJSONOption() {
+		contents += "\t\"math\"\n"
+	}
+	if g.generateCanonicalJSONOption() {
+		contents += "\t\"encoding/base64\"\n"
+		contents += "\t\"encoding/json\"\n"
+		contents += "\t\"sort\"\n"
+		contents += "\t\"strconv\"\n"
+	}
 	if g.Options[thriftImportOption] != "" {
 		contents += "\t\"" + g.Options[thriftImportOption] + "\"\n"
 	} else {
@@ -1115,6 +1204,12 @@ func (g *Generator) GenerateTypesImports(file *os.File) error {
 	contents += "var _ = bytes.Equal\n\n"
 	contents += protections
 	contents += "var GoUnusedProtection__ int\n"
+	if g.generateHashOption() {
+		contents += hashHelpers
+	}
+	if g.generateCanonicalJSONOption() {
+		contents += jsonHelpers
+	}
 	_, err := file.WriteString(contents)
 	return err
 }
@@ -1158,7 +1253,13 @@ func (g *Generator) GenerateServiceResultArgsImports(file *os.File) error {
 func (g *Generator) GenerateServiceImports(file *os.File, s *parser.Service) error {
 	imports := "import (\n"
 	imports += "\t\"bytes\"\n"
+	if g.generateContext() {
+		imports += "\t\"context\"\n"
+	}
 	imports += "\t\"fmt\"\n"
+	if serviceUsesPatterns(s) {
+		imports += "\t\"regexp\"\n"
+	}
 	imports += "\t\"sync\"\n"
 	if len(s.TwowayMethods()) > 0 {
 		// Only non-oneway methods require the time package.
@@ -1280,7 +1381,7 @@ func (g *Generator) GenerateConstants(file *os.File, name string) error {
 	if !g.generateConstants {
 		return nil
 	}
-	constants := fmt.Sprintf("const delimiter = \"%s\"", globals.TopicDelimiter)
+	constants := fmt.Sprintf("const delimiter = \"%s\"", g.TopicDelimiter)
 	_, err := file.WriteString(constants)
 	if err != nil {
 		return err
@@ -1401,7 +1502,7 @@ func (g *Generator) generateInternalPublishMethod(scope *parser.Scope, op *parse
 	}
 
 	publisher += fmt.Sprintf("\top := \"%s\"\n", op.Name)
-	publisher += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	publisher += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 	publisher += "\ttopic := fmt.Sprintf(\"%s" + scopeTitle + "%s%s\", prefix, delimiter, op)\n"
 	publisher += "\tbuffer := frugal.NewTMemoryOutputBuffer(p.transport.GetPublishSizeLimit())\n"
 	publisher += "\toprot := p.protocolFactory.GetProtocol(buffer)\n"
@@ -1423,16 +1524,16 @@ func (g *Generator) generateInternalPublishMethod(scope *parser.Scope, op *parse
 	return publisher
 }
 
-func generatePrefixStringTemplate(scope *parser.Scope) string {
+func generatePrefixStringTemplate(scope *parser.Scope, delimiter string) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
 			return `""`
 		}
-		return fmt.Sprintf(`"%s%s"`, scope.Prefix.String, globals.TopicDelimiter)
+		return fmt.Sprintf(`"%s%s"`, scope.Prefix.String, delimiter)
 	}
 	template := "fmt.Sprintf(\""
 	template += scope.Prefix.Template("%s")
-	template += globals.TopicDelimiter + "\", "
+	template += delimiter + "\", "
 	prefix := ""
 	for _, variable := range scope.Prefix.Variables {
 		template += prefix + variable
@@ -1507,8 +1608,13 @@ func (g *Generator) GenerateSubscriber(file *os.File, scope *parser.Scope) error
 		subscriber += g.generateSubscribeMethod(scope, op, args, argsWithoutTypes)
 	}
 
-	_, err := file.WriteString(subscriber)
-	return err
+	if _, err := file.WriteString(subscriber); err != nil {
+		return err
+	}
+	if g.generateMocksOption() {
+		return g.generateScopeFakeFile(scope, filepath.Dir(file.Name()))
+	}
+	return nil
 }
 
 func (g *Generator) generateSubscribeMethod(scope *parser.Scope, op *parser.Operation, args, argsWithoutTypes string) string {
@@ -1536,7 +1642,7 @@ func (g *Generator) generateSubscribeMethod(scope *parser.Scope, op *parser.Oper
 	subscriber += fmt.Sprintf("func (l *%sSubscriber) Subscribe%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
 		scopeLower, op.Name, args, g.getGoTypeFromThriftType(op.Type))
 	subscriber += fmt.Sprintf("\top := \"%s\"\n", op.Name)
-	subscriber += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope))
+	subscriber += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 	subscriber += "\ttopic := fmt.Sprintf(\"%s" + scopeTitle + "%s%s\", prefix, delimiter, op)\n"
 	subscriber += "\ttransport, protocolFactory := l.provider.NewSubscriber()\n"
 	subscriber += fmt.Sprintf("\tcb := l.recv%s(op, protocolFactory, handler)\n", op.Name)
@@ -1583,8 +1689,13 @@ func (g *Generator) GenerateService(file *os.File, s *parser.Service) error {
 	contents += g.generateServer(s)
 	contents += g.generateServiceArgsResults(s)
 
-	_, err := file.WriteString(contents)
-	return err
+	if _, err := file.WriteString(contents); err != nil {
+		return err
+	}
+	if g.generateMocksOption() {
+		return g.generateServiceMockFile(s, filepath.Dir(file.Name()))
+	}
+	return nil
 }
 
 func (g *Generator) generateServiceInterface(service *parser.Service) string {
@@ -1599,8 +1710,8 @@ func (g *Generator) generateServiceInterface(service *parser.Service) string {
 	}
 	for _, method := range service.Methods {
 		contents += g.generateCommentWithDeprecated(method.Comment, "\t", method.Annotations)
-		contents += fmt.Sprintf("\t%s(ctx frugal.FContext%s) %s\n",
-			snakeToCamel(method.Name), g.generateInterfaceArgs(method.Arguments),
+		contents += fmt.Sprintf("\t%s(ctx %s%s) %s\n",
+			snakeToCamel(method.Name), g.handlerContextType(), g.generateInterfaceArgs(method.Arguments),
 			g.generateReturnArgs(method))
 	}
 	contents += "}\n\n"
@@ -1685,6 +1796,8 @@ func (g *Generator) generateClient(service *parser.Service) string {
 	contents += "\treturn client\n"
 	contents += "}\n\n"
 
+	contents += g.generateClientAnnotations(service)
+
 	for _, method := range service.Methods {
 		contents += g.generateClientMethod(service, method)
 		if g.generateAsync() {
@@ -1694,6 +1807,80 @@ func (g *Generator) generateClient(service *parser.Service) string {
 	return contents
 }
 
+// generateClientAnnotations generates a method returning the IDL annotations
+// of the service methods, keyed by method name. This allows client middleware
+// to act on annotations such as (idempotent).
+func (g *Generator) generateClientAnnotations(service *parser.Service) string {
+	if serviceHasMethod(service, "Annotations") {
+		// Don't clash with the service method or hide one of a base service.
+		return ""
+	}
+	servTitle := snakeToCamel(service.Name)
+	contents := "// Annotations returns the IDL annotations of the service methods keyed by\n"
+	contents += "// method name.\n"
+	contents += fmt.Sprintf("func (f *F%sClient) Annotations() map[string]map[string]string {\n", servTitle)
+	contents += "\tannotations := make(map[string]map[string]string)\n"
+	if service.Extends != "" {
+		// The base client may be vendored and generated by a compiler without
+		// Annotations, so only use it if it is there.
+		contents += fmt.Sprintf("\tif base, ok := interface{}(f.F%sClient).(interface {\n", service.ExtendsService())
+		contents += "\t\tAnnotations() map[string]map[string]string\n"
+		contents += "\t}); ok {\n"
+		contents += "\t\tfor method, methodAnnotations := range base.Annotations() {\n"
+		contents += "\t\t\tannotations[method] = methodAnnotations\n"
+		contents += "\t\t}\n"
+		contents += "\t}\n"
+	}
+	for _, method := range service.Methods {
+		if len(method.Annotations) == 0 {
+			continue
+		}
+		contents += fmt.Sprintf("\tannotations[\"%s\"] = map[string]string{\n", parser.LowercaseFirstLetter(method.Name))
+		for _, annotation := range method.Annotations {
+			contents += fmt.Sprintf("\t\t\"%s\": %s,\n", annotation.Name, g.quote(annotation.Value))
+		}
+		contents += "\t}\n"
+	}
+	contents += "\treturn annotations\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// serviceHasMethod indicates if the service or one of the services it extends
+// has a method generated with the given Go name.
+func serviceHasMethod(service *parser.Service, name string) bool {
+	for service != nil {
+		for _, method := range service.Methods {
+			if snakeToCamel(method.Name) == name {
+				return true
+			}
+		}
+		if service.Extends == "" || service.Frugal == nil {
+			return false
+		}
+		frugal := service.Frugal
+		if include := service.ExtendsInclude(); include != "" {
+			frugal = frugal.ParsedIncludes[include]
+		}
+		service = findService(frugal, service.ExtendsService())
+	}
+	return false
+}
+
+// findService returns the named service of the parsed file, or nil if there
+// is none.
+func findService(frugal *parser.Frugal, name string) *parser.Service {
+	if frugal == nil {
+		return nil
+	}
+	for _, service := range frugal.Services {
+		if service.Name == name {
+			return service
+		}
+	}
+	return nil
+}
+
 func (g *Generator) generateAsyncClientMethod(service *parser.Service, method *parser.Method) string {
 	var (
 		servTitle = snakeToCamel(service.Name)
@@ -1704,8 +1891,8 @@ func (g *Generator) generateAsyncClientMethod(service *parser.Service, method *p
 	if method.Comment != nil {
 		contents += g.GenerateInlineComment(method.Comment, "")
 	}
-	contents += fmt.Sprintf("func (f *F%sClient) %sAsync(ctx frugal.FContext%s) %s {\n",
-		servTitle, nameTitle, g.generateInputArgs(method.Arguments), g.generateAsyncReturnArgs(method))
+	contents += fmt.Sprintf("func (f *F%sClient) %sAsync(ctx %s%s) %s {\n",
+		servTitle, nameTitle, g.handlerContextType(), g.generateInputArgs(method.Arguments), g.generateAsyncReturnArgs(method))
 	contents += "\terrC := make(chan error, 1)\n"
 	if method.ReturnType != nil {
 		contents += fmt.Sprintf("\tresultC := make(chan %s, 1)\n", g.getGoTypeFromThriftType(method.ReturnType))
@@ -1751,8 +1938,8 @@ func (g *Generator) generateClientMethod(service *parser.Service, method *parser
 		contents += fmt.Sprintf("// Deprecated%s\n", deprecationValue)
 	}
 
-	contents += fmt.Sprintf("func (f *F%sClient) %s(ctx frugal.FContext%s) %s {\n",
-		servTitle, nameTitle, g.generateInputArgs(method.Arguments), g.generateReturnArgs(method))
+	contents += fmt.Sprintf("func (f *F%sClient) %s(ctx %s%s) %s {\n",
+		servTitle, nameTitle, g.handlerContextType(), g.generateInputArgs(method.Arguments), g.generateReturnArgs(method))
 
 	if deprecated {
 		contents += fmt.Sprintf("\tlogrus.Warn(\"Call to deprecated function '%s.%s'\")\n", service.Name, nameTitle)
@@ -1977,6 +2164,16 @@ func (g *Generator) generateMethodProcessor(service *parser.Service, method *par
 	contents += "\t}\n\n"
 
 	contents += "\tiprot.ReadMessageEnd()\n"
+	if g.argsNeedValidation(method) {
+		contents += "\tif err = args.Validate(); err != nil {\n"
+		if !method.Oneway {
+			contents += "\t\tp.GetWriteMutex().Lock()\n"
+			contents += fmt.Sprintf("\t\terr = %sWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INVALID_ARGUMENT, \"%s\", err.Error())\n", servLower, nameLower)
+			contents += "\t\tp.GetWriteMutex().Unlock()\n"
+		}
+		contents += "\t\treturn err\n"
+		contents += "\t}\n\n"
+	}
 	if !method.Oneway {
 		contents += fmt.Sprintf("\tresult := %s%sResult{}\n", servTitle, nameTitle)
 	}
@@ -2067,6 +2264,10 @@ func (g *Generator) generateMethodProcessor(service *parser.Service, method *par
 
 func (g *Generator) generateClientArgs(method *parser.Method) string {
 	args := "[]interface{}{ctx"
+	if g.generateContext() {
+		// Middleware always receives an FContext as the first argument.
+		args = "[]interface{}{frugal.FContextFromContext(ctx)"
+	}
 	for _, arg := range method.Arguments {
 		args += ", " + strings.ToLower(arg.Name)
 	}
@@ -2356,6 +2557,39 @@ func (g *Generator) generateSlim() bool {
 	return ok
 }
 
+func (g *Generator) generateStrictEnums() bool {
+	_, ok := g.Options[strictEnumsOption]
+	return ok
+}
+
+func (g *Generator) generateContext() bool {
+	_, ok := g.Options[contextOption]
+	return ok
+}
+
+// handlerContextType returns the type of the context argument taken by
+// service interface and client methods.
+func (g *Generator) handlerContextType() string {
+	if g.generateContext() {
+		return "context.Context"
+	}
+	return "frugal.FContext"
+}
+
+// isVendoredType indicates if the given type is defined by a vendored include
+// which is imported from its vendor path rather than generated.
+func (g *Generator) isVendoredType(t *parser.Type) bool {
+	if !g.UseVendor() || t.IncludeName() == "" {
+		return false
+	}
+	include := g.Frugal.Include(t.IncludeName())
+	if include == nil {
+		return false
+	}
+	_, vendored := include.Annotations.Vendor()
+	return vendored
+}
+
 func (g *Generator) UseVendor() bool {
 	_, ok := g.Options[useVendorOption]
 	return ok
@@ -0,0 +1,405 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// jsonHelpers are written to the types file when the canonical_json option
+// is set. See documentation/json.md for the format they implement.
+const jsonHelpers = `
+type frugalJSONEntry struct {
+	Key   json.RawMessage ` + "`json:\"key\"`" + `
+	Value json.RawMessage ` + "`json:\"value\"`" + `
+}
+
+type frugalJSONValues []json.RawMessage
+
+func (v frugalJSONValues) Len() int           { return len(v) }
+func (v frugalJSONValues) Less(i, j int) bool { return bytes.Compare(v[i], v[j]) < 0 }
+func (v frugalJSONValues) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+func frugalJSONSorted(values []interface{}) (frugalJSONValues, error) {
+	sorted := make(frugalJSONValues, 0, len(values))
+	for _, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		sorted = append(sorted, b)
+	}
+	sort.Sort(sorted)
+	return sorted, nil
+}
+
+func frugalJSONIsNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+func frugalJSONKey(key string) json.RawMessage {
+	raw, _ := json.Marshal(key)
+	return raw
+}
+
+func frugalJSONDouble(v float64) interface{} {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	}
+	return v
+}
+
+func frugalJSONParseDouble(raw json.RawMessage) (float64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		var v float64
+		err = json.Unmarshal(raw, &v)
+		return v, err
+	}
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func frugalJSONParseInt(raw json.RawMessage, bitSize int) (int64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		// Parse the number literal directly so 64-bit values keep their precision.
+		s = string(bytes.TrimSpace(raw))
+	}
+	return strconv.ParseInt(s, 10, bitSize)
+}
+
+func frugalJSONParseEnum(raw json.RawMessage) (string, int64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		v, err := frugalJSONParseInt(raw, 32)
+		return "", v, err
+	}
+	if v, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return "", v, nil
+	}
+	return s, 0, nil
+}
+`
+
+func (g *Generator) generateCanonicalJSONOption() bool {
+	_, ok := g.Options[canonicalJSONOption]
+	return ok
+}
+
+// isJSONObjectKey returns true if maps with keys of the given type are
+// written as JSON objects rather than as lists of entries.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "string":
+		return true
+	}
+	return false
+}
+
+// jsonIntBitSize returns the bit size of the given integer type, or 0 if it
+// is not an integer type.
+func jsonIntBitSize(t *parser.Type) int {
+	switch t.Name {
+	case "byte", "i8":
+		return 8
+	case "i16":
+		return 16
+	case "i32":
+		return 32
+	case "i64":
+		return 64
+	}
+	return 0
+}
+
+func (g *Generator) generateMarshalJSON(s *parser.Struct, sName string) string {
+	contents := fmt.Sprintf("func (p *%s) MarshalJSON() ([]byte, error) {\n", sName)
+	contents += fmt.Sprintf("\tm := make(map[string]interface{}, %d)\n", len(s.Fields))
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		elem := g.GetElem()
+		value := "p." + fName
+		if g.isValuePointerField(field) {
+			value = "*" + value
+		}
+		ind := "\t"
+		switch {
+		case field.Modifier == parser.Optional:
+			contents += fmt.Sprintf("\tif p.IsSet%s() {\n", fName)
+			ind = "\t\t"
+		case g.isPointerField(field):
+			contents += fmt.Sprintf("\tif p.%s != nil {\n", fName)
+			ind = "\t\t"
+		}
+		contents += fmt.Sprintf("%svar %s interface{}\n", ind, elem)
+		contents += g.generateMarshalJSONRec(field.Type, elem, value, ind)
+		contents += fmt.Sprintf("%sm[\"%s\"] = %s\n", ind, field.Name, elem)
+		if ind != "\t" {
+			contents += "\t}\n"
+		}
+	}
+	contents += "\treturn json.Marshal(m)\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateMarshalJSONRec generates code which assigns the canonical JSON
+// form of the source value of the given type to the destination.
+func (g *Generator) generateMarshalJSONRec(t *parser.Type, dst, src, ind string) string {
+	contents := ""
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		contents += fmt.Sprintf("%s%s = %s\n", ind, dst, src)
+	case g.Frugal.IsEnum(underlyingType):
+		enum := src
+		if enumType := g.getGoTypeFromThriftType(underlyingType); enumType != g.getGoTypeFromThriftType(t) {
+			enum = fmt.Sprintf("%s(%s)", enumType, src)
+		}
+		if g.isVendoredType(underlyingType) {
+			// Vendored enums may have been generated without IsValid, but
+			// String has always returned <UNSET> for undeclared values.
+			name := g.GetElem()
+			contents += fmt.Sprintf("%sif %s := %s.String(); %s != \"<UNSET>\" {\n", ind, name, enum, name)
+			contents += fmt.Sprintf("%s\t%s = %s\n", ind, dst, name)
+		} else {
+			contents += fmt.Sprintf("%sif %s.IsValid() {\n", ind, enum)
+			contents += fmt.Sprintf("%s\t%s = %s.String()\n", ind, dst, enum)
+		}
+		contents += ind + "} else {\n"
+		contents += fmt.Sprintf("%s\t%s = int64(%s)\n", ind, dst, src)
+		contents += ind + "}\n"
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		list, elem, value := g.GetElem(), g.GetElem(), g.GetElem()
+		contents += fmt.Sprintf("%s%s := make([]interface{}, 0, len(%s))\n", ind, list, src)
+		if underlyingType.Name == "list" {
+			contents += fmt.Sprintf("%sfor _, %s := range %s {\n", ind, elem, src)
+		} else {
+			contents += fmt.Sprintf("%sfor %s := range %s {\n", ind, elem, src)
+		}
+		contents += fmt.Sprintf("%s\tvar %s interface{}\n", ind, value)
+		contents += g.generateMarshalJSONRec(underlyingType.ValueType, value, elem, ind+"\t")
+		contents += fmt.Sprintf("%s\t%s = append(%s, %s)\n", ind, list, list, value)
+		contents += ind + "}\n"
+		if underlyingType.Name == "list" {
+			contents += fmt.Sprintf("%s%s = %s\n", ind, dst, list)
+		} else {
+			contents += g.generateSortedJSON(dst, list, ind)
+		}
+	case underlyingType.Name == "map" && g.isJSONObjectKey(underlyingType.KeyType):
+		obj, key, value, keyStr, elem := g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem()
+		contents += fmt.Sprintf("%s%s := make(map[string]interface{}, len(%s))\n", ind, obj, src)
+		contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, key, value, src)
+		contents += fmt.Sprintf("%s\tvar %s interface{}\n", ind, keyStr)
+		contents += g.generateMarshalJSONRec(underlyingType.KeyType, keyStr, key, ind+"\t")
+		contents += fmt.Sprintf("%s\tvar %s interface{}\n", ind, elem)
+		contents += g.generateMarshalJSONRec(underlyingType.ValueType, elem, value, ind+"\t")
+		contents += fmt.Sprintf("%s\t%s[fmt.Sprint(%s)] = %s\n", ind, obj, keyStr, elem)
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%s%s = %s\n", ind, dst, obj)
+	case underlyingType.Name == "map":
+		entries, key, value, keyElem, valueElem := g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem()
+		contents += fmt.Sprintf("%s%s := make([]interface{}, 0, len(%s))\n", ind, entries, src)
+		contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, key, value, src)
+		contents += fmt.Sprintf("%s\tvar %s, %s interface{}\n", ind, keyElem, valueElem)
+		contents += g.generateMarshalJSONRec(underlyingType.KeyType, keyElem, key, ind+"\t")
+		contents += g.generateMarshalJSONRec(underlyingType.ValueType, valueElem, value, ind+"\t")
+		contents += fmt.Sprintf("%s\t%s = append(%s, map[string]interface{}{\"key\": %s, \"value\": %s})\n",
+			ind, entries, entries, keyElem, valueElem)
+		contents += ind + "}\n"
+		contents += g.generateSortedJSON(dst, entries, ind)
+	default:
+		switch underlyingType.Name {
+		case "i64":
+			contents += fmt.Sprintf("%s%s = strconv.FormatInt(int64(%s), 10)\n", ind, dst, src)
+		case "double":
+			contents += fmt.Sprintf("%s%s = frugalJSONDouble(float64(%s))\n", ind, dst, src)
+		case "binary":
+			contents += fmt.Sprintf("%s%s = base64.StdEncoding.EncodeToString(%s)\n", ind, dst, src)
+		default:
+			contents += fmt.Sprintf("%s%s = %s\n", ind, dst, src)
+		}
+	}
+	return contents
+}
+
+func (g *Generator) generateSortedJSON(dst, values, ind string) string {
+	sorted := g.GetElem()
+	contents := fmt.Sprintf("%s%s, err := frugalJSONSorted(%s)\n", ind, sorted, values)
+	contents += ind + "if err != nil {\n"
+	contents += ind + "\treturn nil, err\n"
+	contents += ind + "}\n"
+	contents += fmt.Sprintf("%s%s = %s\n", ind, dst, sorted)
+	return contents
+}
+
+func (g *Generator) generateUnmarshalJSON(s *parser.Struct, sName string) string {
+	contents := fmt.Sprintf("func (p *%s) UnmarshalJSON(data []byte) error {\n", sName)
+	contents += "\tvar m map[string]json.RawMessage\n"
+	contents += "\tif err := json.Unmarshal(data, &m); err != nil {\n"
+	contents += "\t\treturn err\n"
+	contents += "\t}\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		elem := g.GetElem()
+		contents += fmt.Sprintf("\tif raw, ok := m[\"%s\"]; ok && !frugalJSONIsNull(raw) {\n", field.Name)
+		contents += fmt.Sprintf("\t\tvar %s %s\n", elem, g.getGoTypeFromThriftType(field.Type))
+		contents += g.generateUnmarshalJSONRec(field.Type, elem, "raw", "\t\t")
+		if g.isValuePointerField(field) {
+			contents += fmt.Sprintf("\t\tp.%s = &%s\n", fName, elem)
+		} else {
+			contents += fmt.Sprintf("\t\tp.%s = %s\n", fName, elem)
+		}
+		if field.Modifier == parser.Required {
+			errorMessage := fmt.Sprintf("Required field '%s' is not present in struct '%s'", fName, s.Name)
+			contents += "\t} else {\n"
+			contents += fmt.Sprintf("\t\treturn fmt.Errorf(\"%s\")\n", errorMessage)
+		}
+		contents += "\t}\n"
+	}
+	if s.Type == parser.StructTypeUnion {
+		// An unset union is encoded as {}, so only reject several fields.
+		contents += fmt.Sprintf("\tif c := p.CountSetFields%s(); c > 1 {\n", sName)
+		contents += "\t\treturn fmt.Errorf(\"%T unmarshal union: at most one field may be set (%d set).\", p, c)\n"
+		contents += "\t}\n"
+	}
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateUnmarshalJSONRec generates code which reads the raw canonical JSON
+// form of the given type into the destination.
+func (g *Generator) generateUnmarshalJSONRec(t *parser.Type, dst, raw, ind string) string {
+	contents := ""
+	goType := g.getGoTypeFromThriftType(t)
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		// Structs are pointers, ie *base.Thing -> base.NewThing()
+		goUnderlyingType := g.getGoTypeFromThriftType(underlyingType)
+		lastInd := strings.LastIndex(goUnderlyingType, ".")
+		if lastInd == -1 {
+			lastInd = 0
+		}
+		contents += fmt.Sprintf("%s%s = %sNew%s()\n", ind, dst, goUnderlyingType[1:lastInd+1], goUnderlyingType[lastInd+1:])
+		contents += fmt.Sprintf("%sif err := %s.UnmarshalJSON(%s); err != nil {\n", ind, dst, raw)
+		contents += ind + "\treturn err\n"
+		contents += ind + "}\n"
+	case g.Frugal.IsEnum(underlyingType):
+		enumType := g.getGoTypeFromThriftType(underlyingType)
+		contents += fmt.Sprintf("%sif name, v, err := frugalJSONParseEnum(%s); err != nil {\n", ind, raw)
+		contents += ind + "\treturn err\n"
+		contents += ind + "} else if name != \"\" {\n"
+		contents += fmt.Sprintf("%s\te, err := %sFromString(name)\n", ind, enumType)
+		contents += ind + "\tif err != nil {\n"
+		contents += ind + "\t\treturn err\n"
+		contents += ind + "\t}\n"
+		contents += fmt.Sprintf("%s\t%s = %s(e)\n", ind, dst, goType)
+		contents += ind + "} else {\n"
+		contents += fmt.Sprintf("%s\t%s = %s(v)\n", ind, dst, goType)
+		contents += ind + "}\n"
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		raws, elemRaw, elem := g.GetElem(), g.GetElem(), g.GetElem()
+		contents += fmt.Sprintf("%svar %s []json.RawMessage\n", ind, raws)
+		contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", ind, raw, raws)
+		contents += ind + "\treturn err\n"
+		contents += ind + "}\n"
+		if underlyingType.Name == "list" {
+			contents += fmt.Sprintf("%s%s = make(%s, 0, len(%s))\n", ind, dst, goType, raws)
+		} else {
+			contents += fmt.Sprintf("%s%s = make(%s, len(%s))\n", ind, dst, goType, raws)
+		}
+		contents += fmt.Sprintf("%sfor _, %s := range %s {\n", ind, elemRaw, raws)
+		contents += fmt.Sprintf("%s\tvar %s %s\n", ind, elem, g.getGoTypeFromThriftType(underlyingType.ValueType))
+		contents += g.generateUnmarshalJSONRec(underlyingType.ValueType, elem, elemRaw, ind+"\t")
+		if underlyingType.Name == "list" {
+			contents += fmt.Sprintf("%s\t%s = append(%s, %s)\n", ind, dst, dst, elem)
+		} else {
+			contents += fmt.Sprintf("%s\t%s[%s] = true\n", ind, dst, elem)
+		}
+		contents += ind + "}\n"
+	case underlyingType.Name == "map":
+		objectKeys := g.isJSONObjectKey(underlyingType.KeyType)
+		raws, keyRaw, valueRaw, key, value := g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem(), g.GetElem()
+		if objectKeys {
+			contents += fmt.Sprintf("%svar %s map[string]json.RawMessage\n", ind, raws)
+		} else {
+			contents += fmt.Sprintf("%svar %s []frugalJSONEntry\n", ind, raws)
+		}
+		contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", ind, raw, raws)
+		contents += ind + "\treturn err\n"
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%s%s = make(%s, len(%s))\n", ind, dst, goType, raws)
+		if objectKeys {
+			keyStr := g.GetElem()
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, keyStr, valueRaw, raws)
+			if g.Frugal.UnderlyingType(underlyingType.KeyType).Name == "bool" {
+				contents += fmt.Sprintf("%s\t%s := json.RawMessage(%s)\n", ind, keyRaw, keyStr)
+			} else {
+				contents += fmt.Sprintf("%s\t%s := frugalJSONKey(%s)\n", ind, keyRaw, keyStr)
+			}
+		} else {
+			entry := g.GetElem()
+			contents += fmt.Sprintf("%sfor _, %s := range %s {\n", ind, entry, raws)
+			contents += fmt.Sprintf("%s\t%s, %s := %s.Key, %s.Value\n", ind, keyRaw, valueRaw, entry, entry)
+		}
+		contents += fmt.Sprintf("%s\tvar %s %s\n", ind, key, g.getGoTypeFromThriftType(underlyingType.KeyType))
+		contents += g.generateUnmarshalJSONRec(underlyingType.KeyType, key, keyRaw, ind+"\t")
+		contents += fmt.Sprintf("%s\tvar %s %s\n", ind, value, g.getGoTypeFromThriftType(underlyingType.ValueType))
+		contents += g.generateUnmarshalJSONRec(underlyingType.ValueType, value, valueRaw, ind+"\t")
+		contents += fmt.Sprintf("%s\t%s[%s] = %s\n", ind, dst, key, value)
+		contents += ind + "}\n"
+	default:
+		switch {
+		case jsonIntBitSize(underlyingType) > 0:
+			contents += fmt.Sprintf("%sif v, err := frugalJSONParseInt(%s, %d); err != nil {\n", ind, raw, jsonIntBitSize(underlyingType))
+			contents += ind + "\treturn err\n"
+			contents += ind + "} else {\n"
+			contents += fmt.Sprintf("%s\t%s = %s(v)\n", ind, dst, goType)
+			contents += ind + "}\n"
+		case underlyingType.Name == "double":
+			contents += fmt.Sprintf("%sif v, err := frugalJSONParseDouble(%s); err != nil {\n", ind, raw)
+			contents += ind + "\treturn err\n"
+			contents += ind + "} else {\n"
+			contents += fmt.Sprintf("%s\t%s = %s(v)\n", ind, dst, goType)
+			contents += ind + "}\n"
+		default:
+			contents += fmt.Sprintf("%sif err := json.Unmarshal(%s, &%s); err != nil {\n", ind, raw, dst)
+			contents += ind + "\treturn err\n"
+			contents += ind + "}\n"
+		}
+	}
+	return contents
+}
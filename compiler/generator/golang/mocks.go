@@ -0,0 +1,521 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const (
+	mockSuffix = "_mock"
+	fakeSuffix = "_fake"
+)
+
+func (g *Generator) generateMocksOption() bool {
+	_, ok := g.Options[mocksOption]
+	return ok
+}
+
+// generateSupportFile writes a file alongside the service and scope files
+// containing test support code for them.
+func (g *Generator) generateSupportFile(name, outputDir, imports, contents string) error {
+	file, err := g.CreateFile(name, outputDir, lang, true)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := g.GenerateDocStringComment(file); err != nil {
+		return err
+	}
+	if err := g.GenerateNewline(file, 2); err != nil {
+		return err
+	}
+	if err := g.generatePackage(file); err != nil {
+		return err
+	}
+	if err := g.GenerateNewline(file, 2); err != nil {
+		return err
+	}
+	if _, err := file.WriteString(imports + "\n\n" + contents); err != nil {
+		return err
+	}
+	return g.PostProcess(file)
+}
+
+// generateSupportImports generates the imports for a mock or fake file. Unused
+// imports are removed by PostProcess.
+func (g *Generator) generateSupportImports(includes []*parser.Include) (string, error) {
+	imports := "import (\n"
+	if g.generateContext() {
+		imports += "\t\"context\"\n"
+	}
+	imports += "\t\"fmt\"\n"
+	imports += "\t\"reflect\"\n"
+	imports += "\t\"sync\"\n\n"
+	if g.Options[frugalImportOption] != "" {
+		imports += "\t\"" + g.Options[frugalImportOption] + "\"\n"
+	} else {
+		imports += "\t\"github.com/Workiva/frugal/lib/go\"\n"
+	}
+
+	pkgPrefix := g.Options[packagePrefixOption]
+	for _, include := range includes {
+		imp, err := g.generateIncludeImport(include, pkgPrefix)
+		if err != nil {
+			return "", err
+		}
+		imports += imp
+	}
+	imports += ")"
+	return imports, nil
+}
+
+// generateServiceMockFile generates a programmable mock implementation of the
+// given service's interface.
+func (g *Generator) generateServiceMockFile(service *parser.Service, outputDir string) error {
+	includes, err := service.ReferencedIncludes()
+	if err != nil {
+		return err
+	}
+	imports, err := g.generateSupportImports(includes)
+	if err != nil {
+		return err
+	}
+	name := strings.ToLower(service.Name) + serviceSuffix + mockSuffix
+	return g.generateSupportFile(name, outputDir, imports, g.generateServiceMock(service))
+}
+
+func (g *Generator) generateServiceMock(service *parser.Service) string {
+	servTitle := snakeToCamel(service.Name)
+	mockName := "F" + servTitle + "Mock"
+	contents := ""
+
+	contents += fmt.Sprintf("// %s is a programmable F%s for use in tests. Use Expect<Method> to\n", mockName, servTitle)
+	contents += "// expect calls with given arguments, or set the <Method>Func fields to define\n"
+	contents += "// how each method behaves. Every call is recorded, and calls which are neither\n"
+	contents += "// expected nor handled by a Func return an error.\n"
+	contents += fmt.Sprintf("type %s struct {\n", mockName)
+	if service.Extends != "" {
+		contents += fmt.Sprintf("\t*%sF%sMock\n\n", g.getServiceExtendsNamespace(service), service.ExtendsService())
+	}
+	contents += "\tmu sync.Mutex\n\n"
+	for _, method := range service.Methods {
+		methodTitle := snakeToCamel(method.Name)
+		contents += fmt.Sprintf("\t%sFunc func(ctx %s%s) %s\n", methodTitle, g.handlerContextType(),
+			g.generateInterfaceArgs(method.Arguments), g.generateMockReturnTypes(method))
+	}
+	contents += "\n"
+	for _, method := range service.Methods {
+		contents += fmt.Sprintf("\t%sCalls []*%s%sCall\n", parser.LowercaseFirstLetter(snakeToCamel(method.Name)),
+			mockName, snakeToCamel(method.Name))
+	}
+	contents += "\n"
+	for _, method := range service.Methods {
+		contents += fmt.Sprintf("\t%sExpectations []*%s%sExpectation\n", parser.LowercaseFirstLetter(snakeToCamel(method.Name)),
+			mockName, snakeToCamel(method.Name))
+	}
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("var _ F%s = (*%s)(nil)\n\n", servTitle, mockName)
+
+	contents += fmt.Sprintf("// New%s creates a new %s with no method behavior set.\n", mockName, mockName)
+	contents += fmt.Sprintf("func New%s() *%s {\n", mockName, mockName)
+	if service.Extends != "" {
+		contents += fmt.Sprintf("\treturn &%s{F%sMock: %sNewF%sMock()}\n", mockName, service.ExtendsService(),
+			g.getServiceExtendsNamespace(service), service.ExtendsService())
+	} else {
+		contents += fmt.Sprintf("\treturn &%s{}\n", mockName)
+	}
+	contents += "}\n\n"
+
+	for _, method := range service.Methods {
+		contents += g.generateMockMethod(mockName, method)
+	}
+	contents += g.generateMockAssertExpectations(service, mockName)
+	return contents
+}
+
+func (g *Generator) generateMockReturnTypes(method *parser.Method) string {
+	if method.ReturnType == nil {
+		return "error"
+	}
+	return fmt.Sprintf("(%s, error)", g.getGoTypeFromThriftType(method.ReturnType))
+}
+
+func (g *Generator) generateMockMethod(mockName string, method *parser.Method) string {
+	methodTitle := snakeToCamel(method.Name)
+	callsField := parser.LowercaseFirstLetter(methodTitle) + "Calls"
+	callName := mockName + methodTitle + "Call"
+	expectationsField := parser.LowercaseFirstLetter(methodTitle) + "Expectations"
+	argsMatch := ""
+	for _, arg := range method.Arguments {
+		argsMatch += fmt.Sprintf(" && reflect.DeepEqual(e.args.%s, %s)", title(arg.Name), arg.Name)
+	}
+	contents := ""
+
+	contents += fmt.Sprintf("// %s records a call to %s.%s.\n", callName, mockName, methodTitle)
+	contents += fmt.Sprintf("type %s struct {\n", callName)
+	contents += fmt.Sprintf("\tCtx %s\n", g.handlerContextType())
+	for _, arg := range method.Arguments {
+		contents += fmt.Sprintf("\t%s %s\n", title(arg.Name), g.getGoTypeFromThriftType(arg.Type))
+	}
+	contents += "}\n\n"
+
+	callArgs := "ctx"
+	for _, arg := range method.Arguments {
+		callArgs += ", " + arg.Name
+	}
+
+	contents += fmt.Sprintf("func (m *%s) %s(ctx %s%s) %s {\n", mockName, methodTitle, g.handlerContextType(),
+		g.generateInterfaceArgs(method.Arguments), g.generateReturnArgs(method))
+	contents += "\tm.mu.Lock()\n"
+	contents += fmt.Sprintf("\tm.%s = append(m.%s, &%s{\n", callsField, callsField, callName)
+	contents += "\t\tCtx: ctx,\n"
+	for _, arg := range method.Arguments {
+		contents += fmt.Sprintf("\t\t%s: %s,\n", title(arg.Name), arg.Name)
+	}
+	contents += "\t})\n"
+	contents += fmt.Sprintf("\tfor _, e := range m.%s {\n", expectationsField)
+	contents += fmt.Sprintf("\t\tif e.calls < e.times%s {\n", argsMatch)
+	contents += "\t\t\te.calls++\n"
+	contents += "\t\t\tm.mu.Unlock()\n"
+	if method.ReturnType == nil {
+		contents += "\t\t\treturn e.err\n"
+	} else {
+		contents += "\t\t\treturn e.r, e.err\n"
+	}
+	contents += "\t\t}\n"
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\tfn := m.%sFunc\n", methodTitle)
+	contents += "\tm.mu.Unlock()\n"
+	contents += "\tif fn == nil {\n"
+	errMsg := fmt.Sprintf("fmt.Errorf(\"%s: unexpected call to %s\")", mockName, methodTitle)
+	if method.ReturnType == nil {
+		contents += fmt.Sprintf("\t\treturn %s\n", errMsg)
+	} else {
+		contents += fmt.Sprintf("\t\treturn r, %s\n", errMsg)
+	}
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\treturn fn(%s)\n", callArgs)
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// %sCalls returns the calls made to %s in the order they were made.\n", methodTitle, methodTitle)
+	contents += fmt.Sprintf("func (m *%s) %sCalls() []*%s {\n", mockName, methodTitle, callName)
+	contents += "\tm.mu.Lock()\n"
+	contents += "\tdefer m.mu.Unlock()\n"
+	contents += fmt.Sprintf("\treturn append([]*%s(nil), m.%s...)\n", callName, callsField)
+	contents += "}\n\n"
+
+	contents += g.generateMockExpectation(mockName, method)
+	return contents
+}
+
+func (g *Generator) generateMockExpectation(mockName string, method *parser.Method) string {
+	methodTitle := snakeToCamel(method.Name)
+	callName := mockName + methodTitle + "Call"
+	expectationName := mockName + methodTitle + "Expectation"
+	expectationsField := parser.LowercaseFirstLetter(methodTitle) + "Expectations"
+	contents := ""
+
+	contents += fmt.Sprintf("// %s is an expected call to %s.%s.\n", expectationName, mockName, methodTitle)
+	contents += fmt.Sprintf("type %s struct {\n", expectationName)
+	contents += "\tmu    *sync.Mutex\n"
+	contents += fmt.Sprintf("\targs  *%s\n", callName)
+	if method.ReturnType != nil {
+		contents += fmt.Sprintf("\tr     %s\n", g.getGoTypeFromThriftType(method.ReturnType))
+	}
+	contents += "\terr   error\n"
+	contents += "\ttimes int\n"
+	contents += "\tcalls int\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// Expect%s expects a call to %s with the given arguments. The call\n", methodTitle, methodTitle)
+	contents += fmt.Sprintf("// is expected once unless changed with Times and takes precedence over\n// %sFunc.\n", methodTitle)
+	contents += fmt.Sprintf("func (m *%s) Expect%s(%s) *%s {\n", mockName, methodTitle,
+		strings.TrimPrefix(g.generateInterfaceArgs(method.Arguments), ", "), expectationName)
+	contents += "\tm.mu.Lock()\n"
+	contents += "\tdefer m.mu.Unlock()\n"
+	contents += fmt.Sprintf("\te := &%s{mu: &m.mu, times: 1, args: &%s{\n", expectationName, callName)
+	for _, arg := range method.Arguments {
+		contents += fmt.Sprintf("\t\t%s: %s,\n", title(arg.Name), arg.Name)
+	}
+	contents += "\t}}\n"
+	contents += fmt.Sprintf("\tm.%s = append(m.%s, e)\n", expectationsField, expectationsField)
+	contents += "\treturn e\n"
+	contents += "}\n\n"
+
+	contents += "// Return sets the results of the expected call.\n"
+	if method.ReturnType == nil {
+		contents += fmt.Sprintf("func (e *%s) Return(err error) *%s {\n", expectationName, expectationName)
+		contents += "\te.mu.Lock()\n"
+		contents += "\tdefer e.mu.Unlock()\n"
+		contents += "\te.err = err\n"
+	} else {
+		contents += fmt.Sprintf("func (e *%s) Return(r %s, err error) *%s {\n", expectationName,
+			g.getGoTypeFromThriftType(method.ReturnType), expectationName)
+		contents += "\te.mu.Lock()\n"
+		contents += "\tdefer e.mu.Unlock()\n"
+		contents += "\te.r, e.err = r, err\n"
+	}
+	contents += "\treturn e\n"
+	contents += "}\n\n"
+
+	contents += "// Times sets the number of times the call is expected.\n"
+	contents += fmt.Sprintf("func (e *%s) Times(n int) *%s {\n", expectationName, expectationName)
+	contents += "\te.mu.Lock()\n"
+	contents += "\tdefer e.mu.Unlock()\n"
+	contents += "\te.times = n\n"
+	contents += "\treturn e\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateMockAssertExpectations generates a method checking every expected
+// call of a mock, including those of the mock it embeds, was made.
+func (g *Generator) generateMockAssertExpectations(service *parser.Service, mockName string) string {
+	contents := ""
+	contents += "// AssertExpectations reports an error to t for each expected call which was\n"
+	contents += "// not made the expected number of times and returns whether all were.\n"
+	contents += fmt.Sprintf("func (m *%s) AssertExpectations(t interface {\n", mockName)
+	contents += "\tErrorf(format string, args ...interface{})\n"
+	contents += "}) bool {\n"
+	if service.Extends != "" {
+		contents += fmt.Sprintf("\tok := m.F%sMock.AssertExpectations(t)\n", service.ExtendsService())
+	} else {
+		contents += "\tok := true\n"
+	}
+	contents += "\tm.mu.Lock()\n"
+	contents += "\tdefer m.mu.Unlock()\n"
+	for _, method := range service.Methods {
+		methodTitle := snakeToCamel(method.Name)
+		format := make([]string, len(method.Arguments))
+		args := ""
+		for i, arg := range method.Arguments {
+			format[i] = "%v"
+			args += fmt.Sprintf("e.args.%s, ", title(arg.Name))
+		}
+		contents += fmt.Sprintf("\tfor _, e := range m.%sExpectations {\n", parser.LowercaseFirstLetter(methodTitle))
+		contents += "\t\tif e.calls != e.times {\n"
+		contents += fmt.Sprintf("\t\t\tt.Errorf(\"%s: expected %%d calls to %s(%s), got %%d\", e.times, %se.calls)\n",
+			mockName, methodTitle, strings.Join(format, ", "), args)
+		contents += "\t\t\tok = false\n"
+		contents += "\t\t}\n"
+		contents += "\t}\n"
+	}
+	contents += "\treturn ok\n"
+	contents += "}\n"
+	return contents
+}
+
+// generateScopeFakeFile generates an in-memory implementation of the given
+// scope's publisher and subscriber interfaces.
+func (g *Generator) generateScopeFakeFile(scope *parser.Scope, outputDir string) error {
+	includes, err := g.Frugal.ReferencedScopeIncludes()
+	if err != nil {
+		return err
+	}
+	imports, err := g.generateSupportImports(includes)
+	if err != nil {
+		return err
+	}
+	name := strings.ToLower(scope.Name) + scopeSuffix + fakeSuffix
+	return g.generateSupportFile(name, outputDir, imports, g.generateScopeFake(scope))
+}
+
+func (g *Generator) generateScopeFake(scope *parser.Scope) string {
+	var (
+		scopeLower = parser.LowercaseFirstLetter(scope.Name)
+		scopeCamel = snakeToCamel(scope.Name)
+		scopeTitle = strings.Title(scope.Name)
+		fakeName   = scopeCamel + "Fake"
+		subName    = scopeLower + "FakeSubscription"
+		contents   = ""
+	)
+
+	args := ""
+	argsWithoutTypes := ""
+	if len(scope.Prefix.Variables) > 0 {
+		args = strings.Join(scope.Prefix.Variables, ", ")
+		argsWithoutTypes = args + ", "
+		args += " string, "
+	}
+
+	contents += fmt.Sprintf("// %s is an in-memory fake of the %s scope for use in tests. It\n", fakeName, scopeCamel)
+	contents += "// implements the publisher and both subscriber interfaces. Published messages\n"
+	contents += "// are recorded and delivered synchronously, without serialization, to\n"
+	contents += "// subscribers of the same topic.\n"
+	contents += fmt.Sprintf("type %s struct {\n", fakeName)
+	contents += "\tmu          sync.Mutex\n"
+	contents += fmt.Sprintf("\tpublished   []*%sMessage\n", fakeName)
+	contents += fmt.Sprintf("\tsubscribers map[string][]*%s\n", subName)
+	contents += "}\n\n"
+
+	contents += "var (\n"
+	contents += fmt.Sprintf("\t_ %sPublisher          = (*%s)(nil)\n", scopeCamel, fakeName)
+	contents += fmt.Sprintf("\t_ %sSubscriber         = (*%s)(nil)\n", scopeCamel, fakeName)
+	contents += fmt.Sprintf("\t_ %sErrorableSubscriber = (*%s)(nil)\n", scopeCamel, fakeName)
+	contents += ")\n\n"
+
+	contents += fmt.Sprintf("// %sMessage records a message published to the fake.\n", fakeName)
+	contents += fmt.Sprintf("type %sMessage struct {\n", fakeName)
+	contents += "\tOp    string\n"
+	contents += "\tTopic string\n"
+	contents += "\tCtx   frugal.FContext\n"
+	contents += "\tReq   interface{}\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// New%s creates a new %s with no published messages or subscribers.\n", fakeName, fakeName)
+	contents += fmt.Sprintf("func New%s() *%s {\n", fakeName, fakeName)
+	contents += fmt.Sprintf("\treturn &%s{subscribers: make(map[string][]*%s)}\n", fakeName, subName)
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) Open() error {\n", fakeName)
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) Close() error {\n", fakeName)
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+
+	contents += "// Published returns every message published in the order they were published.\n"
+	contents += fmt.Sprintf("func (f *%s) Published() []*%sMessage {\n", fakeName, fakeName)
+	contents += "\tf.mu.Lock()\n"
+	contents += "\tdefer f.mu.Unlock()\n"
+	contents += fmt.Sprintf("\treturn append([]*%sMessage(nil), f.published...)\n", fakeName)
+	contents += "}\n\n"
+
+	contents += "// Reset clears the published messages. Subscribers are kept.\n"
+	contents += fmt.Sprintf("func (f *%s) Reset() {\n", fakeName)
+	contents += "\tf.mu.Lock()\n"
+	contents += "\tf.published = nil\n"
+	contents += "\tf.mu.Unlock()\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) publish(ctx frugal.FContext, op, topic string, req interface{}) {\n", fakeName)
+	contents += "\tf.mu.Lock()\n"
+	contents += fmt.Sprintf("\tf.published = append(f.published, &%sMessage{Op: op, Topic: topic, Ctx: ctx, Req: req})\n", fakeName)
+	contents += fmt.Sprintf("\tsubs := append([]*%s(nil), f.subscribers[topic]...)\n", subName)
+	contents += "\tf.mu.Unlock()\n"
+	contents += "\tfor _, sub := range subs {\n"
+	contents += "\t\t// As with a real subscriber, handler errors are not returned to the publisher.\n"
+	contents += "\t\tsub.handler(ctx, req)\n"
+	contents += "\t}\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) subscribe(topic string, handler func(frugal.FContext, interface{}) error) *frugal.FSubscription {\n", fakeName)
+	contents += fmt.Sprintf("\tsub := &%s{fake: f, handler: handler}\n", subName)
+	contents += "\tsub.Subscribe(topic, nil)\n"
+	contents += "\treturn frugal.NewFSubscription(topic, sub)\n"
+	contents += "}\n\n"
+
+	for _, op := range scope.Operations {
+		contents += g.generateFakeOperation(scope, op, fakeName, scopeTitle, args, argsWithoutTypes)
+	}
+
+	contents += g.generateFakeSubscription(fakeName, subName)
+	return contents
+}
+
+func (g *Generator) generateFakeOperation(scope *parser.Scope, op *parser.Operation, fakeName, scopeTitle, args, argsWithoutTypes string) string {
+	opType := g.getGoTypeFromThriftType(op.Type)
+	topic := fmt.Sprintf("\top := \"%s\"\n", op.Name)
+	topic += fmt.Sprintf("\tprefix := %s\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
+	topic += "\ttopic := fmt.Sprintf(\"%s" + scopeTitle + "%s%s\", prefix, delimiter, op)\n"
+	contents := ""
+
+	contents += fmt.Sprintf("func (f *%s) Publish%s(ctx frugal.FContext, %sreq %s) error {\n", fakeName, op.Name, args, opType)
+	contents += topic
+	contents += "\tf.publish(ctx, op, topic, req)\n"
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("// Published%s returns the requests published with Publish%s.\n", op.Name, op.Name)
+	contents += fmt.Sprintf("func (f *%s) Published%s() []%s {\n", fakeName, op.Name, opType)
+	contents += "\tf.mu.Lock()\n"
+	contents += "\tdefer f.mu.Unlock()\n"
+	contents += fmt.Sprintf("\tvar reqs []%s\n", opType)
+	contents += "\tfor _, msg := range f.published {\n"
+	contents += fmt.Sprintf("\t\tif msg.Op == \"%s\" {\n", op.Name)
+	contents += fmt.Sprintf("\t\t\treqs = append(reqs, msg.Req.(%s))\n", opType)
+	contents += "\t\t}\n"
+	contents += "\t}\n"
+	contents += "\treturn reqs\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) Subscribe%s(%shandler func(frugal.FContext, %s)) (*frugal.FSubscription, error) {\n",
+		fakeName, op.Name, args, opType)
+	contents += fmt.Sprintf("\treturn f.Subscribe%sErrorable(%sfunc(fctx frugal.FContext, arg %s) error {\n",
+		op.Name, argsWithoutTypes, opType)
+	contents += "\t\thandler(fctx, arg)\n"
+	contents += "\t\treturn nil\n"
+	contents += "\t})\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (f *%s) Subscribe%sErrorable(%shandler func(frugal.FContext, %s) error) (*frugal.FSubscription, error) {\n",
+		fakeName, op.Name, args, opType)
+	contents += topic
+	contents += "\treturn f.subscribe(topic, func(fctx frugal.FContext, req interface{}) error {\n"
+	contents += fmt.Sprintf("\t\treturn handler(fctx, req.(%s))\n", opType)
+	contents += "\t}), nil\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateFakeSubscription generates the FSubscriberTransport backing the
+// subscriptions returned by a scope fake.
+func (g *Generator) generateFakeSubscription(fakeName, subName string) string {
+	contents := ""
+	contents += fmt.Sprintf("type %s struct {\n", subName)
+	contents += fmt.Sprintf("\tfake    *%s\n", fakeName)
+	contents += "\ttopic   string\n"
+	contents += "\thandler func(frugal.FContext, interface{}) error\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (s *%s) Subscribe(topic string, callback frugal.FAsyncCallback) error {\n", subName)
+	contents += "\ts.fake.mu.Lock()\n"
+	contents += "\tdefer s.fake.mu.Unlock()\n"
+	contents += "\ts.topic = topic\n"
+	contents += "\ts.fake.subscribers[topic] = append(s.fake.subscribers[topic], s)\n"
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (s *%s) Unsubscribe() error {\n", subName)
+	contents += "\ts.fake.mu.Lock()\n"
+	contents += "\tdefer s.fake.mu.Unlock()\n"
+	contents += "\tsubs := s.fake.subscribers[s.topic]\n"
+	contents += "\tfor i, sub := range subs {\n"
+	contents += "\t\tif sub == s {\n"
+	contents += "\t\t\ts.fake.subscribers[s.topic] = append(subs[:i:i], subs[i+1:]...)\n"
+	contents += "\t\t\tbreak\n"
+	contents += "\t\t}\n"
+	contents += "\t}\n"
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+
+	contents += fmt.Sprintf("func (s *%s) IsSubscribed() bool {\n", subName)
+	contents += "\ts.fake.mu.Lock()\n"
+	contents += "\tdefer s.fake.mu.Unlock()\n"
+	contents += "\tfor _, sub := range s.fake.subscribers[s.topic] {\n"
+	contents += "\t\tif sub == s {\n"
+	contents += "\t\t\treturn true\n"
+	contents += "\t\t}\n"
+	contents += "\t}\n"
+	contents += "\treturn false\n"
+	contents += "}\n"
+	return contents
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// generateValidate generates a Validate method which checks the constraints
+// declared with validate.* annotations, if the struct has any.
+func (g *Generator) generateValidate(s *parser.Struct, sName string) string {
+	if !g.Frugal.NeedsValidation(s) {
+		return ""
+	}
+
+	contents := ""
+	for _, field := range s.Fields {
+		if pattern, ok := field.Annotations.Get(parser.ValidatePattern); ok {
+			contents += fmt.Sprintf("var %s_%s_PATTERN = regexp.MustCompile(%s)\n\n",
+				sName, title(field.Name), strconv.Quote(pattern))
+		}
+	}
+
+	contents += fmt.Sprintf("func (p *%s) Validate() error {\n", sName)
+	contents += "\tif p == nil {\n"
+	contents += "\t\treturn nil\n"
+	contents += "\t}\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		value, ind := "p."+fName, "\t"
+		// Constraints only apply to optional fields which are set.
+		unsettable := g.isValuePointerField(field) || g.isNillableField(field)
+		if unsettable {
+			ind = "\t\t"
+			if g.isValuePointerField(field) {
+				value = "*" + value
+			}
+		}
+		checks := g.generateConstraintChecks(s, sName, field, value, ind)
+		checks += g.generateValidateRec(field.Type, value, ind)
+		if checks == "" {
+			continue
+		}
+		if unsettable {
+			contents += fmt.Sprintf("\tif p.%s != nil {\n", fName)
+			contents += checks
+			contents += "\t}\n"
+		} else {
+			contents += checks
+		}
+	}
+	contents += "\treturn nil\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateConstraintChecks generates code which returns an error if the
+// field's value violates one of its constraints.
+func (g *Generator) generateConstraintChecks(s *parser.Struct, sName string, field *parser.Field, value, ind string) string {
+	contents := ""
+	name := fmt.Sprintf("%s.%s", s.Name, field.Name)
+	verb := "%v"
+	switch g.Frugal.UnderlyingType(field.Type).Name {
+	case "byte", "i8", "i16", "i32", "i64":
+		verb = "%d"
+	case "string":
+		verb = "%q"
+	}
+	for _, constraint := range field.Annotations.Constraints() {
+		switch constraint.Name {
+		case parser.ValidateMin:
+			bound := g.constraintBound(field, constraint)
+			contents += fmt.Sprintf("%sif %s < %s {\n", ind, value, bound)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must be at least %s, got %s\", %s)\n",
+				ind, name, bound, verb, value)
+			contents += ind + "}\n"
+		case parser.ValidateMax:
+			bound := g.constraintBound(field, constraint)
+			contents += fmt.Sprintf("%sif %s > %s {\n", ind, value, bound)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must be at most %s, got %s\", %s)\n",
+				ind, name, bound, verb, value)
+			contents += ind + "}\n"
+		case parser.ValidateMinLen:
+			bound := g.constraintBound(field, constraint)
+			contents += fmt.Sprintf("%sif len(%s) < %s {\n", ind, value, bound)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must have a length of at least %s, got %%d\", len(%s))\n",
+				ind, name, bound, value)
+			contents += ind + "}\n"
+		case parser.ValidateMaxLen:
+			bound := g.constraintBound(field, constraint)
+			contents += fmt.Sprintf("%sif len(%s) > %s {\n", ind, value, bound)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must have a length of at most %s, got %%d\", len(%s))\n",
+				ind, name, bound, value)
+			contents += ind + "}\n"
+		case parser.ValidatePattern:
+			pattern := fmt.Sprintf("%s_%s_PATTERN", sName, title(field.Name))
+			contents += fmt.Sprintf("%sif !%s.MatchString(string(%s)) {\n", ind, pattern, value)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must match %%s, got %%q\", %s, %s)\n",
+				ind, name, pattern, value)
+			contents += ind + "}\n"
+		case parser.ValidateNonEmpty:
+			contents += fmt.Sprintf("%sif len(%s) == 0 {\n", ind, value)
+			contents += fmt.Sprintf("%s\treturn fmt.Errorf(\"%s must not be empty\")\n", ind, name)
+			contents += ind + "}\n"
+		}
+	}
+	return contents
+}
+
+// constraintBound returns the Go literal for the value of a min, max, min_len
+// or max_len constraint, which the parser has checked is a finite number. The
+// value is reformatted so that, e.g., 010 is not read as an octal literal.
+func (g *Generator) constraintBound(field *parser.Field, constraint *parser.Annotation) string {
+	isLength := constraint.Name == parser.ValidateMinLen || constraint.Name == parser.ValidateMaxLen
+	if !isLength && g.Frugal.UnderlyingType(field.Type).Name == "double" {
+		bound, _ := strconv.ParseFloat(constraint.Value, 64)
+		return strconv.FormatFloat(bound, 'g', -1, 64)
+	}
+	bound, _ := strconv.ParseInt(constraint.Value, 10, 64)
+	return strconv.FormatInt(bound, 10)
+}
+
+// generateValidateRec generates code which validates the structs contained in
+// a value of the given type.
+func (g *Generator) generateValidateRec(t *parser.Type, value, ind string) string {
+	if !g.typeNeedsValidation(t) {
+		return ""
+	}
+	contents := ""
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch underlyingType.Name {
+	case "list":
+		elem := g.GetElem()
+		contents += fmt.Sprintf("%sfor _, %s := range %s {\n", ind, elem, value)
+		contents += g.generateValidateRec(underlyingType.ValueType, elem, ind+"\t")
+		contents += ind + "}\n"
+	case "set":
+		elem := g.GetElem()
+		contents += fmt.Sprintf("%sfor %s := range %s {\n", ind, elem, value)
+		contents += g.generateValidateRec(underlyingType.ValueType, elem, ind+"\t")
+		contents += ind + "}\n"
+	case "map":
+		key, elem := "_", "_"
+		keyChecks, elemChecks := "", ""
+		if g.typeNeedsValidation(underlyingType.KeyType) {
+			key = g.GetElem()
+			keyChecks = g.generateValidateRec(underlyingType.KeyType, key, ind+"\t")
+		}
+		if g.typeNeedsValidation(underlyingType.ValueType) {
+			elem = g.GetElem()
+			elemChecks = g.generateValidateRec(underlyingType.ValueType, elem, ind+"\t")
+		}
+		if elem == "_" {
+			contents += fmt.Sprintf("%sfor %s := range %s {\n", ind, key, value)
+		} else {
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, key, elem, value)
+		}
+		contents += keyChecks + elemChecks
+		contents += ind + "}\n"
+	default:
+		contents += fmt.Sprintf("%sif err := %s.Validate(); err != nil {\n", ind, value)
+		contents += ind + "\treturn err\n"
+		contents += ind + "}\n"
+	}
+	return contents
+}
+
+// typeNeedsValidation returns true if values of the type contain structs with
+// constraints.
+func (g *Generator) typeNeedsValidation(t *parser.Type) bool {
+	return g.Frugal.NeedsValidation(&parser.Struct{Fields: []*parser.Field{parser.FieldFromType(t, "")}})
+}
+
+// argsNeedValidation returns true if the method's args struct has a Validate
+// method.
+func (g *Generator) argsNeedValidation(method *parser.Method) bool {
+	return g.Frugal.NeedsValidation(&parser.Struct{Fields: method.Arguments})
+}
+
+// typesUsePatterns returns true if a struct in the types file has a
+// validate.pattern annotation.
+func (g *Generator) typesUsePatterns() bool {
+	for _, s := range g.Frugal.DataStructures() {
+		if hasPatternConstraint(s.Fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceUsesPatterns returns true if a method argument of the service has a
+// validate.pattern annotation.
+func serviceUsesPatterns(service *parser.Service) bool {
+	for _, method := range service.Methods {
+		if hasPatternConstraint(method.Arguments) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPatternConstraint returns true if one of the fields has a
+// validate.pattern annotation.
+func hasPatternConstraint(fields []*parser.Field) bool {
+	for _, field := range fields {
+		if _, ok := field.Annotations.Get(parser.ValidatePattern); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,410 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package golang
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+// hashHelpers are written to the types file when the hash option is set. They
+// feed values to a hash in a form which does not depend on the platform.
+const hashHelpers = `
+func frugalHashInt(h hash.Hash64, v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	h.Write(b[:])
+}
+
+func frugalHashBool(h hash.Hash64, v bool) {
+	if v {
+		frugalHashInt(h, 1)
+	} else {
+		frugalHashInt(h, 0)
+	}
+}
+
+func frugalHashDouble(h hash.Hash64, v float64) {
+	if v == 0 {
+		// Hash negative zero the same as zero since they are equal.
+		v = 0
+	}
+	frugalHashInt(h, int64(math.Float64bits(v)))
+}
+
+func frugalHashString(h hash.Hash64, v string) {
+	frugalHashInt(h, int64(len(v)))
+	io.WriteString(h, v)
+}
+
+func frugalHashBytes(h hash.Hash64, v []byte) {
+	frugalHashInt(h, int64(len(v)))
+	h.Write(v)
+}
+`
+
+func (g *Generator) generateEqualsOption() bool {
+	_, ok := g.Options[equalsOption]
+	return ok
+}
+
+func (g *Generator) generateDeepCopyOption() bool {
+	_, ok := g.Options[deepCopyOption]
+	return ok
+}
+
+func (g *Generator) generateHashOption() bool {
+	_, ok := g.Options[hashOption]
+	return ok
+}
+
+// generateValueMethods generates the Equals, DeepCopy, Hash and JSON methods
+// enabled by the generator options.
+func (g *Generator) generateValueMethods(s *parser.Struct) string {
+	contents := ""
+	sName := title(s.Name)
+	if g.generateEqualsOption() {
+		contents += g.generateEquals(s, sName)
+	}
+	if g.generateDeepCopyOption() {
+		contents += g.generateDeepCopy(s, sName)
+	}
+	if g.generateHashOption() {
+		contents += g.generateHash(s, sName)
+	}
+	if g.generateCanonicalJSONOption() {
+		contents += g.generateMarshalJSON(s, sName)
+		contents += g.generateUnmarshalJSON(s, sName)
+	}
+	return contents
+}
+
+// isNillableField returns true if the field is not a pointer but is unset
+// when nil, i.e. an optional binary or container without a default.
+func (g *Generator) isNillableField(field *parser.Field) bool {
+	if field.Modifier != parser.Optional || field.Default != nil {
+		return false
+	}
+	underlyingType := g.Frugal.UnderlyingType(field.Type)
+	return underlyingType.Name == "binary" || underlyingType.IsContainer()
+}
+
+// isValuePointerField returns true if the field is a pointer to a non-struct
+// value.
+func (g *Generator) isValuePointerField(field *parser.Field) bool {
+	return g.isPointerField(field) && !g.Frugal.IsStruct(g.Frugal.UnderlyingType(field.Type))
+}
+
+// isCopiedByValue returns true if assigning a value of the type copies it.
+func (g *Generator) isCopiedByValue(t *parser.Type) bool {
+	return g.isPrimitive(t) || g.Frugal.IsEnum(g.Frugal.UnderlyingType(t))
+}
+
+func (g *Generator) generateEquals(s *parser.Struct, sName string) string {
+	contents := fmt.Sprintf("func (p *%s) Equals(other *%s) bool {\n", sName, sName)
+	contents += "\tif p == other {\n"
+	contents += "\t\treturn true\n"
+	contents += "\t}\n"
+	contents += "\tif p == nil || other == nil {\n"
+	contents += "\t\treturn false\n"
+	contents += "\t}\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		this, that := "p."+fName, "other."+fName
+		switch {
+		case g.isValuePointerField(field):
+			contents += fmt.Sprintf("\tif (%s == nil) != (%s == nil) {\n", this, that)
+			contents += "\t\treturn false\n"
+			contents += "\t}\n"
+			contents += fmt.Sprintf("\tif %s != nil {\n", this)
+			contents += g.generateEqualsRec(field.Type, "*"+this, "*"+that, "\t\t")
+			contents += "\t}\n"
+		case g.isNillableField(field):
+			contents += fmt.Sprintf("\tif (%s == nil) != (%s == nil) {\n", this, that)
+			contents += "\t\treturn false\n"
+			contents += "\t}\n"
+			contents += g.generateEqualsRec(field.Type, this, that, "\t")
+		default:
+			contents += g.generateEqualsRec(field.Type, this, that, "\t")
+		}
+	}
+	contents += "\treturn true\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateEqualsRec generates code which returns false if the two values of
+// the given type are not equal.
+func (g *Generator) generateEqualsRec(t *parser.Type, this, that, ind string) string {
+	contents := ""
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		contents += fmt.Sprintf("%sif !%s.Equals(%s) {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+	case underlyingType.Name == "binary":
+		contents += fmt.Sprintf("%sif !bytes.Equal(%s, %s) {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+	case underlyingType.Name == "list":
+		index, thisElem := g.GetElem(), g.GetElem()
+		thatElem := g.GetElem()
+		contents += fmt.Sprintf("%sif len(%s) != len(%s) {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, index, thisElem, this)
+		contents += fmt.Sprintf("%s\t%s := %s[%s]\n", ind, thatElem, paren(that), index)
+		contents += g.generateEqualsRec(underlyingType.ValueType, thisElem, thatElem, ind+"\t")
+		contents += ind + "}\n"
+	case underlyingType.Name == "set":
+		thisElem := g.GetElem()
+		contents += fmt.Sprintf("%sif len(%s) != len(%s) {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%sfor %s := range %s {\n", ind, thisElem, this)
+		if g.Frugal.IsStruct(g.Frugal.UnderlyingType(underlyingType.ValueType)) {
+			// Struct elements are pointers, so look for an equal element.
+			thatElem, found := g.GetElem(), g.GetElem()
+			contents += fmt.Sprintf("%s\t%s := false\n", ind, found)
+			contents += fmt.Sprintf("%s\tfor %s := range %s {\n", ind, thatElem, that)
+			contents += fmt.Sprintf("%s\t\tif %s.Equals(%s) {\n", ind, thisElem, thatElem)
+			contents += fmt.Sprintf("%s\t\t\t%s = true\n", ind, found)
+			contents += ind + "\t\t\tbreak\n"
+			contents += ind + "\t\t}\n"
+			contents += ind + "\t}\n"
+			contents += fmt.Sprintf("%s\tif !%s {\n", ind, found)
+		} else {
+			contents += fmt.Sprintf("%s\tif _, ok := %s[%s]; !ok {\n", ind, paren(that), thisElem)
+		}
+		contents += ind + "\t\treturn false\n"
+		contents += ind + "\t}\n"
+		contents += ind + "}\n"
+	case underlyingType.Name == "map":
+		thisKey, thisValue := g.GetElem(), g.GetElem()
+		thatValue := g.GetElem()
+		contents += fmt.Sprintf("%sif len(%s) != len(%s) {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, thisKey, thisValue, this)
+		if g.Frugal.IsStruct(g.Frugal.UnderlyingType(underlyingType.KeyType)) {
+			// Struct keys are pointers, so look for an equal key.
+			thatKey, found := g.GetElem(), g.GetElem()
+			contents += fmt.Sprintf("%s\t%s := false\n", ind, found)
+			contents += fmt.Sprintf("%s\tfor %s, %s := range %s {\n", ind, thatKey, thatValue, that)
+			contents += fmt.Sprintf("%s\t\tif !%s.Equals(%s) {\n", ind, thisKey, thatKey)
+			contents += ind + "\t\t\tcontinue\n"
+			contents += ind + "\t\t}\n"
+			contents += g.generateEqualsRec(underlyingType.ValueType, thisValue, thatValue, ind+"\t\t")
+			contents += fmt.Sprintf("%s\t\t%s = true\n", ind, found)
+			contents += ind + "\t\tbreak\n"
+			contents += ind + "\t}\n"
+			contents += fmt.Sprintf("%s\tif !%s {\n", ind, found)
+			contents += ind + "\t\treturn false\n"
+			contents += ind + "\t}\n"
+		} else {
+			contents += fmt.Sprintf("%s\t%s, ok := %s[%s]\n", ind, thatValue, paren(that), thisKey)
+			contents += ind + "\tif !ok {\n"
+			contents += ind + "\t\treturn false\n"
+			contents += ind + "\t}\n"
+			contents += g.generateEqualsRec(underlyingType.ValueType, thisValue, thatValue, ind+"\t")
+		}
+		contents += ind + "}\n"
+	default:
+		contents += fmt.Sprintf("%sif %s != %s {\n", ind, this, that)
+		contents += ind + "\treturn false\n"
+		contents += ind + "}\n"
+	}
+	return contents
+}
+
+func (g *Generator) generateDeepCopy(s *parser.Struct, sName string) string {
+	contents := fmt.Sprintf("func (p *%s) DeepCopy() *%s {\n", sName, sName)
+	contents += "\tif p == nil {\n"
+	contents += "\t\treturn nil\n"
+	contents += "\t}\n"
+	contents += fmt.Sprintf("\tc := new(%s)\n", sName)
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		if g.isValuePointerField(field) {
+			elem := g.GetElem()
+			contents += fmt.Sprintf("\tif p.%s != nil {\n", fName)
+			contents += fmt.Sprintf("\t\tvar %s %s\n", elem, g.getGoTypeFromThriftType(field.Type))
+			contents += g.generateDeepCopyRec(field.Type, elem, "*p."+fName, "\t\t")
+			contents += fmt.Sprintf("\t\tc.%s = &%s\n", fName, elem)
+			contents += "\t}\n"
+		} else {
+			contents += g.generateDeepCopyRec(field.Type, "c."+fName, "p."+fName, "\t")
+		}
+	}
+	contents += "\treturn c\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateDeepCopyRec generates code which assigns a deep copy of the source
+// value of the given type to the destination.
+func (g *Generator) generateDeepCopyRec(t *parser.Type, dst, src, ind string) string {
+	contents := ""
+	goType := g.getGoTypeFromThriftType(t)
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		contents += fmt.Sprintf("%s%s = %s.DeepCopy()\n", ind, dst, src)
+	case underlyingType.Name == "binary":
+		contents += fmt.Sprintf("%sif %s != nil {\n", ind, src)
+		contents += fmt.Sprintf("%s\t%s = append(%s{}, %s...)\n", ind, dst, goType, src)
+		contents += ind + "}\n"
+	case underlyingType.Name == "list":
+		contents += fmt.Sprintf("%sif %s != nil {\n", ind, src)
+		if g.isCopiedByValue(underlyingType.ValueType) {
+			contents += fmt.Sprintf("%s\t%s = append(make(%s, 0, len(%s)), %s...)\n", ind, dst, goType, src, src)
+		} else {
+			srcElem, dstElem := g.GetElem(), g.GetElem()
+			contents += fmt.Sprintf("%s\t%s = make(%s, 0, len(%s))\n", ind, dst, goType, src)
+			contents += fmt.Sprintf("%s\tfor _, %s := range %s {\n", ind, srcElem, src)
+			contents += fmt.Sprintf("%s\t\tvar %s %s\n", ind, dstElem, g.getGoTypeFromThriftType(underlyingType.ValueType))
+			contents += g.generateDeepCopyRec(underlyingType.ValueType, dstElem, srcElem, ind+"\t\t")
+			contents += fmt.Sprintf("%s\t\t%s = append(%s, %s)\n", ind, dst, dst, dstElem)
+			contents += ind + "\t}\n"
+		}
+		contents += ind + "}\n"
+	case underlyingType.Name == "set":
+		srcElem := g.GetElem()
+		contents += fmt.Sprintf("%sif %s != nil {\n", ind, src)
+		contents += fmt.Sprintf("%s\t%s = make(%s, len(%s))\n", ind, dst, goType, src)
+		contents += fmt.Sprintf("%s\tfor %s := range %s {\n", ind, srcElem, src)
+		if g.isCopiedByValue(underlyingType.ValueType) {
+			contents += fmt.Sprintf("%s\t\t%s[%s] = true\n", ind, paren(dst), srcElem)
+		} else {
+			dstElem := g.GetElem()
+			contents += fmt.Sprintf("%s\t\tvar %s %s\n", ind, dstElem, g.getGoTypeFromThriftType(underlyingType.ValueType))
+			contents += g.generateDeepCopyRec(underlyingType.ValueType, dstElem, srcElem, ind+"\t\t")
+			contents += fmt.Sprintf("%s\t\t%s[%s] = true\n", ind, paren(dst), dstElem)
+		}
+		contents += ind + "\t}\n"
+		contents += ind + "}\n"
+	case underlyingType.Name == "map":
+		srcKey, srcValue := g.GetElem(), g.GetElem()
+		dstKey, dstValue := srcKey, srcValue
+		contents += fmt.Sprintf("%sif %s != nil {\n", ind, src)
+		contents += fmt.Sprintf("%s\t%s = make(%s, len(%s))\n", ind, dst, goType, src)
+		contents += fmt.Sprintf("%s\tfor %s, %s := range %s {\n", ind, srcKey, srcValue, src)
+		if !g.isCopiedByValue(underlyingType.KeyType) {
+			dstKey = g.GetElem()
+			contents += fmt.Sprintf("%s\t\tvar %s %s\n", ind, dstKey, g.getGoTypeFromThriftType(underlyingType.KeyType))
+			contents += g.generateDeepCopyRec(underlyingType.KeyType, dstKey, srcKey, ind+"\t\t")
+		}
+		if !g.isCopiedByValue(underlyingType.ValueType) {
+			dstValue = g.GetElem()
+			contents += fmt.Sprintf("%s\t\tvar %s %s\n", ind, dstValue, g.getGoTypeFromThriftType(underlyingType.ValueType))
+			contents += g.generateDeepCopyRec(underlyingType.ValueType, dstValue, srcValue, ind+"\t\t")
+		}
+		contents += fmt.Sprintf("%s\t\t%s[%s] = %s\n", ind, paren(dst), dstKey, dstValue)
+		contents += ind + "\t}\n"
+		contents += ind + "}\n"
+	default:
+		contents += fmt.Sprintf("%s%s = %s\n", ind, dst, src)
+	}
+	return contents
+}
+
+func (g *Generator) generateHash(s *parser.Struct, sName string) string {
+	contents := fmt.Sprintf("func (p *%s) Hash() uint64 {\n", sName)
+	contents += "\tif p == nil {\n"
+	contents += "\t\treturn 0\n"
+	contents += "\t}\n"
+	contents += "\th := fnv.New64a()\n"
+	for _, field := range s.Fields {
+		fName := title(field.Name)
+		contents += fmt.Sprintf("\tfrugalHashInt(h, %d)\n", field.ID)
+		switch {
+		case g.isValuePointerField(field), g.isNillableField(field):
+			value := "p." + fName
+			if g.isValuePointerField(field) {
+				value = "*" + value
+			}
+			contents += fmt.Sprintf("\tfrugalHashBool(h, p.%s != nil)\n", fName)
+			contents += fmt.Sprintf("\tif p.%s != nil {\n", fName)
+			contents += g.generateHashRec(field.Type, value, "h", "\t\t")
+			contents += "\t}\n"
+		default:
+			contents += g.generateHashRec(field.Type, "p."+fName, "h", "\t")
+		}
+	}
+	contents += "\treturn h.Sum64()\n"
+	contents += "}\n\n"
+	return contents
+}
+
+// generateHashRec generates code which writes the value of the given type to
+// the hash. Sets and maps are hashed independently of iteration order.
+func (g *Generator) generateHashRec(t *parser.Type, value, h, ind string) string {
+	contents := ""
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(%s.Hash()))\n", ind, h, value)
+	case g.Frugal.IsEnum(underlyingType):
+		contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(%s))\n", ind, h, value)
+	case underlyingType.Name == "list":
+		elem := g.GetElem()
+		contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(len(%s)))\n", ind, h, value)
+		contents += fmt.Sprintf("%sfor _, %s := range %s {\n", ind, elem, value)
+		contents += g.generateHashRec(underlyingType.ValueType, elem, h, ind+"\t")
+		contents += ind + "}\n"
+	case underlyingType.Name == "set", underlyingType.Name == "map":
+		// Sum the hashes of the entries so their order does not matter.
+		sum, key, elemHash := g.GetElem(), g.GetElem(), g.GetElem()
+		contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(len(%s)))\n", ind, h, value)
+		contents += fmt.Sprintf("%svar %s uint64\n", ind, sum)
+		if underlyingType.Name == "set" {
+			contents += fmt.Sprintf("%sfor %s := range %s {\n", ind, key, value)
+			contents += fmt.Sprintf("%s\t%s := fnv.New64a()\n", ind, elemHash)
+			contents += g.generateHashRec(underlyingType.ValueType, key, elemHash, ind+"\t")
+		} else {
+			mapValue := g.GetElem()
+			contents += fmt.Sprintf("%sfor %s, %s := range %s {\n", ind, key, mapValue, value)
+			contents += fmt.Sprintf("%s\t%s := fnv.New64a()\n", ind, elemHash)
+			contents += g.generateHashRec(underlyingType.KeyType, key, elemHash, ind+"\t")
+			contents += g.generateHashRec(underlyingType.ValueType, mapValue, elemHash, ind+"\t")
+		}
+		contents += fmt.Sprintf("%s\t%s += %s.Sum64()\n", ind, sum, elemHash)
+		contents += ind + "}\n"
+		contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(%s))\n", ind, h, sum)
+	default:
+		switch underlyingType.Name {
+		case "bool":
+			contents += fmt.Sprintf("%sfrugalHashBool(%s, bool(%s))\n", ind, h, value)
+		case "double":
+			contents += fmt.Sprintf("%sfrugalHashDouble(%s, float64(%s))\n", ind, h, value)
+		case "string":
+			contents += fmt.Sprintf("%sfrugalHashString(%s, string(%s))\n", ind, h, value)
+		case "binary":
+			contents += fmt.Sprintf("%sfrugalHashBytes(%s, %s)\n", ind, h, value)
+		default:
+			contents += fmt.Sprintf("%sfrugalHashInt(%s, int64(%s))\n", ind, h, value)
+		}
+	}
+	return contents
+}
+
+// paren wraps a dereferenced expression in parentheses so it can be indexed.
+func paren(expr string) string {
+	if len(expr) > 0 && expr[0] == '*' {
+		return "(" + expr + ")"
+	}
+	return expr
+}
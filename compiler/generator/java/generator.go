@@ -19,7 +19,6 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
 
 	"github.com/Workiva/frugal/compiler/generator"
@@ -45,14 +44,12 @@ const (
 
 type Generator struct {
 	*generator.BaseGenerator
-	time      time.Time
 	outputDir string
 }
 
 func NewGenerator(options map[string]string) generator.LanguageGenerator {
 	return &Generator{
 		&generator.BaseGenerator{Options: options},
-		globals.Now,
 		"",
 	}
 }
@@ -470,10 +467,16 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 
 func (g *Generator) GenerateUnion(union *parser.Struct) error {
 	// I have no idea why java uses this convention as the fields really
-	// should be optional...
-	for _, field := range union.Fields {
-		field.Modifier = parser.Default
+	// should be optional... Copy the fields rather than modifying the parse
+	// tree, which may be shared with other generators.
+	copied := *union
+	copied.Fields = make([]*parser.Field, len(union.Fields))
+	for i, field := range union.Fields {
+		unionField := *field
+		unionField.Modifier = parser.Default
+		copied.Fields[i] = &unionField
 	}
+	union = &copied
 
 	file, err := g.GenerateFile(union.Name, g.outputDir, generator.ObjectFile)
 	defer file.Close()
@@ -523,6 +526,9 @@ func (g *Generator) generateUnion(union *parser.Struct, isArg, isResult bool) st
 	contents += g.generateUnionEquals(union, tab)
 	contents += g.generateUnionCompareTo(union, tab)
 	contents += g.generateUnionHashCode(union, tab)
+	if !isArg && !isResult {
+		contents += g.generateCanonicalJSON(union, tab)
+	}
 	contents += g.generateWriteObject(union, tab)
 	contents += g.generateReadObject(union, tab)
 
@@ -907,6 +913,9 @@ func (g *Generator) generateStruct(s *parser.Struct, isArg, isResult bool, inden
 
 	contents += g.generateToString(s, nestedIndent)
 	contents += g.generateValidate(s, nestedIndent)
+	if !isArg && !isResult {
+		contents += g.generateCanonicalJSON(s, nestedIndent)
+	}
 
 	contents += g.generateWriteObject(s, nestedIndent)
 	contents += g.generateReadObject(s, nestedIndent)
@@ -2242,7 +2251,12 @@ func (g *Generator) GenerateEnumImports(file *os.File) error {
 }
 
 func (g *Generator) GenerateStructImports(file *os.File) error {
-	_, err := file.WriteString(g.generateStructImports())
+	imports := g.generateStructImports()
+	if g.generateCanonicalJSONOption() {
+		imports += "import com.workiva.frugal.util.CanonicalJson;\n"
+		imports += "import com.workiva.frugal.util.Pair;\n\n"
+	}
+	_, err := file.WriteString(imports)
 	return err
 }
 
@@ -2404,7 +2418,7 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 		contents += g.GenerateBlockComment(scope.Comment, indent)
 	}
 	contents += indent + "public static class Client implements Iface {\n"
-	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n\n", globals.TopicDelimiter)
+	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n\n", g.TopicDelimiter)
 	contents += indent + tab + "private final Iface target;\n"
 	contents += indent + tab + "private final Iface proxy;\n\n"
 
@@ -2476,7 +2490,7 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 		}
 
 		contents += indent + tabtabtab + fmt.Sprintf("String op = \"%s\";\n", op.Name)
-		contents += indent + tabtabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope))
+		contents += indent + tabtabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 		contents += indent + tabtabtab + "String topic = String.format(\"%s" + strings.Title(scope.Name) + "%s%s\", prefix, DELIMITER, op);\n"
 		contents += indent + tabtabtab + "TMemoryOutputBuffer memoryBuffer = new TMemoryOutputBuffer(transport.getPublishSizeLimit());\n"
 		contents += indent + tabtabtab + "FProtocol oprot = protocolFactory.getProtocol(memoryBuffer);\n"
@@ -2494,16 +2508,16 @@ func (g *Generator) generatePublisherClient(scope *parser.Scope, indent string)
 	return contents
 }
 
-func generatePrefixStringTemplate(scope *parser.Scope) string {
+func generatePrefixStringTemplate(scope *parser.Scope, delimiter string) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
 			return `""`
 		}
-		return fmt.Sprintf(`"%s%s"`, scope.Prefix.String, globals.TopicDelimiter)
+		return fmt.Sprintf(`"%s%s"`, scope.Prefix.String, delimiter)
 	}
 	template := "String.format(\""
 	template += scope.Prefix.Template("%s")
-	template += globals.TopicDelimiter + "\", "
+	template += delimiter + "\", "
 	prefix := ""
 	for _, variable := range scope.Prefix.Variables {
 		template += prefix + variable
@@ -2597,7 +2611,7 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 	}
 	contents += indent + "public static class Client implements Iface, IfaceThrowable {\n"
 
-	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n", globals.TopicDelimiter)
+	contents += indent + tab + fmt.Sprintf("private static final String DELIMITER = \"%s\";\n", g.TopicDelimiter)
 	contents += indent + tab + "private static final Logger LOGGER = LoggerFactory.getLogger(Client.class);\n\n"
 
 	contents += indent + tab + "private final FScopeProvider provider;\n"
@@ -2625,7 +2639,7 @@ func (g *Generator) generateSubscriberClient(scope *parser.Scope, indent string)
 				contents += indent + tab + fmt.Sprintf("public FSubscription subscribe%s(%sfinal %sHandler handler) throws TException {\n", op.Name, args, op.Name)
 			}
 			contents += indent + tabtab + fmt.Sprintf("final String op = \"%s\";\n", op.Name)
-			contents += indent + tabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope))
+			contents += indent + tabtab + fmt.Sprintf("String prefix = %s;\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 			contents += indent + tabtab + "final String topic = String.format(\"%s" + strings.Title(scope.Name) + "%s%s\", prefix, DELIMITER, op);\n"
 			contents += indent + tabtab + "final FScopeProvider.Subscriber subscriber = provider.buildSubscriber();\n"
 
@@ -3206,7 +3220,7 @@ func (g *Generator) _getJavaType(t *parser.Type, parametrized bool) string {
 		return "java.util.Map"
 	default:
 		// This is a custom type, return a pointer to it
-		return g.qualifiedTypeName(t)
+		return g.qualifiedTypeName(underlyingType)
 	}
 }
 
@@ -3221,7 +3235,7 @@ func (g *Generator) getTType(t *parser.Type) string {
 	default:
 		if g.Frugal.IsStruct(t) {
 			ttype = "STRUCT"
-		} else if g.Frugal.IsEnum(t) {
+		} else if g.Frugal.IsEnum(underlyingType) {
 			ttype = "I32"
 		} else {
 			panic("shouldn't happen: " + underlyingType.Name)
@@ -3338,7 +3352,7 @@ func (g *Generator) includeGeneratedAnnotation() bool {
 func (g *Generator) generatedAnnotation(indent string) string {
 	anno := indent + fmt.Sprintf("@Generated(value = \"Autogenerated by Frugal Compiler (%s)\"", globals.Version)
 	if g.Options[generatedAnnotations] != "undated" {
-		anno += fmt.Sprintf(", "+"date = \"%s\"", g.time.Format("2006-1-2"))
+		anno += fmt.Sprintf(", "+"date = \"%s\"", g.Now.Format("2006-1-2"))
 	}
 	anno += ")\n"
 	return anno
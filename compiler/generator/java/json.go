@@ -0,0 +1,298 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const canonicalJSONOption = "canonical_json"
+
+func (g *Generator) generateCanonicalJSONOption() bool {
+	_, ok := g.Options[canonicalJSONOption]
+	return ok
+}
+
+// generateCanonicalJSON generates the toJsonValue and fromJsonValue methods
+// used with com.workiva.frugal.util.CanonicalJson when the canonical_json
+// option is set. See documentation/json.md for the format they implement.
+func (g *Generator) generateCanonicalJSON(s *parser.Struct, indent string) string {
+	if !g.generateCanonicalJSONOption() {
+		return ""
+	}
+	return g.generateToJSONValue(s, indent) + g.generateFromJSONValue(s, indent)
+}
+
+// isJSONObjectKey returns true if maps with keys of the given type are
+// written as JSON objects rather than as lists of entries.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "string":
+		return true
+	}
+	return false
+}
+
+// jsonZeroValue returns the JSON value a field of the given type is written
+// as when it is null.
+func (g *Generator) jsonZeroValue(t *parser.Type) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return fmt.Sprintf("CanonicalJson.enumValue(null, %s.class)", g.qualifiedTypeName(underlyingType))
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return "false"
+	case "double":
+		return "0.0"
+	case "i64":
+		return "\"0\""
+	case "string", "binary":
+		return "\"\""
+	case "list", "set":
+		return "new ArrayList<Object>()"
+	case "map":
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			return "new HashMap<String, Object>()"
+		}
+		return "new ArrayList<Object>()"
+	}
+	return "0"
+}
+
+func (g *Generator) generateToJSONValue(s *parser.Struct, indent string) string {
+	contents := indent + "public Map<String, Object> toJsonValue() {\n"
+	contents += indent + tab + "Map<String, Object> value = new HashMap<String, Object>();\n"
+	for _, field := range s.Fields {
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		fieldTitle := strings.Title(field.Name)
+		src := "this." + field.Name
+		if s.Type == parser.StructTypeUnion {
+			src = fmt.Sprintf("get%s()", fieldTitle)
+		}
+		code, expr := g.generateToJSONStmts(field.Type, src, indent+tabtab)
+		switch {
+		case s.Type == parser.StructTypeUnion:
+			contents += indent + tab + fmt.Sprintf("if (isSet%s()) {\n", fieldTitle)
+		case field.Modifier == parser.Optional && field.Default != nil &&
+			(underlyingType.IsPrimitive() || g.Frugal.IsEnum(underlyingType)):
+			// Optional fields equal to their default are not set.
+			_, def := g.generateConstantValueRec(field.Type, field.Default, indent)
+			if g.canBeJavaPrimitive(field.Type) {
+				contents += indent + tab + fmt.Sprintf("if (isSet%s() && %s != %s) {\n", fieldTitle, src, def)
+			} else {
+				contents += indent + tab + fmt.Sprintf("if (isSet%s() && !%s.equals(%s)) {\n", fieldTitle, def, src)
+			}
+		case field.Modifier == parser.Optional || g.Frugal.IsStruct(underlyingType):
+			contents += indent + tab + fmt.Sprintf("if (isSet%s()) {\n", fieldTitle)
+		case g.isJavaPrimitive(field.Type):
+			contents += code
+			contents += indent + tab + fmt.Sprintf("value.put(\"%s\", %s);\n", field.Name, expr)
+			continue
+		default:
+			contents += indent + tab + fmt.Sprintf("if (isSet%s()) {\n", fieldTitle)
+			contents += code
+			contents += indent + tabtab + fmt.Sprintf("value.put(\"%s\", %s);\n", field.Name, expr)
+			contents += indent + tab + "} else {\n"
+			contents += indent + tabtab + fmt.Sprintf("value.put(\"%s\", %s);\n", field.Name, g.jsonZeroValue(field.Type))
+			contents += indent + tab + "}\n"
+			continue
+		}
+		contents += code
+		contents += indent + tabtab + fmt.Sprintf("value.put(\"%s\", %s);\n", field.Name, expr)
+		contents += indent + tab + "}\n"
+	}
+	contents += indent + tab + "return value;\n"
+	contents += indent + "}\n\n"
+	return contents
+}
+
+// generateToJSONStmts returns the statements computing the JSON value of the
+// source value of the given type and an expression for it.
+func (g *Generator) generateToJSONStmts(t *parser.Type, src, indent string) (string, string) {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return "", src + ".toJsonValue()"
+	case g.Frugal.IsEnum(underlyingType):
+		return "", fmt.Sprintf("CanonicalJson.enumValue(%s, %s.class)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		list, elem := g.GetElem(), g.GetElem()
+		code, expr := g.generateToJSONStmts(underlyingType.ValueType, elem, indent+tab)
+		contents := indent + fmt.Sprintf("List<Object> %s = new ArrayList<Object>();\n", list)
+		contents += indent + fmt.Sprintf("for (%s %s : %s) {\n",
+			containerType(g.getJavaTypeFromThriftType(underlyingType.ValueType)), elem, src)
+		contents += code
+		contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", list, expr)
+		contents += indent + "}\n"
+		if underlyingType.Name == "set" {
+			return contents, fmt.Sprintf("CanonicalJson.sorted(%s)", list)
+		}
+		return contents, list
+	case underlyingType.Name == "map":
+		result, entry := g.GetElem(), g.GetElem()
+		keyCode, keyExpr := g.generateToJSONStmts(underlyingType.KeyType, entry+".getKey()", indent+tab)
+		valueCode, valueExpr := g.generateToJSONStmts(underlyingType.ValueType, entry+".getValue()", indent+tab)
+		contents := ""
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			contents += indent + fmt.Sprintf("Map<String, Object> %s = new HashMap<String, Object>();\n", result)
+		} else {
+			contents += indent + fmt.Sprintf("List<Object> %s = new ArrayList<Object>();\n", result)
+		}
+		contents += indent + fmt.Sprintf("for (Map.Entry<%s, %s> %s : %s.entrySet()) {\n",
+			containerType(g.getJavaTypeFromThriftType(underlyingType.KeyType)),
+			containerType(g.getJavaTypeFromThriftType(underlyingType.ValueType)), entry, src)
+		contents += keyCode + valueCode
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			contents += indent + tab + fmt.Sprintf("%s.put(CanonicalJson.key(%s), %s);\n", result, keyExpr, valueExpr)
+			contents += indent + "}\n"
+			return contents, result
+		}
+		pair := g.GetElem()
+		contents += indent + tab + fmt.Sprintf("Map<String, Object> %s = new HashMap<String, Object>();\n", pair)
+		contents += indent + tab + fmt.Sprintf("%s.put(\"key\", %s);\n", pair, keyExpr)
+		contents += indent + tab + fmt.Sprintf("%s.put(\"value\", %s);\n", pair, valueExpr)
+		contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", result, pair)
+		contents += indent + "}\n"
+		return contents, fmt.Sprintf("CanonicalJson.sorted(%s)", result)
+	}
+	switch underlyingType.Name {
+	case "i64":
+		return "", fmt.Sprintf("String.valueOf(%s)", src)
+	case "double":
+		return "", fmt.Sprintf("CanonicalJson.doubleValue(%s)", src)
+	case "binary":
+		return "", fmt.Sprintf("CanonicalJson.binaryValue(%s)", src)
+	}
+	return "", src
+}
+
+func (g *Generator) generateFromJSONValue(s *parser.Struct, indent string) string {
+	contents := indent + fmt.Sprintf("public %s fromJsonValue(Object value) throws TException {\n", s.Name)
+	contents += indent + tab + "Map<String, Object> object = CanonicalJson.parseObject(value);\n"
+	if s.Type == parser.StructTypeUnion {
+		contents += indent + tab + "int setFields = 0;\n"
+	}
+	for _, field := range s.Fields {
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		elem := g.GetElem()
+		code, expr := g.generateFromJSONStmts(field.Type, elem, indent+tabtab)
+		contents += indent + tab + fmt.Sprintf("Object %s = object.get(\"%s\");\n", elem, field.Name)
+		contents += indent + tab + fmt.Sprintf("if (%s != null) {\n", elem)
+		contents += code
+		switch {
+		case s.Type == parser.StructTypeUnion && g.Frugal.IsEnum(underlyingType):
+			// Unions cannot hold null, so undeclared enum values are not set.
+			enum := g.GetElem()
+			contents += indent + tabtab + fmt.Sprintf("%s %s = %s;\n", g.qualifiedTypeName(underlyingType), enum, expr)
+			contents += indent + tabtab + fmt.Sprintf("if (%s != null) {\n", enum)
+			contents += indent + tabtabtab + fmt.Sprintf("set%s(%s);\n", strings.Title(field.Name), enum)
+			contents += indent + tabtabtab + "setFields++;\n"
+			contents += indent + tabtab + "}\n"
+		case s.Type == parser.StructTypeUnion:
+			contents += indent + tabtab + fmt.Sprintf("set%s(%s);\n", strings.Title(field.Name), expr)
+			contents += indent + tabtab + "setFields++;\n"
+		default:
+			contents += indent + tabtab + fmt.Sprintf("set%s(%s);\n", strings.Title(field.Name), expr)
+		}
+		if field.Modifier == parser.Required {
+			contents += indent + tab + "} else {\n"
+			contents += indent + tabtab + fmt.Sprintf("throw new TProtocolException(\"Required field '%s' is not present in struct '%s'\");\n",
+				field.Name, s.Name)
+		}
+		contents += indent + tab + "}\n"
+	}
+	if s.Type == parser.StructTypeUnion {
+		// An unset union is written as {}, so only reject several fields.
+		contents += indent + tab + "if (setFields > 1) {\n"
+		contents += indent + tabtab + "throw new TProtocolException(\"The union had more than one field set, \" + setFields + \" were set\");\n"
+		contents += indent + tab + "}\n"
+	}
+	contents += indent + tab + "return this;\n"
+	contents += indent + "}\n\n"
+	return contents
+}
+
+// generateFromJSONStmts returns the statements computing the value of the
+// given type held by the source JSON value and an expression for it.
+func (g *Generator) generateFromJSONStmts(t *parser.Type, src, indent string) (string, string) {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return "", fmt.Sprintf("new %s().fromJsonValue(%s)", g.qualifiedTypeName(underlyingType), src)
+	case g.Frugal.IsEnum(underlyingType):
+		return "", fmt.Sprintf("CanonicalJson.parseEnum(%s, %s.class)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		list, elem := g.GetElem(), g.GetElem()
+		code, expr := g.generateFromJSONStmts(underlyingType.ValueType, elem, indent+tab)
+		valueType := containerType(g.getJavaTypeFromThriftType(underlyingType.ValueType))
+		impl := "ArrayList"
+		if underlyingType.Name == "set" {
+			impl = "HashSet"
+		}
+		contents := indent + fmt.Sprintf("%s %s = new %s<%s>();\n", g.getJavaTypeFromThriftType(underlyingType), list, impl, valueType)
+		contents += indent + fmt.Sprintf("for (Object %s : CanonicalJson.parseList(%s)) {\n", elem, src)
+		contents += code
+		contents += indent + tab + fmt.Sprintf("%s.add(%s);\n", list, expr)
+		contents += indent + "}\n"
+		return contents, list
+	case underlyingType.Name == "map":
+		result, entry := g.GetElem(), g.GetElem()
+		contents := indent + fmt.Sprintf("%s %s = new HashMap<%s, %s>();\n", g.getJavaTypeFromThriftType(underlyingType), result,
+			containerType(g.getJavaTypeFromThriftType(underlyingType.KeyType)),
+			containerType(g.getJavaTypeFromThriftType(underlyingType.ValueType)))
+		var keyCode, keyExpr, valueCode, valueExpr string
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			contents += indent + fmt.Sprintf("for (Map.Entry<String, Object> %s : CanonicalJson.parseObject(%s).entrySet()) {\n", entry, src)
+			if g.Frugal.UnderlyingType(underlyingType.KeyType).Name == "bool" {
+				keyExpr = fmt.Sprintf("CanonicalJson.parseBoolKey(%s.getKey())", entry)
+			} else {
+				keyCode, keyExpr = g.generateFromJSONStmts(underlyingType.KeyType, entry+".getKey()", indent+tab)
+			}
+			valueCode, valueExpr = g.generateFromJSONStmts(underlyingType.ValueType, entry+".getValue()", indent+tab)
+		} else {
+			contents += indent + fmt.Sprintf("for (Pair<Object, Object> %s : CanonicalJson.parseEntries(%s)) {\n", entry, src)
+			keyCode, keyExpr = g.generateFromJSONStmts(underlyingType.KeyType, entry+".getLeft()", indent+tab)
+			valueCode, valueExpr = g.generateFromJSONStmts(underlyingType.ValueType, entry+".getRight()", indent+tab)
+		}
+		contents += keyCode + valueCode
+		contents += indent + tab + fmt.Sprintf("%s.put(%s, %s);\n", result, keyExpr, valueExpr)
+		contents += indent + "}\n"
+		return contents, result
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return "", fmt.Sprintf("CanonicalJson.parseBool(%s)", src)
+	case "byte", "i8":
+		return "", fmt.Sprintf("(byte) CanonicalJson.parseInt(%s, 8)", src)
+	case "i16":
+		return "", fmt.Sprintf("(short) CanonicalJson.parseInt(%s, 16)", src)
+	case "i32":
+		return "", fmt.Sprintf("(int) CanonicalJson.parseInt(%s, 32)", src)
+	case "i64":
+		return "", fmt.Sprintf("CanonicalJson.parseInt(%s, 64)", src)
+	case "double":
+		return "", fmt.Sprintf("CanonicalJson.parseDouble(%s)", src)
+	case "binary":
+		return "", fmt.Sprintf("CanonicalJson.parseBinary(%s)", src)
+	}
+	return "", fmt.Sprintf("CanonicalJson.parseString(%s)", src)
+}
@@ -18,7 +18,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/Workiva/frugal/compiler/globals"
 	"github.com/Workiva/frugal/compiler/parser"
 )
 
@@ -308,7 +307,7 @@ func (a *AsyncIOGenerator) GenerateSubscriber(file *os.File, scope *parser.Scope
 	}
 	subscriber += "\n"
 
-	subscriber += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", globals.TopicDelimiter)
+	subscriber += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", a.TopicDelimiter)
 
 	subscriber += tab + "def __init__(self, provider, middleware=None):\n"
 	subscriber += a.generateDocString([]string{
@@ -358,7 +357,7 @@ func (a *AsyncIOGenerator) generateSubscribeMethod(scope *parser.Scope, op *pars
 	method += "\n"
 
 	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
-	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
+	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope, a.TopicDelimiter))
 	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n\n", scope.Name)
 
 	method += tabtab + "transport, protocol_factory = self._provider.new_subscriber()\n"
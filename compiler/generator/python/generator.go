@@ -78,7 +78,7 @@ func (g *Generator) SetupGenerator(outputDir string) error {
 	// To prevent littering the filesystem with __init__ in every folder between outputDir and the present working
 	// directory, use the relative path between the root output directory and the target outputDir. This creates
 	// __init__ files only in the folders used for frugal generation.
-	outputRoot := globals.Out
+	outputRoot := g.Out
 	if outputRoot == "" {
 		outputRoot = g.DefaultOutputDir()
 	}
@@ -355,7 +355,7 @@ func (g *Generator) GenerateEnum(enum *parser.Enum) error {
 
 // GenerateStruct generates the given struct.
 func (g *Generator) GenerateStruct(s *parser.Struct) error {
-	_, err := g.typesFile.WriteString(g.generateStruct(s))
+	_, err := g.typesFile.WriteString(g.generateStruct(s) + g.generateCanonicalJSON(s))
 	return err
 }
 
@@ -363,13 +363,13 @@ func (g *Generator) GenerateStruct(s *parser.Struct) error {
 func (g *Generator) GenerateUnion(union *parser.Struct) error {
 	// TODO 2.0 consider adding validation only one field is set,
 	// similar to other languages
-	_, err := g.typesFile.WriteString(g.generateStruct(union))
+	_, err := g.typesFile.WriteString(g.generateStruct(union) + g.generateCanonicalJSON(union))
 	return err
 }
 
 // GenerateException generates the given exception.
 func (g *Generator) GenerateException(exception *parser.Struct) error {
-	_, err := g.typesFile.WriteString(g.generateStruct(exception))
+	_, err := g.typesFile.WriteString(g.generateStruct(exception) + g.generateCanonicalJSON(exception))
 	return err
 }
 
@@ -862,6 +862,9 @@ func (g *Generator) GenerateTypesImports(file *os.File, isArgsOrResult bool) err
 		contents += "from .ttypes import *\n"
 	}
 	contents += "from frugal.util import make_hashable\n"
+	if g.generateCanonicalJSONOption() && !isArgsOrResult {
+		contents += "from frugal.util import canonical_json\n"
+	}
 	contents += "from thrift.transport import TTransport\n"
 	contents += "from thrift.protocol import TBinaryProtocol, TProtocol\n"
 
@@ -955,7 +958,7 @@ func (g *Generator) GeneratePublisher(file *os.File, scope *parser.Scope) error
 	}
 	publisher += "\n"
 
-	publisher += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", globals.TopicDelimiter)
+	publisher += tab + fmt.Sprintf("_DELIMITER = '%s'\n\n", g.TopicDelimiter)
 
 	publisher += tab + "def __init__(self, provider, middleware=None):\n"
 	publisher += g.generateDocString([]string{
@@ -1074,7 +1077,7 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 		method += fmt.Sprintf(tabtab+"ctx.set_request_header('_topic_%s', %s)\n", prefixVar, prefixVar)
 	}
 	method += tabtab + fmt.Sprintf("op = '%s'\n", op.Name)
-	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope))
+	method += tabtab + fmt.Sprintf("prefix = %s\n", generatePrefixStringTemplate(scope, g.TopicDelimiter))
 	method += tabtab + fmt.Sprintf("topic = '{}%s{}{}'.format(prefix, self._DELIMITER, op)\n", scope.Name)
 	method += tabtab + "buffer = TMemoryOutputBuffer(self._transport.get_publish_size_limit())\n"
 	method += tabtab + "oprot = self._protocol_factory.get_protocol(buffer)\n"
@@ -1094,14 +1097,14 @@ func (g *Generator) generatePublishMethod(scope *parser.Scope, op *parser.Operat
 	return method
 }
 
-func generatePrefixStringTemplate(scope *parser.Scope) string {
+func generatePrefixStringTemplate(scope *parser.Scope, delimiter string) string {
 	if len(scope.Prefix.Variables) == 0 {
 		if scope.Prefix.String == "" {
 			return "''"
 		}
-		return fmt.Sprintf("'%s%s'", scope.Prefix.String, globals.TopicDelimiter)
+		return fmt.Sprintf("'%s%s'", scope.Prefix.String, delimiter)
 	}
-	template := fmt.Sprintf("'%s%s'.format(", scope.Prefix.Template("{}"), globals.TopicDelimiter)
+	template := fmt.Sprintf("'%s%s'.format(", scope.Prefix.Template("{}"), delimiter)
 	prefix := ""
 	for _, variable := range scope.Prefix.Variables {
 		template += prefix + variable
@@ -1539,7 +1542,7 @@ func (g *Generator) getTType(t *parser.Type) string {
 	default:
 		if g.Frugal.IsStruct(t) {
 			ttype = "STRUCT"
-		} else if g.Frugal.IsEnum(t) {
+		} else if g.Frugal.IsEnum(underlyingType) {
 			ttype = "I32"
 		} else {
 			panic("unrecognized type: " + underlyingType.Name)
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package python
+
+import (
+	"fmt"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const canonicalJSONOption = "canonical_json"
+
+func (g *Generator) generateCanonicalJSONOption() bool {
+	_, ok := g.Options[canonicalJSONOption]
+	return ok
+}
+
+// generateCanonicalJSON generates the to_json_value and from_json_value
+// methods used by frugal.util.canonical_json when the canonical_json option
+// is set. See documentation/json.md for the format they implement.
+func (g *Generator) generateCanonicalJSON(s *parser.Struct) string {
+	if !g.generateCanonicalJSONOption() {
+		return ""
+	}
+	return g.generateToJSONValue(s) + g.generateFromJSONValue(s)
+}
+
+// isJSONObjectKey returns true if maps with keys of the given type are
+// written as JSON objects rather than as lists of entries.
+func (g *Generator) isJSONObjectKey(t *parser.Type) bool {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return true
+	}
+	switch underlyingType.Name {
+	case "bool", "byte", "i8", "i16", "i32", "i64", "string":
+		return true
+	}
+	return false
+}
+
+// jsonZeroValue returns the value a field of the given type is written as
+// when it is None.
+func (g *Generator) jsonZeroValue(t *parser.Type) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	if g.Frugal.IsEnum(underlyingType) {
+		return "0"
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return "False"
+	case "double":
+		return "0.0"
+	case "string":
+		return "''"
+	case "binary":
+		return "b''"
+	case "list":
+		return "[]"
+	case "set":
+		return "set()"
+	case "map":
+		return "{}"
+	}
+	return "0"
+}
+
+func (g *Generator) generateToJSONValue(s *parser.Struct) string {
+	contents := tab + "def to_json_value(self):\n"
+	contents += tabtab + "value = {}\n"
+	for _, field := range s.Fields {
+		underlyingType := g.Frugal.UnderlyingType(field.Type)
+		src := "self." + field.Name
+		switch {
+		case field.Modifier == parser.Optional && field.Default != nil &&
+			(underlyingType.IsPrimitive() || g.Frugal.IsEnum(underlyingType)):
+			// Optional fields equal to their default are not set.
+			contents += fmt.Sprintf(tabtab+"if %s is not None and %s != self._DEFAULT_%s_MARKER:\n", src, src, field.Name)
+			contents += fmt.Sprintf(tabtabtab+"value['%s'] = %s\n", field.Name, g.generateToJSONExpr(field.Type, src))
+		case field.Modifier == parser.Optional || g.Frugal.IsStruct(underlyingType):
+			contents += fmt.Sprintf(tabtab+"if %s is not None:\n", src)
+			contents += fmt.Sprintf(tabtabtab+"value['%s'] = %s\n", field.Name, g.generateToJSONExpr(field.Type, src))
+		default:
+			elem := g.GetElem()
+			contents += fmt.Sprintf(tabtab+"%s = %s if %s is not None else %s\n", elem, src, src, g.jsonZeroValue(field.Type))
+			contents += fmt.Sprintf(tabtab+"value['%s'] = %s\n", field.Name, g.generateToJSONExpr(field.Type, elem))
+		}
+	}
+	contents += tabtab + "return value\n\n"
+	return contents
+}
+
+// generateToJSONExpr returns an expression for the JSON value of the source
+// value of the given type.
+func (g *Generator) generateToJSONExpr(t *parser.Type, src string) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return src
+	case g.Frugal.IsEnum(underlyingType):
+		return fmt.Sprintf("canonical_json.enum(%s, %s)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list", underlyingType.Name == "set":
+		elem := g.GetElem()
+		list := fmt.Sprintf("list(%s)", src)
+		if expr := g.generateToJSONExpr(underlyingType.ValueType, elem); expr != elem {
+			list = fmt.Sprintf("[%s for %s in %s]", expr, elem, src)
+		}
+		if underlyingType.Name == "set" {
+			return fmt.Sprintf("canonical_json.sorted_values(%s)", list)
+		}
+		return list
+	case underlyingType.Name == "map":
+		key, value := g.GetElem(), g.GetElem()
+		keyExpr := g.generateToJSONExpr(underlyingType.KeyType, key)
+		valueExpr := g.generateToJSONExpr(underlyingType.ValueType, value)
+		if g.isJSONObjectKey(underlyingType.KeyType) {
+			return fmt.Sprintf("{canonical_json.key(%s): %s for %s, %s in %s.items()}", keyExpr, valueExpr, key, value, src)
+		}
+		return fmt.Sprintf("canonical_json.sorted_values([{'key': %s, 'value': %s} for %s, %s in %s.items()])",
+			keyExpr, valueExpr, key, value, src)
+	}
+	switch underlyingType.Name {
+	case "i64":
+		return fmt.Sprintf("str(%s)", src)
+	case "double":
+		return fmt.Sprintf("canonical_json.double(%s)", src)
+	case "binary":
+		return fmt.Sprintf("canonical_json.binary(%s)", src)
+	}
+	return src
+}
+
+func (g *Generator) generateFromJSONValue(s *parser.Struct) string {
+	contents := tab + "def from_json_value(self, value):\n"
+	contents += tabtab + "canonical_json.parse_object(value)\n"
+	for _, field := range s.Fields {
+		elem := g.GetElem()
+		contents += fmt.Sprintf(tabtab+"%s = value.get('%s')\n", elem, field.Name)
+		contents += fmt.Sprintf(tabtab+"if %s is not None:\n", elem)
+		contents += fmt.Sprintf(tabtabtab+"self.%s = %s\n", field.Name, g.generateFromJSONExpr(field.Type, elem))
+		if field.Modifier == parser.Required {
+			contents += tabtab + "else:\n"
+			contents += fmt.Sprintf(tabtabtab+"raise ValueError('Required field \\'%s\\' is not present in struct \\'%s\\'')\n", field.Name, s.Name)
+		}
+	}
+	if s.Type == parser.StructTypeUnion {
+		// An unset union is written as {}, so only reject several fields.
+		contents += tabtab + "set_fields = 0\n"
+		for _, field := range s.Fields {
+			contents += fmt.Sprintf(tabtab+"if self.%s is not None:\n", field.Name)
+			contents += tabtabtab + "set_fields += 1\n"
+		}
+		contents += tabtab + "if set_fields > 1:\n"
+		contents += tabtabtab + "raise ValueError('The union had more than one field set, {} were set'.format(set_fields))\n"
+	}
+	contents += tabtab + "return self\n\n"
+	return contents
+}
+
+// generateFromJSONExpr returns an expression for the value of the given type
+// held by the source JSON value.
+func (g *Generator) generateFromJSONExpr(t *parser.Type, src string) string {
+	underlyingType := g.Frugal.UnderlyingType(t)
+	switch {
+	case g.Frugal.IsStruct(underlyingType):
+		return fmt.Sprintf("%s().from_json_value(%s)", g.qualifiedTypeName(underlyingType), src)
+	case g.Frugal.IsEnum(underlyingType):
+		return fmt.Sprintf("canonical_json.parse_enum(%s, %s)", src, g.qualifiedTypeName(underlyingType))
+	case underlyingType.Name == "list":
+		elem := g.GetElem()
+		return fmt.Sprintf("[%s for %s in canonical_json.parse_list(%s)]",
+			g.generateFromJSONExpr(underlyingType.ValueType, elem), elem, src)
+	case underlyingType.Name == "set":
+		elem := g.GetElem()
+		return fmt.Sprintf("set(%s for %s in canonical_json.parse_list(%s))",
+			g.generateFromJSONExpr(underlyingType.ValueType, elem), elem, src)
+	case underlyingType.Name == "map":
+		key, value := g.GetElem(), g.GetElem()
+		keyExpr := g.generateFromJSONExpr(underlyingType.KeyType, key)
+		valueExpr := g.generateFromJSONExpr(underlyingType.ValueType, value)
+		if !g.isJSONObjectKey(underlyingType.KeyType) {
+			return fmt.Sprintf("{%s: %s for %s, %s in canonical_json.parse_entries(%s)}", keyExpr, valueExpr, key, value, src)
+		}
+		if g.Frugal.UnderlyingType(underlyingType.KeyType).Name == "bool" {
+			keyExpr = fmt.Sprintf("canonical_json.parse_bool_key(%s)", key)
+		}
+		return fmt.Sprintf("{%s: %s for %s, %s in canonical_json.parse_object(%s).items()}", keyExpr, valueExpr, key, value, src)
+	}
+	switch underlyingType.Name {
+	case "bool":
+		return fmt.Sprintf("canonical_json.parse_bool(%s)", src)
+	case "byte", "i8":
+		return fmt.Sprintf("canonical_json.parse_int(%s, 8)", src)
+	case "i16":
+		return fmt.Sprintf("canonical_json.parse_int(%s, 16)", src)
+	case "i32":
+		return fmt.Sprintf("canonical_json.parse_int(%s, 32)", src)
+	case "i64":
+		return fmt.Sprintf("canonical_json.parse_int(%s, 64)", src)
+	case "double":
+		return fmt.Sprintf("canonical_json.parse_double(%s)", src)
+	case "binary":
+		return fmt.Sprintf("canonical_json.parse_binary(%s)", src)
+	}
+	return fmt.Sprintf("canonical_json.parse_string(%s)", src)
+}
@@ -16,37 +16,17 @@ package globals
 import (
 	"fmt"
 	"time"
-
-	"github.com/Workiva/frugal/compiler/parser"
 )
 
 // Version of the Frugal compiler.
 const Version = "2.23.0"
 
-// Global variables.
-var (
-	TopicDelimiter = "."
-	Gen            string
-	Out            string
-	FileDir        string
-	DryRun         bool
-	Recurse        bool
-	Verbose        bool
-	Now            = time.Now()
-	CompiledFiles  = make(map[string]*parser.Frugal)
-)
+// Now is the time of generation used by compiler.Compile.
+var Now = time.Now()
 
 // Reset global variables to initial state.
 func Reset() {
-	TopicDelimiter = "."
-	Gen = ""
-	Out = ""
-	FileDir = ""
-	DryRun = false
-	Recurse = false
-	Verbose = false
 	Now = time.Now()
-	CompiledFiles = make(map[string]*parser.Frugal)
 }
 
 // PrintWarning prints the given message to stdout in yellow font.
@@ -0,0 +1,588 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lsp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+var includeLine = regexp.MustCompile(`^\s*include\s+"([^"]+)"`)
+
+// Annotations offered as completions.
+var annotations = []string{
+	parser.VendorAnnotation,
+	parser.DeprecatedAnnotation,
+	parser.AuditIgnoreAnnotation,
+}
+
+var baseTypes = []string{"bool", "byte", "i8", "i16", "i32", "i64", "double", "string", "binary", "list", "set", "map"}
+
+// symbol is a definition which can be navigated to.
+type symbol struct {
+	file      string
+	pos       parser.Pos
+	name      string
+	signature string
+	doc       []string
+	kind      int
+}
+
+// location returns the location of the definition of the given symbol.
+func (s *Server) location(sym *symbol) *Location {
+	text, _ := s.readFile(sym.file)
+	pos := toPosition(string(text), sym.pos)
+	return &Location{URI: pathToURI(sym.file), Range: Range{Start: pos, End: pos}}
+}
+
+func (s *symbol) same(other *symbol) bool {
+	return other != nil && samePath(s.file, other.file) && s.pos == other.pos
+}
+
+// lookup resolves a name as it appears in the given file, such as "Foo",
+// "base.Foo", or "base.Enum.VALUE", to its definition.
+func lookup(f *parser.Frugal, name string) *symbol {
+	if ctx := identifierContext(f, name); ctx != nil {
+		file := f.File
+		if ctx.Include != nil {
+			file = ctx.Include.File
+		}
+		if ctx.Constant != nil {
+			c := ctx.Constant
+			return &symbol{file, c.Pos, c.Name, fmt.Sprintf("const %s %s", c.Type, c.Name), c.Comment, completionProperty}
+		}
+		v := ctx.EnumValue
+		return &symbol{file, v.Pos, v.Name, fmt.Sprintf("%s.%s = %d", ctx.Enum.Name, v.Name, v.Value), v.Comment,
+			completionEnum}
+	}
+
+	pieces := strings.SplitN(name, ".", 2)
+	if include, ok := f.ParsedIncludes[pieces[0]]; ok {
+		if len(pieces) == 1 {
+			return &symbol{include.File, parser.Pos{Line: 1, Col: 1}, name,
+				fmt.Sprintf("include %q", f.Include(name).Value), nil, completionModule}
+		}
+		return lookup(include, pieces[1])
+	}
+	for _, sym := range definitions(f) {
+		if sym.name == name {
+			return sym
+		}
+	}
+	return nil
+}
+
+// identifierContext returns the context of the constant or enum value with
+// the given name, or nil if there is none. The name is checked first since
+// Frugal.ContextFromIdentifier panics if it can't be resolved.
+func identifierContext(f *parser.Frugal, name string) *parser.IdentifierContext {
+	if !isValue(f, name) {
+		return nil
+	}
+	return f.ContextFromIdentifier(parser.Identifier(name))
+}
+
+// isValue indicates if the name resolves to a constant or enum value, in the
+// same way as Frugal.ContextFromIdentifier.
+func isValue(f *parser.Frugal, name string) bool {
+	pieces := strings.Split(name, ".")
+	switch len(pieces) {
+	case 1:
+		return hasConstant(f, pieces[0])
+	case 2:
+		if hasEnumValue(f, pieces[0], pieces[1]) {
+			return true
+		}
+		include, ok := f.ParsedIncludes[pieces[0]]
+		return ok && hasConstant(include, pieces[1])
+	case 3:
+		include, ok := f.ParsedIncludes[pieces[0]]
+		return ok && hasEnumValue(include, pieces[1], pieces[2])
+	}
+	return false
+}
+
+func hasConstant(f *parser.Frugal, name string) bool {
+	for _, constant := range f.Constants {
+		if constant.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnumValue(f *parser.Frugal, enumName, valueName string) bool {
+	for _, enum := range f.Enums {
+		if enum.Name != enumName {
+			continue
+		}
+		for _, value := range enum.Values {
+			if value.Name == valueName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// definitions returns the named types, services, and scopes defined in the
+// given file.
+func definitions(f *parser.Frugal) []*symbol {
+	symbols := []*symbol{}
+	for _, s := range f.DataStructures() {
+		symbols = append(symbols, &symbol{f.File, s.Pos, s.Name, fmt.Sprintf("%s %s", s.Type, s.Name), s.Comment,
+			completionClass})
+	}
+	for _, e := range f.Enums {
+		symbols = append(symbols, &symbol{f.File, e.Pos, e.Name, "enum " + e.Name, e.Comment, completionEnum})
+	}
+	for _, t := range f.Typedefs {
+		symbols = append(symbols, &symbol{f.File, t.Pos, t.Name, fmt.Sprintf("typedef %s %s", t.Type, t.Name),
+			t.Comment, completionClass})
+	}
+	for _, s := range f.Services {
+		signature := "service " + s.Name
+		if s.Extends != "" {
+			signature += " extends " + s.Extends
+		}
+		symbols = append(symbols, &symbol{f.File, s.Pos, s.Name, signature, s.Comment, completionClass})
+	}
+	for _, s := range f.Scopes {
+		signature := "scope " + s.Name
+		if s.Prefix.String != "" {
+			signature += " prefix " + s.Prefix.String
+		}
+		symbols = append(symbols, &symbol{f.File, s.Pos, s.Name, signature, s.Comment, completionClass})
+	}
+	return symbols
+}
+
+// symbolAt returns the definition of the name at the given position in the
+// document.
+func (s *Server) symbolAt(path string, pos Position) *symbol {
+	sym, _ := s.symbolRangeAt(path, pos)
+	return sym
+}
+
+func (s *Server) symbolRangeAt(path string, pos Position) (*symbol, *Range) {
+	frugal := s.frugal(path)
+	text, err := s.readFile(path)
+	if frugal == nil || err != nil {
+		return nil, nil
+	}
+	line := lineAt(string(text), pos.Line)
+	if match := includeLine.FindStringSubmatch(line); match != nil {
+		for _, include := range frugal.Includes {
+			if include.Value == match[1] {
+				return lookup(frugal, include.Name), nil
+			}
+		}
+	}
+	word, r := wordAt(line, pos)
+	if word == "" {
+		return nil, nil
+	}
+	return lookup(frugal, word), r
+}
+
+func (s *Server) hover(path string, pos Position) *Hover {
+	sym, r := s.symbolRangeAt(path, pos)
+	if sym == nil {
+		return nil
+	}
+	value := "```frugal\n" + sym.signature + "\n```"
+	if len(sym.doc) > 0 {
+		value += "\n\n" + strings.Join(sym.doc, "\n")
+	}
+	return &Hover{Contents: markupContent{Kind: "markdown", Value: value}, Range: r}
+}
+
+// reference is a name used in a definition. The first name is the text which
+// appears in the source. The remaining names are alternate resolutions, such
+// as the enum of an enum value.
+type reference struct {
+	names []string
+	pos   parser.Pos
+}
+
+// references returns the locations which refer to the definition at the
+// given position, searching open documents and the workspace.
+func (s *Server) references(path string, pos Position, includeDeclaration bool) []*Location {
+	locations := []*Location{}
+	target := s.symbolAt(path, pos)
+	if target == nil {
+		return locations
+	}
+	if includeDeclaration {
+		locations = append(locations, s.location(target))
+	}
+
+	for _, file := range s.workspaceFiles(path) {
+		frugal := s.frugal(file)
+		text, err := s.readFile(file)
+		if frugal == nil || err != nil {
+			continue
+		}
+		doc := newDocument(string(text))
+		var last parser.Pos
+		offset := 0
+		for _, ref := range references(frugal) {
+			if ref.pos != last {
+				last, offset = ref.pos, doc.offset(ref.pos)
+			}
+			start := findToken(doc.text, offset, ref.names[0])
+			if start < 0 {
+				continue
+			}
+			offset = start + len(ref.names[0])
+			for _, name := range ref.names {
+				if target.same(lookup(frugal, name)) {
+					locations = append(locations, &Location{
+						URI:   pathToURI(file),
+						Range: Range{Start: doc.position(start), End: doc.position(start + len(name))},
+					})
+					break
+				}
+			}
+		}
+	}
+	return locations
+}
+
+// workspaceFiles returns the open documents and the IDL files in the
+// workspace, starting with the given file.
+func (s *Server) workspaceFiles(path string) []string {
+	seen := map[string]bool{path: true}
+	files := []string{path}
+	add := func(file string) {
+		if abs, err := filepath.Abs(file); err == nil && !seen[abs] {
+			seen[abs] = true
+			files = append(files, abs)
+		}
+	}
+	open := make([]string, 0, len(s.documents))
+	for file := range s.documents {
+		open = append(open, file)
+	}
+	sort.Strings(open)
+	for _, file := range open {
+		add(file)
+	}
+	if s.root != "" {
+		filepath.Walk(s.root, func(file string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if info.IsDir() && file != s.root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && (strings.HasSuffix(file, ".frugal") || strings.HasSuffix(file, ".thrift")) {
+				add(file)
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// references returns the names used by the definitions in the given file in
+// source order within each definition.
+func references(f *parser.Frugal) []reference {
+	refs := []reference{}
+	var addType func(t *parser.Type, pos parser.Pos)
+	addType = func(t *parser.Type, pos parser.Pos) {
+		if t == nil {
+			return
+		}
+		if t.IsCustom() {
+			refs = append(refs, reference{[]string{t.Name}, pos})
+		}
+		addType(t.KeyType, pos)
+		addType(t.ValueType, pos)
+	}
+	var addValue func(value interface{}, pos parser.Pos)
+	addValue = func(value interface{}, pos parser.Pos) {
+		switch v := value.(type) {
+		case parser.Identifier:
+			names := []string{string(v)}
+			if i := strings.LastIndex(string(v), "."); i > 0 {
+				names = append(names, string(v)[:i])
+			}
+			refs = append(refs, reference{names, pos})
+		case []interface{}:
+			for _, elem := range v {
+				addValue(elem, pos)
+			}
+		case []parser.KeyValue:
+			for _, kv := range v {
+				addValue(kv.Key, pos)
+				addValue(kv.Value, pos)
+			}
+		}
+	}
+	addFields := func(fields []*parser.Field) {
+		for _, field := range fields {
+			addType(field.Type, field.Pos)
+			addValue(field.Default, field.Pos)
+		}
+	}
+
+	for _, typedef := range f.Typedefs {
+		addType(typedef.Type, typedef.Pos)
+	}
+	for _, constant := range f.Constants {
+		addType(constant.Type, constant.Pos)
+		addValue(constant.Value, constant.Pos)
+	}
+	for _, s := range f.DataStructures() {
+		addFields(s.Fields)
+	}
+	for _, service := range f.Services {
+		if service.Extends != "" {
+			refs = append(refs, reference{[]string{service.Extends}, service.Pos})
+		}
+		for _, method := range service.Methods {
+			addType(method.ReturnType, method.Pos)
+			addFields(method.Arguments)
+			addFields(method.Exceptions)
+		}
+	}
+	for _, scope := range f.Scopes {
+		for _, op := range scope.Operations {
+			addType(op.Type, op.Pos)
+		}
+	}
+	return refs
+}
+
+func (s *Server) completion(path string, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	text, _ := s.readFile(path)
+	line := lineAt(string(text), pos.Line)
+	if inAnnotation(line[:byteOffset(line, pos.Character)]) {
+		for _, annotation := range annotations {
+			items = append(items, CompletionItem{Label: annotation, Kind: completionProperty})
+		}
+		return items
+	}
+
+	for _, name := range baseTypes {
+		items = append(items, CompletionItem{Label: name, Kind: completionKeyword})
+	}
+	frugal := s.frugal(path)
+	if frugal == nil {
+		return items
+	}
+	addDefinitions := func(f *parser.Frugal, prefix string) {
+		for _, sym := range definitions(f) {
+			items = append(items, CompletionItem{
+				Label:         prefix + sym.name,
+				Kind:          sym.kind,
+				Detail:        sym.signature,
+				Documentation: strings.Join(sym.doc, "\n"),
+			})
+		}
+	}
+	addDefinitions(frugal, "")
+	for _, include := range frugal.Includes {
+		items = append(items, CompletionItem{Label: include.Name, Kind: completionModule,
+			Detail: fmt.Sprintf("include %q", include.Value)})
+		if parsed, ok := frugal.ParsedIncludes[include.Name]; ok {
+			addDefinitions(parsed, include.Name+".")
+		}
+	}
+	return items
+}
+
+// inAnnotation indicates if the text preceding the cursor is within an
+// annotation list, as opposed to a method's argument list.
+func inAnnotation(prefix string) bool {
+	depth := 0
+	for i := len(prefix) - 1; i >= 0; i-- {
+		switch prefix[i] {
+		case ')':
+			depth++
+		case '(':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			// Argument lists directly follow the method name.
+			return i == 0 || !isIdentChar(prefix[i-1])
+		}
+	}
+	return false
+}
+
+// wordAt returns the dotted name at the given position, truncated after the
+// segment containing the position, and its range.
+func wordAt(line string, pos Position) (string, *Range) {
+	start := byteOffset(line, pos.Character)
+	end := start
+	for start > 0 && isIdentChar(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isIdentChar(line[end]) && line[end] != '.' {
+		end++
+	}
+	word := strings.Trim(line[start:end], ".")
+	if word == "" {
+		return "", nil
+	}
+	return word, &Range{
+		Start: Position{pos.Line, character(line, start)},
+		End:   Position{pos.Line, character(line, end)},
+	}
+}
+
+// wordRange returns the range of the word at the given position, or the rest
+// of the line if there is no word there.
+func wordRange(text string, pos parser.Pos) Range {
+	if pos.Line < 1 {
+		return Range{}
+	}
+	line := lineAt(text, pos.Line-1)
+	start := runeOffset(line, pos.Col-1)
+	end := start
+	for end < len(line) && isIdentChar(line[end]) {
+		end++
+	}
+	if end == start {
+		end = len(line)
+	}
+	return Range{
+		Start: Position{pos.Line - 1, character(line, start)},
+		End:   Position{pos.Line - 1, character(line, end)},
+	}
+}
+
+// findToken returns the offset of the first occurrence of name at or after
+// offset which is not part of a longer name, or -1 if there is none.
+func findToken(text string, offset int, name string) int {
+	for offset <= len(text) {
+		i := strings.Index(text[offset:], name)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(name)
+		if (start == 0 || !isIdentChar(text[start-1])) && (end == len(text) || !isIdentChar(text[end])) {
+			return start
+		}
+		offset = end
+	}
+	return -1
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func lineAt(text string, line int) string {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return strings.TrimRight(lines[line], "\r")
+}
+
+// toPosition converts a position in the given text from the parser, whose
+// columns count runes, to an LSP position.
+func toPosition(text string, pos parser.Pos) Position {
+	if pos.Line < 1 {
+		return Position{}
+	}
+	line := lineAt(text, pos.Line-1)
+	return Position{Line: pos.Line - 1, Character: character(line, runeOffset(line, pos.Col-1))}
+}
+
+// byteOffset returns the byte offset in line of the given LSP character,
+// which counts UTF-16 code units, clamped to the line.
+func byteOffset(line string, char int) int {
+	units := 0
+	for i, r := range line {
+		if units >= char {
+			return i
+		}
+		units += utf16Len(r)
+	}
+	return len(line)
+}
+
+// character returns the LSP character, which counts UTF-16 code units, of
+// the given byte offset in line.
+func character(line string, offset int) int {
+	units := 0
+	for _, r := range line[:offset] {
+		units += utf16Len(r)
+	}
+	return units
+}
+
+// runeOffset returns the byte offset in line of the rune at the given index,
+// clamped to the line.
+func runeOffset(line string, index int) int {
+	for i := range line {
+		if index <= 0 {
+			return i
+		}
+		index--
+	}
+	return len(line)
+}
+
+func utf16Len(r rune) int {
+	if r >= 0x10000 {
+		return 2
+	}
+	return 1
+}
+
+// document maps between offsets and positions in a file.
+type document struct {
+	text       string
+	lineStarts []int
+}
+
+func newDocument(text string) *document {
+	d := &document{text: text, lineStarts: []int{0}}
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			d.lineStarts = append(d.lineStarts, i+1)
+		}
+	}
+	return d
+}
+
+func (d *document) offset(pos parser.Pos) int {
+	if pos.Line < 1 || pos.Line > len(d.lineStarts) {
+		return len(d.text)
+	}
+	start, end := d.lineStarts[pos.Line-1], len(d.text)
+	if pos.Line < len(d.lineStarts) {
+		end = d.lineStarts[pos.Line]
+	}
+	return start + runeOffset(d.text[start:end], pos.Col-1)
+}
+
+func (d *document) position(offset int) Position {
+	line := sort.Search(len(d.lineStarts), func(i int) bool { return d.lineStarts[i] > offset }) - 1
+	start := d.lineStarts[line]
+	return Position{Line: line, Character: character(d.text[start:], offset-start)}
+}
@@ -0,0 +1,335 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lsp implements a Language Server Protocol server for Frugal IDL.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Workiva/frugal/compiler/parser"
+)
+
+const diagnosticSource = "frugal"
+
+// syntaxError matches the position of a syntax error reported by the parser,
+// e.g. "foo.frugal:3:7 (42): rule SyntaxError: parser: syntax error".
+var syntaxError = regexp.MustCompile(`(\S+):(\d+):(\d+) \(\d+\): (?:rule \w+: )?(.*)`)
+
+// Server is a language server for Frugal IDL which communicates over a pair
+// of streams, typically stdin and stdout.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	root      string
+	includes  []string                  // Include search paths
+	documents map[string]string         // Open documents by path
+	parsed    map[string]*parser.Frugal // Last successful parse by path
+}
+
+// NewServer creates a Server which reads requests from in and writes
+// responses and notifications to out.
+func NewServer(in io.Reader, out io.Writer) *Server {
+	return &Server{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: make(map[string]string),
+		parsed:    make(map[string]*parser.Frugal),
+	}
+}
+
+// SetIncludePaths sets the directories searched for includes, before those
+// listed by parser.IncludePathEnv.
+func (s *Server) SetIncludePaths(paths []string) {
+	s.includes = paths
+}
+
+// Run serves requests until the client sends exit or closes the input.
+func (s *Server) Run() error {
+	for {
+		body, err := s.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		req := &request{}
+		if err := json.Unmarshal(body, req); err != nil {
+			if err := s.replyError(nil, codeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+// read returns the body of the next message, which is preceded by a
+// Content-Length header.
+func (s *Server) read() ([]byte, error) {
+	length := -1
+	for {
+		line, err := s.in.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(line), "content-length:") {
+			length, err = strconv.Atoi(strings.TrimSpace(line[len("content-length:"):]))
+			if err != nil {
+				return nil, fmt.Errorf("lsp: invalid header %q", line)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("lsp: missing Content-Length header")
+	}
+	body := make([]byte, length)
+	_, err := io.ReadFull(s.in, body)
+	return body, err
+}
+
+func (s *Server) write(msg interface{}) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return err
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}) error {
+	return s.write(&response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, msg string) error {
+	return s.write(&errorResponse{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: msg}})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return s.write(&notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) handle(req *request) error {
+	var params textDocumentPositionParams
+	switch req.Method {
+	case "initialize":
+		var init initializeParams
+		if !s.unmarshal(req, &init) {
+			return s.replyError(req.ID, codeInvalidParams, "invalid params")
+		}
+		s.root = init.RootPath
+		if init.RootURI != "" {
+			s.root = uriToPath(init.RootURI)
+		}
+		return s.reply(req.ID, map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // Full
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"hoverProvider":      true,
+				"completionProvider": map[string]interface{}{"triggerCharacters": []string{".", "("}},
+			},
+		})
+	case "shutdown":
+		return s.reply(req.ID, nil)
+	case "textDocument/didOpen":
+		var open didOpenParams
+		if s.unmarshal(req, &open) {
+			path := uriToPath(open.TextDocument.URI)
+			s.documents[path] = open.TextDocument.Text
+			return s.diagnose(path)
+		}
+	case "textDocument/didChange":
+		var change didChangeParams
+		if s.unmarshal(req, &change) && len(change.ContentChanges) > 0 {
+			path := uriToPath(change.TextDocument.URI)
+			s.documents[path] = change.ContentChanges[len(change.ContentChanges)-1].Text
+			return s.diagnose(path)
+		}
+	case "textDocument/didSave":
+		if s.unmarshal(req, &params) {
+			return s.diagnose(uriToPath(params.TextDocument.URI))
+		}
+	case "textDocument/didClose":
+		var close didCloseParams
+		if s.unmarshal(req, &close) {
+			path := uriToPath(close.TextDocument.URI)
+			delete(s.documents, path)
+			delete(s.parsed, path)
+			return s.notify("textDocument/publishDiagnostics",
+				&publishDiagnosticsParams{URI: close.TextDocument.URI, Diagnostics: []Diagnostic{}})
+		}
+	case "textDocument/definition", "textDocument/references", "textDocument/hover", "textDocument/completion":
+		if !s.unmarshal(req, &params) {
+			return s.replyError(req.ID, codeInvalidParams, "invalid params")
+		}
+		path := uriToPath(params.TextDocument.URI)
+		switch req.Method {
+		case "textDocument/definition":
+			if sym := s.symbolAt(path, params.Position); sym != nil {
+				return s.reply(req.ID, s.location(sym))
+			}
+			return s.reply(req.ID, nil)
+		case "textDocument/references":
+			return s.reply(req.ID, s.references(path, params.Position, params.Context.IncludeDeclaration))
+		case "textDocument/hover":
+			if hover := s.hover(path, params.Position); hover != nil {
+				return s.reply(req.ID, hover)
+			}
+			return s.reply(req.ID, nil)
+		default:
+			return s.reply(req.ID, s.completion(path, params.Position))
+		}
+	default:
+		if req.ID != nil {
+			return s.replyError(req.ID, codeMethodNotFound, "method not found: "+req.Method)
+		}
+	}
+	return nil
+}
+
+func (s *Server) unmarshal(req *request, v interface{}) bool {
+	return req.Params != nil && json.Unmarshal(*req.Params, v) == nil
+}
+
+// readFile returns the contents of the open document at the given path, or
+// the file on disk if it isn't open.
+func (s *Server) readFile(path string) ([]byte, error) {
+	if text, ok := s.documents[filepath.Clean(path)]; ok {
+		return []byte(text), nil
+	}
+	return ioutil.ReadFile(path)
+}
+
+// fileExists indicates if there is an open document or a file on disk at the
+// given path.
+func (s *Server) fileExists(path string) bool {
+	if _, ok := s.documents[filepath.Clean(path)]; ok {
+		return true
+	}
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// parse parses the file at the given path, remembering the result if it is
+// valid.
+func (s *Server) parse(path string) (*parser.Frugal, error) {
+	frugal, err := parser.ParseFrugalWithOptions(path, parser.ParseOptions{
+		Read:         s.readFile,
+		Exists:       s.fileExists,
+		IncludePaths: append(append([]string{}, s.includes...), parser.EnvIncludePaths()...),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.parsed[path] = frugal
+	return frugal, nil
+}
+
+// frugal returns the parsed file at the given path. If the file is currently
+// invalid, the last valid version is returned instead.
+func (s *Server) frugal(path string) *parser.Frugal {
+	if frugal, err := s.parse(path); err == nil {
+		return frugal
+	}
+	return s.parsed[path]
+}
+
+func (s *Server) diagnose(path string) error {
+	_, err := s.parse(path)
+	return s.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+		URI:         pathToURI(path),
+		Diagnostics: s.diagnostics(path, err),
+	})
+}
+
+// diagnostics converts an error from parsing the file at the given path to
+// diagnostics. Problems without a position in the file are reported at its
+// start.
+func (s *Server) diagnostics(path string, err error) []Diagnostic {
+	diagnostics := []Diagnostic{}
+	if err == nil {
+		return diagnostics
+	}
+	text, _ := s.readFile(path)
+	add := func(file string, pos parser.Pos, msg, fullMsg string) {
+		if !samePath(file, path) {
+			pos, msg = parser.Pos{Line: 1, Col: 1}, fullMsg
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    wordRange(string(text), pos),
+			Severity: severityError,
+			Source:   diagnosticSource,
+			Message:  msg,
+		})
+	}
+
+	if errs, ok := err.(parser.ValidationErrors); ok {
+		for _, e := range errs {
+			add(e.File, e.Pos, e.Message, e.Error())
+		}
+		return diagnostics
+	}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if match := syntaxError.FindStringSubmatch(line); match != nil {
+			lineNum, _ := strconv.Atoi(match[2])
+			col, _ := strconv.Atoi(match[3])
+			add(match[1], parser.Pos{Line: lineNum, Col: col}, match[4], line)
+		} else {
+			add("", parser.Pos{}, line, line)
+		}
+	}
+	return diagnostics
+}
+
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return filepath.Clean(uri)
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+func pathToURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+func samePath(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
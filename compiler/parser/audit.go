@@ -15,10 +15,138 @@ package parser
 
 import (
 	"fmt"
+	"io/ioutil"
 	"reflect"
+	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AuditIgnoreAnnotation suppresses audit rules for the annotated definition
+// and everything it contains. Its value is a comma-separated list of rule IDs,
+// or empty to suppress all rules.
+const AuditIgnoreAnnotation = "audit.ignore"
+
+// AuditSeverity is the severity of an AuditFinding.
+type AuditSeverity string
+
+// Valid AuditSeverities.
+const (
+	AuditError   AuditSeverity = "error"
+	AuditWarning AuditSeverity = "warning"
 )
 
+// Audit rule IDs.
+const (
+	RuleScopeRemoved            = "scope-removed"
+	RuleScopePrefixChanged      = "scope-prefix-changed"
+	RuleOperationRemoved        = "operation-removed"
+	RuleOperationTypeChanged    = "operation-type-changed"
+	RuleNamespaceChanged        = "namespace-changed"
+	RuleNamespaceRemoved        = "namespace-removed"
+	RuleConstantRemoved         = "constant-removed"
+	RuleConstantTypeChanged     = "constant-type-changed"
+	RuleConstantValueChanged    = "constant-value-changed"
+	RuleEnumRemoved             = "enum-removed"
+	RuleEnumValueRemoved        = "enum-value-removed"
+	RuleEnumValueRenamed        = "enum-value-renamed"
+	RuleStructRemoved           = "struct-removed"
+	RuleServiceRemoved          = "service-removed"
+	RuleServiceExtendsChanged   = "service-extends-changed"
+	RuleMethodRemoved           = "method-removed"
+	RuleMethodOnewayChanged     = "method-oneway-changed"
+	RuleMethodReturnTypeChanged = "method-return-type-changed"
+	RuleMethodExceptionsAdded   = "method-exceptions-added"
+	RuleMethodExceptionsRemoved = "method-exceptions-removed"
+	RuleFieldRemoved            = "field-removed"
+	RuleFieldTypeChanged        = "field-type-changed"
+	RuleFieldModifierChanged    = "field-modifier-changed"
+	RuleFieldDefaultChanged     = "field-default-changed"
+	RuleFieldRenamed            = "field-renamed"
+	RuleFieldAddedInMiddle      = "field-added-in-middle"
+	RuleFieldRequiredAdded      = "field-required-added"
+	RuleIncludeRemoved          = "include-removed"
+	RuleTypedefRemoved          = "typedef-removed"
+	RuleTypedefTypeChanged      = "typedef-type-changed"
+	RuleVendorChanged           = "vendor-changed"
+	RuleDeprecatedChanged       = "deprecated-changed"
+)
+
+// AuditRules describes each audit rule by ID.
+var AuditRules = map[string]string{
+	RuleScopeRemoved:            "A scope was removed.",
+	RuleScopePrefixChanged:      "A scope prefix changed other than by renaming variables.",
+	RuleOperationRemoved:        "A scope operation was removed.",
+	RuleOperationTypeChanged:    "The type of a scope operation changed.",
+	RuleNamespaceChanged:        "A namespace changed.",
+	RuleNamespaceRemoved:        "A namespace was removed.",
+	RuleConstantRemoved:         "A constant was removed.",
+	RuleConstantTypeChanged:     "The type of a constant changed.",
+	RuleConstantValueChanged:    "The value of a constant changed.",
+	RuleEnumRemoved:             "An enum was removed.",
+	RuleEnumValueRemoved:        "An enum value was removed.",
+	RuleEnumValueRenamed:        "An enum value was renamed.",
+	RuleStructRemoved:           "A struct, exception, or union was removed.",
+	RuleServiceRemoved:          "A service was removed.",
+	RuleServiceExtendsChanged:   "The service a service extends changed.",
+	RuleMethodRemoved:           "A service method was removed.",
+	RuleMethodOnewayChanged:     "The oneway modifier of a method changed.",
+	RuleMethodReturnTypeChanged: "The return type of a method changed.",
+	RuleMethodExceptionsAdded:   "Exceptions were added to a method returning void.",
+	RuleMethodExceptionsRemoved: "All exceptions were removed from a method returning void.",
+	RuleFieldRemoved:            "A non-optional field or argument was removed.",
+	RuleFieldTypeChanged:        "The type of a field or argument changed.",
+	RuleFieldModifierChanged:    "A field changed to or from required.",
+	RuleFieldDefaultChanged:     "The default value of a field changed.",
+	RuleFieldRenamed:            "A field or argument was renamed.",
+	RuleFieldAddedInMiddle:      "A field was added between existing field IDs.",
+	RuleFieldRequiredAdded:      "A required field was added.",
+	RuleIncludeRemoved:          "An include was removed.",
+	RuleTypedefRemoved:          "A typedef was removed.",
+	RuleTypedefTypeChanged:      "The underlying type of a typedef changed.",
+	RuleVendorChanged:           "A vendor annotation was added, removed, or changed.",
+	RuleDeprecatedChanged:       "A deprecated annotation was added or removed.",
+}
+
+// AuditFinding is a breaking or potentially breaking change found by an
+// Auditor. The location is in the new file, or in the old file if the
+// definition was removed.
+type AuditFinding struct {
+	Severity AuditSeverity `json:"severity"`
+	RuleID   string        `json:"ruleId"`
+	Message  string        `json:"message"`
+	File     string        `json:"file"`
+	Line     int           `json:"line"`
+	Column   int           `json:"column"`
+	Old      string        `json:"old,omitempty"`
+	New      string        `json:"new,omitempty"`
+}
+
+// AuditConfig configures which rules an Auditor reports.
+type AuditConfig struct {
+	// Suppress contains the IDs of rules which aren't reported.
+	Suppress []string `yaml:"suppress"`
+}
+
+// LoadAuditConfig reads an AuditConfig from the given YAML file.
+func LoadAuditConfig(file string) (*AuditConfig, error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	config := &AuditConfig{}
+	if err := yaml.Unmarshal(contents, config); err != nil {
+		return nil, fmt.Errorf("invalid audit config %s: %s", file, err)
+	}
+	for _, rule := range config.Suppress {
+		if _, ok := AuditRules[rule]; !ok {
+			return nil, fmt.Errorf("invalid audit config %s: unknown rule '%s'", file, rule)
+		}
+	}
+	return config, nil
+}
+
 // ValidationLogger provides an interface to output validation results.
 type ValidationLogger interface {
 	// LogWarning should log a warning message
@@ -35,6 +163,12 @@ type stdOutLogger struct {
 	errorsLogged bool
 }
 
+// NewStdOutLogger returns a ValidationLogger which prints warnings and errors
+// to standard out.
+func NewStdOutLogger() ValidationLogger {
+	return &stdOutLogger{}
+}
+
 // TODO use tty colors to emphasize things?
 func (s *stdOutLogger) LogWarning(warning ...string) {
 	fmt.Println("WARNING:", warning)
@@ -52,46 +186,124 @@ func (s *stdOutLogger) ErrorsLogged() bool {
 // Auditor provides an interface for auditing one frugal file against another
 // for breaking API changes.
 type Auditor struct {
-	logger    ValidationLogger
-	oldFrugal *Frugal
-	newFrugal *Frugal
+	logger     ValidationLogger
+	suppressed map[string]bool
+	findings   []*AuditFinding
+	errors     int
+	includes   []string
+	prefix     string
+	oldFrugal  *Frugal
+	newFrugal  *Frugal
 }
 
 // NewAuditor constructs an auditor that logs warnings and errors to
 // standard output
 func NewAuditor() *Auditor {
-	return &Auditor{
-		logger: &stdOutLogger{},
-	}
+	return NewAuditorWithConfig(NewStdOutLogger(), nil)
 }
 
 // NewAuditorWithLogger constructs an auditor which uses the given logger
 // to log warnings and errors
 func NewAuditorWithLogger(logger ValidationLogger) *Auditor {
-	return &Auditor{
-		logger: logger,
+	return NewAuditorWithConfig(logger, nil)
+}
+
+// NewAuditorWithConfig constructs an auditor which uses the given logger to
+// log warnings and errors and doesn't report the rules suppressed by the
+// given config. Both may be nil, in which case findings are only available
+// through Findings and no rules are suppressed.
+func NewAuditorWithConfig(logger ValidationLogger, config *AuditConfig) *Auditor {
+	a := &Auditor{
+		logger:     logger,
+		suppressed: make(map[string]bool),
+	}
+	if config != nil {
+		for _, rule := range config.Suppress {
+			a.suppressed[rule] = true
+		}
 	}
+	return a
+}
+
+// SetIncludePaths sets the directories searched for includes, before those
+// listed by IncludePathEnv.
+func (a *Auditor) SetIncludePaths(paths []string) {
+	a.includes = paths
+}
+
+// Findings returns the findings of every audit performed by the Auditor.
+func (a *Auditor) Findings() []*AuditFinding {
+	return a.findings
 }
 
 // Compare checks the contents of newFile for breaking changes with respect to
 // oldFile
 func (a *Auditor) Audit(oldFile, newFile string) error {
-	newFrugal, err := ParseFrugal(newFile)
+	newFrugal, err := ParseFrugalWithOptions(newFile, a.parseOptions())
+	if err != nil {
+		return err
+	}
+
+	oldFrugal, err := ParseFrugalWithOptions(oldFile, a.parseOptions())
+	if err != nil {
+		return err
+	}
+
+	return a.audit(oldFrugal, newFrugal, oldFile, newFile)
+}
+
+// AuditRevision checks the contents of file for breaking changes with respect
+// to the file and its includes as they were at the given git revision.
+func (a *Auditor) AuditRevision(revision, file string) error {
+	newFrugal, err := ParseFrugalWithOptions(file, a.parseOptions())
 	if err != nil {
 		return err
 	}
 
-	oldFrugal, err := ParseFrugal(oldFile)
+	options := a.parseOptions()
+	options.Read = GitFileReader(revision)
+	options.Exists = GitFileChecker(revision)
+	oldFrugal, err := ParseFrugalWithOptions(file, options)
 	if err != nil {
 		return err
 	}
 
+	return a.audit(oldFrugal, newFrugal, fmt.Sprintf("%s:%s", revision, file), file)
+}
+
+func (a *Auditor) parseOptions() ParseOptions {
+	return ParseOptions{IncludePaths: append(append([]string{}, a.includes...), EnvIncludePaths()...)}
+}
+
+func (a *Auditor) audit(oldFrugal, newFrugal *Frugal, oldName, newName string) error {
+	a.errors = 0
+	a.auditFrugal(oldFrugal, newFrugal, "", make(map[string]bool))
+
+	if a.errors > 0 {
+		return fmt.Errorf("FAILED: audit of %s against %s", newName, oldName)
+	}
+	return nil
+}
+
+// auditFrugal checks newFrugal for breaking changes with respect to oldFrugal
+// and then recursively does the same for the includes they have in common.
+// Findings in included files are prefixed with the given prefix.
+func (a *Auditor) auditFrugal(oldFrugal, newFrugal *Frugal, prefix string, audited map[string]bool) {
+	key := oldFrugal.File + " " + newFrugal.File
+	if audited[key] {
+		return
+	}
+	audited[key] = true
+
 	a.oldFrugal = oldFrugal
 	a.newFrugal = newFrugal
+	a.prefix = prefix
 
 	a.checkScopes(oldFrugal.Scopes, newFrugal.Scopes)
 
+	a.checkIncludes(oldFrugal.Includes, newFrugal.Includes)
 	a.checkNamespaces(oldFrugal.Namespaces, newFrugal.Namespaces)
+	a.checkTypedefs(oldFrugal.Typedefs, newFrugal.Typedefs)
 	a.checkConstants(oldFrugal.Constants, newFrugal.Constants)
 	a.checkEnums(oldFrugal.Enums, newFrugal.Enums)
 	a.checkStructLike(oldFrugal.Structs, newFrugal.Structs)
@@ -99,10 +311,127 @@ func (a *Auditor) Audit(oldFile, newFile string) error {
 	a.checkStructLike(oldFrugal.Unions, newFrugal.Unions)
 	a.checkServices(oldFrugal.Services, newFrugal.Services)
 
-	if a.logger.ErrorsLogged() {
-		return fmt.Errorf("FAILED: audit of %s against %s", newFile, oldFile)
+	names := make([]string, 0, len(oldFrugal.ParsedIncludes))
+	for name := range oldFrugal.ParsedIncludes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if newInclude, ok := newFrugal.ParsedIncludes[name]; ok {
+			a.auditFrugal(oldFrugal.ParsedIncludes[name], newInclude, newInclude.File+":", audited)
+		}
+	}
+}
+
+// auditContext is the definition a check applies to. Findings are reported
+// at its location unless one of its annotations suppresses them.
+type auditContext struct {
+	message     string
+	file        string
+	pos         Pos
+	oldDef      string
+	newDef      string
+	annotations []Annotations
+}
+
+// child returns the context with the given message appended.
+func (c auditContext) child(message string) auditContext {
+	if c.message != "" {
+		message = c.message + " " + message
+	}
+	c.message = message
+	return c
+}
+
+// at returns the context moved to the given definition. The annotations of
+// the enclosing definitions still apply.
+func (c auditContext) at(file string, pos Pos, oldDef, newDef string, annotations ...Annotations) auditContext {
+	c.file = file
+	c.pos = pos
+	c.oldDef = oldDef
+	c.newDef = newDef
+	c.annotations = append(append([]Annotations{}, c.annotations...), annotations...)
+	return c
+}
+
+// changed returns the context moved to a definition present in both files.
+func (a *Auditor) changed(c auditContext, pos Pos, oldDef, newDef string, oldAnns, newAnns Annotations) auditContext {
+	return c.at(a.newFrugal.File, pos, oldDef, newDef, oldAnns, newAnns)
+}
+
+// removed returns the context moved to a definition only present in the old
+// file.
+func (a *Auditor) removed(c auditContext, pos Pos, oldDef string, annotations Annotations) auditContext {
+	return c.at(a.oldFrugal.File, pos, oldDef, "", annotations)
+}
+
+// added returns the context moved to a definition only present in the new
+// file.
+func (a *Auditor) added(c auditContext, pos Pos, newDef string, annotations Annotations) auditContext {
+	return c.at(a.newFrugal.File, pos, "", newDef, annotations)
+}
+
+// isSuppressed indicates if the given rule is suppressed by the config or by
+// an annotation in the given context.
+func (a *Auditor) isSuppressed(rule string, c auditContext) bool {
+	if a.suppressed[rule] {
+		return true
+	}
+	for _, annotations := range c.annotations {
+		value, ok := annotations.Get(AuditIgnoreAnnotation)
+		if !ok {
+			continue
+		}
+		if strings.TrimSpace(value) == "" {
+			return true
+		}
+		for _, ignored := range strings.Split(value, ",") {
+			if strings.TrimSpace(ignored) == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (a *Auditor) logError(rule string, c auditContext, message ...string) {
+	a.report(AuditError, rule, c, message)
+}
+
+func (a *Auditor) logWarning(rule string, c auditContext, message ...string) {
+	a.report(AuditWarning, rule, c, message)
+}
+
+func (a *Auditor) report(severity AuditSeverity, rule string, c auditContext, message []string) {
+	if a.isSuppressed(rule, c) {
+		return
+	}
+	a.findings = append(a.findings, &AuditFinding{
+		Severity: severity,
+		RuleID:   rule,
+		Message:  strings.Join(message, " "),
+		File:     c.file,
+		Line:     c.pos.Line,
+		Column:   c.pos.Col,
+		Old:      c.oldDef,
+		New:      c.newDef,
+	})
+	if severity == AuditError {
+		a.errors++
+	}
+	if a.logger == nil {
+		return
+	}
+	// Findings hold the file separately, but logged messages need it to say
+	// which include they are about.
+	if a.prefix != "" {
+		message = append([]string{a.prefix}, message...)
+	}
+	if severity == AuditError {
+		a.logger.LogError(message...)
+	} else {
+		a.logger.LogWarning(message...)
 	}
-	return nil
 }
 
 // checkScopes requirements:
@@ -119,23 +448,27 @@ func (a *Auditor) checkScopes(oldScopes, newScopes []*Scope) {
 
 	for _, oldScope := range oldScopes {
 		if newScope, ok := newMap[oldScope.Name]; ok {
-			context := fmt.Sprintf("scope %s:", oldScope.Name)
+			context := a.changed(auditContext{}, newScope.Pos, describeScope(oldScope), describeScope(newScope),
+				oldScope.Annotations, newScope.Annotations).child(fmt.Sprintf("scope %s:", oldScope.Name))
+			a.checkAnnotations(oldScope.Annotations, newScope.Annotations, context)
 			a.checkScopePrefix(oldScope.Prefix, newScope.Prefix, context)
 			a.checkOperations(oldScope.Operations, newScope.Operations, context)
 		} else {
-			a.logger.LogError("missing scope:", oldScope.Name)
+			context := a.removed(auditContext{}, oldScope.Pos, describeScope(oldScope), oldScope.Annotations)
+			a.logError(RuleScopeRemoved, context, "missing scope:", oldScope.Name)
 		}
 	}
 }
 
-func (a *Auditor) checkScopePrefix(oldPrefix, newPrefix *ScopePrefix, context string) {
+func (a *Auditor) checkScopePrefix(oldPrefix, newPrefix *ScopePrefix, context auditContext) {
 	// variable names in scope prefixes should be able to change,
 	// but nothing else should be able to. Changing all the variables
 	// to '{}' allows this
 	oldNorm := normalizeScopePrefix(oldPrefix.String)
 	newNorm := normalizeScopePrefix(newPrefix.String)
 	if oldNorm != newNorm {
-		a.logger.LogError(context, fmt.Sprintf("prefix changed: '%s' -> '%s'", oldNorm, newNorm))
+		a.logError(RuleScopePrefixChanged, context, context.message,
+			fmt.Sprintf("prefix changed: '%s' -> '%s'", oldNorm, newNorm))
 	}
 }
 
@@ -151,7 +484,7 @@ func normalizeScopePrefix(s string) string {
 	return strings.Join(separated, ".")
 }
 
-func (a *Auditor) checkOperations(oldOps, newOps []*Operation, context string) {
+func (a *Auditor) checkOperations(oldOps, newOps []*Operation, context auditContext) {
 	newMap := make(map[string]*Operation)
 	for _, op := range newOps {
 		newMap[op.Name] = op
@@ -159,10 +492,37 @@ func (a *Auditor) checkOperations(oldOps, newOps []*Operation, context string) {
 
 	for _, oldOp := range oldOps {
 		if newOp, ok := newMap[oldOp.Name]; ok {
-			opContext := fmt.Sprintf("%s operation %s:", context, oldOp.Name)
-			a.checkType(oldOp.Type, newOp.Type, false, opContext)
+			opContext := a.changed(context, newOp.Pos, describeOperation(oldOp), describeOperation(newOp),
+				oldOp.Annotations, newOp.Annotations).child(fmt.Sprintf("operation %s:", oldOp.Name))
+			a.checkAnnotations(oldOp.Annotations, newOp.Annotations, opContext)
+			a.checkType(oldOp.Type, newOp.Type, false, RuleOperationTypeChanged, opContext)
 		} else {
-			a.logger.LogError(context, "operation removed:", oldOp.Name)
+			opContext := a.removed(context, oldOp.Pos, describeOperation(oldOp), oldOp.Annotations)
+			a.logError(RuleOperationRemoved, opContext, context.message, "operation removed:", oldOp.Name)
+		}
+	}
+}
+
+// checkIncludes requirements:
+// Warning:
+// - Include removed
+func (a *Auditor) checkIncludes(oldIncludes, newIncludes []*Include) {
+	newMap := make(map[string]*Include)
+	for _, include := range newIncludes {
+		newMap[include.Name] = include
+	}
+
+	// Removing an include is only breaking if something still uses it, in
+	// which case the type changes are reported
+	for _, oldInclude := range oldIncludes {
+		if newInclude, ok := newMap[oldInclude.Name]; ok {
+			context := a.changed(auditContext{}, newInclude.Pos, describeInclude(oldInclude),
+				describeInclude(newInclude), oldInclude.Annotations, newInclude.Annotations)
+			a.checkAnnotations(oldInclude.Annotations, newInclude.Annotations,
+				context.child(fmt.Sprintf("include %s:", oldInclude.Name)))
+		} else {
+			context := a.removed(auditContext{}, oldInclude.Pos, describeInclude(oldInclude), oldInclude.Annotations)
+			a.logWarning(RuleIncludeRemoved, context, "include removed:", oldInclude.Name)
 		}
 	}
 }
@@ -181,11 +541,42 @@ func (a *Auditor) checkNamespaces(oldNamespace, newNamespace []*Namespace) {
 	// network
 	for _, oldNamespace := range oldNamespace {
 		if newNamespace, ok := newMap[oldNamespace.Scope]; ok {
+			context := a.changed(auditContext{}, newNamespace.Pos, describeNamespace(oldNamespace),
+				describeNamespace(newNamespace), oldNamespace.Annotations, newNamespace.Annotations)
 			if oldNamespace.Value != newNamespace.Value {
-				a.logger.LogWarning("namespace changed:", oldNamespace.Scope)
+				a.logWarning(RuleNamespaceChanged, context, "namespace changed:", oldNamespace.Scope)
 			}
+			a.checkAnnotations(oldNamespace.Annotations, newNamespace.Annotations,
+				context.child(fmt.Sprintf("namespace %s:", oldNamespace.Scope)))
+		} else {
+			context := a.removed(auditContext{}, oldNamespace.Pos, describeNamespace(oldNamespace), oldNamespace.Annotations)
+			a.logWarning(RuleNamespaceRemoved, context, "namespace removed:", oldNamespace.Scope)
+		}
+	}
+}
+
+// checkTypedefs requirements:
+// Warning:
+// - Typedef removed
+// Error:
+// - Underlying type changed
+func (a *Auditor) checkTypedefs(oldTypedefs, newTypedefs []*TypeDef) {
+	newMap := make(map[string]*TypeDef)
+	for _, typedef := range newTypedefs {
+		newMap[typedef.Name] = typedef
+	}
+
+	for _, oldTypedef := range oldTypedefs {
+		if newTypedef, ok := newMap[oldTypedef.Name]; ok {
+			context := a.changed(auditContext{}, newTypedef.Pos, describeTypedef(oldTypedef),
+				describeTypedef(newTypedef), oldTypedef.Annotations, newTypedef.Annotations).
+				child(fmt.Sprintf("typedef %s:", oldTypedef.Name))
+			a.checkAnnotations(oldTypedef.Annotations, newTypedef.Annotations, context)
+			a.checkType(oldTypedef.Type, newTypedef.Type, false, RuleTypedefTypeChanged, context)
 		} else {
-			a.logger.LogWarning("namespace removed:", oldNamespace.Scope)
+			// Uses of the typedef are checked by their underlying type
+			context := a.removed(auditContext{}, oldTypedef.Pos, describeTypedef(oldTypedef), oldTypedef.Annotations)
+			a.logWarning(RuleTypedefRemoved, context, "typedef removed:", oldTypedef.Name)
 		}
 	}
 }
@@ -204,13 +595,17 @@ func (a *Auditor) checkConstants(oldConstants, newConstants []*Constant) {
 	// These are warnings as only the actual value is sent over the network
 	for _, oldConstant := range oldConstants {
 		if newConstant, ok := newMap[oldConstant.Name]; ok {
-			context := fmt.Sprintf("constant %s:", oldConstant.Name)
-			a.checkType(oldConstant.Type, newConstant.Type, true, context)
+			context := a.changed(auditContext{}, newConstant.Pos, describeConstant(oldConstant),
+				describeConstant(newConstant), oldConstant.Annotations, newConstant.Annotations)
+			constantContext := context.child(fmt.Sprintf("constant %s:", oldConstant.Name))
+			a.checkAnnotations(oldConstant.Annotations, newConstant.Annotations, constantContext)
+			a.checkType(oldConstant.Type, newConstant.Type, true, RuleConstantTypeChanged, constantContext)
 			if !reflect.DeepEqual(oldConstant.Value, newConstant.Value) {
-				a.logger.LogWarning("constant value changed:", oldConstant.Name)
+				a.logWarning(RuleConstantValueChanged, context, "constant value changed:", oldConstant.Name)
 			}
 		} else {
-			a.logger.LogWarning("constant value removed:", oldConstant.Name)
+			context := a.removed(auditContext{}, oldConstant.Pos, describeConstant(oldConstant), oldConstant.Annotations)
+			a.logWarning(RuleConstantRemoved, context, "constant value removed:", oldConstant.Name)
 		}
 	}
 }
@@ -229,15 +624,18 @@ func (a *Auditor) checkEnums(oldEnums, newEnums []*Enum) {
 
 	for _, oldEnum := range oldEnums {
 		if newEnum, ok := newMap[oldEnum.Name]; ok {
-			context := fmt.Sprintf("enum %s:", oldEnum.Name)
+			context := a.changed(auditContext{}, newEnum.Pos, describeEnum(oldEnum), describeEnum(newEnum),
+				oldEnum.Annotations, newEnum.Annotations).child(fmt.Sprintf("enum %s:", oldEnum.Name))
+			a.checkAnnotations(oldEnum.Annotations, newEnum.Annotations, context)
 			a.checkEnumValues(oldEnum.Values, newEnum.Values, context)
 		} else {
-			a.logger.LogWarning("enum removed:", oldEnum.Name)
+			context := a.removed(auditContext{}, oldEnum.Pos, describeEnum(oldEnum), oldEnum.Annotations)
+			a.logWarning(RuleEnumRemoved, context, "enum removed:", oldEnum.Name)
 		}
 	}
 }
 
-func (a *Auditor) checkEnumValues(oldValues, newValues []*EnumValue, context string) {
+func (a *Auditor) checkEnumValues(oldValues, newValues []*EnumValue, context auditContext) {
 	newMap := make(map[int]*EnumValue)
 	for _, value := range newValues {
 		newMap[value.Value] = value
@@ -245,15 +643,20 @@ func (a *Auditor) checkEnumValues(oldValues, newValues []*EnumValue, context str
 
 	for _, oldValue := range oldValues {
 		if newValue, ok := newMap[oldValue.Value]; ok {
+			valueContext := a.changed(context, newValue.Pos, describeEnumValue(oldValue),
+				describeEnumValue(newValue), oldValue.Annotations, newValue.Annotations)
 			if oldValue.Name != newValue.Name {
 				// enum variant names are allowed to change as
 				// only the numeric value is sent over the
 				// network
-				a.logger.LogWarning("enum variant name changed:", oldValue.Name)
+				a.logWarning(RuleEnumValueRenamed, valueContext, "enum variant name changed:", oldValue.Name)
 			}
+			a.checkAnnotations(oldValue.Annotations, newValue.Annotations,
+				valueContext.child(fmt.Sprintf("variant %s:", oldValue.Name)))
 		} else {
-			a.logger.LogError(fmt.Sprintf("%s variant %s: removed with ID=%d",
-				context, oldValue.Name, oldValue.Value))
+			valueContext := a.removed(context, oldValue.Pos, describeEnumValue(oldValue), oldValue.Annotations)
+			a.logError(RuleEnumValueRemoved, valueContext, fmt.Sprintf("%s variant %s: removed with ID=%d",
+				context.message, oldValue.Name, oldValue.Value))
 		}
 	}
 }
@@ -277,10 +680,13 @@ func (a *Auditor) checkStructLike(oldStructs, newStructs []*Struct) {
 
 	for _, oldStruct := range oldStructs {
 		if newStruct, ok := newMap[oldStruct.Name]; ok {
-			context := fmt.Sprintf("struct %s:", oldStruct.Name)
+			context := a.changed(auditContext{}, newStruct.Pos, describeStruct(oldStruct), describeStruct(newStruct),
+				oldStruct.Annotations, newStruct.Annotations).child(fmt.Sprintf("struct %s:", oldStruct.Name))
+			a.checkAnnotations(oldStruct.Annotations, newStruct.Annotations, context)
 			a.checkFields(oldStruct.Fields, newStruct.Fields, context)
 		} else {
-			a.logger.LogError("missing struct:", oldStruct.Name)
+			context := a.removed(auditContext{}, oldStruct.Pos, describeStruct(oldStruct), oldStruct.Annotations)
+			a.logError(RuleStructRemoved, context, "missing struct:", oldStruct.Name)
 		}
 	}
 }
@@ -309,20 +715,23 @@ func (a *Auditor) checkServices(oldServices, newServices []*Service) {
 
 	for _, oldService := range oldServices {
 		if newService, ok := newMap[oldService.Name]; ok {
+			context := a.changed(auditContext{}, newService.Pos, describeService(oldService), describeService(newService),
+				oldService.Annotations, newService.Annotations).child(fmt.Sprintf("service %s:", oldService.Name))
 			// It's fine to add inheritance, but not change it if it already exists
 			if oldService.Extends != "" && oldService.Extends != newService.Extends {
-				a.logger.LogError(fmt.Sprintf("service %s: extends changed: '%s' -> '%s'",
+				a.logError(RuleServiceExtendsChanged, context, fmt.Sprintf("service %s: extends changed: '%s' -> '%s'",
 					oldService.Name, oldService.Extends, newService.Extends))
 			}
-			context := fmt.Sprintf("service %s:", oldService.Name)
+			a.checkAnnotations(oldService.Annotations, newService.Annotations, context)
 			a.checkServiceMethods(oldService.Methods, newService.Methods, context)
 		} else {
-			a.logger.LogError("missing service:", oldService.Name)
+			context := a.removed(auditContext{}, oldService.Pos, describeService(oldService), oldService.Annotations)
+			a.logError(RuleServiceRemoved, context, "missing service:", oldService.Name)
 		}
 	}
 }
 
-func (a *Auditor) checkServiceMethods(oldMethods, newMethods []*Method, context string) {
+func (a *Auditor) checkServiceMethods(oldMethods, newMethods []*Method, context auditContext) {
 	newMap := make(map[string]*Method)
 	for _, method := range newMethods {
 		newMap[method.Name] = method
@@ -330,12 +739,15 @@ func (a *Auditor) checkServiceMethods(oldMethods, newMethods []*Method, context
 
 	for _, oldMethod := range oldMethods {
 		if newMethod, ok := newMap[oldMethod.Name]; ok {
-			methodContext := fmt.Sprintf("%s method %s:", context, oldMethod.Name)
+			methodContext := a.changed(context, newMethod.Pos, describeMethod(oldMethod), describeMethod(newMethod),
+				oldMethod.Annotations, newMethod.Annotations).child(fmt.Sprintf("method %s:", oldMethod.Name))
+			a.checkAnnotations(oldMethod.Annotations, newMethod.Annotations, methodContext)
 			if oldMethod.Oneway != newMethod.Oneway {
-				a.logger.LogError(methodContext, "one way modifier changed")
+				a.logError(RuleMethodOnewayChanged, methodContext, methodContext.message, "one way modifier changed")
 			}
 
-			a.checkType(oldMethod.ReturnType, newMethod.ReturnType, false, methodContext+" return type:")
+			a.checkType(oldMethod.ReturnType, newMethod.ReturnType, false, RuleMethodReturnTypeChanged,
+				methodContext.child("return type:"))
 
 			a.checkFields(oldMethod.Arguments, newMethod.Arguments, methodContext)
 			a.checkFields(oldMethod.Exceptions, newMethod.Exceptions, methodContext)
@@ -346,19 +758,22 @@ func (a *Auditor) checkServiceMethods(oldMethods, newMethods []*Method, context
 			// "nothing can be returned" and "something can be
 			// returned" isn't allowed
 			if oldMethod.ReturnType == nil && len(oldMethod.Exceptions) == 0 && len(newMethod.Exceptions) > 0 {
-				a.logger.LogError(methodContext, "can't add exceptions with nil return type")
+				a.logError(RuleMethodExceptionsAdded, methodContext, methodContext.message,
+					"can't add exceptions with nil return type")
 			}
 
 			if newMethod.ReturnType == nil && len(newMethod.Exceptions) == 0 && len(oldMethod.Exceptions) > 0 {
-				a.logger.LogError(methodContext, "can't remove exceptions with nil return type")
+				a.logError(RuleMethodExceptionsRemoved, methodContext, methodContext.message,
+					"can't remove exceptions with nil return type")
 			}
 		} else {
-			a.logger.LogError(context, "missing method: "+oldMethod.Name)
+			methodContext := a.removed(context, oldMethod.Pos, describeMethod(oldMethod), oldMethod.Annotations)
+			a.logError(RuleMethodRemoved, methodContext, context.message, "missing method: "+oldMethod.Name)
 		}
 	}
 }
 
-func (a *Auditor) checkFields(oldFields, newFields []*Field, context string) {
+func (a *Auditor) checkFields(oldFields, newFields []*Field, context auditContext) {
 	oldMap := makeFieldsMap(oldFields)
 	newMap := makeFieldsMap(newFields)
 
@@ -372,44 +787,78 @@ func (a *Auditor) checkFields(oldFields, newFields []*Field, context string) {
 			max = oldField.ID
 		}
 
-		fieldContext := fmt.Sprintf("%s field %s:", context, oldField.Name)
+		fieldContext := context.child(fmt.Sprintf("field %s:", oldField.Name))
 		if newField, ok := newMap[oldField.ID]; ok {
-			a.checkType(oldField.Type, newField.Type, false, fieldContext)
+			fieldContext = a.changed(fieldContext, newField.Pos, describeField(oldField), describeField(newField),
+				oldField.Annotations, newField.Annotations)
+			a.checkAnnotations(oldField.Annotations, newField.Annotations, fieldContext)
+			a.checkType(oldField.Type, newField.Type, false, RuleFieldTypeChanged, fieldContext)
 
 			oldFieldReq := oldField.Modifier == Required
 			newFieldReq := newField.Modifier == Required
 			if oldFieldReq != newFieldReq {
-				a.logger.LogError(fieldContext, fmt.Sprintf("field presence modifier changed: '%s' -> '%s'",
-					oldField.Modifier.String(), newField.Modifier.String()))
+				a.logError(RuleFieldModifierChanged, fieldContext, fieldContext.message,
+					fmt.Sprintf("field presence modifier changed: '%s' -> '%s'",
+						oldField.Modifier.String(), newField.Modifier.String()))
 			}
 
 			if !reflect.DeepEqual(oldField.Default, newField.Default) {
-				a.logger.LogWarning(fieldContext, "default value changed")
+				a.logWarning(RuleFieldDefaultChanged, fieldContext, fieldContext.message, "default value changed")
 			}
 			if oldField.Name != newField.Name {
-				a.logger.LogWarning(fieldContext, "name changed")
+				a.logWarning(RuleFieldRenamed, fieldContext, fieldContext.message, "name changed")
 			}
 		} else if oldField.Modifier != Optional {
-			a.logger.LogError(fieldContext, fmt.Sprintf("field removed with ID=%d", oldField.ID))
+			fieldContext = a.removed(fieldContext, oldField.Pos, describeField(oldField), oldField.Annotations)
+			a.logError(RuleFieldRemoved, fieldContext, fieldContext.message,
+				fmt.Sprintf("field removed with ID=%d", oldField.ID))
 		}
 	}
 
 	for _, newField := range newMap {
 		if _, ok := oldMap[newField.ID]; !ok {
-			fieldContext := fmt.Sprintf("%s field %s:", context, newField.Name)
+			fieldContext := a.added(context, newField.Pos, describeField(newField), newField.Annotations).
+				child(fmt.Sprintf("field %s:", newField.Name))
 			// Adding a field "in the middle" is generally a sign
 			// of field ID reuse, which isn't allowed
 			if min < newField.ID && newField.ID < max {
-				a.logger.LogWarning(fieldContext, fmt.Sprintf("added field in the middle with ID=%d", newField.ID))
+				a.logWarning(RuleFieldAddedInMiddle, fieldContext, fieldContext.message,
+					fmt.Sprintf("added field in the middle with ID=%d", newField.ID))
 			}
 
 			if newField.Modifier == Required {
-				a.logger.LogError(fieldContext, "added field is required")
+				a.logError(RuleFieldRequiredAdded, fieldContext, fieldContext.message, "added field is required")
 			}
 		}
 	}
 }
 
+// checkAnnotations requirements:
+// Warning:
+// - Vendor annotation added, removed, or changed
+// - Deprecated annotation added or removed
+func (a *Auditor) checkAnnotations(oldAnnotations, newAnnotations Annotations, context auditContext) {
+	oldVendor, oldVendored := oldAnnotations.Vendor()
+	newVendor, newVendored := newAnnotations.Vendor()
+	switch {
+	case !oldVendored && newVendored:
+		a.logWarning(RuleVendorChanged, context, context.message, "vendor added")
+	case oldVendored && !newVendored:
+		a.logWarning(RuleVendorChanged, context, context.message, "vendor removed")
+	case oldVendor != newVendor:
+		a.logWarning(RuleVendorChanged, context, context.message,
+			fmt.Sprintf("vendor changed: '%s' -> '%s'", oldVendor, newVendor))
+	}
+
+	oldDeprecated := oldAnnotations.IsDeprecated()
+	newDeprecated := newAnnotations.IsDeprecated()
+	if !oldDeprecated && newDeprecated {
+		a.logWarning(RuleDeprecatedChanged, context, context.message, "deprecated added")
+	} else if oldDeprecated && !newDeprecated {
+		a.logWarning(RuleDeprecatedChanged, context, context.message, "deprecated removed")
+	}
+}
+
 func makeFieldsMap(fields []*Field) map[int]*Field {
 	fieldsMap := make(map[int]*Field)
 	for _, field := range fields {
@@ -418,16 +867,16 @@ func makeFieldsMap(fields []*Field) map[int]*Field {
 	return fieldsMap
 }
 
-func (a *Auditor) checkType(oldType, newType *Type, warn bool, context string) {
-	logMismatch := a.logger.LogWarning
+func (a *Auditor) checkType(oldType, newType *Type, warn bool, rule string, context auditContext) {
+	logMismatch := a.logWarning
 	if !warn {
-		logMismatch = a.logger.LogError
+		logMismatch = a.logError
 	}
 
 	// guarding here makes recursive calls easier
 	if oldType == nil || newType == nil {
 		if oldType != newType {
-			logMismatch(context, fmt.Sprintf("types not equal: '%v' -> '%v'", oldType, newType))
+			logMismatch(rule, context, context.message, fmt.Sprintf("types not equal: '%v' -> '%v'", oldType, newType))
 		}
 		return
 	}
@@ -436,11 +885,97 @@ func (a *Auditor) checkType(oldType, newType *Type, warn bool, context string) {
 	underlyingNewType := a.newFrugal.UnderlyingType(newType)
 	// TODO should this exclude the include name?
 	if underlyingOldType.Name != underlyingNewType.Name {
-		logMismatch(context, fmt.Sprintf("types not equal: '%s' -> '%s'",
+		logMismatch(rule, context, context.message, fmt.Sprintf("types not equal: '%s' -> '%s'",
 			underlyingOldType.Name, underlyingNewType.Name))
 		return
 	}
 
-	a.checkType(underlyingOldType.KeyType, underlyingNewType.KeyType, warn, context+" key type:")
-	a.checkType(underlyingOldType.ValueType, underlyingNewType.ValueType, warn, context+" value type:")
+	a.checkType(underlyingOldType.KeyType, underlyingNewType.KeyType, warn, rule, context.child("key type:"))
+	a.checkType(underlyingOldType.ValueType, underlyingNewType.ValueType, warn, rule, context.child("value type:"))
+}
+
+// describeScope returns the IDL definition of a scope without its operations.
+func describeScope(scope *Scope) string {
+	if scope.Prefix == nil || scope.Prefix.String == "" {
+		return fmt.Sprintf("scope %s", scope.Name)
+	}
+	return fmt.Sprintf("scope %s prefix %s", scope.Name, scope.Prefix.String)
+}
+
+func describeOperation(op *Operation) string {
+	return fmt.Sprintf("%s: %s", op.Name, op.Type)
+}
+
+func describeInclude(include *Include) string {
+	return fmt.Sprintf("include \"%s\"", include.Value)
+}
+
+func describeTypedef(typedef *TypeDef) string {
+	return fmt.Sprintf("typedef %s %s", typedef.Type, typedef.Name)
+}
+
+func describeNamespace(namespace *Namespace) string {
+	return fmt.Sprintf("namespace %s %s", namespace.Scope, namespace.Value)
+}
+
+func describeConstant(constant *Constant) string {
+	return fmt.Sprintf("const %s %s = %v", constant.Type, constant.Name, constant.Value)
+}
+
+// describeEnum returns the IDL definition of an enum without its values.
+func describeEnum(enum *Enum) string {
+	return fmt.Sprintf("enum %s", enum.Name)
+}
+
+func describeEnumValue(value *EnumValue) string {
+	return fmt.Sprintf("%s = %d", value.Name, value.Value)
+}
+
+// describeStruct returns the IDL definition of a struct without its fields.
+func describeStruct(s *Struct) string {
+	return fmt.Sprintf("%s %s", s.Type, s.Name)
+}
+
+// describeService returns the IDL definition of a service without its
+// methods.
+func describeService(service *Service) string {
+	if service.Extends == "" {
+		return fmt.Sprintf("service %s", service.Name)
+	}
+	return fmt.Sprintf("service %s extends %s", service.Name, service.Extends)
+}
+
+func describeMethod(method *Method) string {
+	returnType := "void"
+	if method.ReturnType != nil {
+		returnType = method.ReturnType.String()
+	}
+	def := fmt.Sprintf("%s %s(%s)", returnType, method.Name, describeFields(method.Arguments))
+	if method.Oneway {
+		def = "oneway " + def
+	}
+	if len(method.Exceptions) > 0 {
+		def += fmt.Sprintf(" throws (%s)", describeFields(method.Exceptions))
+	}
+	return def
+}
+
+func describeFields(fields []*Field) string {
+	defs := make([]string, len(fields))
+	for i, field := range fields {
+		defs[i] = describeField(field)
+	}
+	return strings.Join(defs, ", ")
+}
+
+func describeField(field *Field) string {
+	def := fmt.Sprintf("%d: ", field.ID)
+	if field.Modifier != Default {
+		def += strings.ToLower(field.Modifier.String()) + " "
+	}
+	def += fmt.Sprintf("%s %s", field.Type, field.Name)
+	if field.Default != nil {
+		def += fmt.Sprintf(" = %v", field.Default)
+	}
+	return def
 }
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	frugalURI    = "https://github.com/Workiva/frugal"
+)
+
+// WriteAuditJSON writes the given findings to w as a JSON array.
+func WriteAuditJSON(w io.Writer, findings []*AuditFinding) error {
+	if findings == nil {
+		findings = []*AuditFinding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(findings)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// WriteAuditSARIF writes the given findings to w as a SARIF 2.1.0 log
+// produced by the given version of the compiler. The old and new definitions
+// of a finding are stored as result properties.
+func WriteAuditSARIF(w io.Writer, version string, findings []*AuditFinding) error {
+	ruleIDs := make([]string, 0, len(AuditRules))
+	for id := range AuditRules {
+		ruleIDs = append(ruleIDs, id)
+	}
+	sort.Strings(ruleIDs)
+	rules := make([]sarifRule, len(ruleIDs))
+	for i, id := range ruleIDs {
+		rules[i] = sarifRule{ID: id, ShortDescription: sarifMessage{Text: AuditRules[id]}}
+	}
+
+	results := make([]sarifResult, len(findings))
+	for i, finding := range findings {
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: finding.File}}
+		if finding.Line > 0 {
+			location.Region = &sarifRegion{StartLine: finding.Line, StartColumn: finding.Column}
+		}
+		var properties map[string]string
+		if finding.Old != "" || finding.New != "" {
+			properties = map[string]string{"old": finding.Old, "new": finding.New}
+		}
+		results[i] = sarifResult{
+			RuleID:     finding.RuleID,
+			Level:      string(finding.Severity),
+			Message:    sarifMessage{Text: finding.Message},
+			Locations:  []sarifLocation{{PhysicalLocation: location}},
+			Properties: properties,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "frugal",
+				Version:        version,
+				InformationURI: frugalURI,
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import "strings"
+
+// SourceComment is a comment in an IDL file which is not a doc string. The
+// parser discards these, so they are recovered separately for tooling which
+// needs to reproduce the source.
+type SourceComment struct {
+	Pos  Pos
+	Text string
+
+	// Trailing indicates the comment follows code on the same line.
+	Trailing bool
+}
+
+// scanComments returns the comments in the given IDL source in the order they
+// appear, skipping doc strings and the contents of string literals.
+func scanComments(src []byte) []*SourceComment {
+	var (
+		runes    = []rune(string(src))
+		comments = []*SourceComment{}
+		line     = 1
+		col      = 1
+		hasCode  = false
+	)
+
+	// advance moves past n runes, tracking the current position.
+	advance := func(i, n int) int {
+		for end := i + n; i < end && i < len(runes); i++ {
+			if runes[i] == '\n' {
+				line++
+				col = 1
+				hasCode = false
+			} else {
+				col++
+			}
+		}
+		return i
+	}
+	hasPrefix := func(i int, prefix string) bool {
+		return strings.HasPrefix(string(runes[i:min(i+len(prefix), len(runes))]), prefix)
+	}
+
+	for i := 0; i < len(runes); {
+		switch {
+		case hasPrefix(i, "/**@"):
+			end := indexFrom(runes, i+4, "*/")
+			i = advance(i, end+2-i)
+			hasCode = true
+		case hasPrefix(i, "/*"):
+			end := indexFrom(runes, i+2, "*/")
+			comment := &SourceComment{Pos: Pos{Line: line, Col: col}, Trailing: hasCode}
+			comment.Text = string(runes[i:min(end+2, len(runes))])
+			i = advance(i, end+2-i)
+			comments = append(comments, comment)
+		case hasPrefix(i, "//"), hasPrefix(i, "#"):
+			end := indexFrom(runes, i, "\n")
+			comment := &SourceComment{Pos: Pos{Line: line, Col: col}, Trailing: hasCode}
+			comment.Text = strings.TrimRight(string(runes[i:min(end, len(runes))]), " \t\r")
+			i = advance(i, end-i)
+			comments = append(comments, comment)
+		case runes[i] == '"' || runes[i] == '\'':
+			quote := runes[i]
+			i = advance(i, 1)
+			for i < len(runes) && runes[i] != quote {
+				if runes[i] == '\\' {
+					i = advance(i, 1)
+				}
+				i = advance(i, 1)
+			}
+			i = advance(i, 1)
+			hasCode = true
+		default:
+			if !strings.ContainsRune(" \t\r\n", runes[i]) {
+				hasCode = true
+			}
+			i = advance(i, 1)
+		}
+	}
+	return comments
+}
+
+// indexFrom returns the index of substr in runes at or after start, or
+// len(runes) if it does not occur.
+func indexFrom(runes []rune, start int, substr string) int {
+	if start > len(runes) {
+		return len(runes)
+	}
+	idx := strings.Index(string(runes[start:]), substr)
+	if idx < 0 {
+		return len(runes)
+	}
+	return start + len([]rune(string(runes[start:])[:idx]))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Field validation annotations. Generators which support them check each
+// constraint in a generated Validate method.
+const (
+	// ValidateMin is the minimum value of a numeric field.
+	ValidateMin = "validate.min"
+
+	// ValidateMax is the maximum value of a numeric field.
+	ValidateMax = "validate.max"
+
+	// ValidateMinLen is the minimum length of a string, binary, or container
+	// field. Strings are measured in bytes.
+	ValidateMinLen = "validate.min_len"
+
+	// ValidateMaxLen is the maximum length of a string, binary, or container
+	// field. Strings are measured in bytes.
+	ValidateMaxLen = "validate.max_len"
+
+	// ValidatePattern is a regular expression, in RE2 syntax, which a string
+	// field must match.
+	ValidatePattern = "validate.pattern"
+
+	// ValidateNonEmpty requires a string, binary, or container field to be
+	// non-empty. It takes no value.
+	ValidateNonEmpty = "validate.non_empty"
+)
+
+const validateAnnotationPrefix = "validate."
+
+// Constraints returns the validation annotations in declaration order.
+func (a Annotations) Constraints() Annotations {
+	var constraints Annotations
+	for _, annotation := range a {
+		if strings.HasPrefix(annotation.Name, validateAnnotationPrefix) {
+			constraints = append(constraints, annotation)
+		}
+	}
+	return constraints
+}
+
+// NeedsValidation returns true if the struct, which is relative to this
+// Frugal, has field constraints or contains a struct which does, directly or
+// through containers.
+func (f *Frugal) NeedsValidation(s *Struct) bool {
+	return f.needsValidation(s, make(map[*Struct]bool))
+}
+
+func (f *Frugal) needsValidation(s *Struct, visited map[*Struct]bool) bool {
+	if visited[s] {
+		return false
+	}
+	visited[s] = true
+	for _, field := range s.Fields {
+		if len(field.Annotations.Constraints()) > 0 || f.typeNeedsValidation(field.Type, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Frugal) typeNeedsValidation(t *Type, visited map[*Struct]bool) bool {
+	underlyingType := f.UnderlyingType(t)
+	if underlyingType.IsContainer() {
+		return (underlyingType.KeyType != nil && f.typeNeedsValidation(underlyingType.KeyType, visited)) ||
+			f.typeNeedsValidation(underlyingType.ValueType, visited)
+	}
+	if s, frugal := f.findDataStructure(underlyingType); s != nil {
+		return frugal.needsValidation(s, visited)
+	}
+	return false
+}
+
+// findDataStructure returns the struct, union, or exception with the given
+// type and the Frugal it is defined in.
+func (f *Frugal) findDataStructure(t *Type) (*Struct, *Frugal) {
+	frugal := f
+	if include := t.IncludeName(); include != "" {
+		parsed, ok := f.ParsedIncludes[include]
+		if !ok {
+			return nil, nil
+		}
+		frugal = parsed
+	}
+	for _, s := range frugal.DataStructures() {
+		if s.Name == t.ParamName() {
+			return s, frugal
+		}
+	}
+	return nil, nil
+}
+
+// validateConstraints ensures the field's constraints are known, have valid
+// values, and apply to its type.
+func (f *Frugal) validateConstraints(v *validator, field *Field) {
+	underlyingType := f.UnderlyingType(field.Type)
+	name := underlyingType.Name
+	bits := map[string]int{"byte": 8, "i8": 8, "i16": 16, "i32": 32, "i64": 64}[name]
+	integral := bits > 0
+	numeric := integral || name == "double"
+	sized := name == "string" || name == "binary" || underlyingType.IsContainer()
+
+	bounds := make(map[string]float64)
+	for _, constraint := range field.Annotations.Constraints() {
+		switch constraint.Name {
+		case ValidateMin, ValidateMax:
+			if !numeric {
+				v.errorf(field.Pos, "%s does not apply to field %s of type %s", constraint.Name, field.Name, field.Type)
+				continue
+			}
+			var err error
+			var bound float64
+			if integral {
+				var i int64
+				i, err = strconv.ParseInt(constraint.Value, 10, bits)
+				bound = float64(i)
+			} else {
+				bound, err = strconv.ParseFloat(constraint.Value, 64)
+			}
+			if err != nil || math.IsInf(bound, 0) || math.IsNaN(bound) {
+				v.errorf(field.Pos, "Invalid %s value '%s' on field %s", constraint.Name, constraint.Value, field.Name)
+				continue
+			}
+			bounds[constraint.Name] = bound
+		case ValidateMinLen, ValidateMaxLen:
+			if !sized {
+				v.errorf(field.Pos, "%s does not apply to field %s of type %s", constraint.Name, field.Name, field.Type)
+				continue
+			}
+			length, err := strconv.ParseUint(constraint.Value, 10, 31)
+			if err != nil {
+				v.errorf(field.Pos, "Invalid %s value '%s' on field %s", constraint.Name, constraint.Value, field.Name)
+				continue
+			}
+			bounds[constraint.Name] = float64(length)
+		case ValidatePattern:
+			if name != "string" {
+				v.errorf(field.Pos, "%s does not apply to field %s of type %s", constraint.Name, field.Name, field.Type)
+				continue
+			}
+			if _, err := regexp.Compile(constraint.Value); err != nil {
+				v.errorf(field.Pos, "Invalid %s value '%s' on field %s: %s", constraint.Name, constraint.Value, field.Name, err)
+			}
+		case ValidateNonEmpty:
+			if !sized {
+				v.errorf(field.Pos, "%s does not apply to field %s of type %s", constraint.Name, field.Name, field.Type)
+				continue
+			}
+			if constraint.Value != "" {
+				v.errorf(field.Pos, "%s on field %s takes no value", constraint.Name, field.Name)
+			}
+		default:
+			v.errorf(field.Pos, "Unknown validation annotation %s on field %s", constraint.Name, field.Name)
+		}
+	}
+
+	checkRange := func(min, max string) {
+		lower, hasLower := bounds[min]
+		upper, hasUpper := bounds[max]
+		if hasLower && hasUpper && lower > upper {
+			v.errorf(field.Pos, "%s is greater than %s on field %s", min, max, field.Name)
+		}
+	}
+	checkRange(ValidateMin, ValidateMax)
+	checkRange(ValidateMinLen, ValidateMaxLen)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitFileChecker returns a FileChecker which checks files exist at the given
+// git revision, without reading them.
+func GitFileChecker(revision string) FileChecker {
+	return func(filePath string) bool {
+		commit, err := resolveRevision(revision, filepath.Dir(filePath))
+		if err != nil {
+			return false
+		}
+		cmd := exec.Command("git", "cat-file", "-e", fmt.Sprintf("%s:./%s", commit, filepath.Base(filePath)))
+		cmd.Dir = filepath.Dir(filePath)
+		return cmd.Run() == nil
+	}
+}
+
+// GitFileReader returns a FileReader which reads files as they were at the
+// given git revision. Files are read from the object store of the repository
+// containing them, so the revision doesn't need to be checked out.
+func GitFileReader(revision string) FileReader {
+	return func(filePath string) ([]byte, error) {
+		commit, err := resolveRevision(revision, filepath.Dir(filePath))
+		if err != nil {
+			return nil, fmt.Errorf("read %s at %s: %s", filePath, revision, err)
+		}
+		// "<revision>:./<path>" is resolved relative to the working
+		// directory, which makes include paths such as "../base.frugal"
+		// work from anywhere in the repository.
+		cmd := exec.Command("git", "show", fmt.Sprintf("%s:./%s", commit, filepath.Base(filePath)))
+		cmd.Dir = filepath.Dir(filePath)
+		stderr := &bytes.Buffer{}
+		cmd.Stderr = stderr
+		contents, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("read %s at %s: %s", filePath, revision, msg)
+			}
+			return nil, fmt.Errorf("read %s at %s: %s", filePath, revision, err)
+		}
+		return contents, nil
+	}
+}
+
+// resolveRevision returns the commit the given revision names in the
+// repository containing dir. The revision is verified before it is passed to
+// other git commands, which would parse a revision starting with "-" as an
+// option.
+func resolveRevision(revision, dir string) (string, error) {
+	if strings.HasPrefix(revision, "-") {
+		return "", fmt.Errorf("invalid revision %q", revision)
+	}
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", revision+"^{commit}")
+	cmd.Dir = dir
+	commit, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %q", revision)
+	}
+	return strings.TrimSpace(string(commit)), nil
+}
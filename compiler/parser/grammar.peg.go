@@ -1,3 +1,5 @@
+// Code generated by pigeon; DO NOT EDIT.
+
 package parser
 
 import (
@@ -6,9 +8,11 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode"
@@ -84,35 +88,58 @@ func toAnnotations(v interface{}) Annotations {
 	return Annotations(v.([]*Annotation))
 }
 
+// definitionPos returns the position of the definition matched by c, skipping
+// over its leading doc string, if any.
+func definitionPos(c *current) Pos {
+	pos := Pos{Line: c.pos.line, Col: c.pos.col}
+	text := string(c.text)
+	if !strings.HasPrefix(text, "/**@") {
+		return pos
+	}
+	end := strings.Index(text, "*/") + 2
+	for end < len(text) && strings.ContainsRune(" \t\r\n", rune(text[end])) {
+		end++
+	}
+	for _, r := range text[:end] {
+		if r == '\n' {
+			pos.Line++
+			pos.Col = 1
+		} else {
+			pos.Col++
+		}
+	}
+	return pos
+}
+
 var g = &grammar{
 	rules: []*rule{
 		{
 			name: "Grammar",
-			pos:  position{line: 85, col: 1, offset: 2393},
+			pos:  position{line: 121, col: 1, offset: 3680},
 			expr: &actionExpr{
-				pos: position{line: 85, col: 12, offset: 2404},
+				pos: position{line: 121, col: 12, offset: 3691},
 				run: (*parser).callonGrammar1,
 				expr: &seqExpr{
-					pos: position{line: 85, col: 12, offset: 2404},
+					pos: position{line: 121, col: 12, offset: 3691},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 85, col: 12, offset: 2404},
+							pos:  position{line: 121, col: 12, offset: 3691},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 85, col: 15, offset: 2407},
+							pos:   position{line: 121, col: 15, offset: 3694},
 							label: "statements",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 85, col: 26, offset: 2418},
+								pos: position{line: 121, col: 26, offset: 3705},
 								expr: &seqExpr{
-									pos: position{line: 85, col: 28, offset: 2420},
+									pos: position{line: 121, col: 28, offset: 3707},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 85, col: 28, offset: 2420},
+											pos:  position{line: 121, col: 28, offset: 3707},
 											name: "Statement",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 85, col: 38, offset: 2430},
+											pos:  position{line: 121, col: 38, offset: 3717},
 											name: "__",
 										},
 									},
@@ -120,14 +147,14 @@ var g = &grammar{
 							},
 						},
 						&choiceExpr{
-							pos: position{line: 85, col: 45, offset: 2437},
+							pos: position{line: 121, col: 45, offset: 3724},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 85, col: 45, offset: 2437},
+									pos:  position{line: 121, col: 45, offset: 3724},
 									name: "EOF",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 85, col: 51, offset: 2443},
+									pos:  position{line: 121, col: 51, offset: 3730},
 									name: "SyntaxError",
 								},
 							},
@@ -138,38 +165,38 @@ var g = &grammar{
 		},
 		{
 			name: "SyntaxError",
-			pos:  position{line: 150, col: 1, offset: 4792},
+			pos:  position{line: 188, col: 1, offset: 6159},
 			expr: &actionExpr{
-				pos: position{line: 150, col: 16, offset: 4807},
+				pos: position{line: 188, col: 16, offset: 6174},
 				run: (*parser).callonSyntaxError1,
 				expr: &anyMatcher{
-					line: 150, col: 16, offset: 4807,
+					line: 188, col: 16, offset: 6174,
 				},
 			},
 		},
 		{
 			name: "Statement",
-			pos:  position{line: 154, col: 1, offset: 4865},
+			pos:  position{line: 192, col: 1, offset: 6232},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 14, offset: 4878},
+				pos: position{line: 192, col: 14, offset: 6245},
 				run: (*parser).callonStatement1,
 				expr: &seqExpr{
-					pos: position{line: 154, col: 14, offset: 4878},
+					pos: position{line: 192, col: 14, offset: 6245},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 154, col: 14, offset: 4878},
+							pos:   position{line: 192, col: 14, offset: 6245},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 154, col: 21, offset: 4885},
+								pos: position{line: 192, col: 21, offset: 6252},
 								expr: &seqExpr{
-									pos: position{line: 154, col: 22, offset: 4886},
+									pos: position{line: 192, col: 22, offset: 6253},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 154, col: 22, offset: 4886},
+											pos:  position{line: 192, col: 22, offset: 6253},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 154, col: 32, offset: 4896},
+											pos:  position{line: 192, col: 32, offset: 6263},
 											name: "__",
 										},
 									},
@@ -177,10 +204,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 154, col: 37, offset: 4901},
+							pos:   position{line: 192, col: 37, offset: 6268},
 							label: "statement",
 							expr: &ruleRefExpr{
-								pos:  position{line: 154, col: 47, offset: 4911},
+								pos:  position{line: 192, col: 47, offset: 6278},
 								name: "FrugalStatement",
 							},
 						},
@@ -190,48 +217,48 @@ var g = &grammar{
 		},
 		{
 			name: "FrugalStatement",
-			pos:  position{line: 167, col: 1, offset: 5381},
+			pos:  position{line: 205, col: 1, offset: 6748},
 			expr: &choiceExpr{
-				pos: position{line: 167, col: 20, offset: 5400},
+				pos: position{line: 205, col: 20, offset: 6767},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 20, offset: 5400},
+						pos:  position{line: 205, col: 20, offset: 6767},
 						name: "Include",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 30, offset: 5410},
+						pos:  position{line: 205, col: 30, offset: 6777},
 						name: "Namespace",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 42, offset: 5422},
+						pos:  position{line: 205, col: 42, offset: 6789},
 						name: "Const",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 50, offset: 5430},
+						pos:  position{line: 205, col: 50, offset: 6797},
 						name: "Enum",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 57, offset: 5437},
+						pos:  position{line: 205, col: 57, offset: 6804},
 						name: "TypeDef",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 67, offset: 5447},
+						pos:  position{line: 205, col: 67, offset: 6814},
 						name: "Struct",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 76, offset: 5456},
+						pos:  position{line: 205, col: 76, offset: 6823},
 						name: "Exception",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 88, offset: 5468},
+						pos:  position{line: 205, col: 88, offset: 6835},
 						name: "Union",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 96, offset: 5476},
+						pos:  position{line: 205, col: 96, offset: 6843},
 						name: "Service",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 167, col: 106, offset: 5486},
+						pos:  position{line: 205, col: 106, offset: 6853},
 						name: "Scope",
 					},
 				},
@@ -239,47 +266,47 @@ var g = &grammar{
 		},
 		{
 			name: "Include",
-			pos:  position{line: 169, col: 1, offset: 5493},
+			pos:  position{line: 207, col: 1, offset: 6860},
 			expr: &actionExpr{
-				pos: position{line: 169, col: 12, offset: 5504},
+				pos: position{line: 207, col: 12, offset: 6871},
 				run: (*parser).callonInclude1,
 				expr: &seqExpr{
-					pos: position{line: 169, col: 12, offset: 5504},
+					pos: position{line: 207, col: 12, offset: 6871},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 169, col: 12, offset: 5504},
+							pos:        position{line: 207, col: 12, offset: 6871},
 							val:        "include",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 169, col: 22, offset: 5514},
+							pos:  position{line: 207, col: 22, offset: 6881},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 169, col: 24, offset: 5516},
+							pos:   position{line: 207, col: 24, offset: 6883},
 							label: "file",
 							expr: &ruleRefExpr{
-								pos:  position{line: 169, col: 29, offset: 5521},
+								pos:  position{line: 207, col: 29, offset: 6888},
 								name: "Literal",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 169, col: 37, offset: 5529},
+							pos:  position{line: 207, col: 37, offset: 6896},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 169, col: 39, offset: 5531},
+							pos:   position{line: 207, col: 39, offset: 6898},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 169, col: 51, offset: 5543},
+								pos: position{line: 207, col: 51, offset: 6910},
 								expr: &ruleRefExpr{
-									pos:  position{line: 169, col: 51, offset: 5543},
+									pos:  position{line: 207, col: 51, offset: 6910},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 169, col: 68, offset: 5560},
+							pos:  position{line: 207, col: 68, offset: 6927},
 							name: "EOS",
 						},
 					},
@@ -288,29 +315,29 @@ var g = &grammar{
 		},
 		{
 			name: "Namespace",
-			pos:  position{line: 181, col: 1, offset: 5837},
+			pos:  position{line: 220, col: 1, offset: 7243},
 			expr: &actionExpr{
-				pos: position{line: 181, col: 14, offset: 5850},
+				pos: position{line: 220, col: 14, offset: 7256},
 				run: (*parser).callonNamespace1,
 				expr: &seqExpr{
-					pos: position{line: 181, col: 14, offset: 5850},
+					pos: position{line: 220, col: 14, offset: 7256},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 181, col: 14, offset: 5850},
+							pos:        position{line: 220, col: 14, offset: 7256},
 							val:        "namespace",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 181, col: 26, offset: 5862},
+							pos:  position{line: 220, col: 26, offset: 7268},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 181, col: 28, offset: 5864},
+							pos:   position{line: 220, col: 28, offset: 7270},
 							label: "scope",
 							expr: &oneOrMoreExpr{
-								pos: position{line: 181, col: 34, offset: 5870},
+								pos: position{line: 220, col: 34, offset: 7276},
 								expr: &charClassMatcher{
-									pos:        position{line: 181, col: 34, offset: 5870},
+									pos:        position{line: 220, col: 34, offset: 7276},
 									val:        "[*a-z.-]",
 									chars:      []rune{'*', '.', '-'},
 									ranges:     []rune{'a', 'z'},
@@ -320,34 +347,34 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 181, col: 44, offset: 5880},
+							pos:  position{line: 220, col: 44, offset: 7286},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 181, col: 46, offset: 5882},
+							pos:   position{line: 220, col: 46, offset: 7288},
 							label: "ns",
 							expr: &ruleRefExpr{
-								pos:  position{line: 181, col: 49, offset: 5885},
+								pos:  position{line: 220, col: 49, offset: 7291},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 181, col: 60, offset: 5896},
+							pos:  position{line: 220, col: 60, offset: 7302},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 181, col: 62, offset: 5898},
+							pos:   position{line: 220, col: 62, offset: 7304},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 181, col: 74, offset: 5910},
+								pos: position{line: 220, col: 74, offset: 7316},
 								expr: &ruleRefExpr{
-									pos:  position{line: 181, col: 74, offset: 5910},
+									pos:  position{line: 220, col: 74, offset: 7316},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 181, col: 91, offset: 5927},
+							pos:  position{line: 220, col: 91, offset: 7333},
 							name: "EOS",
 						},
 					},
@@ -356,80 +383,80 @@ var g = &grammar{
 		},
 		{
 			name: "Const",
-			pos:  position{line: 189, col: 1, offset: 6113},
+			pos:  position{line: 229, col: 1, offset: 7558},
 			expr: &actionExpr{
-				pos: position{line: 189, col: 10, offset: 6122},
+				pos: position{line: 229, col: 10, offset: 7567},
 				run: (*parser).callonConst1,
 				expr: &seqExpr{
-					pos: position{line: 189, col: 10, offset: 6122},
+					pos: position{line: 229, col: 10, offset: 7567},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 189, col: 10, offset: 6122},
+							pos:        position{line: 229, col: 10, offset: 7567},
 							val:        "const",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 18, offset: 6130},
+							pos:  position{line: 229, col: 18, offset: 7575},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 189, col: 20, offset: 6132},
+							pos:   position{line: 229, col: 20, offset: 7577},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 189, col: 24, offset: 6136},
+								pos:  position{line: 229, col: 24, offset: 7581},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 34, offset: 6146},
+							pos:  position{line: 229, col: 34, offset: 7591},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 189, col: 36, offset: 6148},
+							pos:   position{line: 229, col: 36, offset: 7593},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 189, col: 41, offset: 6153},
+								pos:  position{line: 229, col: 41, offset: 7598},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 52, offset: 6164},
+							pos:  position{line: 229, col: 52, offset: 7609},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 189, col: 54, offset: 6166},
+							pos:        position{line: 229, col: 54, offset: 7611},
 							val:        "=",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 58, offset: 6170},
+							pos:  position{line: 229, col: 58, offset: 7615},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 189, col: 60, offset: 6172},
+							pos:   position{line: 229, col: 60, offset: 7617},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 189, col: 66, offset: 6178},
+								pos:  position{line: 229, col: 66, offset: 7623},
 								name: "ConstValue",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 77, offset: 6189},
+							pos:  position{line: 229, col: 77, offset: 7634},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 189, col: 79, offset: 6191},
+							pos:   position{line: 229, col: 79, offset: 7636},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 189, col: 91, offset: 6203},
+								pos: position{line: 229, col: 91, offset: 7648},
 								expr: &ruleRefExpr{
-									pos:  position{line: 189, col: 91, offset: 6203},
+									pos:  position{line: 229, col: 91, offset: 7648},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 189, col: 108, offset: 6220},
+							pos:  position{line: 229, col: 108, offset: 7665},
 							name: "EOS",
 						},
 					},
@@ -438,57 +465,57 @@ var g = &grammar{
 		},
 		{
 			name: "Enum",
-			pos:  position{line: 198, col: 1, offset: 6414},
+			pos:  position{line: 239, col: 1, offset: 7898},
 			expr: &actionExpr{
-				pos: position{line: 198, col: 9, offset: 6422},
+				pos: position{line: 239, col: 9, offset: 7906},
 				run: (*parser).callonEnum1,
 				expr: &seqExpr{
-					pos: position{line: 198, col: 9, offset: 6422},
+					pos: position{line: 239, col: 9, offset: 7906},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 198, col: 9, offset: 6422},
+							pos:        position{line: 239, col: 9, offset: 7906},
 							val:        "enum",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 198, col: 16, offset: 6429},
+							pos:  position{line: 239, col: 16, offset: 7913},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 198, col: 18, offset: 6431},
+							pos:   position{line: 239, col: 18, offset: 7915},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 198, col: 23, offset: 6436},
+								pos:  position{line: 239, col: 23, offset: 7920},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 198, col: 34, offset: 6447},
+							pos:  position{line: 239, col: 34, offset: 7931},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 198, col: 37, offset: 6450},
+							pos:        position{line: 239, col: 37, offset: 7934},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 198, col: 41, offset: 6454},
+							pos:  position{line: 239, col: 41, offset: 7938},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 198, col: 44, offset: 6457},
+							pos:   position{line: 239, col: 44, offset: 7941},
 							label: "values",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 198, col: 51, offset: 6464},
+								pos: position{line: 239, col: 51, offset: 7948},
 								expr: &seqExpr{
-									pos: position{line: 198, col: 52, offset: 6465},
+									pos: position{line: 239, col: 52, offset: 7949},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 198, col: 52, offset: 6465},
+											pos:  position{line: 239, col: 52, offset: 7949},
 											name: "EnumValue",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 198, col: 62, offset: 6475},
+											pos:  position{line: 239, col: 62, offset: 7959},
 											name: "__",
 										},
 									},
@@ -496,27 +523,27 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 198, col: 67, offset: 6480},
+							pos:        position{line: 239, col: 67, offset: 7964},
 							val:        "}",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 198, col: 71, offset: 6484},
+							pos:  position{line: 239, col: 71, offset: 7968},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 198, col: 73, offset: 6486},
+							pos:   position{line: 239, col: 73, offset: 7970},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 198, col: 85, offset: 6498},
+								pos: position{line: 239, col: 85, offset: 7982},
 								expr: &ruleRefExpr{
-									pos:  position{line: 198, col: 85, offset: 6498},
+									pos:  position{line: 239, col: 85, offset: 7982},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 198, col: 102, offset: 6515},
+							pos:  position{line: 239, col: 102, offset: 7999},
 							name: "EOS",
 						},
 					},
@@ -525,27 +552,27 @@ var g = &grammar{
 		},
 		{
 			name: "EnumValue",
-			pos:  position{line: 222, col: 1, offset: 7177},
+			pos:  position{line: 265, col: 1, offset: 8731},
 			expr: &actionExpr{
-				pos: position{line: 222, col: 14, offset: 7190},
+				pos: position{line: 265, col: 14, offset: 8744},
 				run: (*parser).callonEnumValue1,
 				expr: &seqExpr{
-					pos: position{line: 222, col: 14, offset: 7190},
+					pos: position{line: 265, col: 14, offset: 8744},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 222, col: 14, offset: 7190},
+							pos:   position{line: 265, col: 14, offset: 8744},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 21, offset: 7197},
+								pos: position{line: 265, col: 21, offset: 8751},
 								expr: &seqExpr{
-									pos: position{line: 222, col: 22, offset: 7198},
+									pos: position{line: 265, col: 22, offset: 8752},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 222, col: 22, offset: 7198},
+											pos:  position{line: 265, col: 22, offset: 8752},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 222, col: 32, offset: 7208},
+											pos:  position{line: 265, col: 32, offset: 8762},
 											name: "__",
 										},
 									},
@@ -553,36 +580,36 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 37, offset: 7213},
+							pos:   position{line: 265, col: 37, offset: 8767},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 42, offset: 7218},
+								pos:  position{line: 265, col: 42, offset: 8772},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 53, offset: 7229},
+							pos:  position{line: 265, col: 53, offset: 8783},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 55, offset: 7231},
+							pos:   position{line: 265, col: 55, offset: 8785},
 							label: "value",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 61, offset: 7237},
+								pos: position{line: 265, col: 61, offset: 8791},
 								expr: &seqExpr{
-									pos: position{line: 222, col: 62, offset: 7238},
+									pos: position{line: 265, col: 62, offset: 8792},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 222, col: 62, offset: 7238},
+											pos:        position{line: 265, col: 62, offset: 8792},
 											val:        "=",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 222, col: 66, offset: 7242},
+											pos:  position{line: 265, col: 66, offset: 8796},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 222, col: 68, offset: 7244},
+											pos:  position{line: 265, col: 68, offset: 8798},
 											name: "IntConstant",
 										},
 									},
@@ -590,24 +617,24 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 82, offset: 7258},
+							pos:  position{line: 265, col: 82, offset: 8812},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 84, offset: 7260},
+							pos:   position{line: 265, col: 84, offset: 8814},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 96, offset: 7272},
+								pos: position{line: 265, col: 96, offset: 8826},
 								expr: &ruleRefExpr{
-									pos:  position{line: 222, col: 96, offset: 7272},
+									pos:  position{line: 265, col: 96, offset: 8826},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 222, col: 113, offset: 7289},
+							pos: position{line: 265, col: 113, offset: 8843},
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 113, offset: 7289},
+								pos:  position{line: 265, col: 113, offset: 8843},
 								name: "ListSeparator",
 							},
 						},
@@ -617,59 +644,59 @@ var g = &grammar{
 		},
 		{
 			name: "TypeDef",
-			pos:  position{line: 238, col: 1, offset: 7687},
+			pos:  position{line: 282, col: 1, offset: 9280},
 			expr: &actionExpr{
-				pos: position{line: 238, col: 12, offset: 7698},
+				pos: position{line: 282, col: 12, offset: 9291},
 				run: (*parser).callonTypeDef1,
 				expr: &seqExpr{
-					pos: position{line: 238, col: 12, offset: 7698},
+					pos: position{line: 282, col: 12, offset: 9291},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 238, col: 12, offset: 7698},
+							pos:        position{line: 282, col: 12, offset: 9291},
 							val:        "typedef",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 22, offset: 7708},
+							pos:  position{line: 282, col: 22, offset: 9301},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 238, col: 24, offset: 7710},
+							pos:   position{line: 282, col: 24, offset: 9303},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 238, col: 28, offset: 7714},
+								pos:  position{line: 282, col: 28, offset: 9307},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 38, offset: 7724},
+							pos:  position{line: 282, col: 38, offset: 9317},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 238, col: 40, offset: 7726},
+							pos:   position{line: 282, col: 40, offset: 9319},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 238, col: 45, offset: 7731},
+								pos:  position{line: 282, col: 45, offset: 9324},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 56, offset: 7742},
+							pos:  position{line: 282, col: 56, offset: 9335},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 238, col: 58, offset: 7744},
+							pos:   position{line: 282, col: 58, offset: 9337},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 238, col: 70, offset: 7756},
+								pos: position{line: 282, col: 70, offset: 9349},
 								expr: &ruleRefExpr{
-									pos:  position{line: 238, col: 70, offset: 7756},
+									pos:  position{line: 282, col: 70, offset: 9349},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 238, col: 87, offset: 7773},
+							pos:  position{line: 282, col: 87, offset: 9366},
 							name: "EOS",
 						},
 					},
@@ -678,27 +705,27 @@ var g = &grammar{
 		},
 		{
 			name: "Struct",
-			pos:  position{line: 246, col: 1, offset: 7945},
+			pos:  position{line: 291, col: 1, offset: 9577},
 			expr: &actionExpr{
-				pos: position{line: 246, col: 11, offset: 7955},
+				pos: position{line: 291, col: 11, offset: 9587},
 				run: (*parser).callonStruct1,
 				expr: &seqExpr{
-					pos: position{line: 246, col: 11, offset: 7955},
+					pos: position{line: 291, col: 11, offset: 9587},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 246, col: 11, offset: 7955},
+							pos:        position{line: 291, col: 11, offset: 9587},
 							val:        "struct",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 246, col: 20, offset: 7964},
+							pos:  position{line: 291, col: 20, offset: 9596},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 246, col: 22, offset: 7966},
+							pos:   position{line: 291, col: 22, offset: 9598},
 							label: "st",
 							expr: &ruleRefExpr{
-								pos:  position{line: 246, col: 25, offset: 7969},
+								pos:  position{line: 291, col: 25, offset: 9601},
 								name: "StructLike",
 							},
 						},
@@ -708,27 +735,27 @@ var g = &grammar{
 		},
 		{
 			name: "Exception",
-			pos:  position{line: 247, col: 1, offset: 8009},
+			pos:  position{line: 296, col: 1, offset: 9685},
 			expr: &actionExpr{
-				pos: position{line: 247, col: 14, offset: 8022},
+				pos: position{line: 296, col: 14, offset: 9698},
 				run: (*parser).callonException1,
 				expr: &seqExpr{
-					pos: position{line: 247, col: 14, offset: 8022},
+					pos: position{line: 296, col: 14, offset: 9698},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 247, col: 14, offset: 8022},
+							pos:        position{line: 296, col: 14, offset: 9698},
 							val:        "exception",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 247, col: 26, offset: 8034},
+							pos:  position{line: 296, col: 26, offset: 9710},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 247, col: 28, offset: 8036},
+							pos:   position{line: 296, col: 28, offset: 9712},
 							label: "st",
 							expr: &ruleRefExpr{
-								pos:  position{line: 247, col: 31, offset: 8039},
+								pos:  position{line: 296, col: 31, offset: 9715},
 								name: "StructLike",
 							},
 						},
@@ -738,27 +765,27 @@ var g = &grammar{
 		},
 		{
 			name: "Union",
-			pos:  position{line: 248, col: 1, offset: 8090},
+			pos:  position{line: 301, col: 1, offset: 9810},
 			expr: &actionExpr{
-				pos: position{line: 248, col: 10, offset: 8099},
+				pos: position{line: 301, col: 10, offset: 9819},
 				run: (*parser).callonUnion1,
 				expr: &seqExpr{
-					pos: position{line: 248, col: 10, offset: 8099},
+					pos: position{line: 301, col: 10, offset: 9819},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 248, col: 10, offset: 8099},
+							pos:        position{line: 301, col: 10, offset: 9819},
 							val:        "union",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 248, col: 18, offset: 8107},
+							pos:  position{line: 301, col: 18, offset: 9827},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 248, col: 20, offset: 8109},
+							pos:   position{line: 301, col: 20, offset: 9829},
 							label: "st",
 							expr: &ruleRefExpr{
-								pos:  position{line: 248, col: 23, offset: 8112},
+								pos:  position{line: 301, col: 23, offset: 9832},
 								name: "StructLike",
 							},
 						},
@@ -768,64 +795,64 @@ var g = &grammar{
 		},
 		{
 			name: "StructLike",
-			pos:  position{line: 249, col: 1, offset: 8159},
+			pos:  position{line: 306, col: 1, offset: 9923},
 			expr: &actionExpr{
-				pos: position{line: 249, col: 15, offset: 8173},
+				pos: position{line: 306, col: 15, offset: 9937},
 				run: (*parser).callonStructLike1,
 				expr: &seqExpr{
-					pos: position{line: 249, col: 15, offset: 8173},
+					pos: position{line: 306, col: 15, offset: 9937},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 249, col: 15, offset: 8173},
+							pos:   position{line: 306, col: 15, offset: 9937},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 249, col: 20, offset: 8178},
+								pos:  position{line: 306, col: 20, offset: 9942},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 31, offset: 8189},
+							pos:  position{line: 306, col: 31, offset: 9953},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 249, col: 34, offset: 8192},
+							pos:        position{line: 306, col: 34, offset: 9956},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 38, offset: 8196},
+							pos:  position{line: 306, col: 38, offset: 9960},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 249, col: 41, offset: 8199},
+							pos:   position{line: 306, col: 41, offset: 9963},
 							label: "fields",
 							expr: &ruleRefExpr{
-								pos:  position{line: 249, col: 48, offset: 8206},
+								pos:  position{line: 306, col: 48, offset: 9970},
 								name: "FieldList",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 249, col: 58, offset: 8216},
+							pos:        position{line: 306, col: 58, offset: 9980},
 							val:        "}",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 62, offset: 8220},
+							pos:  position{line: 306, col: 62, offset: 9984},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 249, col: 64, offset: 8222},
+							pos:   position{line: 306, col: 64, offset: 9986},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 249, col: 76, offset: 8234},
+								pos: position{line: 306, col: 76, offset: 9998},
 								expr: &ruleRefExpr{
-									pos:  position{line: 249, col: 76, offset: 8234},
+									pos:  position{line: 306, col: 76, offset: 9998},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 249, col: 93, offset: 8251},
+							pos:  position{line: 306, col: 93, offset: 10015},
 							name: "EOS",
 						},
 					},
@@ -834,24 +861,24 @@ var g = &grammar{
 		},
 		{
 			name: "FieldList",
-			pos:  position{line: 260, col: 1, offset: 8468},
+			pos:  position{line: 317, col: 1, offset: 10232},
 			expr: &actionExpr{
-				pos: position{line: 260, col: 14, offset: 8481},
+				pos: position{line: 317, col: 14, offset: 10245},
 				run: (*parser).callonFieldList1,
 				expr: &labeledExpr{
-					pos:   position{line: 260, col: 14, offset: 8481},
+					pos:   position{line: 317, col: 14, offset: 10245},
 					label: "fields",
 					expr: &zeroOrMoreExpr{
-						pos: position{line: 260, col: 21, offset: 8488},
+						pos: position{line: 317, col: 21, offset: 10252},
 						expr: &seqExpr{
-							pos: position{line: 260, col: 22, offset: 8489},
+							pos: position{line: 317, col: 22, offset: 10253},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 260, col: 22, offset: 8489},
+									pos:  position{line: 317, col: 22, offset: 10253},
 									name: "Field",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 260, col: 28, offset: 8495},
+									pos:  position{line: 317, col: 28, offset: 10259},
 									name: "__",
 								},
 							},
@@ -862,27 +889,27 @@ var g = &grammar{
 		},
 		{
 			name: "Field",
-			pos:  position{line: 269, col: 1, offset: 8676},
+			pos:  position{line: 326, col: 1, offset: 10440},
 			expr: &actionExpr{
-				pos: position{line: 269, col: 10, offset: 8685},
+				pos: position{line: 326, col: 10, offset: 10449},
 				run: (*parser).callonField1,
 				expr: &seqExpr{
-					pos: position{line: 269, col: 10, offset: 8685},
+					pos: position{line: 326, col: 10, offset: 10449},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 269, col: 10, offset: 8685},
+							pos:   position{line: 326, col: 10, offset: 10449},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 269, col: 17, offset: 8692},
+								pos: position{line: 326, col: 17, offset: 10456},
 								expr: &seqExpr{
-									pos: position{line: 269, col: 18, offset: 8693},
+									pos: position{line: 326, col: 18, offset: 10457},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 269, col: 18, offset: 8693},
+											pos:  position{line: 326, col: 18, offset: 10457},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 269, col: 28, offset: 8703},
+											pos:  position{line: 326, col: 28, offset: 10467},
 											name: "__",
 										},
 									},
@@ -890,84 +917,84 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 33, offset: 8708},
+							pos:   position{line: 326, col: 33, offset: 10472},
 							label: "id",
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 36, offset: 8711},
+								pos:  position{line: 326, col: 36, offset: 10475},
 								name: "IntConstant",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 48, offset: 8723},
+							pos:  position{line: 326, col: 48, offset: 10487},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 269, col: 50, offset: 8725},
+							pos:        position{line: 326, col: 50, offset: 10489},
 							val:        ":",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 54, offset: 8729},
+							pos:  position{line: 326, col: 54, offset: 10493},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 56, offset: 8731},
+							pos:   position{line: 326, col: 56, offset: 10495},
 							label: "mod",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 269, col: 60, offset: 8735},
+								pos: position{line: 326, col: 60, offset: 10499},
 								expr: &ruleRefExpr{
-									pos:  position{line: 269, col: 60, offset: 8735},
+									pos:  position{line: 326, col: 60, offset: 10499},
 									name: "FieldModifier",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 75, offset: 8750},
+							pos:  position{line: 326, col: 75, offset: 10514},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 77, offset: 8752},
+							pos:   position{line: 326, col: 77, offset: 10516},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 81, offset: 8756},
+								pos:  position{line: 326, col: 81, offset: 10520},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 91, offset: 8766},
+							pos:  position{line: 326, col: 91, offset: 10530},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 93, offset: 8768},
+							pos:   position{line: 326, col: 93, offset: 10532},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 98, offset: 8773},
+								pos:  position{line: 326, col: 98, offset: 10537},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 109, offset: 8784},
+							pos:  position{line: 326, col: 109, offset: 10548},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 112, offset: 8787},
+							pos:   position{line: 326, col: 112, offset: 10551},
 							label: "def",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 269, col: 116, offset: 8791},
+								pos: position{line: 326, col: 116, offset: 10555},
 								expr: &seqExpr{
-									pos: position{line: 269, col: 117, offset: 8792},
+									pos: position{line: 326, col: 117, offset: 10556},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 269, col: 117, offset: 8792},
+											pos:        position{line: 326, col: 117, offset: 10556},
 											val:        "=",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 269, col: 121, offset: 8796},
+											pos:  position{line: 326, col: 121, offset: 10560},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 269, col: 123, offset: 8798},
+											pos:  position{line: 326, col: 123, offset: 10562},
 											name: "ConstValue",
 										},
 									},
@@ -975,24 +1002,24 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 269, col: 136, offset: 8811},
+							pos:  position{line: 326, col: 136, offset: 10575},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 269, col: 138, offset: 8813},
+							pos:   position{line: 326, col: 138, offset: 10577},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 269, col: 150, offset: 8825},
+								pos: position{line: 326, col: 150, offset: 10589},
 								expr: &ruleRefExpr{
-									pos:  position{line: 269, col: 150, offset: 8825},
+									pos:  position{line: 326, col: 150, offset: 10589},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 269, col: 167, offset: 8842},
+							pos: position{line: 326, col: 167, offset: 10606},
 							expr: &ruleRefExpr{
-								pos:  position{line: 269, col: 167, offset: 8842},
+								pos:  position{line: 326, col: 167, offset: 10606},
 								name: "ListSeparator",
 							},
 						},
@@ -1002,20 +1029,20 @@ var g = &grammar{
 		},
 		{
 			name: "FieldModifier",
-			pos:  position{line: 292, col: 1, offset: 9374},
+			pos:  position{line: 350, col: 1, offset: 11177},
 			expr: &actionExpr{
-				pos: position{line: 292, col: 18, offset: 9391},
+				pos: position{line: 350, col: 18, offset: 11194},
 				run: (*parser).callonFieldModifier1,
 				expr: &choiceExpr{
-					pos: position{line: 292, col: 19, offset: 9392},
+					pos: position{line: 350, col: 19, offset: 11195},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 292, col: 19, offset: 9392},
+							pos:        position{line: 350, col: 19, offset: 11195},
 							val:        "required",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 292, col: 32, offset: 9405},
+							pos:        position{line: 350, col: 32, offset: 11208},
 							val:        "optional",
 							ignoreCase: false,
 						},
@@ -1025,57 +1052,57 @@ var g = &grammar{
 		},
 		{
 			name: "Service",
-			pos:  position{line: 300, col: 1, offset: 9548},
+			pos:  position{line: 358, col: 1, offset: 11351},
 			expr: &actionExpr{
-				pos: position{line: 300, col: 12, offset: 9559},
+				pos: position{line: 358, col: 12, offset: 11362},
 				run: (*parser).callonService1,
 				expr: &seqExpr{
-					pos: position{line: 300, col: 12, offset: 9559},
+					pos: position{line: 358, col: 12, offset: 11362},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 300, col: 12, offset: 9559},
+							pos:        position{line: 358, col: 12, offset: 11362},
 							val:        "service",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 22, offset: 9569},
+							pos:  position{line: 358, col: 22, offset: 11372},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 300, col: 24, offset: 9571},
+							pos:   position{line: 358, col: 24, offset: 11374},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 300, col: 29, offset: 9576},
+								pos:  position{line: 358, col: 29, offset: 11379},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 40, offset: 9587},
+							pos:  position{line: 358, col: 40, offset: 11390},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 300, col: 42, offset: 9589},
+							pos:   position{line: 358, col: 42, offset: 11392},
 							label: "extends",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 300, col: 50, offset: 9597},
+								pos: position{line: 358, col: 50, offset: 11400},
 								expr: &seqExpr{
-									pos: position{line: 300, col: 51, offset: 9598},
+									pos: position{line: 358, col: 51, offset: 11401},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 300, col: 51, offset: 9598},
+											pos:        position{line: 358, col: 51, offset: 11401},
 											val:        "extends",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 300, col: 61, offset: 9608},
+											pos:  position{line: 358, col: 61, offset: 11411},
 											name: "__",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 300, col: 64, offset: 9611},
+											pos:  position{line: 358, col: 64, offset: 11414},
 											name: "Identifier",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 300, col: 75, offset: 9622},
+											pos:  position{line: 358, col: 75, offset: 11425},
 											name: "__",
 										},
 									},
@@ -1083,32 +1110,32 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 80, offset: 9627},
+							pos:  position{line: 358, col: 80, offset: 11430},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 300, col: 83, offset: 9630},
+							pos:        position{line: 358, col: 83, offset: 11433},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 87, offset: 9634},
+							pos:  position{line: 358, col: 87, offset: 11437},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 300, col: 90, offset: 9637},
+							pos:   position{line: 358, col: 90, offset: 11440},
 							label: "methods",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 300, col: 98, offset: 9645},
+								pos: position{line: 358, col: 98, offset: 11448},
 								expr: &seqExpr{
-									pos: position{line: 300, col: 99, offset: 9646},
+									pos: position{line: 358, col: 99, offset: 11449},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 300, col: 99, offset: 9646},
+											pos:  position{line: 358, col: 99, offset: 11449},
 											name: "Function",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 300, col: 108, offset: 9655},
+											pos:  position{line: 358, col: 108, offset: 11458},
 											name: "__",
 										},
 									},
@@ -1116,36 +1143,36 @@ var g = &grammar{
 							},
 						},
 						&choiceExpr{
-							pos: position{line: 300, col: 114, offset: 9661},
+							pos: position{line: 358, col: 114, offset: 11464},
 							alternatives: []interface{}{
 								&litMatcher{
-									pos:        position{line: 300, col: 114, offset: 9661},
+									pos:        position{line: 358, col: 114, offset: 11464},
 									val:        "}",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 300, col: 120, offset: 9667},
+									pos:  position{line: 358, col: 120, offset: 11470},
 									name: "EndOfServiceError",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 139, offset: 9686},
+							pos:  position{line: 358, col: 139, offset: 11489},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 300, col: 141, offset: 9688},
+							pos:   position{line: 358, col: 141, offset: 11491},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 300, col: 153, offset: 9700},
+								pos: position{line: 358, col: 153, offset: 11503},
 								expr: &ruleRefExpr{
-									pos:  position{line: 300, col: 153, offset: 9700},
+									pos:  position{line: 358, col: 153, offset: 11503},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 300, col: 170, offset: 9717},
+							pos:  position{line: 358, col: 170, offset: 11520},
 							name: "EOS",
 						},
 					},
@@ -1154,38 +1181,38 @@ var g = &grammar{
 		},
 		{
 			name: "EndOfServiceError",
-			pos:  position{line: 317, col: 1, offset: 10158},
+			pos:  position{line: 376, col: 1, offset: 12000},
 			expr: &actionExpr{
-				pos: position{line: 317, col: 22, offset: 10179},
+				pos: position{line: 376, col: 22, offset: 12021},
 				run: (*parser).callonEndOfServiceError1,
 				expr: &anyMatcher{
-					line: 317, col: 22, offset: 10179,
+					line: 376, col: 22, offset: 12021,
 				},
 			},
 		},
 		{
 			name: "Function",
-			pos:  position{line: 321, col: 1, offset: 10248},
+			pos:  position{line: 380, col: 1, offset: 12090},
 			expr: &actionExpr{
-				pos: position{line: 321, col: 13, offset: 10260},
+				pos: position{line: 380, col: 13, offset: 12102},
 				run: (*parser).callonFunction1,
 				expr: &seqExpr{
-					pos: position{line: 321, col: 13, offset: 10260},
+					pos: position{line: 380, col: 13, offset: 12102},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 321, col: 13, offset: 10260},
+							pos:   position{line: 380, col: 13, offset: 12102},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 321, col: 20, offset: 10267},
+								pos: position{line: 380, col: 20, offset: 12109},
 								expr: &seqExpr{
-									pos: position{line: 321, col: 21, offset: 10268},
+									pos: position{line: 380, col: 21, offset: 12110},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 321, col: 21, offset: 10268},
+											pos:  position{line: 380, col: 21, offset: 12110},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 321, col: 31, offset: 10278},
+											pos:  position{line: 380, col: 31, offset: 12120},
 											name: "__",
 										},
 									},
@@ -1193,20 +1220,20 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 36, offset: 10283},
+							pos:   position{line: 380, col: 36, offset: 12125},
 							label: "oneway",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 321, col: 43, offset: 10290},
+								pos: position{line: 380, col: 43, offset: 12132},
 								expr: &seqExpr{
-									pos: position{line: 321, col: 44, offset: 10291},
+									pos: position{line: 380, col: 44, offset: 12133},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 321, col: 44, offset: 10291},
+											pos:        position{line: 380, col: 44, offset: 12133},
 											val:        "oneway",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 321, col: 53, offset: 10300},
+											pos:  position{line: 380, col: 53, offset: 12142},
 											name: "__",
 										},
 									},
@@ -1214,85 +1241,85 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 58, offset: 10305},
+							pos:   position{line: 380, col: 58, offset: 12147},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 321, col: 62, offset: 10309},
+								pos:  position{line: 380, col: 62, offset: 12151},
 								name: "FunctionType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 321, col: 75, offset: 10322},
+							pos:  position{line: 380, col: 75, offset: 12164},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 78, offset: 10325},
+							pos:   position{line: 380, col: 78, offset: 12167},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 321, col: 83, offset: 10330},
+								pos:  position{line: 380, col: 83, offset: 12172},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 321, col: 94, offset: 10341},
+							pos:  position{line: 380, col: 94, offset: 12183},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 321, col: 96, offset: 10343},
+							pos:        position{line: 380, col: 96, offset: 12185},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 321, col: 100, offset: 10347},
+							pos:  position{line: 380, col: 100, offset: 12189},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 103, offset: 10350},
+							pos:   position{line: 380, col: 103, offset: 12192},
 							label: "arguments",
 							expr: &ruleRefExpr{
-								pos:  position{line: 321, col: 113, offset: 10360},
+								pos:  position{line: 380, col: 113, offset: 12202},
 								name: "FieldList",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 321, col: 123, offset: 10370},
+							pos:        position{line: 380, col: 123, offset: 12212},
 							val:        ")",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 321, col: 127, offset: 10374},
+							pos:  position{line: 380, col: 127, offset: 12216},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 130, offset: 10377},
+							pos:   position{line: 380, col: 130, offset: 12219},
 							label: "exceptions",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 321, col: 141, offset: 10388},
+								pos: position{line: 380, col: 141, offset: 12230},
 								expr: &ruleRefExpr{
-									pos:  position{line: 321, col: 141, offset: 10388},
+									pos:  position{line: 380, col: 141, offset: 12230},
 									name: "Throws",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 321, col: 149, offset: 10396},
+							pos:  position{line: 380, col: 149, offset: 12238},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 321, col: 151, offset: 10398},
+							pos:   position{line: 380, col: 151, offset: 12240},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 321, col: 163, offset: 10410},
+								pos: position{line: 380, col: 163, offset: 12252},
 								expr: &ruleRefExpr{
-									pos:  position{line: 321, col: 163, offset: 10410},
+									pos:  position{line: 380, col: 163, offset: 12252},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 321, col: 180, offset: 10427},
+							pos: position{line: 380, col: 180, offset: 12269},
 							expr: &ruleRefExpr{
-								pos:  position{line: 321, col: 180, offset: 10427},
+								pos:  position{line: 380, col: 180, offset: 12269},
 								name: "ListSeparator",
 							},
 						},
@@ -1302,23 +1329,23 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionType",
-			pos:  position{line: 349, col: 1, offset: 11078},
+			pos:  position{line: 409, col: 1, offset: 12959},
 			expr: &actionExpr{
-				pos: position{line: 349, col: 17, offset: 11094},
+				pos: position{line: 409, col: 17, offset: 12975},
 				run: (*parser).callonFunctionType1,
 				expr: &labeledExpr{
-					pos:   position{line: 349, col: 17, offset: 11094},
+					pos:   position{line: 409, col: 17, offset: 12975},
 					label: "typ",
 					expr: &choiceExpr{
-						pos: position{line: 349, col: 22, offset: 11099},
+						pos: position{line: 409, col: 22, offset: 12980},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 349, col: 22, offset: 11099},
+								pos:        position{line: 409, col: 22, offset: 12980},
 								val:        "void",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 349, col: 31, offset: 11108},
+								pos:  position{line: 409, col: 31, offset: 12989},
 								name: "FieldType",
 							},
 						},
@@ -1328,41 +1355,41 @@ var g = &grammar{
 		},
 		{
 			name: "Throws",
-			pos:  position{line: 356, col: 1, offset: 11230},
+			pos:  position{line: 416, col: 1, offset: 13111},
 			expr: &actionExpr{
-				pos: position{line: 356, col: 11, offset: 11240},
+				pos: position{line: 416, col: 11, offset: 13121},
 				run: (*parser).callonThrows1,
 				expr: &seqExpr{
-					pos: position{line: 356, col: 11, offset: 11240},
+					pos: position{line: 416, col: 11, offset: 13121},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 356, col: 11, offset: 11240},
+							pos:        position{line: 416, col: 11, offset: 13121},
 							val:        "throws",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 356, col: 20, offset: 11249},
+							pos:  position{line: 416, col: 20, offset: 13130},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 356, col: 23, offset: 11252},
+							pos:        position{line: 416, col: 23, offset: 13133},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 356, col: 27, offset: 11256},
+							pos:  position{line: 416, col: 27, offset: 13137},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 356, col: 30, offset: 11259},
+							pos:   position{line: 416, col: 30, offset: 13140},
 							label: "exceptions",
 							expr: &ruleRefExpr{
-								pos:  position{line: 356, col: 41, offset: 11270},
+								pos:  position{line: 416, col: 41, offset: 13151},
 								name: "FieldList",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 356, col: 51, offset: 11280},
+							pos:        position{line: 416, col: 51, offset: 13161},
 							val:        ")",
 							ignoreCase: false,
 						},
@@ -1372,26 +1399,26 @@ var g = &grammar{
 		},
 		{
 			name: "FieldType",
-			pos:  position{line: 360, col: 1, offset: 11316},
+			pos:  position{line: 420, col: 1, offset: 13197},
 			expr: &actionExpr{
-				pos: position{line: 360, col: 14, offset: 11329},
+				pos: position{line: 420, col: 14, offset: 13210},
 				run: (*parser).callonFieldType1,
 				expr: &labeledExpr{
-					pos:   position{line: 360, col: 14, offset: 11329},
+					pos:   position{line: 420, col: 14, offset: 13210},
 					label: "typ",
 					expr: &choiceExpr{
-						pos: position{line: 360, col: 19, offset: 11334},
+						pos: position{line: 420, col: 19, offset: 13215},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 360, col: 19, offset: 11334},
+								pos:  position{line: 420, col: 19, offset: 13215},
 								name: "BaseType",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 360, col: 30, offset: 11345},
+								pos:  position{line: 420, col: 30, offset: 13226},
 								name: "ContainerType",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 360, col: 46, offset: 11361},
+								pos:  position{line: 420, col: 46, offset: 13242},
 								name: "Identifier",
 							},
 						},
@@ -1401,32 +1428,32 @@ var g = &grammar{
 		},
 		{
 			name: "BaseType",
-			pos:  position{line: 367, col: 1, offset: 11486},
+			pos:  position{line: 427, col: 1, offset: 13367},
 			expr: &actionExpr{
-				pos: position{line: 367, col: 13, offset: 11498},
+				pos: position{line: 427, col: 13, offset: 13379},
 				run: (*parser).callonBaseType1,
 				expr: &seqExpr{
-					pos: position{line: 367, col: 13, offset: 11498},
+					pos: position{line: 427, col: 13, offset: 13379},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 367, col: 13, offset: 11498},
+							pos:   position{line: 427, col: 13, offset: 13379},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 367, col: 18, offset: 11503},
+								pos:  position{line: 427, col: 18, offset: 13384},
 								name: "BaseTypeName",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 367, col: 31, offset: 11516},
+							pos:  position{line: 427, col: 31, offset: 13397},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 367, col: 33, offset: 11518},
+							pos:   position{line: 427, col: 33, offset: 13399},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 367, col: 45, offset: 11530},
+								pos: position{line: 427, col: 45, offset: 13411},
 								expr: &ruleRefExpr{
-									pos:  position{line: 367, col: 45, offset: 11530},
+									pos:  position{line: 427, col: 45, offset: 13411},
 									name: "TypeAnnotations",
 								},
 							},
@@ -1437,50 +1464,50 @@ var g = &grammar{
 		},
 		{
 			name: "BaseTypeName",
-			pos:  position{line: 374, col: 1, offset: 11666},
+			pos:  position{line: 434, col: 1, offset: 13547},
 			expr: &actionExpr{
-				pos: position{line: 374, col: 17, offset: 11682},
+				pos: position{line: 434, col: 17, offset: 13563},
 				run: (*parser).callonBaseTypeName1,
 				expr: &choiceExpr{
-					pos: position{line: 374, col: 18, offset: 11683},
+					pos: position{line: 434, col: 18, offset: 13564},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 374, col: 18, offset: 11683},
+							pos:        position{line: 434, col: 18, offset: 13564},
 							val:        "bool",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 27, offset: 11692},
+							pos:        position{line: 434, col: 27, offset: 13573},
 							val:        "byte",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 36, offset: 11701},
+							pos:        position{line: 434, col: 36, offset: 13582},
 							val:        "i16",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 44, offset: 11709},
+							pos:        position{line: 434, col: 44, offset: 13590},
 							val:        "i32",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 52, offset: 11717},
+							pos:        position{line: 434, col: 52, offset: 13598},
 							val:        "i64",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 60, offset: 11725},
+							pos:        position{line: 434, col: 60, offset: 13606},
 							val:        "double",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 71, offset: 11736},
+							pos:        position{line: 434, col: 71, offset: 13617},
 							val:        "string",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 374, col: 82, offset: 11747},
+							pos:        position{line: 434, col: 82, offset: 13628},
 							val:        "binary",
 							ignoreCase: false,
 						},
@@ -1490,26 +1517,26 @@ var g = &grammar{
 		},
 		{
 			name: "ContainerType",
-			pos:  position{line: 378, col: 1, offset: 11794},
+			pos:  position{line: 438, col: 1, offset: 13675},
 			expr: &actionExpr{
-				pos: position{line: 378, col: 18, offset: 11811},
+				pos: position{line: 438, col: 18, offset: 13692},
 				run: (*parser).callonContainerType1,
 				expr: &labeledExpr{
-					pos:   position{line: 378, col: 18, offset: 11811},
+					pos:   position{line: 438, col: 18, offset: 13692},
 					label: "typ",
 					expr: &choiceExpr{
-						pos: position{line: 378, col: 23, offset: 11816},
+						pos: position{line: 438, col: 23, offset: 13697},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 378, col: 23, offset: 11816},
+								pos:  position{line: 438, col: 23, offset: 13697},
 								name: "MapType",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 378, col: 33, offset: 11826},
+								pos:  position{line: 438, col: 33, offset: 13707},
 								name: "SetType",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 378, col: 43, offset: 11836},
+								pos:  position{line: 438, col: 43, offset: 13717},
 								name: "ListType",
 							},
 						},
@@ -1519,78 +1546,78 @@ var g = &grammar{
 		},
 		{
 			name: "MapType",
-			pos:  position{line: 382, col: 1, offset: 11871},
+			pos:  position{line: 442, col: 1, offset: 13752},
 			expr: &actionExpr{
-				pos: position{line: 382, col: 12, offset: 11882},
+				pos: position{line: 442, col: 12, offset: 13763},
 				run: (*parser).callonMapType1,
 				expr: &seqExpr{
-					pos: position{line: 382, col: 12, offset: 11882},
+					pos: position{line: 442, col: 12, offset: 13763},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 382, col: 12, offset: 11882},
+							pos: position{line: 442, col: 12, offset: 13763},
 							expr: &ruleRefExpr{
-								pos:  position{line: 382, col: 12, offset: 11882},
+								pos:  position{line: 442, col: 12, offset: 13763},
 								name: "CppType",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 382, col: 21, offset: 11891},
+							pos:        position{line: 442, col: 21, offset: 13772},
 							val:        "map<",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 382, col: 28, offset: 11898},
+							pos:  position{line: 442, col: 28, offset: 13779},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 382, col: 31, offset: 11901},
+							pos:   position{line: 442, col: 31, offset: 13782},
 							label: "key",
 							expr: &ruleRefExpr{
-								pos:  position{line: 382, col: 35, offset: 11905},
+								pos:  position{line: 442, col: 35, offset: 13786},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 382, col: 45, offset: 11915},
+							pos:  position{line: 442, col: 45, offset: 13796},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 382, col: 48, offset: 11918},
+							pos:        position{line: 442, col: 48, offset: 13799},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 382, col: 52, offset: 11922},
+							pos:  position{line: 442, col: 52, offset: 13803},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 382, col: 55, offset: 11925},
+							pos:   position{line: 442, col: 55, offset: 13806},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 382, col: 61, offset: 11931},
+								pos:  position{line: 442, col: 61, offset: 13812},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 382, col: 71, offset: 11941},
+							pos:  position{line: 442, col: 71, offset: 13822},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 382, col: 74, offset: 11944},
+							pos:        position{line: 442, col: 74, offset: 13825},
 							val:        ">",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 382, col: 78, offset: 11948},
+							pos:  position{line: 442, col: 78, offset: 13829},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 382, col: 80, offset: 11950},
+							pos:   position{line: 442, col: 80, offset: 13831},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 382, col: 92, offset: 11962},
+								pos: position{line: 442, col: 92, offset: 13843},
 								expr: &ruleRefExpr{
-									pos:  position{line: 382, col: 92, offset: 11962},
+									pos:  position{line: 442, col: 92, offset: 13843},
 									name: "TypeAnnotations",
 								},
 							},
@@ -1601,57 +1628,57 @@ var g = &grammar{
 		},
 		{
 			name: "SetType",
-			pos:  position{line: 391, col: 1, offset: 12160},
+			pos:  position{line: 451, col: 1, offset: 14041},
 			expr: &actionExpr{
-				pos: position{line: 391, col: 12, offset: 12171},
+				pos: position{line: 451, col: 12, offset: 14052},
 				run: (*parser).callonSetType1,
 				expr: &seqExpr{
-					pos: position{line: 391, col: 12, offset: 12171},
+					pos: position{line: 451, col: 12, offset: 14052},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 391, col: 12, offset: 12171},
+							pos: position{line: 451, col: 12, offset: 14052},
 							expr: &ruleRefExpr{
-								pos:  position{line: 391, col: 12, offset: 12171},
+								pos:  position{line: 451, col: 12, offset: 14052},
 								name: "CppType",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 391, col: 21, offset: 12180},
+							pos:        position{line: 451, col: 21, offset: 14061},
 							val:        "set<",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 391, col: 28, offset: 12187},
+							pos:  position{line: 451, col: 28, offset: 14068},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 391, col: 31, offset: 12190},
+							pos:   position{line: 451, col: 31, offset: 14071},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 391, col: 35, offset: 12194},
+								pos:  position{line: 451, col: 35, offset: 14075},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 391, col: 45, offset: 12204},
+							pos:  position{line: 451, col: 45, offset: 14085},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 391, col: 48, offset: 12207},
+							pos:        position{line: 451, col: 48, offset: 14088},
 							val:        ">",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 391, col: 52, offset: 12211},
+							pos:  position{line: 451, col: 52, offset: 14092},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 391, col: 54, offset: 12213},
+							pos:   position{line: 451, col: 54, offset: 14094},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 391, col: 66, offset: 12225},
+								pos: position{line: 451, col: 66, offset: 14106},
 								expr: &ruleRefExpr{
-									pos:  position{line: 391, col: 66, offset: 12225},
+									pos:  position{line: 451, col: 66, offset: 14106},
 									name: "TypeAnnotations",
 								},
 							},
@@ -1662,50 +1689,50 @@ var g = &grammar{
 		},
 		{
 			name: "ListType",
-			pos:  position{line: 399, col: 1, offset: 12387},
+			pos:  position{line: 459, col: 1, offset: 14268},
 			expr: &actionExpr{
-				pos: position{line: 399, col: 13, offset: 12399},
+				pos: position{line: 459, col: 13, offset: 14280},
 				run: (*parser).callonListType1,
 				expr: &seqExpr{
-					pos: position{line: 399, col: 13, offset: 12399},
+					pos: position{line: 459, col: 13, offset: 14280},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 399, col: 13, offset: 12399},
+							pos:        position{line: 459, col: 13, offset: 14280},
 							val:        "list<",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 399, col: 21, offset: 12407},
+							pos:  position{line: 459, col: 21, offset: 14288},
 							name: "WS",
 						},
 						&labeledExpr{
-							pos:   position{line: 399, col: 24, offset: 12410},
+							pos:   position{line: 459, col: 24, offset: 14291},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 399, col: 28, offset: 12414},
+								pos:  position{line: 459, col: 28, offset: 14295},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 399, col: 38, offset: 12424},
+							pos:  position{line: 459, col: 38, offset: 14305},
 							name: "WS",
 						},
 						&litMatcher{
-							pos:        position{line: 399, col: 41, offset: 12427},
+							pos:        position{line: 459, col: 41, offset: 14308},
 							val:        ">",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 399, col: 45, offset: 12431},
+							pos:  position{line: 459, col: 45, offset: 14312},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 399, col: 47, offset: 12433},
+							pos:   position{line: 459, col: 47, offset: 14314},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 399, col: 59, offset: 12445},
+								pos: position{line: 459, col: 59, offset: 14326},
 								expr: &ruleRefExpr{
-									pos:  position{line: 399, col: 59, offset: 12445},
+									pos:  position{line: 459, col: 59, offset: 14326},
 									name: "TypeAnnotations",
 								},
 							},
@@ -1716,23 +1743,23 @@ var g = &grammar{
 		},
 		{
 			name: "CppType",
-			pos:  position{line: 407, col: 1, offset: 12608},
+			pos:  position{line: 467, col: 1, offset: 14489},
 			expr: &actionExpr{
-				pos: position{line: 407, col: 12, offset: 12619},
+				pos: position{line: 467, col: 12, offset: 14500},
 				run: (*parser).callonCppType1,
 				expr: &seqExpr{
-					pos: position{line: 407, col: 12, offset: 12619},
+					pos: position{line: 467, col: 12, offset: 14500},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 407, col: 12, offset: 12619},
+							pos:        position{line: 467, col: 12, offset: 14500},
 							val:        "cpp_type",
 							ignoreCase: false,
 						},
 						&labeledExpr{
-							pos:   position{line: 407, col: 23, offset: 12630},
+							pos:   position{line: 467, col: 23, offset: 14511},
 							label: "cppType",
 							expr: &ruleRefExpr{
-								pos:  position{line: 407, col: 31, offset: 12638},
+								pos:  position{line: 467, col: 31, offset: 14519},
 								name: "Literal",
 							},
 						},
@@ -1742,36 +1769,36 @@ var g = &grammar{
 		},
 		{
 			name: "ConstValue",
-			pos:  position{line: 411, col: 1, offset: 12675},
+			pos:  position{line: 471, col: 1, offset: 14556},
 			expr: &choiceExpr{
-				pos: position{line: 411, col: 15, offset: 12689},
+				pos: position{line: 471, col: 15, offset: 14570},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 15, offset: 12689},
+						pos:  position{line: 471, col: 15, offset: 14570},
 						name: "Literal",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 25, offset: 12699},
+						pos:  position{line: 471, col: 25, offset: 14580},
 						name: "BoolConstant",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 40, offset: 12714},
+						pos:  position{line: 471, col: 40, offset: 14595},
 						name: "DoubleConstant",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 57, offset: 12731},
+						pos:  position{line: 471, col: 57, offset: 14612},
 						name: "IntConstant",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 71, offset: 12745},
+						pos:  position{line: 471, col: 71, offset: 14626},
 						name: "ConstMap",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 82, offset: 12756},
+						pos:  position{line: 471, col: 82, offset: 14637},
 						name: "ConstList",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 411, col: 94, offset: 12768},
+						pos:  position{line: 471, col: 94, offset: 14649},
 						name: "Identifier",
 					},
 				},
@@ -1779,35 +1806,35 @@ var g = &grammar{
 		},
 		{
 			name: "TypeAnnotations",
-			pos:  position{line: 413, col: 1, offset: 12780},
+			pos:  position{line: 473, col: 1, offset: 14661},
 			expr: &actionExpr{
-				pos: position{line: 413, col: 20, offset: 12799},
+				pos: position{line: 473, col: 20, offset: 14680},
 				run: (*parser).callonTypeAnnotations1,
 				expr: &seqExpr{
-					pos: position{line: 413, col: 20, offset: 12799},
+					pos: position{line: 473, col: 20, offset: 14680},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 413, col: 20, offset: 12799},
+							pos:        position{line: 473, col: 20, offset: 14680},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 413, col: 24, offset: 12803},
+							pos:  position{line: 473, col: 24, offset: 14684},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 413, col: 27, offset: 12806},
+							pos:   position{line: 473, col: 27, offset: 14687},
 							label: "annotations",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 413, col: 39, offset: 12818},
+								pos: position{line: 473, col: 39, offset: 14699},
 								expr: &ruleRefExpr{
-									pos:  position{line: 413, col: 39, offset: 12818},
+									pos:  position{line: 473, col: 39, offset: 14699},
 									name: "TypeAnnotation",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 413, col: 55, offset: 12834},
+							pos:        position{line: 473, col: 55, offset: 14715},
 							val:        ")",
 							ignoreCase: false,
 						},
@@ -1817,50 +1844,50 @@ var g = &grammar{
 		},
 		{
 			name: "TypeAnnotation",
-			pos:  position{line: 421, col: 1, offset: 12998},
+			pos:  position{line: 481, col: 1, offset: 14879},
 			expr: &actionExpr{
-				pos: position{line: 421, col: 19, offset: 13016},
+				pos: position{line: 481, col: 19, offset: 14897},
 				run: (*parser).callonTypeAnnotation1,
 				expr: &seqExpr{
-					pos: position{line: 421, col: 19, offset: 13016},
+					pos: position{line: 481, col: 19, offset: 14897},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 421, col: 19, offset: 13016},
+							pos:   position{line: 481, col: 19, offset: 14897},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 421, col: 24, offset: 13021},
+								pos:  position{line: 481, col: 24, offset: 14902},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 421, col: 35, offset: 13032},
+							pos:  position{line: 481, col: 35, offset: 14913},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 421, col: 37, offset: 13034},
+							pos:   position{line: 481, col: 37, offset: 14915},
 							label: "value",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 421, col: 43, offset: 13040},
+								pos: position{line: 481, col: 43, offset: 14921},
 								expr: &actionExpr{
-									pos: position{line: 421, col: 44, offset: 13041},
+									pos: position{line: 481, col: 44, offset: 14922},
 									run: (*parser).callonTypeAnnotation8,
 									expr: &seqExpr{
-										pos: position{line: 421, col: 44, offset: 13041},
+										pos: position{line: 481, col: 44, offset: 14922},
 										exprs: []interface{}{
 											&litMatcher{
-												pos:        position{line: 421, col: 44, offset: 13041},
+												pos:        position{line: 481, col: 44, offset: 14922},
 												val:        "=",
 												ignoreCase: false,
 											},
 											&ruleRefExpr{
-												pos:  position{line: 421, col: 48, offset: 13045},
+												pos:  position{line: 481, col: 48, offset: 14926},
 												name: "__",
 											},
 											&labeledExpr{
-												pos:   position{line: 421, col: 51, offset: 13048},
+												pos:   position{line: 481, col: 51, offset: 14929},
 												label: "value",
 												expr: &ruleRefExpr{
-													pos:  position{line: 421, col: 57, offset: 13054},
+													pos:  position{line: 481, col: 57, offset: 14935},
 													name: "Literal",
 												},
 											},
@@ -1870,14 +1897,14 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 421, col: 89, offset: 13086},
+							pos: position{line: 481, col: 89, offset: 14967},
 							expr: &ruleRefExpr{
-								pos:  position{line: 421, col: 89, offset: 13086},
+								pos:  position{line: 481, col: 89, offset: 14967},
 								name: "ListSeparator",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 421, col: 104, offset: 13101},
+							pos:  position{line: 481, col: 104, offset: 14982},
 							name: "__",
 						},
 					},
@@ -1886,20 +1913,20 @@ var g = &grammar{
 		},
 		{
 			name: "BoolConstant",
-			pos:  position{line: 432, col: 1, offset: 13297},
+			pos:  position{line: 492, col: 1, offset: 15178},
 			expr: &actionExpr{
-				pos: position{line: 432, col: 17, offset: 13313},
+				pos: position{line: 492, col: 17, offset: 15194},
 				run: (*parser).callonBoolConstant1,
 				expr: &choiceExpr{
-					pos: position{line: 432, col: 18, offset: 13314},
+					pos: position{line: 492, col: 18, offset: 15195},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 432, col: 18, offset: 13314},
+							pos:        position{line: 492, col: 18, offset: 15195},
 							val:        "true",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 432, col: 27, offset: 13323},
+							pos:        position{line: 492, col: 27, offset: 15204},
 							val:        "false",
 							ignoreCase: false,
 						},
@@ -1909,17 +1936,17 @@ var g = &grammar{
 		},
 		{
 			name: "IntConstant",
-			pos:  position{line: 436, col: 1, offset: 13378},
+			pos:  position{line: 496, col: 1, offset: 15259},
 			expr: &actionExpr{
-				pos: position{line: 436, col: 16, offset: 13393},
+				pos: position{line: 496, col: 16, offset: 15274},
 				run: (*parser).callonIntConstant1,
 				expr: &seqExpr{
-					pos: position{line: 436, col: 16, offset: 13393},
+					pos: position{line: 496, col: 16, offset: 15274},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 436, col: 16, offset: 13393},
+							pos: position{line: 496, col: 16, offset: 15274},
 							expr: &charClassMatcher{
-								pos:        position{line: 436, col: 16, offset: 13393},
+								pos:        position{line: 496, col: 16, offset: 15274},
 								val:        "[-+]",
 								chars:      []rune{'-', '+'},
 								ignoreCase: false,
@@ -1927,9 +1954,9 @@ var g = &grammar{
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 436, col: 22, offset: 13399},
+							pos: position{line: 496, col: 22, offset: 15280},
 							expr: &ruleRefExpr{
-								pos:  position{line: 436, col: 22, offset: 13399},
+								pos:  position{line: 496, col: 22, offset: 15280},
 								name: "Digit",
 							},
 						},
@@ -1939,17 +1966,17 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleConstant",
-			pos:  position{line: 440, col: 1, offset: 13463},
+			pos:  position{line: 500, col: 1, offset: 15344},
 			expr: &actionExpr{
-				pos: position{line: 440, col: 19, offset: 13481},
+				pos: position{line: 500, col: 19, offset: 15362},
 				run: (*parser).callonDoubleConstant1,
 				expr: &seqExpr{
-					pos: position{line: 440, col: 19, offset: 13481},
+					pos: position{line: 500, col: 19, offset: 15362},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 440, col: 19, offset: 13481},
+							pos: position{line: 500, col: 19, offset: 15362},
 							expr: &charClassMatcher{
-								pos:        position{line: 440, col: 19, offset: 13481},
+								pos:        position{line: 500, col: 19, offset: 15362},
 								val:        "[+-]",
 								chars:      []rune{'+', '-'},
 								ignoreCase: false,
@@ -1957,38 +1984,38 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 440, col: 25, offset: 13487},
+							pos: position{line: 500, col: 25, offset: 15368},
 							expr: &ruleRefExpr{
-								pos:  position{line: 440, col: 25, offset: 13487},
+								pos:  position{line: 500, col: 25, offset: 15368},
 								name: "Digit",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 440, col: 32, offset: 13494},
+							pos:        position{line: 500, col: 32, offset: 15375},
 							val:        ".",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 440, col: 36, offset: 13498},
+							pos: position{line: 500, col: 36, offset: 15379},
 							expr: &ruleRefExpr{
-								pos:  position{line: 440, col: 36, offset: 13498},
+								pos:  position{line: 500, col: 36, offset: 15379},
 								name: "Digit",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 440, col: 43, offset: 13505},
+							pos: position{line: 500, col: 43, offset: 15386},
 							expr: &seqExpr{
-								pos: position{line: 440, col: 45, offset: 13507},
+								pos: position{line: 500, col: 45, offset: 15388},
 								exprs: []interface{}{
 									&charClassMatcher{
-										pos:        position{line: 440, col: 45, offset: 13507},
+										pos:        position{line: 500, col: 45, offset: 15388},
 										val:        "['Ee']",
 										chars:      []rune{'\'', 'E', 'e', '\''},
 										ignoreCase: false,
 										inverted:   false,
 									},
 									&ruleRefExpr{
-										pos:  position{line: 440, col: 52, offset: 13514},
+										pos:  position{line: 500, col: 52, offset: 15395},
 										name: "IntConstant",
 									},
 								},
@@ -2000,47 +2027,47 @@ var g = &grammar{
 		},
 		{
 			name: "ConstList",
-			pos:  position{line: 444, col: 1, offset: 13584},
+			pos:  position{line: 504, col: 1, offset: 15465},
 			expr: &actionExpr{
-				pos: position{line: 444, col: 14, offset: 13597},
+				pos: position{line: 504, col: 14, offset: 15478},
 				run: (*parser).callonConstList1,
 				expr: &seqExpr{
-					pos: position{line: 444, col: 14, offset: 13597},
+					pos: position{line: 504, col: 14, offset: 15478},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 444, col: 14, offset: 13597},
+							pos:        position{line: 504, col: 14, offset: 15478},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 444, col: 18, offset: 13601},
+							pos:  position{line: 504, col: 18, offset: 15482},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 444, col: 21, offset: 13604},
+							pos:   position{line: 504, col: 21, offset: 15485},
 							label: "values",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 444, col: 28, offset: 13611},
+								pos: position{line: 504, col: 28, offset: 15492},
 								expr: &seqExpr{
-									pos: position{line: 444, col: 29, offset: 13612},
+									pos: position{line: 504, col: 29, offset: 15493},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 444, col: 29, offset: 13612},
+											pos:  position{line: 504, col: 29, offset: 15493},
 											name: "ConstValue",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 444, col: 40, offset: 13623},
+											pos:  position{line: 504, col: 40, offset: 15504},
 											name: "__",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 444, col: 43, offset: 13626},
+											pos: position{line: 504, col: 43, offset: 15507},
 											expr: &ruleRefExpr{
-												pos:  position{line: 444, col: 43, offset: 13626},
+												pos:  position{line: 504, col: 43, offset: 15507},
 												name: "ListSeparator",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 444, col: 58, offset: 13641},
+											pos:  position{line: 504, col: 58, offset: 15522},
 											name: "__",
 										},
 									},
@@ -2048,11 +2075,11 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 444, col: 63, offset: 13646},
+							pos:  position{line: 504, col: 63, offset: 15527},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 444, col: 66, offset: 13649},
+							pos:        position{line: 504, col: 66, offset: 15530},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -2062,67 +2089,67 @@ var g = &grammar{
 		},
 		{
 			name: "ConstMap",
-			pos:  position{line: 453, col: 1, offset: 13843},
+			pos:  position{line: 513, col: 1, offset: 15724},
 			expr: &actionExpr{
-				pos: position{line: 453, col: 13, offset: 13855},
+				pos: position{line: 513, col: 13, offset: 15736},
 				run: (*parser).callonConstMap1,
 				expr: &seqExpr{
-					pos: position{line: 453, col: 13, offset: 13855},
+					pos: position{line: 513, col: 13, offset: 15736},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 453, col: 13, offset: 13855},
+							pos:        position{line: 513, col: 13, offset: 15736},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 453, col: 17, offset: 13859},
+							pos:  position{line: 513, col: 17, offset: 15740},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 453, col: 20, offset: 13862},
+							pos:   position{line: 513, col: 20, offset: 15743},
 							label: "values",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 453, col: 27, offset: 13869},
+								pos: position{line: 513, col: 27, offset: 15750},
 								expr: &seqExpr{
-									pos: position{line: 453, col: 28, offset: 13870},
+									pos: position{line: 513, col: 28, offset: 15751},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 28, offset: 13870},
+											pos:  position{line: 513, col: 28, offset: 15751},
 											name: "ConstValue",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 39, offset: 13881},
+											pos:  position{line: 513, col: 39, offset: 15762},
 											name: "__",
 										},
 										&litMatcher{
-											pos:        position{line: 453, col: 42, offset: 13884},
+											pos:        position{line: 513, col: 42, offset: 15765},
 											val:        ":",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 46, offset: 13888},
+											pos:  position{line: 513, col: 46, offset: 15769},
 											name: "__",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 49, offset: 13891},
+											pos:  position{line: 513, col: 49, offset: 15772},
 											name: "ConstValue",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 60, offset: 13902},
+											pos:  position{line: 513, col: 60, offset: 15783},
 											name: "__",
 										},
 										&choiceExpr{
-											pos: position{line: 453, col: 64, offset: 13906},
+											pos: position{line: 513, col: 64, offset: 15787},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 453, col: 64, offset: 13906},
+													pos:        position{line: 513, col: 64, offset: 15787},
 													val:        ",",
 													ignoreCase: false,
 												},
 												&andExpr{
-													pos: position{line: 453, col: 70, offset: 13912},
+													pos: position{line: 513, col: 70, offset: 15793},
 													expr: &litMatcher{
-														pos:        position{line: 453, col: 71, offset: 13913},
+														pos:        position{line: 513, col: 71, offset: 15794},
 														val:        "}",
 														ignoreCase: false,
 													},
@@ -2130,7 +2157,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 453, col: 76, offset: 13918},
+											pos:  position{line: 513, col: 76, offset: 15799},
 											name: "__",
 										},
 									},
@@ -2138,7 +2165,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 453, col: 81, offset: 13923},
+							pos:        position{line: 513, col: 81, offset: 15804},
 							val:        "}",
 							ignoreCase: false,
 						},
@@ -2148,27 +2175,27 @@ var g = &grammar{
 		},
 		{
 			name: "Scope",
-			pos:  position{line: 473, col: 1, offset: 14473},
+			pos:  position{line: 533, col: 1, offset: 16354},
 			expr: &actionExpr{
-				pos: position{line: 473, col: 10, offset: 14482},
+				pos: position{line: 533, col: 10, offset: 16363},
 				run: (*parser).callonScope1,
 				expr: &seqExpr{
-					pos: position{line: 473, col: 10, offset: 14482},
+					pos: position{line: 533, col: 10, offset: 16363},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 473, col: 10, offset: 14482},
+							pos:   position{line: 533, col: 10, offset: 16363},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 473, col: 17, offset: 14489},
+								pos: position{line: 533, col: 17, offset: 16370},
 								expr: &seqExpr{
-									pos: position{line: 473, col: 18, offset: 14490},
+									pos: position{line: 533, col: 18, offset: 16371},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 473, col: 18, offset: 14490},
+											pos:  position{line: 533, col: 18, offset: 16371},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 473, col: 28, offset: 14500},
+											pos:  position{line: 533, col: 28, offset: 16381},
 											name: "__",
 										},
 									},
@@ -2176,64 +2203,64 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 473, col: 33, offset: 14505},
+							pos:        position{line: 533, col: 33, offset: 16386},
 							val:        "scope",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 41, offset: 14513},
+							pos:  position{line: 533, col: 41, offset: 16394},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 44, offset: 14516},
+							pos:   position{line: 533, col: 44, offset: 16397},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 473, col: 49, offset: 14521},
+								pos:  position{line: 533, col: 49, offset: 16402},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 60, offset: 14532},
+							pos:  position{line: 533, col: 60, offset: 16413},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 63, offset: 14535},
+							pos:   position{line: 533, col: 63, offset: 16416},
 							label: "prefix",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 473, col: 70, offset: 14542},
+								pos: position{line: 533, col: 70, offset: 16423},
 								expr: &ruleRefExpr{
-									pos:  position{line: 473, col: 70, offset: 14542},
+									pos:  position{line: 533, col: 70, offset: 16423},
 									name: "Prefix",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 78, offset: 14550},
+							pos:  position{line: 533, col: 78, offset: 16431},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 473, col: 81, offset: 14553},
+							pos:        position{line: 533, col: 81, offset: 16434},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 85, offset: 14557},
+							pos:  position{line: 533, col: 85, offset: 16438},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 88, offset: 14560},
+							pos:   position{line: 533, col: 88, offset: 16441},
 							label: "operations",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 473, col: 99, offset: 14571},
+								pos: position{line: 533, col: 99, offset: 16452},
 								expr: &seqExpr{
-									pos: position{line: 473, col: 100, offset: 14572},
+									pos: position{line: 533, col: 100, offset: 16453},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 473, col: 100, offset: 14572},
+											pos:  position{line: 533, col: 100, offset: 16453},
 											name: "Operation",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 473, col: 110, offset: 14582},
+											pos:  position{line: 533, col: 110, offset: 16463},
 											name: "__",
 										},
 									},
@@ -2241,36 +2268,36 @@ var g = &grammar{
 							},
 						},
 						&choiceExpr{
-							pos: position{line: 473, col: 116, offset: 14588},
+							pos: position{line: 533, col: 116, offset: 16469},
 							alternatives: []interface{}{
 								&litMatcher{
-									pos:        position{line: 473, col: 116, offset: 14588},
+									pos:        position{line: 533, col: 116, offset: 16469},
 									val:        "}",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 473, col: 122, offset: 14594},
+									pos:  position{line: 533, col: 122, offset: 16475},
 									name: "EndOfScopeError",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 139, offset: 14611},
+							pos:  position{line: 533, col: 139, offset: 16492},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 473, col: 141, offset: 14613},
+							pos:   position{line: 533, col: 141, offset: 16494},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 473, col: 153, offset: 14625},
+								pos: position{line: 533, col: 153, offset: 16506},
 								expr: &ruleRefExpr{
-									pos:  position{line: 473, col: 153, offset: 14625},
+									pos:  position{line: 533, col: 153, offset: 16506},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 473, col: 170, offset: 14642},
+							pos:  position{line: 533, col: 170, offset: 16523},
 							name: "EOS",
 						},
 					},
@@ -2279,49 +2306,49 @@ var g = &grammar{
 		},
 		{
 			name: "EndOfScopeError",
-			pos:  position{line: 495, col: 1, offset: 15239},
+			pos:  position{line: 556, col: 1, offset: 17159},
 			expr: &actionExpr{
-				pos: position{line: 495, col: 20, offset: 15258},
+				pos: position{line: 556, col: 20, offset: 17178},
 				run: (*parser).callonEndOfScopeError1,
 				expr: &anyMatcher{
-					line: 495, col: 20, offset: 15258,
+					line: 556, col: 20, offset: 17178,
 				},
 			},
 		},
 		{
 			name: "Prefix",
-			pos:  position{line: 499, col: 1, offset: 15325},
+			pos:  position{line: 560, col: 1, offset: 17245},
 			expr: &actionExpr{
-				pos: position{line: 499, col: 11, offset: 15335},
+				pos: position{line: 560, col: 11, offset: 17255},
 				run: (*parser).callonPrefix1,
 				expr: &seqExpr{
-					pos: position{line: 499, col: 11, offset: 15335},
+					pos: position{line: 560, col: 11, offset: 17255},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 499, col: 11, offset: 15335},
+							pos:        position{line: 560, col: 11, offset: 17255},
 							val:        "prefix",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 499, col: 20, offset: 15344},
+							pos:  position{line: 560, col: 20, offset: 17264},
 							name: "__",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 499, col: 23, offset: 15347},
+							pos:  position{line: 560, col: 23, offset: 17267},
 							name: "PrefixToken",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 499, col: 35, offset: 15359},
+							pos: position{line: 560, col: 35, offset: 17279},
 							expr: &seqExpr{
-								pos: position{line: 499, col: 36, offset: 15360},
+								pos: position{line: 560, col: 36, offset: 17280},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 499, col: 36, offset: 15360},
+										pos:        position{line: 560, col: 36, offset: 17280},
 										val:        ".",
 										ignoreCase: false,
 									},
 									&ruleRefExpr{
-										pos:  position{line: 499, col: 40, offset: 15364},
+										pos:  position{line: 560, col: 40, offset: 17284},
 										name: "PrefixToken",
 									},
 								},
@@ -2333,31 +2360,31 @@ var g = &grammar{
 		},
 		{
 			name: "PrefixToken",
-			pos:  position{line: 504, col: 1, offset: 15495},
+			pos:  position{line: 565, col: 1, offset: 17415},
 			expr: &choiceExpr{
-				pos: position{line: 504, col: 16, offset: 15510},
+				pos: position{line: 565, col: 16, offset: 17430},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 504, col: 17, offset: 15511},
+						pos: position{line: 565, col: 17, offset: 17431},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 504, col: 17, offset: 15511},
+								pos:        position{line: 565, col: 17, offset: 17431},
 								val:        "{",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 504, col: 21, offset: 15515},
+								pos:  position{line: 565, col: 21, offset: 17435},
 								name: "PrefixWord",
 							},
 							&litMatcher{
-								pos:        position{line: 504, col: 32, offset: 15526},
+								pos:        position{line: 565, col: 32, offset: 17446},
 								val:        "}",
 								ignoreCase: false,
 							},
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 504, col: 39, offset: 15533},
+						pos:  position{line: 565, col: 39, offset: 17453},
 						name: "PrefixWord",
 					},
 				},
@@ -2365,11 +2392,11 @@ var g = &grammar{
 		},
 		{
 			name: "PrefixWord",
-			pos:  position{line: 506, col: 1, offset: 15545},
+			pos:  position{line: 567, col: 1, offset: 17465},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 506, col: 15, offset: 15559},
+				pos: position{line: 567, col: 15, offset: 17479},
 				expr: &charClassMatcher{
-					pos:        position{line: 506, col: 15, offset: 15559},
+					pos:        position{line: 567, col: 15, offset: 17479},
 					val:        "[^\\r\\n\\t\\f .{}]",
 					chars:      []rune{'\r', '\n', '\t', '\f', ' ', '.', '{', '}'},
 					ignoreCase: false,
@@ -2379,27 +2406,27 @@ var g = &grammar{
 		},
 		{
 			name: "Operation",
-			pos:  position{line: 508, col: 1, offset: 15577},
+			pos:  position{line: 569, col: 1, offset: 17497},
 			expr: &actionExpr{
-				pos: position{line: 508, col: 14, offset: 15590},
+				pos: position{line: 569, col: 14, offset: 17510},
 				run: (*parser).callonOperation1,
 				expr: &seqExpr{
-					pos: position{line: 508, col: 14, offset: 15590},
+					pos: position{line: 569, col: 14, offset: 17510},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 508, col: 14, offset: 15590},
+							pos:   position{line: 569, col: 14, offset: 17510},
 							label: "docstr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 508, col: 21, offset: 15597},
+								pos: position{line: 569, col: 21, offset: 17517},
 								expr: &seqExpr{
-									pos: position{line: 508, col: 22, offset: 15598},
+									pos: position{line: 569, col: 22, offset: 17518},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 508, col: 22, offset: 15598},
+											pos:  position{line: 569, col: 22, offset: 17518},
 											name: "DocString",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 508, col: 32, offset: 15608},
+											pos:  position{line: 569, col: 32, offset: 17528},
 											name: "__",
 										},
 									},
@@ -2407,53 +2434,53 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 508, col: 37, offset: 15613},
+							pos:   position{line: 569, col: 37, offset: 17533},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 508, col: 42, offset: 15618},
+								pos:  position{line: 569, col: 42, offset: 17538},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 508, col: 53, offset: 15629},
+							pos:  position{line: 569, col: 53, offset: 17549},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 508, col: 55, offset: 15631},
+							pos:        position{line: 569, col: 55, offset: 17551},
 							val:        ":",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 508, col: 59, offset: 15635},
+							pos:  position{line: 569, col: 59, offset: 17555},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 508, col: 62, offset: 15638},
+							pos:   position{line: 569, col: 62, offset: 17558},
 							label: "typ",
 							expr: &ruleRefExpr{
-								pos:  position{line: 508, col: 66, offset: 15642},
+								pos:  position{line: 569, col: 66, offset: 17562},
 								name: "FieldType",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 508, col: 76, offset: 15652},
+							pos:  position{line: 569, col: 76, offset: 17572},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 508, col: 78, offset: 15654},
+							pos:   position{line: 569, col: 78, offset: 17574},
 							label: "annotations",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 508, col: 90, offset: 15666},
+								pos: position{line: 569, col: 90, offset: 17586},
 								expr: &ruleRefExpr{
-									pos:  position{line: 508, col: 90, offset: 15666},
+									pos:  position{line: 569, col: 90, offset: 17586},
 									name: "TypeAnnotations",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 508, col: 107, offset: 15683},
+							pos: position{line: 569, col: 107, offset: 17603},
 							expr: &ruleRefExpr{
-								pos:  position{line: 508, col: 107, offset: 15683},
+								pos:  position{line: 569, col: 107, offset: 17603},
 								name: "ListSeparator",
 							},
 						},
@@ -2463,33 +2490,33 @@ var g = &grammar{
 		},
 		{
 			name: "Literal",
-			pos:  position{line: 525, col: 1, offset: 16243},
+			pos:  position{line: 587, col: 1, offset: 18202},
 			expr: &actionExpr{
-				pos: position{line: 525, col: 12, offset: 16254},
+				pos: position{line: 587, col: 12, offset: 18213},
 				run: (*parser).callonLiteral1,
 				expr: &choiceExpr{
-					pos: position{line: 525, col: 13, offset: 16255},
+					pos: position{line: 587, col: 13, offset: 18214},
 					alternatives: []interface{}{
 						&seqExpr{
-							pos: position{line: 525, col: 14, offset: 16256},
+							pos: position{line: 587, col: 14, offset: 18215},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 525, col: 14, offset: 16256},
+									pos:        position{line: 587, col: 14, offset: 18215},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 525, col: 18, offset: 16260},
+									pos: position{line: 587, col: 18, offset: 18219},
 									expr: &choiceExpr{
-										pos: position{line: 525, col: 19, offset: 16261},
+										pos: position{line: 587, col: 19, offset: 18220},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 525, col: 19, offset: 16261},
+												pos:        position{line: 587, col: 19, offset: 18220},
 												val:        "\\\"",
 												ignoreCase: false,
 											},
 											&charClassMatcher{
-												pos:        position{line: 525, col: 26, offset: 16268},
+												pos:        position{line: 587, col: 26, offset: 18227},
 												val:        "[^\"]",
 												chars:      []rune{'"'},
 												ignoreCase: false,
@@ -2499,32 +2526,32 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 525, col: 33, offset: 16275},
+									pos:        position{line: 587, col: 33, offset: 18234},
 									val:        "\"",
 									ignoreCase: false,
 								},
 							},
 						},
 						&seqExpr{
-							pos: position{line: 525, col: 41, offset: 16283},
+							pos: position{line: 587, col: 41, offset: 18242},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 525, col: 41, offset: 16283},
+									pos:        position{line: 587, col: 41, offset: 18242},
 									val:        "'",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 525, col: 46, offset: 16288},
+									pos: position{line: 587, col: 46, offset: 18247},
 									expr: &choiceExpr{
-										pos: position{line: 525, col: 47, offset: 16289},
+										pos: position{line: 587, col: 47, offset: 18248},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 525, col: 47, offset: 16289},
+												pos:        position{line: 587, col: 47, offset: 18248},
 												val:        "\\'",
 												ignoreCase: false,
 											},
 											&charClassMatcher{
-												pos:        position{line: 525, col: 54, offset: 16296},
+												pos:        position{line: 587, col: 54, offset: 18255},
 												val:        "[^']",
 												chars:      []rune{'\''},
 												ignoreCase: false,
@@ -2534,7 +2561,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 525, col: 61, offset: 16303},
+									pos:        position{line: 587, col: 61, offset: 18262},
 									val:        "'",
 									ignoreCase: false,
 								},
@@ -2546,24 +2573,24 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 534, col: 1, offset: 16589},
+			pos:  position{line: 596, col: 1, offset: 18548},
 			expr: &actionExpr{
-				pos: position{line: 534, col: 15, offset: 16603},
+				pos: position{line: 596, col: 15, offset: 18562},
 				run: (*parser).callonIdentifier1,
 				expr: &seqExpr{
-					pos: position{line: 534, col: 15, offset: 16603},
+					pos: position{line: 596, col: 15, offset: 18562},
 					exprs: []interface{}{
 						&oneOrMoreExpr{
-							pos: position{line: 534, col: 15, offset: 16603},
+							pos: position{line: 596, col: 15, offset: 18562},
 							expr: &choiceExpr{
-								pos: position{line: 534, col: 16, offset: 16604},
+								pos: position{line: 596, col: 16, offset: 18563},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 534, col: 16, offset: 16604},
+										pos:  position{line: 596, col: 16, offset: 18563},
 										name: "Letter",
 									},
 									&litMatcher{
-										pos:        position{line: 534, col: 25, offset: 16613},
+										pos:        position{line: 596, col: 25, offset: 18572},
 										val:        "_",
 										ignoreCase: false,
 									},
@@ -2571,20 +2598,20 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 534, col: 31, offset: 16619},
+							pos: position{line: 596, col: 31, offset: 18578},
 							expr: &choiceExpr{
-								pos: position{line: 534, col: 32, offset: 16620},
+								pos: position{line: 596, col: 32, offset: 18579},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 534, col: 32, offset: 16620},
+										pos:  position{line: 596, col: 32, offset: 18579},
 										name: "Letter",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 534, col: 41, offset: 16629},
+										pos:  position{line: 596, col: 41, offset: 18588},
 										name: "Digit",
 									},
 									&charClassMatcher{
-										pos:        position{line: 534, col: 49, offset: 16637},
+										pos:        position{line: 596, col: 49, offset: 18596},
 										val:        "[._]",
 										chars:      []rune{'.', '_'},
 										ignoreCase: false,
@@ -2599,9 +2626,9 @@ var g = &grammar{
 		},
 		{
 			name: "ListSeparator",
-			pos:  position{line: 538, col: 1, offset: 16692},
+			pos:  position{line: 600, col: 1, offset: 18651},
 			expr: &charClassMatcher{
-				pos:        position{line: 538, col: 18, offset: 16709},
+				pos:        position{line: 600, col: 18, offset: 18668},
 				val:        "[,;]",
 				chars:      []rune{',', ';'},
 				ignoreCase: false,
@@ -2610,9 +2637,9 @@ var g = &grammar{
 		},
 		{
 			name: "Letter",
-			pos:  position{line: 539, col: 1, offset: 16714},
+			pos:  position{line: 601, col: 1, offset: 18673},
 			expr: &charClassMatcher{
-				pos:        position{line: 539, col: 11, offset: 16724},
+				pos:        position{line: 601, col: 11, offset: 18683},
 				val:        "[A-Za-z]",
 				ranges:     []rune{'A', 'Z', 'a', 'z'},
 				ignoreCase: false,
@@ -2621,9 +2648,9 @@ var g = &grammar{
 		},
 		{
 			name: "Digit",
-			pos:  position{line: 540, col: 1, offset: 16733},
+			pos:  position{line: 602, col: 1, offset: 18692},
 			expr: &charClassMatcher{
-				pos:        position{line: 540, col: 10, offset: 16742},
+				pos:        position{line: 602, col: 10, offset: 18701},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -2632,47 +2659,47 @@ var g = &grammar{
 		},
 		{
 			name: "SourceChar",
-			pos:  position{line: 542, col: 1, offset: 16749},
+			pos:  position{line: 604, col: 1, offset: 18708},
 			expr: &anyMatcher{
-				line: 542, col: 15, offset: 16763,
+				line: 604, col: 15, offset: 18722,
 			},
 		},
 		{
 			name: "DocString",
-			pos:  position{line: 543, col: 1, offset: 16765},
+			pos:  position{line: 605, col: 1, offset: 18724},
 			expr: &actionExpr{
-				pos: position{line: 543, col: 14, offset: 16778},
+				pos: position{line: 605, col: 14, offset: 18737},
 				run: (*parser).callonDocString1,
 				expr: &seqExpr{
-					pos: position{line: 543, col: 14, offset: 16778},
+					pos: position{line: 605, col: 14, offset: 18737},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 543, col: 14, offset: 16778},
+							pos:        position{line: 605, col: 14, offset: 18737},
 							val:        "/**@",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 543, col: 21, offset: 16785},
+							pos: position{line: 605, col: 21, offset: 18744},
 							expr: &seqExpr{
-								pos: position{line: 543, col: 23, offset: 16787},
+								pos: position{line: 605, col: 23, offset: 18746},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 543, col: 23, offset: 16787},
+										pos: position{line: 605, col: 23, offset: 18746},
 										expr: &litMatcher{
-											pos:        position{line: 543, col: 24, offset: 16788},
+											pos:        position{line: 605, col: 24, offset: 18747},
 											val:        "*/",
 											ignoreCase: false,
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 543, col: 29, offset: 16793},
+										pos:  position{line: 605, col: 29, offset: 18752},
 										name: "SourceChar",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 543, col: 43, offset: 16807},
+							pos:        position{line: 605, col: 43, offset: 18766},
 							val:        "*/",
 							ignoreCase: false,
 						},
@@ -2682,16 +2709,16 @@ var g = &grammar{
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 549, col: 1, offset: 16987},
+			pos:  position{line: 611, col: 1, offset: 18946},
 			expr: &choiceExpr{
-				pos: position{line: 549, col: 12, offset: 16998},
+				pos: position{line: 611, col: 12, offset: 18957},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 549, col: 12, offset: 16998},
+						pos:  position{line: 611, col: 12, offset: 18957},
 						name: "MultiLineComment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 549, col: 31, offset: 17017},
+						pos:  position{line: 611, col: 31, offset: 18976},
 						name: "SingleLineComment",
 					},
 				},
@@ -2699,44 +2726,44 @@ var g = &grammar{
 		},
 		{
 			name: "MultiLineComment",
-			pos:  position{line: 550, col: 1, offset: 17035},
+			pos:  position{line: 612, col: 1, offset: 18994},
 			expr: &seqExpr{
-				pos: position{line: 550, col: 21, offset: 17055},
+				pos: position{line: 612, col: 21, offset: 19014},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 550, col: 21, offset: 17055},
+						pos: position{line: 612, col: 21, offset: 19014},
 						expr: &ruleRefExpr{
-							pos:  position{line: 550, col: 22, offset: 17056},
+							pos:  position{line: 612, col: 22, offset: 19015},
 							name: "DocString",
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 550, col: 32, offset: 17066},
+						pos:        position{line: 612, col: 32, offset: 19025},
 						val:        "/*",
 						ignoreCase: false,
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 550, col: 37, offset: 17071},
+						pos: position{line: 612, col: 37, offset: 19030},
 						expr: &seqExpr{
-							pos: position{line: 550, col: 39, offset: 17073},
+							pos: position{line: 612, col: 39, offset: 19032},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 550, col: 39, offset: 17073},
+									pos: position{line: 612, col: 39, offset: 19032},
 									expr: &litMatcher{
-										pos:        position{line: 550, col: 40, offset: 17074},
+										pos:        position{line: 612, col: 40, offset: 19033},
 										val:        "*/",
 										ignoreCase: false,
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 550, col: 45, offset: 17079},
+									pos:  position{line: 612, col: 45, offset: 19038},
 									name: "SourceChar",
 								},
 							},
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 550, col: 59, offset: 17093},
+						pos:        position{line: 612, col: 59, offset: 19052},
 						val:        "*/",
 						ignoreCase: false,
 					},
@@ -2745,53 +2772,53 @@ var g = &grammar{
 		},
 		{
 			name: "MultiLineCommentNoLineTerminator",
-			pos:  position{line: 551, col: 1, offset: 17098},
+			pos:  position{line: 613, col: 1, offset: 19057},
 			expr: &seqExpr{
-				pos: position{line: 551, col: 37, offset: 17134},
+				pos: position{line: 613, col: 37, offset: 19093},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 551, col: 37, offset: 17134},
+						pos: position{line: 613, col: 37, offset: 19093},
 						expr: &ruleRefExpr{
-							pos:  position{line: 551, col: 38, offset: 17135},
+							pos:  position{line: 613, col: 38, offset: 19094},
 							name: "DocString",
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 551, col: 48, offset: 17145},
+						pos:        position{line: 613, col: 48, offset: 19104},
 						val:        "/*",
 						ignoreCase: false,
 					},
 					&zeroOrMoreExpr{
-						pos: position{line: 551, col: 53, offset: 17150},
+						pos: position{line: 613, col: 53, offset: 19109},
 						expr: &seqExpr{
-							pos: position{line: 551, col: 55, offset: 17152},
+							pos: position{line: 613, col: 55, offset: 19111},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 551, col: 55, offset: 17152},
+									pos: position{line: 613, col: 55, offset: 19111},
 									expr: &choiceExpr{
-										pos: position{line: 551, col: 58, offset: 17155},
+										pos: position{line: 613, col: 58, offset: 19114},
 										alternatives: []interface{}{
 											&litMatcher{
-												pos:        position{line: 551, col: 58, offset: 17155},
+												pos:        position{line: 613, col: 58, offset: 19114},
 												val:        "*/",
 												ignoreCase: false,
 											},
 											&ruleRefExpr{
-												pos:  position{line: 551, col: 65, offset: 17162},
+												pos:  position{line: 613, col: 65, offset: 19121},
 												name: "EOL",
 											},
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 551, col: 71, offset: 17168},
+									pos:  position{line: 613, col: 71, offset: 19127},
 									name: "SourceChar",
 								},
 							},
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 551, col: 85, offset: 17182},
+						pos:        position{line: 613, col: 85, offset: 19141},
 						val:        "*/",
 						ignoreCase: false,
 					},
@@ -2800,32 +2827,32 @@ var g = &grammar{
 		},
 		{
 			name: "SingleLineComment",
-			pos:  position{line: 552, col: 1, offset: 17187},
+			pos:  position{line: 614, col: 1, offset: 19146},
 			expr: &choiceExpr{
-				pos: position{line: 552, col: 22, offset: 17208},
+				pos: position{line: 614, col: 22, offset: 19167},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 552, col: 23, offset: 17209},
+						pos: position{line: 614, col: 23, offset: 19168},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 552, col: 23, offset: 17209},
+								pos:        position{line: 614, col: 23, offset: 19168},
 								val:        "//",
 								ignoreCase: false,
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 552, col: 28, offset: 17214},
+								pos: position{line: 614, col: 28, offset: 19173},
 								expr: &seqExpr{
-									pos: position{line: 552, col: 30, offset: 17216},
+									pos: position{line: 614, col: 30, offset: 19175},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 552, col: 30, offset: 17216},
+											pos: position{line: 614, col: 30, offset: 19175},
 											expr: &ruleRefExpr{
-												pos:  position{line: 552, col: 31, offset: 17217},
+												pos:  position{line: 614, col: 31, offset: 19176},
 												name: "EOL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 552, col: 35, offset: 17221},
+											pos:  position{line: 614, col: 35, offset: 19180},
 											name: "SourceChar",
 										},
 									},
@@ -2834,27 +2861,27 @@ var g = &grammar{
 						},
 					},
 					&seqExpr{
-						pos: position{line: 552, col: 53, offset: 17239},
+						pos: position{line: 614, col: 53, offset: 19198},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 552, col: 53, offset: 17239},
+								pos:        position{line: 614, col: 53, offset: 19198},
 								val:        "#",
 								ignoreCase: false,
 							},
 							&zeroOrMoreExpr{
-								pos: position{line: 552, col: 57, offset: 17243},
+								pos: position{line: 614, col: 57, offset: 19202},
 								expr: &seqExpr{
-									pos: position{line: 552, col: 59, offset: 17245},
+									pos: position{line: 614, col: 59, offset: 19204},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 552, col: 59, offset: 17245},
+											pos: position{line: 614, col: 59, offset: 19204},
 											expr: &ruleRefExpr{
-												pos:  position{line: 552, col: 60, offset: 17246},
+												pos:  position{line: 614, col: 60, offset: 19205},
 												name: "EOL",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 552, col: 64, offset: 17250},
+											pos:  position{line: 614, col: 64, offset: 19209},
 											name: "SourceChar",
 										},
 									},
@@ -2867,22 +2894,22 @@ var g = &grammar{
 		},
 		{
 			name: "__",
-			pos:  position{line: 554, col: 1, offset: 17266},
+			pos:  position{line: 616, col: 1, offset: 19225},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 554, col: 7, offset: 17272},
+				pos: position{line: 616, col: 7, offset: 19231},
 				expr: &choiceExpr{
-					pos: position{line: 554, col: 9, offset: 17274},
+					pos: position{line: 616, col: 9, offset: 19233},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 554, col: 9, offset: 17274},
+							pos:  position{line: 616, col: 9, offset: 19233},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 554, col: 22, offset: 17287},
+							pos:  position{line: 616, col: 22, offset: 19246},
 							name: "EOL",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 554, col: 28, offset: 17293},
+							pos:  position{line: 616, col: 28, offset: 19252},
 							name: "Comment",
 						},
 					},
@@ -2891,18 +2918,18 @@ var g = &grammar{
 		},
 		{
 			name: "_",
-			pos:  position{line: 555, col: 1, offset: 17304},
+			pos:  position{line: 617, col: 1, offset: 19263},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 555, col: 6, offset: 17309},
+				pos: position{line: 617, col: 6, offset: 19268},
 				expr: &choiceExpr{
-					pos: position{line: 555, col: 8, offset: 17311},
+					pos: position{line: 617, col: 8, offset: 19270},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 555, col: 8, offset: 17311},
+							pos:  position{line: 617, col: 8, offset: 19270},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 555, col: 21, offset: 17324},
+							pos:  position{line: 617, col: 21, offset: 19283},
 							name: "MultiLineCommentNoLineTerminator",
 						},
 					},
@@ -2911,20 +2938,20 @@ var g = &grammar{
 		},
 		{
 			name: "WS",
-			pos:  position{line: 556, col: 1, offset: 17360},
+			pos:  position{line: 618, col: 1, offset: 19319},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 556, col: 7, offset: 17366},
+				pos: position{line: 618, col: 7, offset: 19325},
 				expr: &ruleRefExpr{
-					pos:  position{line: 556, col: 7, offset: 17366},
+					pos:  position{line: 618, col: 7, offset: 19325},
 					name: "Whitespace",
 				},
 			},
 		},
 		{
 			name: "Whitespace",
-			pos:  position{line: 558, col: 1, offset: 17379},
+			pos:  position{line: 620, col: 1, offset: 19338},
 			expr: &charClassMatcher{
-				pos:        position{line: 558, col: 15, offset: 17393},
+				pos:        position{line: 620, col: 15, offset: 19352},
 				val:        "[ \\t\\r]",
 				chars:      []rune{' ', '\t', '\r'},
 				ignoreCase: false,
@@ -2933,62 +2960,62 @@ var g = &grammar{
 		},
 		{
 			name: "EOL",
-			pos:  position{line: 559, col: 1, offset: 17401},
+			pos:  position{line: 621, col: 1, offset: 19360},
 			expr: &litMatcher{
-				pos:        position{line: 559, col: 8, offset: 17408},
+				pos:        position{line: 621, col: 8, offset: 19367},
 				val:        "\n",
 				ignoreCase: false,
 			},
 		},
 		{
 			name: "EOS",
-			pos:  position{line: 560, col: 1, offset: 17413},
+			pos:  position{line: 622, col: 1, offset: 19372},
 			expr: &choiceExpr{
-				pos: position{line: 560, col: 8, offset: 17420},
+				pos: position{line: 622, col: 8, offset: 19379},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 560, col: 8, offset: 17420},
+						pos: position{line: 622, col: 8, offset: 19379},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 560, col: 8, offset: 17420},
+								pos:  position{line: 622, col: 8, offset: 19379},
 								name: "__",
 							},
 							&litMatcher{
-								pos:        position{line: 560, col: 11, offset: 17423},
+								pos:        position{line: 622, col: 11, offset: 19382},
 								val:        ";",
 								ignoreCase: false,
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 560, col: 17, offset: 17429},
+						pos: position{line: 622, col: 17, offset: 19388},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 560, col: 17, offset: 17429},
+								pos:  position{line: 622, col: 17, offset: 19388},
 								name: "_",
 							},
 							&zeroOrOneExpr{
-								pos: position{line: 560, col: 19, offset: 17431},
+								pos: position{line: 622, col: 19, offset: 19390},
 								expr: &ruleRefExpr{
-									pos:  position{line: 560, col: 19, offset: 17431},
+									pos:  position{line: 622, col: 19, offset: 19390},
 									name: "SingleLineComment",
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 560, col: 38, offset: 17450},
+								pos:  position{line: 622, col: 38, offset: 19409},
 								name: "EOL",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 560, col: 44, offset: 17456},
+						pos: position{line: 622, col: 44, offset: 19415},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 560, col: 44, offset: 17456},
+								pos:  position{line: 622, col: 44, offset: 19415},
 								name: "__",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 560, col: 47, offset: 17459},
+								pos:  position{line: 622, col: 47, offset: 19418},
 								name: "EOF",
 							},
 						},
@@ -2998,11 +3025,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 562, col: 1, offset: 17464},
+			pos:  position{line: 624, col: 1, offset: 19423},
 			expr: &notExpr{
-				pos: position{line: 562, col: 8, offset: 17471},
+				pos: position{line: 624, col: 8, offset: 19430},
 				expr: &anyMatcher{
-					line: 562, col: 9, offset: 17472,
+					line: 624, col: 9, offset: 19431,
 				},
 			},
 		},
@@ -3031,6 +3058,7 @@ func (c *current) onGrammar1(statements interface{}) (interface{}, error) {
 		wrapper := st.([]interface{})[0].(*statementWrapper)
 		switch v := wrapper.statement.(type) {
 		case *Namespace:
+			v.Comment = wrapper.comment
 			frugal.Namespaces = append(frugal.Namespaces, v)
 			frugal.namespaceIndex[v.Scope] = v
 		case *Constant:
@@ -3062,6 +3090,7 @@ func (c *current) onGrammar1(statements interface{}) (interface{}, error) {
 			v.Frugal = frugal
 			frugal.Services = append(frugal.Services, v)
 		case *Include:
+			v.Comment = wrapper.comment
 			frugal.Includes = append(frugal.Includes, v)
 		case *Scope:
 			v.Comment = wrapper.comment
@@ -3114,6 +3143,7 @@ func (c *current) onInclude1(file, annotations interface{}) (interface{}, error)
 		Name:        name,
 		Value:       file.(string),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}, nil
 }
 
@@ -3128,6 +3158,7 @@ func (c *current) onNamespace1(scope, ns, annotations interface{}) (interface{},
 		Scope:       ifaceSliceToString(scope),
 		Value:       string(ns.(Identifier)),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}, nil
 }
 
@@ -3143,6 +3174,7 @@ func (c *current) onConst1(typ, name, value, annotations interface{}) (interface
 		Type:        typ.(*Type),
 		Value:       value,
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}, nil
 }
 
@@ -3158,6 +3190,7 @@ func (c *current) onEnum1(name, values, annotations interface{}) (interface{}, e
 		Name:        string(name.(Identifier)),
 		Values:      make([]*EnumValue, len(vs)),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	// Assigns numbers in order. This will behave badly if some values are
 	// defined and other are not, but I think that's ok since that's a silly
@@ -3167,6 +3200,7 @@ func (c *current) onEnum1(name, values, annotations interface{}) (interface{}, e
 		ev := v.([]interface{})[0].(*EnumValue)
 		if ev.Value < 0 {
 			ev.Value = next
+			ev.implicit = true
 		}
 		if ev.Value >= next {
 			next = ev.Value + 1
@@ -3187,6 +3221,7 @@ func (c *current) onEnumValue1(docstr, name, value, annotations interface{}) (in
 		Name:        string(name.(Identifier)),
 		Value:       -1,
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if docstr != nil {
 		raw := docstr.([]interface{})[0].(string)
@@ -3209,6 +3244,7 @@ func (c *current) onTypeDef1(typ, name, annotations interface{}) (interface{}, e
 		Name:        string(name.(Identifier)),
 		Type:        typ.(*Type),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}, nil
 }
 
@@ -3219,7 +3255,9 @@ func (p *parser) callonTypeDef1() (interface{}, error) {
 }
 
 func (c *current) onStruct1(st interface{}) (interface{}, error) {
-	return st.(*Struct), nil
+	s := st.(*Struct)
+	s.Pos = definitionPos(c)
+	return s, nil
 }
 
 func (p *parser) callonStruct1() (interface{}, error) {
@@ -3229,7 +3267,9 @@ func (p *parser) callonStruct1() (interface{}, error) {
 }
 
 func (c *current) onException1(st interface{}) (interface{}, error) {
-	return exception(st.(*Struct)), nil
+	s := st.(*Struct)
+	s.Pos = definitionPos(c)
+	return exception(s), nil
 }
 
 func (p *parser) callonException1() (interface{}, error) {
@@ -3239,7 +3279,9 @@ func (p *parser) callonException1() (interface{}, error) {
 }
 
 func (c *current) onUnion1(st interface{}) (interface{}, error) {
-	return union(st.(*Struct)), nil
+	s := st.(*Struct)
+	s.Pos = definitionPos(c)
+	return union(s), nil
 }
 
 func (p *parser) callonUnion1() (interface{}, error) {
@@ -3286,6 +3328,7 @@ func (c *current) onField1(docstr, id, mod, typ, name, def, annotations interfac
 		Name:        string(name.(Identifier)),
 		Type:        typ.(*Type),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if docstr != nil {
 		raw := docstr.([]interface{})[0].(string)
@@ -3329,6 +3372,7 @@ func (c *current) onService1(name, extends, methods, annotations interface{}) (i
 		Name:        string(name.(Identifier)),
 		Methods:     make([]*Method, len(ms)),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if extends != nil {
 		svc.Extends = string(extends.([]interface{})[2].(Identifier))
@@ -3360,6 +3404,7 @@ func (c *current) onFunction1(docstr, oneway, typ, name, arguments, exceptions,
 	m := &Method{
 		Name:        string(name.(Identifier)),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if docstr != nil {
 		raw := docstr.([]interface{})[0].(string)
@@ -3627,6 +3672,7 @@ func (c *current) onScope1(docstr, name, prefix, operations, annotations interfa
 		Operations:  make([]*Operation, len(ops)),
 		Prefix:      defaultPrefix,
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if docstr != nil {
 		raw := docstr.([]interface{})[0].(string)
@@ -3674,6 +3720,7 @@ func (c *current) onOperation1(docstr, name, typ, annotations interface{}) (inte
 		Name:        string(name.(Identifier)),
 		Type:        typ.(*Type),
 		Annotations: toAnnotations(annotations),
+		Pos:         definitionPos(c),
 	}
 	if docstr != nil {
 		raw := docstr.([]interface{})[0].(string)
@@ -3730,18 +3777,84 @@ var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
 
+	// errInvalidEntrypoint is returned when the specified entrypoint rule
+	// does not exit.
+	errInvalidEntrypoint = errors.New("invalid entrypoint")
+
 	// errInvalidEncoding is returned when the source is not properly
 	// utf8-encoded.
 	errInvalidEncoding = errors.New("invalid encoding")
 
-	// errNoMatch is returned if no match could be found.
-	errNoMatch = errors.New("no match found")
+	// errMaxExprCnt is used to signal that the maximum number of
+	// expressions have been parsed.
+	errMaxExprCnt = errors.New("max number of expresssions parsed")
 )
 
 // Option is a function that can set an option on the parser. It returns
 // the previous setting as an Option.
 type Option func(*parser) Option
 
+// MaxExpressions creates an Option to stop parsing after the provided
+// number of expressions have been parsed, if the value is 0 then the parser will
+// parse for as many steps as needed (possibly an infinite number).
+//
+// The default for maxExprCnt is 0.
+func MaxExpressions(maxExprCnt uint64) Option {
+	return func(p *parser) Option {
+		oldMaxExprCnt := p.maxExprCnt
+		p.maxExprCnt = maxExprCnt
+		return MaxExpressions(oldMaxExprCnt)
+	}
+}
+
+// Entrypoint creates an Option to set the rule name to use as entrypoint.
+// The rule name must have been specified in the -alternate-entrypoints
+// if generating the parser with the -optimize-grammar flag, otherwise
+// it may have been optimized out. Passing an empty string sets the
+// entrypoint to the first rule in the grammar.
+//
+// The default is to start parsing at the first rule in the grammar.
+func Entrypoint(ruleName string) Option {
+	return func(p *parser) Option {
+		oldEntrypoint := p.entrypoint
+		p.entrypoint = ruleName
+		if ruleName == "" {
+			p.entrypoint = g.rules[0].name
+		}
+		return Entrypoint(oldEntrypoint)
+	}
+}
+
+// Statistics adds a user provided Stats struct to the parser to allow
+// the user to process the results after the parsing has finished.
+// Also the key for the "no match" counter is set.
+//
+// Example usage:
+//
+//	input := "input"
+//	stats := Stats{}
+//	_, err := Parse("input-file", []byte(input), Statistics(&stats, "no match"))
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	b, err := json.MarshalIndent(stats.ChoiceAltCnt, "", "  ")
+//	if err != nil {
+//	    log.Panicln(err)
+//	}
+//	fmt.Println(string(b))
+func Statistics(stats *Stats, choiceNoMatch string) Option {
+	return func(p *parser) Option {
+		oldStats := p.Stats
+		p.Stats = stats
+		oldChoiceNoMatch := p.choiceNoMatch
+		p.choiceNoMatch = choiceNoMatch
+		if p.Stats.ChoiceAltCnt == nil {
+			p.Stats.ChoiceAltCnt = make(map[string]map[string]int)
+		}
+		return Statistics(oldStats, oldChoiceNoMatch)
+	}
+}
+
 // Debug creates an Option to set the debug flag to b. When set to true,
 // debugging information is printed to stdout while parsing.
 //
@@ -3768,6 +3881,20 @@ func Memoize(b bool) Option {
 	}
 }
 
+// AllowInvalidUTF8 creates an Option to allow invalid UTF-8 bytes.
+// Every invalid UTF-8 byte is treated as a utf8.RuneError (U+FFFD)
+// by character class matchers and is matched by the any matcher.
+// The returned matched value, c.text and c.offset are NOT affected.
+//
+// The default is false.
+func AllowInvalidUTF8(b bool) Option {
+	return func(p *parser) Option {
+		old := p.allowInvalidUTF8
+		p.allowInvalidUTF8 = b
+		return AllowInvalidUTF8(old)
+	}
+}
+
 // Recover creates an Option to set the recover flag to b. When set to
 // true, this causes the parser to recover from panics and convert it
 // to an error. Setting it to false can be useful while debugging to
@@ -3782,13 +3909,37 @@ func Recover(b bool) Option {
 	}
 }
 
+// GlobalStore creates an Option to set a key to a certain value in
+// the globalStore.
+func GlobalStore(key string, value interface{}) Option {
+	return func(p *parser) Option {
+		old := p.cur.globalStore[key]
+		p.cur.globalStore[key] = value
+		return GlobalStore(key, old)
+	}
+}
+
+// InitState creates an Option to set a key to a certain value in
+// the global "state" store.
+func InitState(key string, value interface{}) Option {
+	return func(p *parser) Option {
+		old := p.cur.state[key]
+		p.cur.state[key] = value
+		return InitState(key, old)
+	}
+}
+
 // ParseFile parses the file identified by filename.
-func ParseFile(filename string, opts ...Option) (interface{}, error) {
+func ParseFile(filename string, opts ...Option) (i interface{}, err error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}()
 	return ParseReader(filename, f, opts...)
 }
 
@@ -3829,8 +3980,22 @@ type savepoint struct {
 type current struct {
 	pos  position // start position of the match
 	text []byte   // raw text of the match
+
+	// state is a store for arbitrary key,value pairs that the user wants to be
+	// tied to the backtracking of the parser.
+	// This is always rolled back if a parsing rule fails.
+	state storeDict
+
+	// globalStore is a general store for the user to store arbitrary key-value
+	// pairs that they need to manage and that they do not want tied to the
+	// backtracking of the parser. This is only modified by the user and never
+	// rolled back by the parser. It is always up to the user to keep this in a
+	// consistent state.
+	globalStore storeDict
 }
 
+type storeDict map[string]interface{}
+
 // the AST types...
 
 type grammar struct {
@@ -3856,11 +4021,23 @@ type actionExpr struct {
 	run  func(*parser) (interface{}, error)
 }
 
+type recoveryExpr struct {
+	pos          position
+	expr         interface{}
+	recoverExpr  interface{}
+	failureLabel []string
+}
+
 type seqExpr struct {
 	pos   position
 	exprs []interface{}
 }
 
+type throwExpr struct {
+	pos   position
+	label string
+}
+
 type labeledExpr struct {
 	pos   position
 	label string
@@ -3883,6 +4060,11 @@ type ruleRefExpr struct {
 	name string
 }
 
+type stateCodeExpr struct {
+	pos position
+	run func(*parser) error
+}
+
 type andCodeExpr struct {
 	pos position
 	run func(*parser) (bool, error)
@@ -3900,13 +4082,14 @@ type litMatcher struct {
 }
 
 type charClassMatcher struct {
-	pos        position
-	val        string
-	chars      []rune
-	ranges     []rune
-	classes    []*unicode.RangeTable
-	ignoreCase bool
-	inverted   bool
+	pos             position
+	val             string
+	basicLatinChars [128]bool
+	chars           []rune
+	ranges          []rune
+	classes         []*unicode.RangeTable
+	ignoreCase      bool
+	inverted        bool
 }
 
 type anyMatcher position
@@ -3960,9 +4143,10 @@ func (e errList) Error() string {
 // parserError wraps an error with a prefix indicating the rule in which
 // the error occurred. The original error is stored in the Inner field.
 type parserError struct {
-	Inner  error
-	pos    position
-	prefix string
+	Inner    error
+	pos      position
+	prefix   string
+	expected []string
 }
 
 // Error returns the error message.
@@ -3972,14 +4156,32 @@ func (p *parserError) Error() string {
 
 // newParser creates a parser with the specified input source and options.
 func newParser(filename string, b []byte, opts ...Option) *parser {
+	stats := Stats{
+		ChoiceAltCnt: make(map[string]map[string]int),
+	}
+
 	p := &parser{
 		filename: filename,
 		errs:     new(errList),
 		data:     b,
 		pt:       savepoint{position: position{line: 1}},
 		recover:  true,
+		cur: current{
+			state:       make(storeDict),
+			globalStore: make(storeDict),
+		},
+		maxFailPos:      position{col: 1, line: 1},
+		maxFailExpected: make([]string, 0, 20),
+		Stats:           &stats,
+		// start rule is rule [0] unless an alternate entrypoint is specified
+		entrypoint: g.rules[0].name,
 	}
 	p.setOptions(opts)
+
+	if p.maxExprCnt == 0 {
+		p.maxExprCnt = math.MaxUint64
+	}
+
 	return p
 }
 
@@ -3996,6 +4198,30 @@ type resultTuple struct {
 	end savepoint
 }
 
+const choiceNoMatch = -1
+
+// Stats stores some statistics, gathered during parsing
+type Stats struct {
+	// ExprCnt counts the number of expressions processed during parsing
+	// This value is compared to the maximum number of expressions allowed
+	// (set by the MaxExpressions option).
+	ExprCnt uint64
+
+	// ChoiceAltCnt is used to count for each ordered choice expression,
+	// which alternative is used how may times.
+	// These numbers allow to optimize the order of the ordered choice expression
+	// to increase the performance of the parser
+	//
+	// The outer key of ChoiceAltCnt is composed of the name of the rule as well
+	// as the line and the column of the ordered choice.
+	// The inner key of ChoiceAltCnt is the number (one-based) of the matching alternative.
+	// For each alternative the number of matches are counted. If an ordered choice does not
+	// match, a special counter is incremented. The name of this counter is set with
+	// the parser option Statistics.
+	// For an alternative to be included in ChoiceAltCnt, it has to match at least once.
+	ChoiceAltCnt map[string]map[string]int
+}
+
 type parser struct {
 	filename string
 	pt       savepoint
@@ -4004,9 +4230,9 @@ type parser struct {
 	data []byte
 	errs *errList
 
+	depth   int
 	recover bool
 	debug   bool
-	depth   int
 
 	memoize bool
 	// memoization table for the packrat algorithm:
@@ -4020,8 +4246,23 @@ type parser struct {
 	// rule stack, allows identification of the current rule in errors
 	rstack []*rule
 
-	// stats
-	exprCnt int
+	// parse fail
+	maxFailPos            position
+	maxFailExpected       []string
+	maxFailInvertExpected bool
+
+	// max number of expressions to be parsed
+	maxExprCnt uint64
+	// entrypoint for the parser
+	entrypoint string
+
+	allowInvalidUTF8 bool
+
+	*Stats
+
+	choiceNoMatch string
+	// recovery expression stack, keeps track of the currently available recovery expression, these are traversed in reverse
+	recoveryStack []map[string]interface{}
 }
 
 // push a variable set on the vstack.
@@ -4056,6 +4297,31 @@ func (p *parser) popV() {
 	p.vstack = p.vstack[:len(p.vstack)-1]
 }
 
+// push a recovery expression with its labels to the recoveryStack
+func (p *parser) pushRecovery(labels []string, expr interface{}) {
+	if cap(p.recoveryStack) == len(p.recoveryStack) {
+		// create new empty slot in the stack
+		p.recoveryStack = append(p.recoveryStack, nil)
+	} else {
+		// slice to 1 more
+		p.recoveryStack = p.recoveryStack[:len(p.recoveryStack)+1]
+	}
+
+	m := make(map[string]interface{}, len(labels))
+	for _, fl := range labels {
+		m[fl] = expr
+	}
+	p.recoveryStack[len(p.recoveryStack)-1] = m
+}
+
+// pop a recovery expression from the recoveryStack
+func (p *parser) popRecovery() {
+	// GC that map
+	p.recoveryStack[len(p.recoveryStack)-1] = nil
+
+	p.recoveryStack = p.recoveryStack[:len(p.recoveryStack)-1]
+}
+
 func (p *parser) print(prefix, s string) string {
 	if !p.debug {
 		return s
@@ -4077,10 +4343,10 @@ func (p *parser) out(s string) string {
 }
 
 func (p *parser) addErr(err error) {
-	p.addErrAt(err, p.pt.position)
+	p.addErrAt(err, p.pt.position, []string{})
 }
 
-func (p *parser) addErrAt(err error, pos position) {
+func (p *parser) addErrAt(err error, pos position, expected []string) {
 	var buf bytes.Buffer
 	if p.filename != "" {
 		buf.WriteString(p.filename)
@@ -4100,10 +4366,29 @@ func (p *parser) addErrAt(err error, pos position) {
 			buf.WriteString("rule " + rule.name)
 		}
 	}
-	pe := &parserError{Inner: err, pos: pos, prefix: buf.String()}
+	pe := &parserError{Inner: err, pos: pos, prefix: buf.String(), expected: expected}
 	p.errs.add(pe)
 }
 
+func (p *parser) failAt(fail bool, pos position, want string) {
+	// process fail if parsing fails and not inverted or parsing succeeds and invert is set
+	if fail == p.maxFailInvertExpected {
+		if pos.offset < p.maxFailPos.offset {
+			return
+		}
+
+		if pos.offset > p.maxFailPos.offset {
+			p.maxFailPos = pos
+			p.maxFailExpected = p.maxFailExpected[:0]
+		}
+
+		if p.maxFailInvertExpected {
+			want = "!" + want
+		}
+		p.maxFailExpected = append(p.maxFailExpected, want)
+	}
+}
+
 // read advances the parser to the next rune.
 func (p *parser) read() {
 	p.pt.offset += p.pt.w
@@ -4116,8 +4401,8 @@ func (p *parser) read() {
 		p.pt.col = 0
 	}
 
-	if rn == utf8.RuneError {
-		if n > 0 {
+	if rn == utf8.RuneError && n == 1 { // see utf8.DecodeRune
+		if !p.allowInvalidUTF8 {
 			p.addErr(errInvalidEncoding)
 		}
 	}
@@ -4134,6 +4419,43 @@ func (p *parser) restore(pt savepoint) {
 	p.pt = pt
 }
 
+// Cloner is implemented by any value that has a Clone method, which returns a
+// copy of the value. This is mainly used for types which are not passed by
+// value (e.g map, slice, chan) or structs that contain such types.
+//
+// This is used in conjunction with the global state feature to create proper
+// copies of the state to allow the parser to properly restore the state in
+// the case of backtracking.
+type Cloner interface {
+	Clone() interface{}
+}
+
+// clone and return parser current state.
+func (p *parser) cloneState() storeDict {
+	if p.debug {
+		defer p.out(p.in("cloneState"))
+	}
+
+	state := make(storeDict, len(p.cur.state))
+	for k, v := range p.cur.state {
+		if c, ok := v.(Cloner); ok {
+			state[k] = c.Clone()
+		} else {
+			state[k] = v
+		}
+	}
+	return state
+}
+
+// restore parser current state to the state storeDict.
+// every restoreState should applied only one time for every cloned state
+func (p *parser) restoreState(state storeDict) {
+	if p.debug {
+		defer p.out(p.in("restoreState"))
+	}
+	p.cur.state = state
+}
+
 // get the slice of bytes from the savepoint start to the current position.
 func (p *parser) sliceFrom(start savepoint) []byte {
 	return p.data[start.position.offset:p.pt.position.offset]
@@ -4199,19 +4521,54 @@ func (p *parser) parse(g *grammar) (val interface{}, err error) {
 		}()
 	}
 
-	// start rule is rule [0]
+	startRule, ok := p.rules[p.entrypoint]
+	if !ok {
+		p.addErr(errInvalidEntrypoint)
+		return nil, p.errs.err()
+	}
+
 	p.read() // advance to first rune
-	val, ok := p.parseRule(g.rules[0])
+	val, ok = p.parseRule(startRule)
 	if !ok {
 		if len(*p.errs) == 0 {
-			// make sure this doesn't go out silently
-			p.addErr(errNoMatch)
+			// If parsing fails, but no errors have been recorded, the expected values
+			// for the farthest parser position are returned as error.
+			maxFailExpectedMap := make(map[string]struct{}, len(p.maxFailExpected))
+			for _, v := range p.maxFailExpected {
+				maxFailExpectedMap[v] = struct{}{}
+			}
+			expected := make([]string, 0, len(maxFailExpectedMap))
+			eof := false
+			if _, ok := maxFailExpectedMap["!."]; ok {
+				delete(maxFailExpectedMap, "!.")
+				eof = true
+			}
+			for k := range maxFailExpectedMap {
+				expected = append(expected, k)
+			}
+			sort.Strings(expected)
+			if eof {
+				expected = append(expected, "EOF")
+			}
+			p.addErrAt(errors.New("no match found, expected: "+listJoin(expected, ", ", "or")), p.maxFailPos, expected)
 		}
+
 		return nil, p.errs.err()
 	}
 	return val, p.errs.err()
 }
 
+func listJoin(list []string, sep string, lastSep string) string {
+	switch len(list) {
+	case 0:
+		return ""
+	case 1:
+		return list[0]
+	default:
+		return fmt.Sprintf("%s %s %s", strings.Join(list[:len(list)-1], sep), lastSep, list[len(list)-1])
+	}
+}
+
 func (p *parser) parseRule(rule *rule) (interface{}, bool) {
 	if p.debug {
 		defer p.out(p.in("parseRule " + rule.name))
@@ -4243,7 +4600,6 @@ func (p *parser) parseRule(rule *rule) (interface{}, bool) {
 
 func (p *parser) parseExpr(expr interface{}) (interface{}, bool) {
 	var pt savepoint
-	var ok bool
 
 	if p.memoize {
 		res, ok := p.getMemoized(expr)
@@ -4254,8 +4610,13 @@ func (p *parser) parseExpr(expr interface{}) (interface{}, bool) {
 		pt = p.pt
 	}
 
-	p.exprCnt++
+	p.ExprCnt++
+	if p.ExprCnt > p.maxExprCnt {
+		panic(errMaxExprCnt)
+	}
+
 	var val interface{}
+	var ok bool
 	switch expr := expr.(type) {
 	case *actionExpr:
 		val, ok = p.parseActionExpr(expr)
@@ -4279,10 +4640,16 @@ func (p *parser) parseExpr(expr interface{}) (interface{}, bool) {
 		val, ok = p.parseNotExpr(expr)
 	case *oneOrMoreExpr:
 		val, ok = p.parseOneOrMoreExpr(expr)
+	case *recoveryExpr:
+		val, ok = p.parseRecoveryExpr(expr)
 	case *ruleRefExpr:
 		val, ok = p.parseRuleRefExpr(expr)
 	case *seqExpr:
 		val, ok = p.parseSeqExpr(expr)
+	case *stateCodeExpr:
+		val, ok = p.parseStateCodeExpr(expr)
+	case *throwExpr:
+		val, ok = p.parseThrowExpr(expr)
 	case *zeroOrMoreExpr:
 		val, ok = p.parseZeroOrMoreExpr(expr)
 	case *zeroOrOneExpr:
@@ -4306,10 +4673,13 @@ func (p *parser) parseActionExpr(act *actionExpr) (interface{}, bool) {
 	if ok {
 		p.cur.pos = start.position
 		p.cur.text = p.sliceFrom(start)
+		state := p.cloneState()
 		actVal, err := act.run(p)
 		if err != nil {
-			p.addErrAt(err, start.position)
+			p.addErrAt(err, start.position, []string{})
 		}
+		p.restoreState(state)
+
 		val = actVal
 	}
 	if ok && p.debug {
@@ -4323,10 +4693,14 @@ func (p *parser) parseAndCodeExpr(and *andCodeExpr) (interface{}, bool) {
 		defer p.out(p.in("parseAndCodeExpr"))
 	}
 
+	state := p.cloneState()
+
 	ok, err := and.run(p)
 	if err != nil {
 		p.addErr(err)
 	}
+	p.restoreState(state)
+
 	return nil, ok
 }
 
@@ -4336,10 +4710,13 @@ func (p *parser) parseAndExpr(and *andExpr) (interface{}, bool) {
 	}
 
 	pt := p.pt
+	state := p.cloneState()
 	p.pushV()
 	_, ok := p.parseExpr(and.expr)
 	p.popV()
+	p.restoreState(state)
 	p.restore(pt)
+
 	return nil, ok
 }
 
@@ -4348,12 +4725,15 @@ func (p *parser) parseAnyMatcher(any *anyMatcher) (interface{}, bool) {
 		defer p.out(p.in("parseAnyMatcher"))
 	}
 
-	if p.pt.rn != utf8.RuneError {
-		start := p.pt
-		p.read()
-		return p.sliceFrom(start), true
+	if p.pt.rn == utf8.RuneError && p.pt.w == 0 {
+		// EOF - see utf8.DecodeRune
+		p.failAt(false, p.pt.position, ".")
+		return nil, false
 	}
-	return nil, false
+	start := p.pt
+	p.read()
+	p.failAt(true, start.position, ".")
+	return p.sliceFrom(start), true
 }
 
 func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (interface{}, bool) {
@@ -4362,11 +4742,14 @@ func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (interface{}, bool
 	}
 
 	cur := p.pt.rn
+	start := p.pt
+
 	// can't match EOF
-	if cur == utf8.RuneError {
+	if cur == utf8.RuneError && p.pt.w == 0 { // see utf8.DecodeRune
+		p.failAt(false, start.position, chr.val)
 		return nil, false
 	}
-	start := p.pt
+
 	if chr.ignoreCase {
 		cur = unicode.ToLower(cur)
 	}
@@ -4375,9 +4758,11 @@ func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (interface{}, bool
 	for _, rn := range chr.chars {
 		if rn == cur {
 			if chr.inverted {
+				p.failAt(false, start.position, chr.val)
 				return nil, false
 			}
 			p.read()
+			p.failAt(true, start.position, chr.val)
 			return p.sliceFrom(start), true
 		}
 	}
@@ -4386,9 +4771,11 @@ func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (interface{}, bool
 	for i := 0; i < len(chr.ranges); i += 2 {
 		if cur >= chr.ranges[i] && cur <= chr.ranges[i+1] {
 			if chr.inverted {
+				p.failAt(false, start.position, chr.val)
 				return nil, false
 			}
 			p.read()
+			p.failAt(true, start.position, chr.val)
 			return p.sliceFrom(start), true
 		}
 	}
@@ -4397,33 +4784,60 @@ func (p *parser) parseCharClassMatcher(chr *charClassMatcher) (interface{}, bool
 	for _, cl := range chr.classes {
 		if unicode.Is(cl, cur) {
 			if chr.inverted {
+				p.failAt(false, start.position, chr.val)
 				return nil, false
 			}
 			p.read()
+			p.failAt(true, start.position, chr.val)
 			return p.sliceFrom(start), true
 		}
 	}
 
 	if chr.inverted {
 		p.read()
+		p.failAt(true, start.position, chr.val)
 		return p.sliceFrom(start), true
 	}
+	p.failAt(false, start.position, chr.val)
 	return nil, false
 }
 
+func (p *parser) incChoiceAltCnt(ch *choiceExpr, altI int) {
+	choiceIdent := fmt.Sprintf("%s %d:%d", p.rstack[len(p.rstack)-1].name, ch.pos.line, ch.pos.col)
+	m := p.ChoiceAltCnt[choiceIdent]
+	if m == nil {
+		m = make(map[string]int)
+		p.ChoiceAltCnt[choiceIdent] = m
+	}
+	// We increment altI by 1, so the keys do not start at 0
+	alt := strconv.Itoa(altI + 1)
+	if altI == choiceNoMatch {
+		alt = p.choiceNoMatch
+	}
+	m[alt]++
+}
+
 func (p *parser) parseChoiceExpr(ch *choiceExpr) (interface{}, bool) {
 	if p.debug {
 		defer p.out(p.in("parseChoiceExpr"))
 	}
 
-	for _, alt := range ch.alternatives {
+	for altI, alt := range ch.alternatives {
+		// dummy assignment to prevent compile error if optimized
+		_ = altI
+
+		state := p.cloneState()
+
 		p.pushV()
 		val, ok := p.parseExpr(alt)
 		p.popV()
 		if ok {
+			p.incChoiceAltCnt(ch, altI)
 			return val, ok
 		}
+		p.restoreState(state)
 	}
+	p.incChoiceAltCnt(ch, choiceNoMatch)
 	return nil, false
 }
 
@@ -4447,6 +4861,11 @@ func (p *parser) parseLitMatcher(lit *litMatcher) (interface{}, bool) {
 		defer p.out(p.in("parseLitMatcher"))
 	}
 
+	ignoreCase := ""
+	if lit.ignoreCase {
+		ignoreCase = "i"
+	}
+	val := fmt.Sprintf("%q%s", lit.val, ignoreCase)
 	start := p.pt
 	for _, want := range lit.val {
 		cur := p.pt.rn
@@ -4454,11 +4873,13 @@ func (p *parser) parseLitMatcher(lit *litMatcher) (interface{}, bool) {
 			cur = unicode.ToLower(cur)
 		}
 		if cur != want {
+			p.failAt(false, start.position, val)
 			p.restore(start)
 			return nil, false
 		}
 		p.read()
 	}
+	p.failAt(true, start.position, val)
 	return p.sliceFrom(start), true
 }
 
@@ -4467,10 +4888,14 @@ func (p *parser) parseNotCodeExpr(not *notCodeExpr) (interface{}, bool) {
 		defer p.out(p.in("parseNotCodeExpr"))
 	}
 
+	state := p.cloneState()
+
 	ok, err := not.run(p)
 	if err != nil {
 		p.addErr(err)
 	}
+	p.restoreState(state)
+
 	return nil, !ok
 }
 
@@ -4480,10 +4905,15 @@ func (p *parser) parseNotExpr(not *notExpr) (interface{}, bool) {
 	}
 
 	pt := p.pt
+	state := p.cloneState()
 	p.pushV()
+	p.maxFailInvertExpected = !p.maxFailInvertExpected
 	_, ok := p.parseExpr(not.expr)
+	p.maxFailInvertExpected = !p.maxFailInvertExpected
 	p.popV()
+	p.restoreState(state)
 	p.restore(pt)
+
 	return nil, !ok
 }
 
@@ -4509,6 +4939,18 @@ func (p *parser) parseOneOrMoreExpr(expr *oneOrMoreExpr) (interface{}, bool) {
 	}
 }
 
+func (p *parser) parseRecoveryExpr(recover *recoveryExpr) (interface{}, bool) {
+	if p.debug {
+		defer p.out(p.in("parseRecoveryExpr (" + strings.Join(recover.failureLabel, ",") + ")"))
+	}
+
+	p.pushRecovery(recover.failureLabel, recover.recoverExpr)
+	val, ok := p.parseExpr(recover.expr)
+	p.popRecovery()
+
+	return val, ok
+}
+
 func (p *parser) parseRuleRefExpr(ref *ruleRefExpr) (interface{}, bool) {
 	if p.debug {
 		defer p.out(p.in("parseRuleRefExpr " + ref.name))
@@ -4531,12 +4973,14 @@ func (p *parser) parseSeqExpr(seq *seqExpr) (interface{}, bool) {
 		defer p.out(p.in("parseSeqExpr"))
 	}
 
-	var vals []interface{}
+	vals := make([]interface{}, 0, len(seq.exprs))
 
 	pt := p.pt
+	state := p.cloneState()
 	for _, expr := range seq.exprs {
 		val, ok := p.parseExpr(expr)
 		if !ok {
+			p.restoreState(state)
 			p.restore(pt)
 			return nil, false
 		}
@@ -4545,6 +4989,34 @@ func (p *parser) parseSeqExpr(seq *seqExpr) (interface{}, bool) {
 	return vals, true
 }
 
+func (p *parser) parseStateCodeExpr(state *stateCodeExpr) (interface{}, bool) {
+	if p.debug {
+		defer p.out(p.in("parseStateCodeExpr"))
+	}
+
+	err := state.run(p)
+	if err != nil {
+		p.addErr(err)
+	}
+	return nil, true
+}
+
+func (p *parser) parseThrowExpr(expr *throwExpr) (interface{}, bool) {
+	if p.debug {
+		defer p.out(p.in("parseThrowExpr"))
+	}
+
+	for i := len(p.recoveryStack) - 1; i >= 0; i-- {
+		if recoverExpr, ok := p.recoveryStack[i][expr.label]; ok {
+			if val, ok := p.parseExpr(recoverExpr); ok {
+				return val, ok
+			}
+		}
+	}
+
+	return nil, false
+}
+
 func (p *parser) parseZeroOrMoreExpr(expr *zeroOrMoreExpr) (interface{}, bool) {
 	if p.debug {
 		defer p.out(p.in("parseZeroOrMoreExpr"))
@@ -4574,18 +5046,3 @@ func (p *parser) parseZeroOrOneExpr(expr *zeroOrOneExpr) (interface{}, bool) {
 	// whether it matched or not, consider it a match
 	return val, true
 }
-
-func rangeTable(class string) *unicode.RangeTable {
-	if rt, ok := unicode.Categories[class]; ok {
-		return rt
-	}
-	if rt, ok := unicode.Properties[class]; ok {
-		return rt
-	}
-	if rt, ok := unicode.Scripts[class]; ok {
-		return rt
-	}
-
-	// cannot happen
-	panic(fmt.Sprintf("invalid Unicode class: %s", class))
-}
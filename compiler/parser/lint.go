@@ -0,0 +1,295 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Lint rule IDs.
+const (
+	LintFieldID              = "field-id"
+	LintRequiredField        = "required-field"
+	LintServiceDocstring     = "service-docstring"
+	LintEnumValueImplicit    = "enum-value-implicit"
+	LintScopePrefixCollision = "scope-prefix-collision"
+	LintUnusedInclude        = "unused-include"
+)
+
+// LintRules describes each lint rule by ID.
+var LintRules = map[string]string{
+	LintFieldID:              "A field ID is below 1.",
+	LintRequiredField:        "A field is marked required.",
+	LintServiceDocstring:     "A service has no doc string.",
+	LintEnumValueImplicit:    "An enum value has no explicit number.",
+	LintScopePrefixCollision: "A scope prefix variable has the same name as an operation.",
+	LintUnusedInclude:        "An include is not referenced.",
+}
+
+// LintOff disables a lint rule in a LintConfig.
+const LintOff = "off"
+
+// LintConfig configures the severity of each lint rule.
+type LintConfig struct {
+	// Rules maps rule IDs to "error", "warning", or "off". Rules which are
+	// not listed are reported as warnings.
+	Rules map[string]string `yaml:"rules"`
+}
+
+// LoadLintConfig reads a LintConfig from the given YAML file.
+func LoadLintConfig(file string) (*LintConfig, error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	config := &LintConfig{}
+	if err := yaml.Unmarshal(contents, config); err != nil {
+		return nil, fmt.Errorf("invalid lint config %s: %s", file, err)
+	}
+	for rule, severity := range config.Rules {
+		if _, ok := LintRules[rule]; !ok {
+			return nil, fmt.Errorf("invalid lint config %s: unknown rule '%s'", file, rule)
+		}
+		switch severity {
+		case string(AuditError), string(AuditWarning), LintOff:
+		default:
+			return nil, fmt.Errorf("invalid lint config %s: invalid severity '%s' for rule '%s'",
+				file, severity, rule)
+		}
+	}
+	return config, nil
+}
+
+// Linter checks frugal files for style and safety issues which are not
+// validation errors.
+type Linter struct {
+	logger     ValidationLogger
+	severities map[string]string
+	includes   []string
+	findings   []*lintFinding
+}
+
+type lintFinding struct {
+	rule    string
+	pos     Pos
+	message string
+}
+
+// NewLinter constructs a linter which logs findings to the given logger with
+// the severities in the given config. The config may be nil, in which case
+// every rule is reported as a warning.
+func NewLinter(logger ValidationLogger, config *LintConfig) *Linter {
+	l := &Linter{logger: logger, severities: make(map[string]string)}
+	if config != nil {
+		for rule, severity := range config.Rules {
+			l.severities[rule] = severity
+		}
+	}
+	return l
+}
+
+// SetIncludePaths sets the directories searched for includes, before those
+// listed by IncludePathEnv.
+func (l *Linter) SetIncludePaths(paths []string) {
+	l.includes = paths
+}
+
+// Lint checks the given frugal file, logging findings in source order.
+// Returns an error if the file is invalid or any finding is an error.
+func (l *Linter) Lint(file string) error {
+	frugal, err := ParseFrugalWithOptions(file, ParseOptions{
+		IncludePaths: append(append([]string{}, l.includes...), EnvIncludePaths()...),
+	})
+	if err != nil {
+		return err
+	}
+
+	l.findings = nil
+	l.checkFields(frugal)
+	l.checkServices(frugal)
+	l.checkEnums(frugal)
+	l.checkScopes(frugal)
+	l.checkIncludes(frugal)
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i].pos, l.findings[j].pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Col < b.Col)
+	})
+	errors := 0
+	for _, finding := range l.findings {
+		diagnostic := &ValidationError{File: file, Pos: finding.pos, Message: finding.message}
+		message := fmt.Sprintf("%s [%s]", diagnostic, finding.rule)
+		if l.severity(finding.rule) == string(AuditError) {
+			errors++
+			l.logger.LogError(message)
+		} else {
+			l.logger.LogWarning(message)
+		}
+	}
+	if errors > 0 {
+		return fmt.Errorf("FAILED: lint of %s", file)
+	}
+	return nil
+}
+
+func (l *Linter) severity(rule string) string {
+	if severity, ok := l.severities[rule]; ok {
+		return severity
+	}
+	return string(AuditWarning)
+}
+
+func (l *Linter) report(rule string, pos Pos, format string, args ...interface{}) {
+	if l.severity(rule) == LintOff {
+		return
+	}
+	l.findings = append(l.findings, &lintFinding{rule: rule, pos: pos, message: fmt.Sprintf(format, args...)})
+}
+
+// checkFields requirements:
+// 1. Field IDs are at least 1. Fields without IDs are rejected by the parser.
+// 2. Fields are not required.
+func (l *Linter) checkFields(frugal *Frugal) {
+	checkField := func(field *Field, parent string) {
+		if field.ID < 1 {
+			l.report(LintFieldID, field.Pos, "Field %s of %s has ID %d, IDs should be at least 1",
+				field.Name, parent, field.ID)
+		}
+		if field.Modifier == Required {
+			l.report(LintRequiredField, field.Pos, "Field %s of %s is required", field.Name, parent)
+		}
+	}
+	for _, s := range append(append(append([]*Struct{}, frugal.Structs...), frugal.Exceptions...), frugal.Unions...) {
+		for _, field := range s.Fields {
+			checkField(field, s.Name)
+		}
+	}
+	for _, service := range frugal.Services {
+		for _, method := range service.Methods {
+			for _, field := range append(append([]*Field{}, method.Arguments...), method.Exceptions...) {
+				checkField(field, service.Name+"."+method.Name)
+			}
+		}
+	}
+}
+
+// checkServices requirements:
+// 1. Services have doc strings.
+func (l *Linter) checkServices(frugal *Frugal) {
+	for _, service := range frugal.Services {
+		if len(service.Comment) == 0 {
+			l.report(LintServiceDocstring, service.Pos, "Service %s has no doc string", service.Name)
+		}
+	}
+}
+
+// checkEnums requirements:
+// 1. Enum values are explicitly numbered.
+func (l *Linter) checkEnums(frugal *Frugal) {
+	for _, enum := range frugal.Enums {
+		for _, value := range enum.Values {
+			if value.implicit {
+				l.report(LintEnumValueImplicit, value.Pos, "Enum value %s.%s has no explicit number",
+					enum.Name, value.Name)
+			}
+		}
+	}
+}
+
+// checkScopes requirements:
+// 1. Prefix variables don't share a name with an operation in the scope.
+func (l *Linter) checkScopes(frugal *Frugal) {
+	for _, scope := range frugal.Scopes {
+		for _, variable := range scope.Prefix.Variables {
+			for _, op := range scope.Operations {
+				if strings.EqualFold(variable, op.Name) {
+					l.report(LintScopePrefixCollision, op.Pos,
+						"Operation %s of scope %s collides with prefix variable %s", op.Name, scope.Name, variable)
+				}
+			}
+		}
+	}
+}
+
+// checkIncludes requirements:
+// 1. Each include is referenced by a type, constant, or service.
+func (l *Linter) checkIncludes(frugal *Frugal) {
+	used := make(map[string]bool)
+	var useType func(t *Type)
+	useType = func(t *Type) {
+		if t == nil {
+			return
+		}
+		used[t.IncludeName()] = true
+		useType(t.KeyType)
+		useType(t.ValueType)
+	}
+	var useValue func(value interface{})
+	useValue = func(value interface{}) {
+		switch v := value.(type) {
+		case Identifier:
+			used[(&Type{Name: string(v)}).IncludeName()] = true
+		case []interface{}:
+			for _, elem := range v {
+				useValue(elem)
+			}
+		case []KeyValue:
+			for _, kv := range v {
+				useValue(kv.Key)
+				useValue(kv.Value)
+			}
+		}
+	}
+	useFields := func(fields []*Field) {
+		for _, field := range fields {
+			useType(field.Type)
+			useValue(field.Default)
+		}
+	}
+
+	for _, typedef := range frugal.Typedefs {
+		useType(typedef.Type)
+	}
+	for _, constant := range frugal.Constants {
+		useType(constant.Type)
+		useValue(constant.Value)
+	}
+	for _, s := range append(append(append([]*Struct{}, frugal.Structs...), frugal.Exceptions...), frugal.Unions...) {
+		useFields(s.Fields)
+	}
+	for _, service := range frugal.Services {
+		used[service.ExtendsInclude()] = true
+		for _, method := range service.Methods {
+			useType(method.ReturnType)
+			useFields(method.Arguments)
+			useFields(method.Exceptions)
+		}
+	}
+	for _, scope := range frugal.Scopes {
+		for _, op := range scope.Operations {
+			useType(op.Type)
+		}
+	}
+
+	for _, include := range frugal.Includes {
+		if !used[include.Name] {
+			l.report(LintUnusedInclude, include.Pos, "Include %s is not used", include.Value)
+		}
+	}
+}
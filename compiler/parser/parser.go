@@ -15,6 +15,7 @@ package parser
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -39,21 +40,110 @@ const (
 
 	// DeprecatedAnnotation is the annotation to mark a service method as deprecated.
 	DeprecatedAnnotation = "deprecated"
+
+	// UnknownValueAnnotation is used on enum definitions to name the value
+	// which unknown integers are decoded as when generators are set to check
+	// enum values.
+	UnknownValueAnnotation = "unknown_value"
 )
 
+// IncludePathEnv is the environment variable listing directories to search
+// for includes, separated as in PATH.
+const IncludePathEnv = "FRUGAL_INCLUDE_PATH"
+
+// FileReader reads the contents of the IDL file at the given path.
+type FileReader func(filePath string) ([]byte, error)
+
+// FileChecker indicates if the IDL file at the given path exists, without
+// reading it.
+type FileChecker func(filePath string) bool
+
+// ParseOptions configures how a Frugal file and its includes are read.
+type ParseOptions struct {
+	// Read reads IDL files. Defaults to reading from disk.
+	Read FileReader
+
+	// Exists checks IDL files exist when searching for includes. Defaults to
+	// checking the disk, or to reading the file with Read if it is set.
+	Exists FileChecker
+
+	// IncludePaths are directories searched, in order, for includes which
+	// are not found relative to the including file.
+	IncludePaths []string
+}
+
 // ParseFrugal parses the given Frugal file into its semantic representation.
 func ParseFrugal(filePath string) (*Frugal, error) {
-	return parseFrugal(filePath, []string{})
+	return ParseFrugalWithReader(filePath, ioutil.ReadFile)
+}
+
+// ParseFrugalWithReader parses the given Frugal file into its semantic
+// representation, reading the file and its includes with the given
+// FileReader. Includes are searched for in the directories listed by
+// IncludePathEnv.
+func ParseFrugalWithReader(filePath string, read FileReader) (*Frugal, error) {
+	return ParseFrugalWithOptions(filePath, ParseOptions{Read: read, IncludePaths: EnvIncludePaths()})
+}
+
+// ParseFrugalWithOptions parses the given Frugal file into its semantic
+// representation using the given options.
+func ParseFrugalWithOptions(filePath string, options ParseOptions) (*Frugal, error) {
+	if options.Exists == nil {
+		options.Exists = fileExists
+		if read := options.Read; read != nil {
+			options.Exists = func(filePath string) bool {
+				_, err := read(filePath)
+				return err == nil
+			}
+		}
+	}
+	if options.Read == nil {
+		options.Read = ioutil.ReadFile
+	}
+	return parseFrugal(filePath, options, []string{})
+}
+
+// EnvIncludePaths returns the include search paths set by IncludePathEnv.
+func EnvIncludePaths() []string {
+	paths := []string{}
+	for _, path := range filepath.SplitList(os.Getenv(IncludePathEnv)) {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ParseSource parses the contents of a single Frugal file without resolving
+// its includes or validating it. The returned Frugal retains the file's
+// comments, making it suitable for tooling which rewrites IDL.
+func ParseSource(filePath string, contents []byte) (*Frugal, error) {
+	name, err := getName(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := Parse(filePath, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	frugal := parsed.(*Frugal)
+	frugal.Name = name
+	frugal.File = filePath
+	frugal.Dir = filepath.Dir(filePath)
+	frugal.Path = filePath
+	frugal.Comments = scanComments(contents)
+	return frugal, nil
 }
 
-func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
-	file, err := os.Open(filePath)
+func parseFrugal(filePath string, options ParseOptions, visitedIncludes []string) (*Frugal, error) {
+	contents, err := options.Read(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	name, err := getName(file)
+	name, err := getName(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -63,24 +153,23 @@ func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
 	}
 	visitedIncludes = append(visitedIncludes, name)
 
-	parsed, err := ParseReader(filePath, file)
+	frugal, err := ParseSource(filePath, contents)
 	if err != nil {
 		return nil, err
 	}
 
-	frugal := parsed.(*Frugal)
-	frugal.Name = name
-	frugal.File = filePath
-	frugal.Dir = filepath.Dir(file.Name())
-	frugal.Path = filePath
 	for _, incl := range frugal.Includes {
 		include := incl.Value
 		if !strings.HasSuffix(include, ".thrift") && !strings.HasSuffix(include, ".frugal") {
 			return nil, fmt.Errorf("Bad include name: %s", include)
 		}
 
-		parsedIncl, err := parseFrugal(filepath.Join(frugal.Dir, include), visitedIncludes)
+		parsedIncl, err := parseFrugal(resolveInclude(frugal.Dir, include, options), options, visitedIncludes)
 		if err != nil {
+			if _, ok := err.(ValidationErrors); ok {
+				// Diagnostics already identify the included file.
+				return nil, err
+			}
 			return nil, fmt.Errorf("Include %s: %s", include, err)
 		}
 
@@ -103,14 +192,36 @@ func parseFrugal(filePath string, visitedIncludes []string) (*Frugal, error) {
 	return frugal, nil
 }
 
-func getName(f *os.File) (string, error) {
-	info, err := f.Stat()
-	if err != nil {
-		return "", err
+// resolveInclude returns the path of the given include, which is relative to
+// the including file's directory unless it only exists in one of the include
+// search paths.
+func resolveInclude(dir, include string, options ParseOptions) string {
+	path := filepath.Join(dir, include)
+	if len(options.IncludePaths) == 0 || filepath.IsAbs(include) {
+		return path
+	}
+	if options.Exists(path) {
+		return path
 	}
-	parts := strings.Split(info.Name(), ".")
+	for _, includePath := range options.IncludePaths {
+		candidate := filepath.Join(includePath, include)
+		if options.Exists(candidate) {
+			return candidate
+		}
+	}
+	return path
+}
+
+// fileExists indicates if the file at the given path exists on disk.
+func fileExists(filePath string) bool {
+	info, err := os.Stat(filePath)
+	return err == nil && !info.IsDir()
+}
+
+func getName(filePath string) (string, error) {
+	parts := strings.Split(filepath.Base(filePath), ".")
 	if len(parts) != 2 {
-		return "", fmt.Errorf("Invalid file: %s", f.Name())
+		return "", fmt.Errorf("Invalid file: %s", filePath)
 	}
 	return parts[0], nil
 }
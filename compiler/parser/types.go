@@ -94,11 +94,24 @@ func TypeFromStruct(s *Struct) *Type {
 	}
 }
 
+// Pos is the line and column at which a definition starts in an IDL file.
+type Pos struct {
+	Line int
+	Col  int
+}
+
+// String returns the position as "line:col".
+func (p Pos) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Col)
+}
+
 // Include represents an IDL file include.
 type Include struct {
 	Name        string
 	Value       string
 	Annotations Annotations
+	Comment     []string
+	Pos         Pos
 }
 
 type byIncludeName []Include
@@ -120,6 +133,8 @@ type Namespace struct {
 	Scope       string
 	Value       string
 	Annotations Annotations
+	Comment     []string
+	Pos         Pos
 }
 
 // Wildcard indicates if this Namespace is a wildcard (*).
@@ -189,6 +204,7 @@ type TypeDef struct {
 	Name        string
 	Type        *Type
 	Annotations Annotations
+	Pos         Pos
 }
 
 // EnumValue represents an IDL enum value.
@@ -197,6 +213,8 @@ type EnumValue struct {
 	Name        string
 	Value       int
 	Annotations Annotations
+	Pos         Pos
+	implicit    bool // Value was assigned by the parser
 }
 
 // Enum represents an IDL enum.
@@ -205,6 +223,7 @@ type Enum struct {
 	Name        string
 	Values      []*EnumValue
 	Annotations Annotations
+	Pos         Pos
 }
 
 // Constant represents an IDL constant.
@@ -214,6 +233,7 @@ type Constant struct {
 	Type        *Type
 	Value       interface{}
 	Annotations Annotations
+	Pos         Pos
 }
 
 // Field represents an IDL field on a struct or method.
@@ -225,6 +245,7 @@ type Field struct {
 	Type        *Type
 	Default     interface{}
 	Annotations Annotations
+	Pos         Pos
 }
 
 // StructType represents what "type" a struct is (struct, exception, or union).
@@ -258,6 +279,7 @@ type Struct struct {
 	Fields      []*Field
 	Type        StructType
 	Annotations Annotations
+	Pos         Pos
 }
 
 // Method represents an IDL service method.
@@ -269,6 +291,7 @@ type Method struct {
 	Arguments   []*Field
 	Exceptions  []*Field
 	Annotations Annotations
+	Pos         Pos
 }
 
 // Service represents an IDL service.
@@ -278,6 +301,7 @@ type Service struct {
 	Extends     string
 	Methods     []*Method
 	Annotations Annotations
+	Pos         Pos
 	Frugal      *Frugal // Pointer back to containing Frugal
 }
 
@@ -414,16 +438,16 @@ func (s *Service) ReferencedInternals() []string {
 
 // validate ensures Service oneways don't return anything and field ids aren't
 // duplicated.
-func (s *Service) validate() error {
+func (s *Service) validate(v *validator) {
 	for _, method := range s.Methods {
 		// Ensure oneways don't return anything.
 		if method.Oneway {
 			if len(method.Exceptions) > 0 {
-				return fmt.Errorf("Oneway method %s.%s cannot throw an exception",
+				v.errorf(method.Pos, "Oneway method %s.%s cannot throw an exception",
 					s.Name, method.Name)
 			}
 			if method.ReturnType != nil {
-				return fmt.Errorf("Void method %s.%s cannot return %s",
+				v.errorf(method.Pos, "Void method %s.%s cannot return %s",
 					s.Name, method.Name, method.ReturnType)
 			}
 		}
@@ -432,13 +456,12 @@ func (s *Service) validate() error {
 		ids := make(map[int]struct{})
 		for _, arg := range method.Arguments {
 			if _, ok := ids[arg.ID]; ok {
-				return fmt.Errorf("Duplicate field id %d in method %s.%s",
+				v.errorf(arg.Pos, "Duplicate field id %d in method %s.%s",
 					arg.ID, s.Name, method.Name)
 			}
 			ids[arg.ID] = struct{}{}
 		}
 	}
-	return nil
 }
 
 // Identifier represents an IDL identifier.
@@ -493,6 +516,12 @@ func (a Annotations) Deprecated() (string, bool) {
 	return a.Get(DeprecatedAnnotation)
 }
 
+// UnknownValue returns true if the "unknown_value" annotation is present and
+// its associated value, if any.
+func (a Annotations) UnknownValue() (string, bool) {
+	return a.Get(UnknownValueAnnotation)
+}
+
 // IsDeprecated returns true if the "deprecated" annotation is present.
 func (a Annotations) IsDeprecated() bool {
 	_, d := a.Deprecated()
@@ -554,6 +583,7 @@ type Operation struct {
 	Name        string
 	Type        *Type
 	Annotations Annotations
+	Pos         Pos
 	Scope       *Scope // Pointer back to containing Scope
 }
 
@@ -578,6 +608,7 @@ type Scope struct {
 	Prefix      *ScopePrefix
 	Operations  []*Operation
 	Annotations Annotations
+	Pos         Pos
 	Frugal      *Frugal // Pointer back to containing Frugal
 }
 
@@ -642,6 +673,9 @@ type Frugal struct {
 	Services   []*Service
 	Scopes     []*Scope
 
+	// Comments holds the comments in the file which are not doc strings.
+	Comments []*SourceComment
+
 	typedefIndex   map[string]*TypeDef
 	namespaceIndex map[string]*Namespace
 }
@@ -676,6 +710,26 @@ func (f *Frugal) FindStruct(typ *Type) *Struct {
 	return nil
 }
 
+// FindEnum returns the Enum with the given name, or nil if it doesn't exist.
+func (f *Frugal) FindEnum(typ *Type) *Enum {
+	frugal := f
+	if includeName := typ.IncludeName(); includeName != "" {
+		frugalInclude, ok := f.ParsedIncludes[includeName]
+		if !ok {
+			return nil
+		}
+		frugal = frugalInclude
+	}
+
+	for _, enum := range frugal.Enums {
+		if typ.ParamName() == enum.Name {
+			return enum
+		}
+	}
+
+	return nil
+}
+
 // Include returns the Include with the given name.
 func (f *Frugal) Include(name string) *Include {
 	name = filepath.Base(name)
@@ -821,22 +875,42 @@ func (f *Frugal) UnderlyingType(t *Type) *Type {
 	if t == nil {
 		panic("Attempted to get underlying type of nil type")
 	}
-	typedefIndex := f.typedefIndex
 	include := t.IncludeName()
 	if include != "" {
 		parsed, ok := f.ParsedIncludes[include]
 		if !ok {
 			return t
 		}
-		typedefIndex = parsed.typedefIndex
+		if typedef, ok := parsed.typedefIndex[t.ParamName()]; ok {
+			// The typedef is relative to the include, so resolve it there
+			// and qualify the result for use in this file.
+			return qualifyType(parsed.UnderlyingType(typedef.Type), include)
+		}
+		return t
 	}
-	if typedef, ok := typedefIndex[t.ParamName()]; ok {
+	if typedef, ok := f.typedefIndex[t.Name]; ok {
 		// Recursively call underlying type to handle typedef nesting.
 		return f.UnderlyingType(typedef.Type)
 	}
 	return t
 }
 
+// qualifyType returns a copy of the given type, which is relative to the
+// given include, with unqualified custom types prefixed by the include name.
+func qualifyType(t *Type, include string) *Type {
+	if t == nil || t.IsPrimitive() {
+		return t
+	}
+	qualified := *t
+	if t.IsContainer() {
+		qualified.KeyType = qualifyType(t.KeyType, include)
+		qualified.ValueType = qualifyType(t.ValueType, include)
+	} else if t.IncludeName() == "" {
+		qualified.Name = include + "." + t.Name
+	}
+	return &qualified
+}
+
 // ConstantFromField returns a new Constant from the given Field and value.
 func (f *Frugal) ConstantFromField(field *Field, value interface{}) *Constant {
 	return &Constant{
@@ -968,9 +1042,52 @@ func (f *Frugal) assignFrugal() {
 	}
 }
 
+// ValidationError is an invalid IDL definition.
+type ValidationError struct {
+	File    string
+	Pos     Pos
+	Message string
+}
+
+// Error returns the error as a "file:line:col: message" diagnostic.
+func (e *ValidationError) Error() string {
+	if e.Pos.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%s: %s", e.File, e.Pos, e.Message)
+}
+
+// ValidationErrors contains every ValidationError found in an IDL file.
+type ValidationErrors []*ValidationError
+
+// Error returns the errors as diagnostics, one per line.
+func (e ValidationErrors) Error() string {
+	diagnostics := make([]string, len(e))
+	for i, err := range e {
+		diagnostics[i] = err.Error()
+	}
+	return strings.Join(diagnostics, "\n")
+}
+
+// validator collects the validation errors of an IDL file.
+type validator struct {
+	file   string
+	errors ValidationErrors
+}
+
+func (v *validator) errorf(pos Pos, format string, args ...interface{}) {
+	v.errors = append(v.errors, &ValidationError{
+		File:    v.file,
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
 // validate parsed Frugal IDL by ensuring there are no duplicate service/scope
 // names and the Frugal IDL is valid.
 func (f *Frugal) validate() error {
+	v := &validator{file: f.File}
+
 	// Ensure there are no duplicate names between services and scopes.
 	names := make(map[string]string)
 	for _, service := range f.Services {
@@ -979,9 +1096,10 @@ func (f *Frugal) validate() error {
 		lowercaseService := LowercaseFirstLetter(service.Name)
 		if providedService, ok := names[lowercaseService]; ok {
 			if service.Name == providedService {
-				return fmt.Errorf("Duplicate service name %s", service.Name)
+				v.errorf(service.Pos, "Duplicate service name %s", service.Name)
+			} else {
+				v.errorf(service.Pos, "%s", conflictMessage("Services", service.Name, providedService))
 			}
-			return getConflictError("Services", service.Name, providedService)
 		}
 		names[lowercaseService] = service.Name
 
@@ -992,9 +1110,10 @@ func (f *Frugal) validate() error {
 			lowercaseMethod := LowercaseFirstLetter(method.Name)
 			if providedMethod, ok := methodNames[lowercaseMethod]; ok {
 				if method.Name == providedMethod {
-					return fmt.Errorf("Duplicate method name %s", method.Name)
+					v.errorf(method.Pos, "Duplicate method name %s", method.Name)
+				} else {
+					v.errorf(method.Pos, "%s", conflictMessage("Methods", method.Name, providedMethod))
 				}
-				return getConflictError("Methods", method.Name, providedMethod)
 			}
 			methodNames[lowercaseMethod] = method.Name
 		}
@@ -1007,9 +1126,10 @@ func (f *Frugal) validate() error {
 		lowercaseScope := LowercaseFirstLetter(scope.Name)
 		if providedScope, ok := names[lowercaseScope]; ok {
 			if scope.Name == providedScope {
-				return fmt.Errorf("Duplicate scope name %s", scope.Name)
+				v.errorf(scope.Pos, "Duplicate scope name %s", scope.Name)
+			} else {
+				v.errorf(scope.Pos, "%s", conflictMessage("Scopes", scope.Name, providedScope))
 			}
-			return getConflictError("Scopes", scope.Name, providedScope)
 		}
 		names[lowercaseScope] = scope.Name
 
@@ -1020,70 +1140,56 @@ func (f *Frugal) validate() error {
 			lowercaseOp := LowercaseFirstLetter(op.Name)
 			if providedOp, ok := opNames[lowercaseOp]; ok {
 				if op.Name == providedOp {
-					return fmt.Errorf("Duplicate operation name %s", op.Name)
+					v.errorf(op.Pos, "Duplicate operation name %s", op.Name)
+				} else {
+					v.errorf(op.Pos, "%s", conflictMessage("Operations", op.Name, providedOp))
 				}
-				return getConflictError("Operations", op.Name, providedOp)
 			}
 			opNames[lowercaseOp] = op.Name
 		}
 	}
 
-	if err := f.validateNamespaces(); err != nil {
-		return err
-	}
-	if err := f.validateIncludes(); err != nil {
-		return err
-	}
-	if err := f.validateConstants(); err != nil {
-		return err
-	}
-	if err := f.validateTypedefs(); err != nil {
-		return err
-	}
-	if err := f.validateStructs(); err != nil {
-		return err
-	}
-	if err := f.validateUnions(); err != nil {
-		return err
-	}
-	if err := f.validateExceptions(); err != nil {
-		return err
-	}
-	if err := f.validateServices(f.ParsedIncludes); err != nil {
-		return err
+	f.validateNamespaces(v)
+	f.validateIncludes(v)
+	f.validateConstants(v)
+	f.validateTypedefs(v)
+	f.validateEnums(v)
+	f.validateStructs(v)
+	f.validateUnions(v)
+	f.validateExceptions(v)
+	f.validateServices(v)
+
+	if len(v.errors) > 0 {
+		return v.errors
 	}
 	return nil
 }
 
-func (f *Frugal) validateNamespaces() error {
+func (f *Frugal) validateNamespaces(v *validator) {
 	for _, namespace := range f.Namespaces {
 		_, vendor := namespace.Annotations.Vendor()
 		if namespace.Wildcard() && vendor {
-			return fmt.Errorf("\"%s\" annotation not compatible with * namespace", VendorAnnotation)
+			v.errorf(namespace.Pos, "\"%s\" annotation not compatible with * namespace", VendorAnnotation)
 		}
 	}
-	return nil
 }
 
-func (f *Frugal) validateIncludes() error {
+func (f *Frugal) validateIncludes(v *validator) {
 	includes := map[string]struct{}{}
 	for _, include := range f.Includes {
 		if _, ok := includes[include.Name]; ok {
-			return fmt.Errorf("Duplicate include: %s", include.Name)
+			v.errorf(include.Pos, "Duplicate include: %s", include.Name)
 		}
 		includes[include.Name] = struct{}{}
 	}
-	return nil
 }
 
-func (f *Frugal) validateConstants() error {
+func (f *Frugal) validateConstants(v *validator) {
 	for _, constant := range f.Constants {
 		if err := f.validateConstant(constant); err != nil {
-			return err
+			v.errorf(constant.Pos, "%s", err)
 		}
 	}
-
-	return nil
 }
 
 func (f *Frugal) validateConstant(constant *Constant) error {
@@ -1136,55 +1242,65 @@ func (f *Frugal) validateConstant(constant *Constant) error {
 	return fmt.Errorf("Invalid constant name %s", name)
 }
 
-func (f *Frugal) validateTypedefs() error {
+func (f *Frugal) validateTypedefs(v *validator) {
 	for _, typedef := range f.Typedefs {
 		if !f.isValidType(typedef.Type) {
-			return fmt.Errorf("Invalid alias %s, type %s doesn't exist",
+			v.errorf(typedef.Pos, "Invalid alias %s, type %s doesn't exist",
 				typedef.Name, typedef.Type.Name)
 		}
 	}
-	return nil
 }
 
-func (f *Frugal) validateStructs() error {
-	for _, s := range f.Structs {
-		if err := f.validateStructLike(s); err != nil {
-			return err
+func (f *Frugal) validateEnums(v *validator) {
+	for _, enum := range f.Enums {
+		name, ok := enum.Annotations.UnknownValue()
+		if !ok {
+			continue
+		}
+		found := false
+		for _, value := range enum.Values {
+			if value.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.errorf(enum.Pos, "Invalid %s annotation on enum %s, %q is not a value of the enum",
+				UnknownValueAnnotation, enum.Name, name)
 		}
 	}
-	return nil
 }
 
-func (f *Frugal) validateUnions() error {
+func (f *Frugal) validateStructs(v *validator) {
+	for _, s := range f.Structs {
+		f.validateStructLike(v, s)
+	}
+}
+
+func (f *Frugal) validateUnions(v *validator) {
 	for _, union := range f.Unions {
-		if err := f.validateStructLike(union); err != nil {
-			return err
-		}
+		f.validateStructLike(v, union)
 	}
-	return nil
 }
 
-func (f *Frugal) validateExceptions() error {
+func (f *Frugal) validateExceptions(v *validator) {
 	for _, exception := range f.Exceptions {
-		if err := f.validateStructLike(exception); err != nil {
-			return err
-		}
+		f.validateStructLike(v, exception)
 	}
-	return nil
 }
 
-func (f *Frugal) validateStructLike(s *Struct) error {
+func (f *Frugal) validateStructLike(v *validator, s *Struct) {
 	ids := make(map[int]struct{})
 	for _, field := range s.Fields {
 		if !f.isValidType(field.Type) {
-			return fmt.Errorf("Invalid type %s on struct %s", field.Type.String(), s.Name)
+			v.errorf(field.Pos, "Invalid type %s on struct %s", field.Type.String(), s.Name)
 		}
 		if _, ok := ids[field.ID]; ok {
-			return fmt.Errorf("Duplicate field id %d in struct %s", field.ID, s.Name)
+			v.errorf(field.Pos, "Duplicate field id %d in struct %s", field.ID, s.Name)
 		}
 		ids[field.ID] = struct{}{}
+		f.validateConstraints(v, field)
 	}
-	return nil
 }
 
 func (f *Frugal) isValidType(typ *Type) bool {
@@ -1249,44 +1365,39 @@ func (f *Frugal) isValidType(typ *Type) bool {
 	return false
 }
 
-func (f *Frugal) validateServices(includes map[string]*Frugal) error {
+func (f *Frugal) validateServices(v *validator) {
 	for _, service := range f.Services {
-		if err := f.validateServiceTypes(service, includes); err != nil {
-			return err
-		}
-		if err := service.validate(); err != nil {
-			return err
-		}
+		f.validateServiceTypes(v, service)
+		service.validate(v)
 	}
-	return nil
 }
 
-func (f *Frugal) validateServiceTypes(service *Service, includes map[string]*Frugal) error {
+func (f *Frugal) validateServiceTypes(v *validator, service *Service) {
 	for _, method := range service.Methods {
 		if method.ReturnType != nil {
 			if !f.isValidType(method.ReturnType) {
-				return fmt.Errorf("Invalid return type %s for %s.%s",
+				v.errorf(method.Pos, "Invalid return type %s for %s.%s",
 					method.ReturnType.Name, service.Name, method.Name)
 			}
 		}
 		for _, field := range method.Arguments {
 			if !f.isValidType(field.Type) {
-				return fmt.Errorf("Invalid argument type %s for %s.%s",
+				v.errorf(field.Pos, "Invalid argument type %s for %s.%s",
 					field.Type.Name, service.Name, method.Name)
 			}
+			f.validateConstraints(v, field)
 		}
 		for _, field := range method.Exceptions {
 			if !f.isValidType(field.Type) {
-				return fmt.Errorf("Invalid exception type %s for %s.%s",
+				v.errorf(field.Pos, "Invalid exception type %s for %s.%s",
 					field.Type.Name, service.Name, method.Name)
 			}
 		}
 	}
-	return nil
 }
 
-func getConflictError(type_, name1, name2 string) error {
-	return fmt.Errorf("%s %s and %s conflict. Some languages do not support"+
+func conflictMessage(type_, name1, name2 string) string {
+	return fmt.Sprintf("%s %s and %s conflict. Some languages do not support"+
 		" exported lowercase classes/methods. Only one of %s or %s may be used.",
 		type_, name1, name2, name1, name2)
 }
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TargetConfig lists the targets to generate in a single invocation.
+type TargetConfig struct {
+	Targets []Target `yaml:"targets"`
+}
+
+// LoadTargets reads the targets listed in the given YAML file. Output
+// locations are relative to the working directory, as with the -out flag.
+func LoadTargets(file string) ([]Target, error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	config := &TargetConfig{}
+	if err := yaml.Unmarshal(contents, config); err != nil {
+		return nil, fmt.Errorf("invalid gen config %s: %s", file, err)
+	}
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("invalid gen config %s: no targets", file)
+	}
+	for i, target := range config.Targets {
+		if target.Gen == "" {
+			return nil, fmt.Errorf("invalid gen config %s: target %d has no gen", file, i+1)
+		}
+	}
+	return config.Targets, nil
+}
+
+// PairTargets pairs each gen with an output location. Outs may be empty to
+// use the default location of each language, have a single entry used by
+// every gen, or have one entry per gen.
+func PairTargets(gens, outs []string) ([]Target, error) {
+	if len(outs) > 1 && len(outs) != len(gens) {
+		return nil, fmt.Errorf("%d output locations given for %d languages", len(outs), len(gens))
+	}
+	targets := make([]Target, len(gens))
+	for i, gen := range gens {
+		targets[i].Gen = gen
+		switch len(outs) {
+		case 0:
+		case 1:
+			targets[i].Out = outs[0]
+		default:
+			targets[i].Out = outs[i]
+		}
+	}
+	return targets, nil
+}
@@ -16,6 +16,7 @@ library frugal;
 export 'src/frugal.dart'
     show
         BaseFTransportMonitor,
+        CanonicalJson,
         FAdapterTransport,
         FAsyncCallback,
         FAdapterTransport,
@@ -47,3 +47,4 @@ part 'frugal/transport/monitor_runner.dart';
 part 'frugal/transport/t_framed_transport.dart';
 part 'frugal/transport/t_memory_output_buffer.dart';
 part 'frugal/transport/t_memory_transport.dart';
+part 'frugal/util/canonical_json.dart';
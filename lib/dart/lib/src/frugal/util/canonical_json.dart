@@ -0,0 +1,370 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+part of frugal.src.frugal;
+
+/// Helpers for the canonical JSON form of Frugal types, used by code
+/// generated with the canonical_json option. See documentation/json.md for
+/// the format.
+///
+/// Generated types convert themselves to and from JSON values, which are maps
+/// with string keys, lists, strings, bools, numbers and null. Use [encode]
+/// and [decode] to convert JSON values to and from JSON text, e.g.
+/// `CanonicalJson.encode(struct.toJsonValue())` and
+/// `new Struct()..fromJsonValue(CanonicalJson.decode(json))`.
+class CanonicalJson {
+  static final RegExp _intPattern = new RegExp(r'^[+-]?[0-9]+$');
+  static final RegExp _doublePattern =
+      new RegExp(r'^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$');
+  static const Map<int, String> _shortEscapes = const {
+    0x22: r'\"',
+    0x5c: r'\\',
+    0x08: r'\b',
+    0x0c: r'\f',
+    0x0a: r'\n',
+    0x0d: r'\r',
+    0x09: r'\t',
+  };
+
+  /// Encodes a JSON value as canonical JSON.
+  static String encode(Object value) {
+    StringBuffer out = new StringBuffer();
+    _encode(value, out);
+    return out.toString();
+  }
+
+  /// Decodes JSON text into a JSON value.
+  static Object decode(String json) {
+    try {
+      return JSON.decode(json);
+    } on FormatException catch (e) {
+      throw new TProtocolError(
+          TProtocolErrorType.INVALID_DATA, 'invalid JSON: ${e.message}');
+    }
+  }
+
+  static void _encode(Object value, StringBuffer out) {
+    if (value == null || value is bool || value is int) {
+      out.write(value);
+    } else if (value is double) {
+      out.write(_formatDouble(value));
+    } else if (value is String) {
+      _encodeString(value, out);
+    } else if (value is Map) {
+      List<String> keys = new List<String>.from(value.keys)
+        ..sort(_compareCodePoints);
+      out.write('{');
+      for (int i = 0; i < keys.length; i++) {
+        if (i > 0) {
+          out.write(',');
+        }
+        _encodeString(keys[i], out);
+        out.write(':');
+        _encode(value[keys[i]], out);
+      }
+      out.write('}');
+    } else if (value is Iterable) {
+      out.write('[');
+      bool first = true;
+      for (Object elem in value) {
+        if (!first) {
+          out.write(',');
+        }
+        first = false;
+        _encode(elem, out);
+      }
+      out.write(']');
+    } else {
+      throw new ArgumentError('cannot encode $value as JSON');
+    }
+  }
+
+  static void _encodeString(String value, StringBuffer out) {
+    out.write('"');
+    for (int i = 0; i < value.length; i++) {
+      int c = value.codeUnitAt(i);
+      if (_shortEscapes.containsKey(c)) {
+        out.write(_shortEscapes[c]);
+      } else if (c < 0x20 ||
+          c == 0x3c ||
+          c == 0x3e ||
+          c == 0x26 ||
+          c == 0x2028 ||
+          c == 0x2029) {
+        out.write(r'\u');
+        out.write(c.toRadixString(16).padLeft(4, '0'));
+      } else if (_isHighSurrogate(c) &&
+          i + 1 < value.length &&
+          _isLowSurrogate(value.codeUnitAt(i + 1))) {
+        out.writeCharCode(c);
+        out.writeCharCode(value.codeUnitAt(++i));
+      } else if (_isHighSurrogate(c) || _isLowSurrogate(c)) {
+        out.writeCharCode(0xfffd);
+      } else {
+        out.writeCharCode(c);
+      }
+    }
+    out.write('"');
+  }
+
+  static bool _isHighSurrogate(int c) => c >= 0xd800 && c <= 0xdbff;
+
+  static bool _isLowSurrogate(int c) => c >= 0xdc00 && c <= 0xdfff;
+
+  // Orders strings by their code points, which is the order of their UTF-8
+  // bytes.
+  static int _compareCodePoints(String a, String b) {
+    List<int> x = a.runes.toList();
+    List<int> y = b.runes.toList();
+    for (int i = 0; i < x.length && i < y.length; i++) {
+      if (x[i] != y[i]) {
+        return x[i].compareTo(y[i]);
+      }
+    }
+    return x.length.compareTo(y.length);
+  }
+
+  // Formats a finite double as JavaScript's Number.prototype.toString would,
+  // using the shortest digits which read back as the same value.
+  static String _formatDouble(double value) {
+    if (value == 0) {
+      return value.isNegative ? '-0' : '0';
+    }
+    String sign = value < 0 ? '-' : '';
+    String repr = value.abs().toString();
+    int e = repr.indexOf('e');
+    String mantissa = e < 0 ? repr : repr.substring(0, e);
+    int exp = e < 0 ? 0 : int.parse(repr.substring(e + 1));
+    int dot = mantissa.indexOf('.');
+    String whole = dot < 0 ? mantissa : mantissa.substring(0, dot);
+    String frac = dot < 0 ? '' : mantissa.substring(dot + 1);
+    String digits = (whole + frac).replaceFirst(new RegExp('^0+'), '');
+    // The value is 0.<digits> * 10^point.
+    int point =
+        whole.length + exp - ((whole + frac).length - digits.length);
+    digits = digits.replaceFirst(new RegExp(r'0+$'), '');
+    if (digits.length <= point && point <= 21) {
+      return sign + digits + '0' * (point - digits.length);
+    }
+    if (0 < point && point <= 21) {
+      return '$sign${digits.substring(0, point)}.${digits.substring(point)}';
+    }
+    if (-6 < point && point <= 0) {
+      return '${sign}0.${'0' * -point}$digits';
+    }
+    exp = point - 1;
+    if (digits.length > 1) {
+      digits = '${digits[0]}.${digits.substring(1)}';
+    }
+    return '$sign${digits}e${exp >= 0 ? '+' : '-'}${exp.abs()}';
+  }
+
+  /// Returns the JSON value of a double.
+  static Object doubleValue(double value) {
+    if (value.isNaN) {
+      return 'NaN';
+    }
+    if (value.isInfinite) {
+      return value > 0 ? 'Infinity' : '-Infinity';
+    }
+    return value.toDouble();
+  }
+
+  /// Returns the JSON value of a binary.
+  static String binaryValue(List<int> value) => BASE64.encode(value);
+
+  /// Returns the JSON value of an enum, its name if it is declared.
+  static Object enumValue(int value, Map<int, String> names) =>
+      names[value] ?? value;
+
+  /// Returns the JSON value of an enum generated with the use_enums option,
+  /// its name.
+  static String enumNameValue(Object value) =>
+      value.toString().substring(value.toString().indexOf('.') + 1);
+
+  /// Returns the object key for the JSON value of a map key.
+  static String key(Object value) => value.toString();
+
+  /// Returns the JSON values sorted by their encoding.
+  static List sorted(Iterable values) {
+    Map<Object, String> encoded = new Map<Object, String>.identity();
+    for (Object value in values) {
+      encoded[value] = encode(value);
+    }
+    return values.toList()
+      ..sort((a, b) => _compareCodePoints(encoded[a], encoded[b]));
+  }
+
+  /// Returns the JSON object for a map whose keys are written as strings.
+  static Map<String, Object> mapValue(
+      Map map, Object keyValue(dynamic key), Object valueValue(dynamic value)) {
+    Map<String, Object> object = {};
+    map.forEach((k, v) {
+      object[CanonicalJson.key(keyValue(k))] = valueValue(v);
+    });
+    return object;
+  }
+
+  /// Returns the sorted JSON entries for a map whose keys are not written as
+  /// strings.
+  static List entriesValue(
+      Map map, Object keyValue(dynamic key), Object valueValue(dynamic value)) {
+    List entries = [];
+    map.forEach((k, v) {
+      entries.add({'key': keyValue(k), 'value': valueValue(v)});
+    });
+    return sorted(entries);
+  }
+
+  /// Returns the JSON value if it is an object.
+  static Map<String, Object> parseObject(Object value) {
+    if (value is! Map) {
+      throw _invalid('a JSON object', value);
+    }
+    return new Map<String, Object>.from(value);
+  }
+
+  /// Returns the JSON value if it is an array.
+  static List parseList(Object value) {
+    if (value is! List) {
+      throw _invalid('a JSON array', value);
+    }
+    return new List.from(value);
+  }
+
+  /// Returns the map held by a JSON object whose keys are strings.
+  static Map parseMap(Object value, dynamic parseKey(String key),
+      dynamic parseValue(Object value)) {
+    Map map = {};
+    parseObject(value).forEach((k, v) {
+      map[parseKey(k)] = parseValue(v);
+    });
+    return map;
+  }
+
+  /// Returns the map held by a JSON array of entries.
+  static Map parseEntries(Object value, dynamic parseKey(Object key),
+      dynamic parseValue(Object value)) {
+    Map map = {};
+    for (Object entry in parseList(value)) {
+      Map<String, Object> object = parseObject(entry);
+      map[parseKey(object['key'])] = parseValue(object['value']);
+    }
+    return map;
+  }
+
+  /// Returns the bool held by the JSON value.
+  static bool parseBool(Object value) {
+    if (value is! bool) {
+      throw _invalid('a bool', value);
+    }
+    return value == true;
+  }
+
+  /// Returns the bool held by an object key.
+  static bool parseBoolKey(String value) {
+    if (value != 'true' && value != 'false') {
+      throw _invalid('a bool', value);
+    }
+    return value == 'true';
+  }
+
+  /// Returns the integer held by the JSON value, a number or a string, if it
+  /// fits in the given number of bits.
+  static int parseInt(Object value, int bits) {
+    int result;
+    if (value is String && _intPattern.hasMatch(value)) {
+      result = int.parse(value);
+    } else if (value is int) {
+      result = value;
+    } else {
+      throw _invalid('an integer', value);
+    }
+    if (result < -(1 << (bits - 1)) || result >= 1 << (bits - 1)) {
+      throw new TProtocolError(
+          TProtocolErrorType.INVALID_DATA, '$value out of range for i$bits');
+    }
+    return result;
+  }
+
+  /// Returns the double held by the JSON value, a number or a string.
+  static double parseDouble(Object value) {
+    if (value is num) {
+      return value.toDouble();
+    }
+    if (value == 'NaN') {
+      return double.NAN;
+    }
+    if (value == 'Infinity') {
+      return double.INFINITY;
+    }
+    if (value == '-Infinity') {
+      return double.NEGATIVE_INFINITY;
+    }
+    if (value is String && _doublePattern.hasMatch(value)) {
+      return double.parse(value);
+    }
+    throw _invalid('a double', value);
+  }
+
+  /// Returns the string held by the JSON value.
+  static String parseString(Object value) {
+    if (value is! String) {
+      throw _invalid('a string', value);
+    }
+    return value.toString();
+  }
+
+  /// Returns the bytes held by the base64 JSON value.
+  static Uint8List parseBinary(Object value) {
+    try {
+      return new Uint8List.fromList(BASE64.decode(parseString(value)));
+    } on FormatException {
+      throw _invalid('base64', value);
+    }
+  }
+
+  /// Returns the enum value held by the JSON value, a name or a number.
+  static int parseEnum(Object value, Map<int, String> names) {
+    if (value is String && !_intPattern.hasMatch(value)) {
+      for (int enumValue in names.keys) {
+        if (names[enumValue] == value) {
+          return enumValue;
+        }
+      }
+      throw new TProtocolError(
+          TProtocolErrorType.INVALID_DATA, '$value is not a valid enum name');
+    }
+    return parseInt(value, 32);
+  }
+
+  /// Returns the enum generated with the use_enums option held by the JSON
+  /// value, a name or a number.
+  static dynamic parseEnumName(
+      Object value, List values, dynamic deserialize(int value)) {
+    if (value is String && !_intPattern.hasMatch(value)) {
+      for (Object enumValue in values) {
+        if (enumNameValue(enumValue) == value) {
+          return enumValue;
+        }
+      }
+      throw new TProtocolError(
+          TProtocolErrorType.INVALID_DATA, '$value is not a valid enum name');
+    }
+    return deserialize(parseInt(value, 32));
+  }
+
+  static TProtocolError _invalid(String expected, Object value) =>
+      new TProtocolError(
+          TProtocolErrorType.INVALID_DATA, 'expected $expected, got $value');
+}
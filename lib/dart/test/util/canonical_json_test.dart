@@ -0,0 +1,71 @@
+import "package:frugal/frugal.dart";
+import "package:test/test.dart";
+import "package:thrift/thrift.dart";
+
+void main() {
+  group('CanonicalJson', () {
+    test('encodes doubles', () {
+      List<List> cases = [
+        [0.0, '0'],
+        [-0.0, '-0'],
+        [100.0, '100'],
+        [-1.5, '-1.5'],
+        [0.1, '0.1'],
+        [0.000001, '0.000001'],
+        [1e-7, '1e-7'],
+        [123456789012345680000.0, '123456789012345680000'],
+        [1e21, '1e+21'],
+        [1.2345e-300, '1.2345e-300'],
+        [double.NAN, '"NaN"'],
+        [double.NEGATIVE_INFINITY, '"-Infinity"'],
+      ];
+      for (List c in cases) {
+        expect(CanonicalJson.encode(CanonicalJson.doubleValue(c[0])), c[1]);
+      }
+    });
+
+    test('encodes strings', () {
+      expect(
+          CanonicalJson.encode(
+              '"\\\b\f\n\r\t\x01<\u2028\u00e9\u{1f600}\ud83d'),
+          r'"\"\\\b\f\n\r\t\u0001\u003c\u2028' '\u00e9\u{1f600}\ufffd"');
+    });
+
+    test('sorts object keys', () {
+      expect(
+          CanonicalJson.encode({
+            '2': {'b': true, 'a': null},
+            '10': []
+          }),
+          '{"10":[],"2":{"a":null,"b":true}}');
+    });
+
+    test('sorts values by their encoding', () {
+      expect(CanonicalJson.sorted(['b', 10, 'a', 2]), ['a', 'b', 10, 2]);
+    });
+
+    test('parses integers', () {
+      expect(CanonicalJson.parseInt('-5', 8), -5);
+      expect(CanonicalJson.parseInt(-128, 8), -128);
+      for (Object value in [128, '1.0', ' 1', 1.5, true, null]) {
+        expect(() => CanonicalJson.parseInt(value, 8),
+            throwsA(new isInstanceOf<TProtocolError>()));
+      }
+    });
+
+    test('parses doubles', () {
+      expect(CanonicalJson.parseDouble('1.5'), 1.5);
+      expect(CanonicalJson.parseDouble(2), 2.0);
+      expect(CanonicalJson.parseDouble('NaN').isNaN, isTrue);
+      for (Object value in ['inf', '', true]) {
+        expect(() => CanonicalJson.parseDouble(value),
+            throwsA(new isInstanceOf<TProtocolError>()));
+      }
+    });
+
+    test('rejects invalid JSON', () {
+      expect(() => CanonicalJson.decode('{"scale":NaN}'),
+          throwsA(new isInstanceOf<TProtocolError>()));
+    });
+  });
+}
@@ -191,6 +191,8 @@ func (f *fAdapterTransport) Oneway(ctx FContext, payload []byte) error {
 	select {
 	case err := <-errorC:
 		return err
+	case <-ToContext(ctx).Done():
+		return contextError(ctx)
 	case <-time.After(ctx.Timeout()):
 		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
 	}
@@ -213,6 +215,8 @@ func (f *fAdapterTransport) Request(ctx FContext, payload []byte) (thrift.TTrans
 		return &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(result)}, nil
 	case err := <-errorC:
 		return nil, err
+	case <-ToContext(ctx).Done():
+		return nil, contextError(ctx)
 	case <-time.After(ctx.Timeout()):
 		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
 	}
@@ -14,6 +14,7 @@
 package frugal
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/mattrobenolt/gocql/uuid"
 )
 
@@ -61,6 +63,11 @@ const (
 //		1)	The CorrelationID should be stored as a request header with the
 //			header name "_cid"
 //		2)	Threadsafe
+//
+// FContexts created by this package also implement context.Context. Use
+// NewFContextFromContext to derive an FContext from an existing
+// context.Context so that its deadline, cancellation, and values carry
+// through to transports and handlers, and ToContext to get back at it.
 type FContext interface {
 	// CorrelationID returns the correlation id for the context.
 	CorrelationID() string
@@ -99,8 +106,9 @@ type FContext interface {
 // TODO 3.0 consider adding this to the FContext interface.
 func Clone(ctx FContext) FContext {
 	clone := &FContextImpl{
-		requestHeaders: ctx.RequestHeaders(),
+		requestHeaders:  ctx.RequestHeaders(),
 		responseHeaders: ctx.ResponseHeaders(),
+		ctx:             ToContext(ctx),
 	}
 	clone.requestHeaders[opIDHeader] = getNextOpID()
 	return clone
@@ -112,11 +120,65 @@ func getNextOpID() string {
 	return strconv.FormatUint(atomic.AddUint64(&nextOpID, 1), 10)
 }
 
-// FContextImpl is an implementation of FContext.
+// ToContext returns the context.Context backing the given FContext. If the
+// FContext does not implement context.Context, context.Background() is
+// returned.
+// TODO 3.0 consider adding this to the FContext interface.
+func ToContext(ctx FContext) context.Context {
+	if c, ok := ctx.(context.Context); ok {
+		return c
+	}
+	return context.Background()
+}
+
+// FContextFromContext returns the FContext for the given context.Context. If
+// ctx is itself an FContext, it is returned as is. If an FContext was
+// attached with WithFContext, or ctx is derived from an FContext, that FContext
+// is returned bound to ctx, with its timeout set by the deadline of ctx.
+// Otherwise, a new FContext is derived from ctx with NewFContextFromContext.
+func FContextFromContext(ctx context.Context) FContext {
+	if fctx, ok := ctx.(FContext); ok {
+		return fctx
+	}
+	if fctx, ok := ctx.Value(fContextKey{}).(FContext); ok {
+		bound := &FContextImpl{
+			requestHeaders:  fctx.RequestHeaders(),
+			responseHeaders: fctx.ResponseHeaders(),
+			ctx:             ctx,
+			parent:          fctx,
+		}
+		bound.requestHeaders[opIDHeader] = getNextOpID()
+		if deadline, ok := ctx.Deadline(); ok {
+			bound.SetTimeout(remaining(deadline))
+		}
+		return bound
+	}
+	return NewFContextFromContext(ctx, "")
+}
+
+// WithFContext returns a copy of parent carrying the given FContext. The
+// headers of the FContext can be retrieved with FContextFromContext.
+func WithFContext(parent context.Context, fctx FContext) context.Context {
+	return context.WithValue(parent, fContextKey{}, fctx)
+}
+
+// fContextKey is the context.Context value key used by WithFContext.
+type fContextKey struct{}
+
+// FContextImpl is an implementation of FContext. It also implements
+// context.Context, delegating to the context.Context it was created from.
 type FContextImpl struct {
 	requestHeaders  map[string]string
 	responseHeaders map[string]string
 	mu              sync.RWMutex
+
+	// ctx is the context.Context this FContext was created from, nil if
+	// there is none.
+	ctx context.Context
+
+	// parent is the FContext attached with WithFContext this FContext was
+	// created from, if any. Response headers are copied back to it.
+	parent FContext
 }
 
 // NewFContext returns a Context for the given correlation id. If an empty
@@ -139,6 +201,19 @@ func NewFContext(correlationID string) FContext {
 	return ctx
 }
 
+// NewFContextFromContext returns an FContext for the given correlation id
+// which is backed by the given context.Context. If ctx has a deadline, the
+// request timeout is the time remaining until it. Cancelling ctx aborts any
+// in-flight request made with the returned FContext.
+func NewFContextFromContext(ctx context.Context, correlationID string) FContext {
+	fctx := NewFContext(correlationID).(*FContextImpl)
+	fctx.ctx = ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		fctx.SetTimeout(remaining(deadline))
+	}
+	return fctx
+}
+
 // CorrelationID returns the correlation id for the context.
 func (c *FContextImpl) CorrelationID() string {
 	c.mu.RLock()
@@ -182,6 +257,9 @@ func (c *FContextImpl) AddResponseHeader(name, value string) FContext {
 	c.mu.Lock()
 	c.responseHeaders[name] = value
 	c.mu.Unlock()
+	if c.parent != nil {
+		c.parent.AddResponseHeader(name, value)
+	}
 	return c
 }
 
@@ -213,16 +291,79 @@ func (c *FContextImpl) SetTimeout(timeout time.Duration) FContext {
 	return c
 }
 
-// Timeout returns the request timeout.
+// Timeout returns the request timeout. If the FContext is backed by a
+// context.Context with a deadline, the timeout is capped at the time
+// remaining until that deadline.
 func (c *FContextImpl) Timeout() time.Duration {
 	c.mu.RLock()
 	timeoutMillisStr := c.requestHeaders[timeoutHeader]
 	c.mu.RUnlock()
-	timeoutMillis, err := strconv.ParseInt(timeoutMillisStr, 10, 64)
-	if err != nil {
-		return defaultTimeout
+	timeout := defaultTimeout
+	if timeoutMillis, err := strconv.ParseInt(timeoutMillisStr, 10, 64); err == nil {
+		timeout = time.Millisecond * time.Duration(timeoutMillis)
+	}
+	if deadline, ok := c.Deadline(); ok {
+		if r := remaining(deadline); r < timeout {
+			timeout = r
+		}
+	}
+	return timeout
+}
+
+// Deadline returns the deadline of the backing context.Context, if any.
+func (c *FContextImpl) Deadline() (time.Time, bool) {
+	if c.ctx == nil {
+		return time.Time{}, false
+	}
+	return c.ctx.Deadline()
+}
+
+// Done returns the done channel of the backing context.Context. It returns
+// nil if the FContext has no backing context.Context.
+func (c *FContextImpl) Done() <-chan struct{} {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Done()
+}
+
+// Err returns the error of the backing context.Context, if any.
+func (c *FContextImpl) Err() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
+// Value returns the value associated with key in the backing
+// context.Context, if any. The FContext itself is the value of the key used by
+// WithFContext, so contexts derived from it carry its headers.
+func (c *FContextImpl) Value(key interface{}) interface{} {
+	if _, ok := key.(fContextKey); ok {
+		return c
+	}
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Value(key)
+}
+
+// remaining returns the time left until the given deadline, never less than
+// zero.
+func remaining(deadline time.Time) time.Duration {
+	if r := time.Until(deadline); r > 0 {
+		return r
+	}
+	return 0
+}
+
+// contextError returns the TTransportException for a request whose
+// context.Context is done.
+func contextError(ctx FContext) error {
+	if ToContext(ctx).Err() == context.Canceled {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_CANCELLED, "frugal: request cancelled")
 	}
-	return time.Millisecond * time.Duration(timeoutMillis)
+	return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
 }
 
 // setRequestOpID sets the request operation id for context.
@@ -14,10 +14,13 @@
 package frugal
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
+	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -140,3 +143,113 @@ func TestFContextClone(t *testing.T) {
 	_, ok := cloned.RequestHeader("baz")
 	assert.False(t, ok)
 }
+
+// Ensures NewFContextFromContext derives the timeout from the deadline of the
+// given context.Context and exposes it through ToContext.
+func TestNewFContextFromContext(t *testing.T) {
+	assert := assert.New(t)
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	ctx := NewFContextFromContext(parent, "fooid")
+
+	assert.Equal("fooid", ctx.CorrelationID())
+	assert.True(ctx.Timeout() <= time.Second)
+	assert.True(ctx.Timeout() > 0)
+	deadline, ok := ToContext(ctx).Deadline()
+	assert.True(ok)
+	parentDeadline, _ := parent.Deadline()
+	assert.Equal(parentDeadline, deadline)
+
+	// SetTimeout cannot extend the deadline.
+	ctx.SetTimeout(time.Minute)
+	assert.True(ctx.Timeout() <= time.Second)
+}
+
+// Ensures an FContext backed by a context.Context without a deadline uses the
+// default timeout and is cancelled with its parent.
+func TestNewFContextFromContextCancel(t *testing.T) {
+	assert := assert.New(t)
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := NewFContextFromContext(parent, "")
+
+	assert.Equal(defaultTimeout, ctx.Timeout())
+	assert.Nil(ToContext(ctx).Err())
+	cancel()
+	<-ToContext(ctx).Done()
+	assert.Equal(context.Canceled, ToContext(ctx).Err())
+	err := contextError(ctx)
+	assert.Equal(TRANSPORT_EXCEPTION_CANCELLED, err.(thrift.TTransportException).TypeId())
+}
+
+// Ensures ToContext returns a context.Context which is never done for an
+// FContext created with NewFContext.
+func TestToContextBackground(t *testing.T) {
+	assert := assert.New(t)
+	ctx := ToContext(NewFContext(""))
+	assert.Nil(ctx.Done())
+	assert.Nil(ctx.Err())
+	_, ok := ctx.Deadline()
+	assert.False(ok)
+}
+
+// Ensures FContextFromContext returns the FContext attached with
+// WithFContext and copies response headers back to it.
+func TestFContextFromContext(t *testing.T) {
+	assert := assert.New(t)
+	fctx := NewFContext("fooid")
+	fctx.AddRequestHeader("foo", "bar")
+	ctx := WithFContext(context.Background(), fctx)
+
+	bound := FContextFromContext(ctx)
+
+	assert.Equal("fooid", bound.CorrelationID())
+	foo, _ := bound.RequestHeader("foo")
+	assert.Equal("bar", foo)
+	boundOpID, _ := getOpID(bound)
+	opID, _ := getOpID(fctx)
+	assert.NotEqual(opID, boundOpID)
+	bound.AddResponseHeader("baz", "qux")
+	baz, _ := fctx.ResponseHeader("baz")
+	assert.Equal("qux", baz)
+
+	// An FContext is returned as is.
+	assert.Equal(fctx, FContextFromContext(fctx.(context.Context)))
+
+	// A new FContext is created for a plain context.Context.
+	assert.NotEmpty(FContextFromContext(context.Background()).CorrelationID())
+}
+
+// Ensures FContextFromContext returns the headers of an FContext a
+// context.Context was derived from.
+func TestFContextFromDerivedContext(t *testing.T) {
+	assert := assert.New(t)
+	fctx := NewFContextFromContext(context.Background(), "fooid")
+	fctx.AddRequestHeader("foo", "bar")
+	ctx, cancel := context.WithCancel(ToContext(fctx))
+	defer cancel()
+
+	bound := FContextFromContext(ctx)
+
+	assert.Equal("fooid", bound.CorrelationID())
+	foo, _ := bound.RequestHeader("foo")
+	assert.Equal("bar", foo)
+}
+
+// Ensures the timeout of an FContext attached with WithFContext is set by the
+// deadline of the context.Context it is bound to.
+func TestFContextFromContextDeadline(t *testing.T) {
+	assert := assert.New(t)
+	fctx := NewFContext("fooid")
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	bound := FContextFromContext(WithFContext(parent, fctx))
+
+	timeoutMillis, _ := bound.RequestHeader(timeoutHeader)
+	timeout, err := strconv.ParseInt(timeoutMillis, 10, 64)
+	assert.Nil(err)
+	assert.True(timeout <= 1000)
+	assert.True(timeout > 0)
+	assert.Equal(defaultTimeout, fctx.Timeout())
+}
@@ -34,6 +34,10 @@ const (
 	// TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE is a TTransportException
 	// error type indicating the response exceeded the size limit.
 	TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE = 101
+
+	// TRANSPORT_EXCEPTION_CANCELLED is a TTransportException error type
+	// indicating the context.Context backing a request was cancelled.
+	TRANSPORT_EXCEPTION_CANCELLED = 102
 )
 
 // TApplicationException types used in frugal instantiated
@@ -55,6 +59,11 @@ const (
 	// APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE is a TApplicationException
 	// error type indicating the response exceeded the size limit.
 	APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE = 100
+
+	// APPLICATION_EXCEPTION_INVALID_ARGUMENT is a TApplicationException
+	// error type indicating the request arguments violated their declared
+	// constraints.
+	APPLICATION_EXCEPTION_INVALID_ARGUMENT = 101
 )
 
 // IsErrTooLarge indicates if the given error is a TTransportException
@@ -152,6 +152,7 @@ func (p *TFramedTransport) readFrameHeader() (uint32, error) {
 	}
 	size := binary.BigEndian.Uint32(buf)
 	if size < 0 || size > p.maxLength {
+		stats().FrameTooLarge("framed")
 		return 0, thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
 			fmt.Sprintf("frugal: incorrect frame size (%d)", size))
 	}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package frugaltest provides in-memory transports for testing Frugal
+// clients, handlers, publishers, and subscribers without a message broker or
+// network listener.
+//
+// A loopback FTransport feeds requests straight into an FProcessor:
+//
+//	processor := example.NewFFooProcessor(handler)
+//	transport := frugaltest.NewLoopbackTransport(processor, protocolFactory)
+//	provider := frugal.NewFServiceProvider(transport, protocolFactory)
+//	client := example.NewFFooClient(provider)
+//
+// A Broker delivers published messages to subscribers of matching topics:
+//
+//	broker := frugaltest.NewBroker()
+//	provider := frugal.NewFScopeProvider(
+//		frugaltest.NewPublisherTransportFactory(broker),
+//		frugaltest.NewSubscriberTransportFactory(broker),
+//		protocolFactory)
+package frugaltest
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// loopbackTransport is an FTransport which processes requests in-process
+// with an FProcessor.
+type loopbackTransport struct {
+	processor    frugal.FProcessor
+	protoFactory *frugal.FProtocolFactory
+
+	mu        sync.RWMutex
+	isOpen    bool
+	closeChan chan error
+}
+
+// NewLoopbackTransport returns an FTransport which passes request frames
+// directly to the given FProcessor and returns its responses. Requests
+// respect the timeout and cancellation of their FContext.
+func NewLoopbackTransport(processor frugal.FProcessor, protoFactory *frugal.FProtocolFactory) frugal.FTransport {
+	return &loopbackTransport{processor: processor, protoFactory: protoFactory}
+}
+
+// Open prepares the transport to send data.
+func (l *loopbackTransport) Open() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.isOpen {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: transport already open")
+	}
+	l.isOpen = true
+	l.closeChan = make(chan error, 1)
+	return nil
+}
+
+// IsOpen returns true if the transport is open, false otherwise.
+func (l *loopbackTransport) IsOpen() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.isOpen
+}
+
+// Close closes the transport.
+func (l *loopbackTransport) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.isOpen {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: transport not open")
+	}
+	l.isOpen = false
+	l.closeChan <- nil
+	close(l.closeChan)
+	return nil
+}
+
+// Closed channel receives the cause of an FTransport close (nil if clean
+// close).
+func (l *loopbackTransport) Closed() <-chan error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.closeChan
+}
+
+// SetMonitor is a no-op since the transport is never closed uncleanly.
+func (l *loopbackTransport) SetMonitor(frugal.FTransportMonitor) {}
+
+// GetRequestSizeLimit returns 0 since requests are unbounded.
+func (l *loopbackTransport) GetRequestSizeLimit() uint {
+	return 0
+}
+
+// Oneway processes the given frame and discards the response.
+func (l *loopbackTransport) Oneway(ctx frugal.FContext, payload []byte) error {
+	_, err := l.Request(ctx, payload)
+	return err
+}
+
+// Request processes the given frame and returns the response.
+func (l *loopbackTransport) Request(ctx frugal.FContext, payload []byte) (thrift.TTransport, error) {
+	if !l.IsOpen() {
+		return nil, thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: transport not open")
+	}
+	if len(payload) < 4 {
+		return nil, thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_UNKNOWN,
+			"frugal: invalid frame")
+	}
+
+	type result struct {
+		output *bytes.Buffer
+		err    error
+	}
+	resultC := make(chan result, 1)
+	go func() {
+		input := &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(payload[4:])} // Discard frame size
+		output := new(bytes.Buffer)
+		iprot := l.protoFactory.GetProtocol(input)
+		oprot := l.protoFactory.GetProtocol(&thrift.TMemoryBuffer{Buffer: output})
+		resultC <- result{output: output, err: l.processor.Process(iprot, oprot)}
+	}()
+
+	select {
+	case r := <-resultC:
+		if r.err != nil {
+			return nil, thrift.NewTTransportExceptionFromError(r.err)
+		}
+		if r.output.Len() == 0 {
+			return nil, nil
+		}
+		return &thrift.TMemoryBuffer{Buffer: r.output}, nil
+	case <-frugal.ToContext(ctx).Done():
+		if frugal.ToContext(ctx).Err() == context.Canceled {
+			return nil, thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_CANCELLED,
+				"frugal: request cancelled")
+		}
+		return nil, thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT,
+			"frugal: request timed out")
+	case <-time.After(ctx.Timeout()):
+		return nil, thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT,
+			"frugal: request timed out")
+	}
+}
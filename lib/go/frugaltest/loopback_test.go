@@ -0,0 +1,119 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugaltest
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var protoFactory = frugal.NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+
+// Ensures requests are processed by the FProcessor and its response is
+// returned.
+func TestLoopbackTransportRequest(t *testing.T) {
+	assert := assert.New(t)
+	transport := NewLoopbackTransport(&echoProcessor{}, protoFactory)
+	assert.Nil(transport.Open())
+
+	ctx := frugal.NewFContext("cid")
+	result, err := transport.Request(ctx, requestFrame(t, ctx, "ping"))
+	assert.Nil(err)
+
+	rprot := protoFactory.GetProtocol(result)
+	assert.Nil(rprot.ReadResponseHeader(ctx))
+	body, err := ioutil.ReadAll(result)
+	assert.Nil(err)
+	assert.Equal("ping", string(body))
+
+	assert.Nil(transport.Close())
+	_, err = transport.Request(ctx, requestFrame(t, ctx, "ping"))
+	assert.Equal(frugal.TRANSPORT_EXCEPTION_NOT_OPEN, err.(thrift.TTransportException).TypeId())
+}
+
+// Ensures requests respect the timeout and cancellation of the FContext and
+// processor errors are returned.
+func TestLoopbackTransportErrors(t *testing.T) {
+	assert := assert.New(t)
+	transport := NewLoopbackTransport(&echoProcessor{delay: time.Second}, protoFactory)
+	assert.Nil(transport.Open())
+
+	ctx := frugal.NewFContext("")
+	ctx.SetTimeout(10 * time.Millisecond)
+	_, err := transport.Request(ctx, requestFrame(t, ctx, "ping"))
+	assert.Equal(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctx = frugal.NewFContextFromContext(cancelCtx, "")
+	_, err = transport.Request(ctx, requestFrame(t, ctx, "ping"))
+	assert.Equal(frugal.TRANSPORT_EXCEPTION_CANCELLED, err.(thrift.TTransportException).TypeId())
+
+	transport = NewLoopbackTransport(&echoProcessor{err: errors.New("boom")}, protoFactory)
+	assert.Nil(transport.Open())
+	ctx = frugal.NewFContext("")
+	_, err = transport.Request(ctx, requestFrame(t, ctx, "ping"))
+	assert.Equal("boom", err.Error())
+}
+
+// requestFrame returns a framed request with the headers of ctx and the given
+// body.
+func requestFrame(t *testing.T, ctx frugal.FContext, body string) []byte {
+	buffer := frugal.NewTMemoryOutputBuffer(0)
+	oprot := protoFactory.GetProtocol(buffer)
+	if err := oprot.WriteRequestHeader(ctx); err != nil {
+		t.Fatal(err)
+	}
+	buffer.Write([]byte(body))
+	return buffer.Bytes()
+}
+
+// echoProcessor responds with the body of the request.
+type echoProcessor struct {
+	delay time.Duration
+	err   error
+}
+
+func (e *echoProcessor) Process(iprot, oprot *frugal.FProtocol) error {
+	time.Sleep(e.delay)
+	if e.err != nil {
+		return e.err
+	}
+	ctx, err := iprot.ReadRequestHeader()
+	if err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(iprot.Transport())
+	if err != nil {
+		return err
+	}
+	if err := oprot.WriteResponseHeader(ctx); err != nil {
+		return err
+	}
+	_, err = oprot.Transport().Write(body)
+	return err
+}
+
+func (e *echoProcessor) AddMiddleware(frugal.ServiceMiddleware) {}
+
+func (e *echoProcessor) Annotations() map[string]map[string]string {
+	return nil
+}
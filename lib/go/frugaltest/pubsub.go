@@ -0,0 +1,226 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugaltest
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+const (
+	// topicDelimiter separates the tokens of a topic.
+	topicDelimiter = "."
+
+	// singleWildcard matches exactly one token of a topic.
+	singleWildcard = "*"
+
+	// fullWildcard matches one or more trailing tokens of a topic.
+	fullWildcard = ">"
+)
+
+// Broker is an in-memory message broker. Published messages are delivered
+// synchronously to every subscriber whose topic matches, before Publish
+// returns. Subscription topics may contain NATS-style wildcards: "*" matches
+// a single token and a trailing ">" matches one or more tokens.
+type Broker struct {
+	mu            sync.RWMutex
+	subscriptions map[*subscriberTransport]string
+}
+
+// NewBroker returns a new, empty Broker.
+func NewBroker() *Broker {
+	return &Broker{subscriptions: make(map[*subscriberTransport]string)}
+}
+
+// publish delivers the frame to the subscribers of matching topics.
+func (b *Broker) publish(topic string, frame []byte) {
+	b.mu.RLock()
+	var callbacks []frugal.FAsyncCallback
+	for sub, pattern := range b.subscriptions {
+		if matchTopic(pattern, topic) {
+			callbacks = append(callbacks, sub.callback)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		// Each subscriber gets its own copy, without the frame size.
+		data := make([]byte, len(frame)-4)
+		copy(data, frame[4:])
+		if err := callback(&thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(data)}); err != nil {
+			logrus.Warn("frugal: error executing callback: ", err)
+		}
+	}
+}
+
+func (b *Broker) subscribe(sub *subscriberTransport, topic string) {
+	b.mu.Lock()
+	b.subscriptions[sub] = topic
+	b.mu.Unlock()
+}
+
+func (b *Broker) unsubscribe(sub *subscriberTransport) {
+	b.mu.Lock()
+	delete(b.subscriptions, sub)
+	b.mu.Unlock()
+}
+
+// matchTopic indicates if the topic matches the pattern.
+func matchTopic(pattern, topic string) bool {
+	patternTokens := strings.Split(pattern, topicDelimiter)
+	topicTokens := strings.Split(topic, topicDelimiter)
+	for i, token := range patternTokens {
+		if token == fullWildcard && i == len(patternTokens)-1 {
+			return len(topicTokens) > i
+		}
+		if i >= len(topicTokens) {
+			return false
+		}
+		if token != singleWildcard && token != topicTokens[i] {
+			return false
+		}
+	}
+	return len(patternTokens) == len(topicTokens)
+}
+
+type publisherTransportFactory struct {
+	broker *Broker
+}
+
+// NewPublisherTransportFactory returns an FPublisherTransportFactory which
+// produces FPublisherTransports publishing to the given Broker.
+func NewPublisherTransportFactory(broker *Broker) frugal.FPublisherTransportFactory {
+	return &publisherTransportFactory{broker: broker}
+}
+
+// GetTransport returns a new FPublisherTransport.
+func (p *publisherTransportFactory) GetTransport() frugal.FPublisherTransport {
+	return &publisherTransport{broker: p.broker}
+}
+
+// publisherTransport is an FPublisherTransport which publishes to a Broker.
+type publisherTransport struct {
+	broker *Broker
+	mu     sync.RWMutex
+	isOpen bool
+}
+
+// Open opens the transport.
+func (p *publisherTransport) Open() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.isOpen {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: transport already open")
+	}
+	p.isOpen = true
+	return nil
+}
+
+// Close closes the transport.
+func (p *publisherTransport) Close() error {
+	p.mu.Lock()
+	p.isOpen = false
+	p.mu.Unlock()
+	return nil
+}
+
+// IsOpen returns true if the transport is open, false otherwise.
+func (p *publisherTransport) IsOpen() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.isOpen
+}
+
+// GetPublishSizeLimit returns 0 since payloads are unbounded.
+func (p *publisherTransport) GetPublishSizeLimit() uint {
+	return 0
+}
+
+// Publish delivers the framed payload to the subscribers of the topic.
+func (p *publisherTransport) Publish(topic string, data []byte) error {
+	if !p.IsOpen() {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: transport not open")
+	}
+	if len(data) < 4 {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_UNKNOWN,
+			"frugal: invalid frame")
+	}
+	p.broker.publish(topic, data)
+	return nil
+}
+
+type subscriberTransportFactory struct {
+	broker *Broker
+}
+
+// NewSubscriberTransportFactory returns an FSubscriberTransportFactory which
+// produces FSubscriberTransports subscribing to the given Broker.
+func NewSubscriberTransportFactory(broker *Broker) frugal.FSubscriberTransportFactory {
+	return &subscriberTransportFactory{broker: broker}
+}
+
+// GetTransport returns a new FSubscriberTransport.
+func (s *subscriberTransportFactory) GetTransport() frugal.FSubscriberTransport {
+	return &subscriberTransport{broker: s.broker}
+}
+
+// subscriberTransport is an FSubscriberTransport which subscribes to a
+// Broker.
+type subscriberTransport struct {
+	broker       *Broker
+	callback     frugal.FAsyncCallback
+	mu           sync.RWMutex
+	isSubscribed bool
+}
+
+// Subscribe subscribes to the topic, which may contain wildcards.
+func (s *subscriberTransport) Subscribe(topic string, callback frugal.FAsyncCallback) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.isSubscribed {
+		return thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: already subscribed")
+	}
+	s.callback = callback
+	s.isSubscribed = true
+	s.broker.subscribe(s, topic)
+	return nil
+}
+
+// Unsubscribe unsubscribes from the topic.
+func (s *subscriberTransport) Unsubscribe() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.isSubscribed {
+		return nil
+	}
+	s.broker.unsubscribe(s)
+	s.isSubscribed = false
+	return nil
+}
+
+// IsSubscribed returns true if the transport is subscribed to a topic, false
+// otherwise.
+func (s *subscriberTransport) IsSubscribed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.isSubscribed
+}
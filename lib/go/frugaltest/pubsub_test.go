@@ -0,0 +1,95 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugaltest
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures topics are matched against subscriptions with wildcards.
+func TestMatchTopic(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(matchTopic("foo.bar", "foo.bar"))
+	assert.False(matchTopic("foo.bar", "foo.baz"))
+	assert.False(matchTopic("foo.bar", "foo.bar.baz"))
+	assert.False(matchTopic("foo.bar.baz", "foo.bar"))
+	assert.True(matchTopic("foo.*.baz", "foo.bar.baz"))
+	assert.False(matchTopic("foo.*", "foo.bar.baz"))
+	assert.True(matchTopic("foo.>", "foo.bar.baz"))
+	assert.True(matchTopic("foo.>", "foo.bar"))
+	assert.False(matchTopic("foo.>", "foo"))
+	assert.True(matchTopic(">", "foo"))
+}
+
+// Ensures published messages are delivered to the subscribers of matching
+// topics until they unsubscribe.
+func TestBrokerPublishSubscribe(t *testing.T) {
+	assert := assert.New(t)
+	broker := NewBroker()
+	publisher := NewPublisherTransportFactory(broker).GetTransport()
+	subscribers := NewSubscriberTransportFactory(broker)
+
+	received := make(map[string][]string)
+	subscribe := func(topic string) {
+		sub := subscribers.GetTransport()
+		assert.Nil(sub.Subscribe(topic, func(tr thrift.TTransport) error {
+			data, err := ioutil.ReadAll(tr)
+			received[topic] = append(received[topic], string(data))
+			return err
+		}))
+		assert.True(sub.IsSubscribed())
+	}
+	subscribe("v1.Events.created")
+	subscribe("v1.Events.*")
+	subscribe("v1.>")
+	subscribe("v2.>")
+
+	assert.NotNil(publisher.Publish("v1.Events.created", []byte{0, 0, 0, 1, 'a'}))
+	assert.Nil(publisher.Open())
+	assert.True(publisher.IsOpen())
+	assert.Nil(publisher.Publish("v1.Events.created", []byte{0, 0, 0, 1, 'a'}))
+	assert.Nil(publisher.Publish("v1.Events.deleted", []byte{0, 0, 0, 1, 'b'}))
+	assert.Nil(publisher.Publish("v1.Other.created", []byte{0, 0, 0, 1, 'c'}))
+
+	assert.Equal(map[string][]string{
+		"v1.Events.created": {"a"},
+		"v1.Events.*":       {"a", "b"},
+		"v1.>":              {"a", "b", "c"},
+	}, received)
+}
+
+// Ensures unsubscribed transports no longer receive messages.
+func TestBrokerUnsubscribe(t *testing.T) {
+	assert := assert.New(t)
+	broker := NewBroker()
+	publisher := NewPublisherTransportFactory(broker).GetTransport()
+	assert.Nil(publisher.Open())
+	sub := NewSubscriberTransportFactory(broker).GetTransport()
+
+	calls := 0
+	assert.Nil(sub.Subscribe("foo", func(thrift.TTransport) error {
+		calls++
+		return nil
+	}))
+	assert.Nil(publisher.Publish("foo", []byte{0, 0, 0, 0}))
+	assert.Nil(sub.Unsubscribe())
+	assert.False(sub.IsSubscribed())
+	assert.Nil(publisher.Publish("foo", []byte{0, 0, 0, 0}))
+
+	assert.Equal(1, calls)
+}
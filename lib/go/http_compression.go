@@ -0,0 +1,82 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	// GzipCompression compresses HTTP payloads with gzip.
+	GzipCompression = "gzip"
+
+	// SnappyCompression compresses HTTP payloads with the snappy framing
+	// format.
+	SnappyCompression = "snappy"
+
+	contentEncodingHeader    = "content-encoding"
+	httpAcceptEncodingHeader = "accept-encoding"
+	varyHeader               = "vary"
+
+	// minCompressionSize is the smallest response which is compressed. Smaller
+	// payloads don't compress well enough to be worth it.
+	minCompressionSize = 1024
+)
+
+// newCompressor returns a writer which compresses to w with the given
+// encoding.
+func newCompressor(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case GzipCompression:
+		return gzip.NewWriter(w), nil
+	case SnappyCompression:
+		return snappy.NewBufferedWriter(w), nil
+	}
+	return nil, fmt.Errorf("frugal: unsupported compression %q", encoding)
+}
+
+// newDecompressor returns a reader which decompresses r with the given
+// encoding.
+func newDecompressor(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case GzipCompression:
+		return gzip.NewReader(r)
+	case SnappyCompression:
+		return ioutil.NopCloser(snappy.NewReader(r)), nil
+	}
+	return nil, fmt.Errorf("frugal: unsupported compression %q", encoding)
+}
+
+// negotiateCompression returns the first supported encoding in the given
+// Accept-Encoding header value, or an empty string if there is none.
+func negotiateCompression(acceptEncoding string) string {
+	for _, value := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(value, ";")
+		encoding := strings.ToLower(strings.TrimSpace(params[0]))
+		if encoding != GzipCompression && encoding != SnappyCompression {
+			continue
+		}
+		if len(params) > 1 && strings.Replace(params[1], " ", "", -1) == "q=0" {
+			continue
+		}
+		return encoding
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures the first supported encoding of an Accept-Encoding header is
+// negotiated.
+func TestNegotiateCompression(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("", negotiateCompression(""))
+	assert.Equal("", negotiateCompression("br, deflate"))
+	assert.Equal(GzipCompression, negotiateCompression("gzip"))
+	assert.Equal(SnappyCompression, negotiateCompression("br, Snappy, gzip"))
+	assert.Equal(GzipCompression, negotiateCompression("snappy;q=0, gzip;q=0.5"))
+}
+
+// Ensures payloads round trip through the supported compressions and
+// unsupported compressions are rejected.
+func TestCompressionRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+	payload := bytes.Repeat([]byte("frugal"), 100)
+	for _, encoding := range []string{GzipCompression, SnappyCompression} {
+		compressed := new(bytes.Buffer)
+		compressor, err := newCompressor(encoding, compressed)
+		assert.Nil(err)
+		compressor.Write(payload)
+		assert.Nil(compressor.Close())
+
+		decompressor, err := newDecompressor(encoding, compressed)
+		assert.Nil(err)
+		decompressed, err := ioutil.ReadAll(decompressor)
+		assert.Nil(err)
+		assert.Equal(payload, decompressed)
+	}
+
+	_, err := newCompressor("br", new(bytes.Buffer))
+	assert.NotNil(err)
+	_, err = newDecompressor("br", new(bytes.Buffer))
+	assert.NotNil(err)
+}
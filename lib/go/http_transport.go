@@ -16,26 +16,49 @@ package frugal
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 const (
 	payloadLimitHeader            = "x-frugal-payload-limit"
 	acceptHeader                  = "accept"
 	contentTypeHeader             = "content-type"
+	contentLengthHeader           = "content-length"
 	contentTransferEncodingHeader = "content-transfer-encoding"
 
+	// encodingHeader marks a request or response body as binary rather than
+	// base64. acceptEncodingHeader is sent by clients which can read binary
+	// responses. A server which responds with a binary body also accepts
+	// binary requests.
+	encodingHeader       = "x-frugal-encoding"
+	acceptEncodingHeader = "x-frugal-accept-encoding"
+
+	// requestIDHeader is a response header echoing the correlation ID of the
+	// request.
+	requestIDHeader = "x-request-id"
+
+	// streamBufferSize is the number of bytes of a binary response buffered
+	// before it is streamed.
+	streamBufferSize = 32 * 1024
+
 	frugalContentType = "application/x-frugal"
 	base64Encoding    = "base64"
+	binaryEncoding    = "binary"
 )
 
 var newEncoder = func(buf *bytes.Buffer) io.WriteCloser {
@@ -64,14 +87,30 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 			}
 		}
 
-		// Need 4 bytes for the frame size, at a minimum.
-		if r.ContentLength < 4 {
+		// Need 4 bytes for the frame size, at a minimum. Streamed requests
+		// have an unknown length.
+		if r.ContentLength >= 0 && r.ContentLength < 4 {
 			http.Error(w, fmt.Sprintf("Invalid request size %d", r.ContentLength), http.StatusBadRequest)
 			return
 		}
 
+		// Decompress the payload
+		var body io.Reader = r.Body
+		if encoding := r.Header.Get(contentEncodingHeader); encoding != "" {
+			decompressor, err := newDecompressor(encoding, r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+				return
+			}
+			defer decompressor.Close()
+			body = decompressor
+		}
+
 		// Create a decoder based on the payload
-		decoder := base64.NewDecoder(base64.StdEncoding, r.Body)
+		decoder := body
+		if r.Header.Get(encodingHeader) != binaryEncoding {
+			decoder = base64.NewDecoder(base64.StdEncoding, body)
+		}
 
 		// Read out the frame size
 		// TODO: should we do something with the frame size?
@@ -86,9 +125,16 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 
 		// Read and process frame
 		input := thrift.NewStreamTransportR(decoder)
+		iprot := protocolFactory.GetProtocol(input)
+
+		// Stream the response as it is written to clients which accept binary
+		if r.Header.Get(acceptEncodingHeader) == binaryEncoding {
+			processBinary(w, r, processor, iprot, protocolFactory, limit)
+			return
+		}
+
 		outBuf := new(bytes.Buffer)
 		output := &thrift.TMemoryBuffer{Buffer: outBuf}
-		iprot := protocolFactory.GetProtocol(input)
 		oprot := protocolFactory.GetProtocol(output)
 		if err := processor.Process(iprot, oprot); err != nil {
 			http.Error(w,
@@ -100,6 +146,7 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 
 		// If client requested a limit, check the buffer size
 		if limit > 0 && outBuf.Len() > int(limit) {
+			stats().FrameTooLarge("http")
 			http.Error(w,
 				fmt.Sprintf("Response size (%d) larger than requested size (%d)", outBuf.Len(), limit),
 				http.StatusRequestEntityTooLarge,
@@ -107,13 +154,17 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 			return
 		}
 
+		binary.BigEndian.PutUint32(frameSize, uint32(outBuf.Len()))
+
+		// Echo the correlation ID so requests can be traced through proxies
+		setRequestID(w, outBuf.Bytes())
+
 		// Encode response
 		var (
 			encoded = new(bytes.Buffer)
 			encoder = newEncoder(encoded)
 			err     error
 		)
-		binary.BigEndian.PutUint32(frameSize, uint32(outBuf.Len()))
 		if _, e := encoder.Write(frameSize); e != nil {
 			err = e
 		}
@@ -134,8 +185,184 @@ func NewFrugalHandlerFunc(processor FProcessor, protocolFactory *FProtocolFactor
 		}
 
 		w.Header().Add(contentTransferEncodingHeader, base64Encoding)
-		w.Write(encoded.Bytes())
+		writeResponse(w, r, encoded.Bytes())
+	}
+}
+
+// processBinary processes a request and streams the binary response. Binary
+// responses are not prefixed with the frame size, the frame ends with the
+// body, so the response is written as the processor produces it.
+func processBinary(w http.ResponseWriter, r *http.Request, processor FProcessor,
+	iprot *FProtocol, protocolFactory *FProtocolFactory, limit int64) {
+
+	response := &binaryResponseWriter{w: w, r: r, limit: limit}
+	output := thrift.NewStreamTransportW(response)
+	err := processor.Process(iprot, protocolFactory.GetProtocol(output))
+	if err == nil {
+		err = output.Flush()
+	}
+	if err == nil {
+		err = response.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if response.tooLarge {
+		stats().FrameTooLarge("http")
+	}
+	switch {
+	case response.committed():
+		// Part of the response was sent, abort it so the client doesn't
+		// mistake it for a complete one.
+		logger().Errorf("frugal: error streaming response: %s", err)
+		panic(http.ErrAbortHandler)
+	case response.tooLarge:
+		http.Error(w,
+			fmt.Sprintf("Response size (%d) larger than requested size (%d)", response.size, limit),
+			http.StatusRequestEntityTooLarge,
+		)
+	default:
+		http.Error(w,
+			fmt.Sprintf("Error processing request: %s", err),
+			http.StatusInternalServerError,
+		)
+	}
+}
+
+// binaryResponseWriter streams a binary response. The start of the response is
+// buffered so that small responses are sent with a Content-Length and errors,
+// such as exceeding the size limit, can still be reported with a status code.
+type binaryResponseWriter struct {
+	w          http.ResponseWriter
+	r          *http.Request
+	limit      int64
+	size       int64
+	tooLarge   bool
+	buf        bytes.Buffer
+	out        io.Writer
+	compressor io.WriteCloser
+}
+
+// Write buffers or sends the given bytes, failing once the size limit is
+// exceeded.
+func (b *binaryResponseWriter) Write(p []byte) (int, error) {
+	b.size += int64(len(p))
+	if b.limit > 0 && b.size > b.limit {
+		b.tooLarge = true
+	}
+	if b.tooLarge {
+		return 0, b.tooLargeError()
+	}
+	if b.committed() {
+		return b.out.Write(p)
+	}
+	b.buf.Write(p)
+	if b.buf.Len() >= streamBufferSize {
+		if err := b.commit(false); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close sends any buffered bytes and finishes the response. It fails if the
+// size limit was exceeded, even if the failed write was ignored.
+func (b *binaryResponseWriter) Close() error {
+	if b.tooLarge {
+		return b.tooLargeError()
+	}
+	if !b.committed() {
+		return b.commit(true)
+	}
+	if b.compressor != nil {
+		return b.compressor.Close()
+	}
+	return nil
+}
+
+// tooLargeError returns the error for a response exceeding the size limit.
+func (b *binaryResponseWriter) tooLargeError() error {
+	return thrift.NewTTransportException(TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE,
+		fmt.Sprintf("response exceeds %d bytes", b.limit))
+}
+
+// committed indicates if the response headers have been sent.
+func (b *binaryResponseWriter) committed() bool {
+	return b.out != nil
+}
+
+// commit sends the response headers followed by the buffered bytes. If final,
+// the buffer holds the whole response.
+func (b *binaryResponseWriter) commit(final bool) error {
+	b.w.Header().Set(encodingHeader, binaryEncoding)
+	setRequestID(b.w, b.buf.Bytes())
+
+	b.out = b.w
+	encoding := negotiateCompression(b.r.Header.Get(httpAcceptEncodingHeader))
+	switch {
+	case encoding != "" && b.buf.Len() >= minCompressionSize:
+		b.w.Header().Set(contentEncodingHeader, encoding)
+		b.w.Header().Add(varyHeader, httpAcceptEncodingHeader)
+		b.compressor, _ = newCompressor(encoding, b.w)
+		b.out = b.compressor
+	case final:
+		b.w.Header().Set(contentLengthHeader, strconv.Itoa(b.buf.Len()))
+	}
+
+	if _, err := b.buf.WriteTo(b.out); err != nil {
+		return err
+	}
+	if final && b.compressor != nil {
+		return b.compressor.Close()
+	}
+	return nil
+}
+
+// setRequestID echoes the correlation ID of the given response frame so
+// requests can be traced through proxies.
+func setRequestID(w http.ResponseWriter, frame []byte) {
+	if headers, err := getHeadersFromFrame(frame); err == nil && headers[cidHeader] != "" {
+		w.Header().Set(requestIDHeader, headers[cidHeader])
+	}
+}
+
+// writeResponse writes the given response chunks, compressed if the client
+// accepts a supported Content-Encoding and the response is large enough.
+func writeResponse(w http.ResponseWriter, r *http.Request, chunks ...[]byte) {
+	length := 0
+	for _, chunk := range chunks {
+		length += len(chunk)
+	}
+
+	encoding := negotiateCompression(r.Header.Get(httpAcceptEncodingHeader))
+	if encoding == "" || length < minCompressionSize {
+		w.Header().Set(contentLengthHeader, strconv.Itoa(length))
+		for _, chunk := range chunks {
+			w.Write(chunk)
+		}
+		return
+	}
+
+	w.Header().Set(contentEncodingHeader, encoding)
+	w.Header().Add(varyHeader, httpAcceptEncodingHeader)
+	compressor, _ := newCompressor(encoding, w)
+	for _, chunk := range chunks {
+		if _, err := compressor.Write(chunk); err != nil {
+			logger().Errorf("frugal: error writing compressed response: %s", err)
+			return
+		}
 	}
+	if err := compressor.Close(); err != nil {
+		logger().Errorf("frugal: error writing compressed response: %s", err)
+	}
+}
+
+// NewH2CHandler wraps the given handler, such as one returned by
+// NewFrugalHandlerFunc, to also serve HTTP/2 without TLS (h2c). Clients
+// connect using FHTTPTransportBuilder.WithH2C.
+func NewH2CHandler(handler http.Handler) http.Handler {
+	return h2c.NewHandler(handler, &http2.Server{})
 }
 
 type GetHeadersWithContext func(FContext) map[string]string
@@ -148,6 +375,9 @@ type FHTTPTransportBuilder struct {
 	responseSizeLimit uint
 	requestHeaders    map[string]string
 	getRequestHeaders GetHeadersWithContext
+	binaryEncoding    bool
+	compression       string
+	h2c               bool
 }
 
 // NewFHTTPTransportBuilder creates a builder which configures and builds HTTP
@@ -188,15 +418,57 @@ func (h *FHTTPTransportBuilder) WithRequestHeadersFromFContext(getRequestHeaders
 	return h
 }
 
+// WithBinaryEncoding enables sending and receiving unencoded binary payloads
+// instead of base64, which avoids its overhead. Binary responses are streamed
+// by the server as they are written. The encoding is negotiated with the
+// server, so it can be enabled for servers which don't support it. Requests
+// are sent as base64 until a binary response is received.
+func (h *FHTTPTransportBuilder) WithBinaryEncoding() *FHTTPTransportBuilder {
+	h.binaryEncoding = true
+	return h
+}
+
+// WithCompression compresses request payloads with the given encoding,
+// GzipCompression or SnappyCompression, and asks the server to compress
+// responses with it. If set to an empty string (the default), payloads are not
+// compressed.
+func (h *FHTTPTransportBuilder) WithCompression(encoding string) *FHTTPTransportBuilder {
+	h.compression = encoding
+	return h
+}
+
+// WithH2C makes requests using HTTP/2 without TLS (h2c). This requires the
+// server to support h2c, such as a handler wrapped by NewH2CHandler. The
+// configured client is copied and its Transport replaced. Connections are
+// dialed with the client's Timeout.
+func (h *FHTTPTransportBuilder) WithH2C() *FHTTPTransportBuilder {
+	h.h2c = true
+	return h
+}
+
 // Build a new configured HTTP FTransport.
 func (h *FHTTPTransportBuilder) Build() FTransport {
+	client := h.client
+	if h.h2c {
+		h2cClient := *client
+		dialer := &net.Dialer{Timeout: client.Timeout}
+		h2cClient.Transport = &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dialer.Dial(network, addr)
+			},
+		}
+		client = &h2cClient
+	}
 	return &fHTTPTransport{
 		fBaseTransport:    newFBaseTransport(h.requestSizeLimit),
-		client:            h.client,
+		client:            client,
 		url:               h.url,
 		responseSizeLimit: h.responseSizeLimit,
 		requestHeaders:    h.requestHeaders,
 		getRequestHeaders: h.getRequestHeaders,
+		binaryEncoding:    h.binaryEncoding,
+		compression:       h.compression,
 	}
 }
 
@@ -213,6 +485,9 @@ type fHTTPTransport struct {
 	isOpen            bool
 	requestHeaders    map[string]string
 	getRequestHeaders GetHeadersWithContext
+	binaryEncoding    bool
+	serverBinary      int32
+	compression       string
 }
 
 // Open initializes the transport for use.
@@ -254,6 +529,7 @@ func (h *fHTTPTransport) Request(ctx FContext, data []byte) (thrift.TTransport,
 	}
 
 	if h.requestSizeLimit > 0 && len(data) > int(h.requestSizeLimit) {
+		stats().FrameTooLarge("http")
 		return nil, thrift.NewTTransportException(
 			TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
 			fmt.Sprintf("Message exceeds %d bytes, was %d bytes", h.requestSizeLimit, len(data)))
@@ -262,6 +538,9 @@ func (h *fHTTPTransport) Request(ctx FContext, data []byte) (thrift.TTransport,
 	// Make the HTTP request
 	response, err := h.makeRequest(ctx, data)
 	if err != nil {
+		if ToContext(ctx).Err() != nil {
+			return nil, contextError(ctx)
+		}
 		if strings.HasSuffix(err.Error(), "net/http: request canceled") ||
 			strings.HasSuffix(err.Error(), "net/http: timeout awaiting response headers") ||
 			strings.HasSuffix(err.Error(), "net/http: request canceled while waiting for connection") {
@@ -302,23 +581,22 @@ func (h *fHTTPTransport) SetMonitor(monitor FTransportMonitor) {
 }
 
 func (h *fHTTPTransport) makeRequest(fCtx FContext, requestPayload []byte) ([]byte, error) {
-	// Encode request payload
-	encoded := new(bytes.Buffer)
-	encoder := newEncoder(encoded)
-	if _, err := encoder.Write(requestPayload); err != nil {
-		return nil, err
-	}
-	if err := encoder.Close(); err != nil {
+	// Encode request payload, unless the server is known to accept binary
+	sendBinary := h.binaryEncoding && atomic.LoadInt32(&h.serverBinary) == 1
+	requestBody, contentLength, err := h.requestBody(requestPayload, sendBinary)
+	if err != nil {
 		return nil, err
 	}
+	defer requestBody.Close()
 
 	// Initialize request
-	ctx, cancel := context.WithTimeout(context.Background(), fCtx.Timeout())
+	ctx, cancel := context.WithTimeout(ToContext(fCtx), fCtx.Timeout())
 	defer cancel()
-	request, err := http.NewRequest("POST", h.url, encoded)
+	request, err := http.NewRequest("POST", h.url, requestBody)
 	if err != nil {
 		return nil, err
 	}
+	request.ContentLength = contentLength
 	request = request.WithContext(ctx)
 
 	// add user supplied headers first, to avoid monkeying
@@ -339,7 +617,18 @@ func (h *fHTTPTransport) makeRequest(fCtx FContext, requestPayload []byte) ([]by
 	// Add request headers
 	request.Header.Set(contentTypeHeader, frugalContentType)
 	request.Header.Set(acceptHeader, frugalContentType)
-	request.Header.Set(contentTransferEncodingHeader, base64Encoding)
+	if sendBinary {
+		request.Header.Set(encodingHeader, binaryEncoding)
+	} else {
+		request.Header.Set(contentTransferEncodingHeader, base64Encoding)
+	}
+	if h.binaryEncoding {
+		request.Header.Set(acceptEncodingHeader, binaryEncoding)
+	}
+	if h.compression != "" {
+		request.Header.Set(contentEncodingHeader, h.compression)
+		request.Header.Set(httpAcceptEncodingHeader, h.compression)
+	}
 	if h.responseSizeLimit > 0 {
 		request.Header.Add(payloadLimitHeader, strconv.FormatUint(uint64(h.responseSizeLimit), 10))
 	}
@@ -352,16 +641,36 @@ func (h *fHTTPTransport) makeRequest(fCtx FContext, requestPayload []byte) ([]by
 
 	// Response too large
 	if response.StatusCode == http.StatusRequestEntityTooLarge {
+		stats().FrameTooLarge("http")
 		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE,
 			"response was too large for the transport")
 	}
 
+	// Decompress body. Without configured compression, the http.Client
+	// decompresses transparently.
+	responseBody := response.Body
+	if encoding := response.Header.Get(contentEncodingHeader); h.compression != "" && encoding != "" {
+		decompressor, err := newDecompressor(encoding, response.Body)
+		if err != nil {
+			response.Body.Close()
+			return nil, err
+		}
+		defer response.Body.Close()
+		responseBody = decompressor
+	}
+
+	// Read binary body
+	if response.StatusCode < 300 && response.Header.Get(encodingHeader) == binaryEncoding {
+		atomic.StoreInt32(&h.serverBinary, 1)
+		return h.readBinaryResponse(responseBody)
+	}
+
 	// Decode body
 	buf := new(bytes.Buffer)
-	if _, err := buf.ReadFrom(response.Body); err != nil {
+	if _, err := buf.ReadFrom(responseBody); err != nil {
 		return nil, err
 	}
-	if err := response.Body.Close(); err != nil {
+	if err := responseBody.Close(); err != nil {
 		return nil, err
 	}
 	body := string(buf.Bytes())
@@ -382,6 +691,83 @@ func (h *fHTTPTransport) makeRequest(fCtx FContext, requestPayload []byte) ([]by
 
 }
 
+// requestBody returns the request body for the given payload and its length,
+// -1 if unknown. The payload is encoded and compressed through a pipe as the
+// request is sent rather than buffered.
+func (h *fHTTPTransport) requestBody(payload []byte, sendBinary bool) (io.ReadCloser, int64, error) {
+	if sendBinary && h.compression == "" {
+		return ioutil.NopCloser(bytes.NewReader(payload)), int64(len(payload)), nil
+	}
+
+	reader, writer := io.Pipe()
+	var (
+		body       io.Writer = writer
+		compressor io.WriteCloser
+		length     = int64(base64.StdEncoding.EncodedLen(len(payload)))
+	)
+	if sendBinary {
+		length = int64(len(payload))
+	}
+	if h.compression != "" {
+		var err error
+		if compressor, err = newCompressor(h.compression, writer); err != nil {
+			return nil, 0, err
+		}
+		body = compressor
+		length = -1
+	}
+
+	go func() {
+		writer.CloseWithError(writeRequestBody(body, compressor, payload, sendBinary))
+	}()
+	return reader, length, nil
+}
+
+// writeRequestBody writes the payload, base64 encoded unless sendBinary, and
+// closes the compressor, if any.
+func writeRequestBody(body io.Writer, compressor io.WriteCloser, payload []byte, sendBinary bool) error {
+	if sendBinary {
+		if _, err := body.Write(payload); err != nil {
+			return err
+		}
+	} else {
+		encoder := base64.NewEncoder(base64.StdEncoding, body)
+		if _, err := encoder.Write(payload); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+	}
+	if compressor != nil {
+		return compressor.Close()
+	}
+	return nil
+}
+
+// readBinaryResponse reads a binary response body, the response frame without
+// its size, and returns the framed response. Reading stops once the response
+// size limit is exceeded.
+func (h *fHTTPTransport) readBinaryResponse(body io.ReadCloser) ([]byte, error) {
+	defer body.Close()
+	reader := io.Reader(body)
+	if h.responseSizeLimit > 0 {
+		reader = io.LimitReader(body, int64(h.responseSizeLimit)+1)
+	}
+	buf := bytes.NewBuffer(make([]byte, 4))
+	if _, err := buf.ReadFrom(reader); err != nil {
+		return nil, err
+	}
+	frame := buf.Bytes()
+	if h.responseSizeLimit > 0 && len(frame)-4 > int(h.responseSizeLimit) {
+		stats().FrameTooLarge("http")
+		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE,
+			"response was too large for the transport")
+	}
+	binary.BigEndian.PutUint32(frame, uint32(len(frame)-4))
+	return frame, nil
+}
+
 func (h *fHTTPTransport) getClosedConditionError(prefix string) error {
 	return thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
 		fmt.Sprintf("%s HTTP TTransport not open", prefix))
@@ -15,19 +15,24 @@ package frugal
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
 )
 
 type mockFProcessorForHTTP struct {
@@ -63,6 +68,23 @@ func (m *mockFProcessorForHTTP) Annotations() map[string]map[string]string {
 	return nil
 }
 
+// chunkedFProcessorForHTTP writes its response in chunks, failing on the
+// first write error.
+type chunkedFProcessorForHTTP struct {
+	mockFProcessorForHTTP
+	chunk  []byte
+	chunks int
+}
+
+func (m *chunkedFProcessorForHTTP) Process(iprot, oprot *FProtocol) error {
+	for i := 0; i < m.chunks; i++ {
+		if _, err := oprot.TProtocol.Transport().Write(m.chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type mockWriteCloser struct {
 	writeErr error
 	closeErr error
@@ -763,3 +785,279 @@ func TestHTTPRequestHeadersWithContextReturnsEmptyMap(t *testing.T) {
 	// Close
 	assert.Nil(transport.Close())
 }
+
+// Ensures a binary request is processed and, if accepted, a binary response
+// is returned.
+func TestFrugalHandlerFuncBinary(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+
+	expectedBody := []byte{4, 5, 6, 7, 8}
+	framedBody := append([]byte{0, 0, 0, 5}, expectedBody...)
+	r, err := http.NewRequest("POST", "fooUrl", bytes.NewReader(framedBody))
+	assert.Nil(err)
+	r.Header.Set(encodingHeader, binaryEncoding)
+	r.Header.Set(acceptEncodingHeader, binaryEncoding)
+
+	response := []byte{9, 10, 11, 12}
+	mockProcessor := &mockFProcessorForHTTP{expectedPayload: expectedBody, response: response}
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	handler := NewFrugalHandlerFunc(mockProcessor, protocolFactory)
+
+	handler(w, r)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal(frugalContentType, w.Header().Get(contentTypeHeader))
+	assert.Equal(binaryEncoding, w.Header().Get(encodingHeader))
+	assert.Equal("", w.Header().Get(contentTransferEncodingHeader))
+	assert.Equal("4", w.Header().Get(contentLengthHeader))
+	assert.Equal(response, w.Body.Bytes())
+}
+
+// Ensures a binary response larger than the stream buffer is streamed rather
+// than sent with a Content-Length.
+func TestHTTPTransportBinaryStreaming(t *testing.T) {
+	assert := assert.New(t)
+	requestBytes := []byte("Hello from the other side")
+	responseBytes := bytes.Repeat([]byte("I must've called a thousand times"), streamBufferSize/10)
+
+	processor := &mockFProcessorForHTTP{expectedPayload: requestBytes, response: responseBytes}
+	handler := NewFrugalHandlerFunc(processor, NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+	contentLengths := make(chan int64, 2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLengths <- r.ContentLength
+		handler(w, r)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	transport := NewFHTTPTransportBuilder(client, ts.URL).WithBinaryEncoding().Build()
+	for i := 0; i < 2; i++ {
+		result, err := transport.Request(NewFContext(""), prependFrameSize(requestBytes))
+		assert.Nil(err)
+		assert.Equal(responseBytes, result.(*thrift.TMemoryBuffer).Bytes())
+	}
+
+	// The base64 request is piped with a known length.
+	framedLength := len(requestBytes) + 4
+	assert.Equal(int64(base64.StdEncoding.EncodedLen(framedLength)), <-contentLengths)
+	assert.Equal(int64(framedLength), <-contentLengths)
+
+	resp, err := client.Do(binaryRequest(ts.URL, requestBytes))
+	assert.Nil(err)
+	defer resp.Body.Close()
+	assert.Equal(int64(-1), resp.ContentLength)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(err)
+	assert.Equal(responseBytes, body)
+}
+
+// Ensures a binary response exceeding the requested size limit is rejected
+// with a status code if it has not been sent yet and aborted otherwise.
+func TestFrugalHandlerFuncBinaryTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	requestBytes := []byte("Hello from the other side")
+	responseBytes := bytes.Repeat([]byte{9}, streamBufferSize)
+
+	processor := &chunkedFProcessorForHTTP{chunk: responseBytes, chunks: 2}
+	handler := NewFrugalHandlerFunc(processor, NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	request := binaryRequest(ts.URL, requestBytes)
+	request.Header.Set(payloadLimitHeader, "10")
+	resp, err := http.DefaultClient.Do(request)
+	assert.Nil(err)
+	resp.Body.Close()
+	assert.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	request = binaryRequest(ts.URL, requestBytes)
+	request.Header.Set(payloadLimitHeader, strconv.Itoa(streamBufferSize+1))
+	resp, err = http.DefaultClient.Do(request)
+	if err == nil {
+		_, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	assert.NotNil(err)
+}
+
+// Ensures a binary response larger than the response size limit is rejected
+// by the client without reading all of it.
+func TestHTTPTransportBinaryResponseTooLarge(t *testing.T) {
+	assert := assert.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(encodingHeader, binaryEncoding)
+		w.Write(bytes.Repeat([]byte{9}, 100))
+	}))
+	defer ts.Close()
+
+	transport := NewFHTTPTransportBuilder(&http.Client{}, ts.URL).
+		WithBinaryEncoding().WithResponseSizeLimit(10).Build()
+	_, err := transport.Request(NewFContext(""), prependFrameSize([]byte("foo")))
+	assert.Equal(TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, err.(thrift.TTransportException).TypeId())
+}
+
+// binaryRequest returns a binary request for the given payload which accepts
+// a binary response.
+func binaryRequest(url string, payload []byte) *http.Request {
+	request, _ := http.NewRequest("POST", url, bytes.NewReader(prependFrameSize(payload)))
+	request.Header.Set(encodingHeader, binaryEncoding)
+	request.Header.Set(acceptEncodingHeader, binaryEncoding)
+	return request
+}
+
+// Ensures a client with binary encoding enabled sends base64 until the server
+// responds with binary and then streams binary requests.
+func TestHTTPTransportBinaryEncodingNegotiation(t *testing.T) {
+	assert := assert.New(t)
+	requestBytes := []byte("Hello from the other side")
+	responseBytes := []byte("I must've called a thousand times")
+
+	var requestEncodings []string
+	processor := &mockFProcessorForHTTP{expectedPayload: requestBytes, response: responseBytes}
+	handler := NewFrugalHandlerFunc(processor, NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(binaryEncoding, r.Header.Get(acceptEncodingHeader))
+		requestEncodings = append(requestEncodings,
+			r.Header.Get(encodingHeader)+r.Header.Get(contentTransferEncodingHeader))
+		handler(w, r)
+	}))
+	defer ts.Close()
+
+	transport := NewFHTTPTransportBuilder(&http.Client{}, ts.URL).WithBinaryEncoding().Build()
+	assert.Nil(transport.Open())
+
+	for i := 0; i < 2; i++ {
+		result, err := transport.Request(NewFContext(""), prependFrameSize(requestBytes))
+		assert.Nil(err)
+		assert.Equal(responseBytes, result.(*thrift.TMemoryBuffer).Bytes())
+	}
+	assert.Equal([]string{base64Encoding, binaryEncoding}, requestEncodings)
+}
+
+// Ensures a client with binary encoding enabled keeps using base64 with a
+// server which doesn't support binary.
+func TestHTTPTransportBinaryEncodingUnsupported(t *testing.T) {
+	assert := assert.New(t)
+	responseBytes := []byte("I must've called a thousand times")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(base64Encoding, r.Header.Get(contentTransferEncodingHeader))
+		assert.Equal("", r.Header.Get(encodingHeader))
+		w.Header().Set(contentTransferEncodingHeader, base64Encoding)
+		w.Write([]byte(base64.StdEncoding.EncodeToString(prependFrameSize(responseBytes))))
+	}))
+	defer ts.Close()
+
+	transport := NewFHTTPTransportBuilder(&http.Client{}, ts.URL).WithBinaryEncoding().Build()
+	assert.Nil(transport.Open())
+
+	for i := 0; i < 2; i++ {
+		result, err := transport.Request(NewFContext(""), prependFrameSize([]byte("foo")))
+		assert.Nil(err)
+		assert.Equal(responseBytes, result.(*thrift.TMemoryBuffer).Bytes())
+	}
+}
+
+// Ensures compressed requests are decompressed, large responses are
+// compressed for clients which accept it, and the correlation ID is echoed.
+func TestFrugalHandlerFuncCompression(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+
+	expectedBody := []byte{4, 5, 6, 7, 8}
+	compressed := new(bytes.Buffer)
+	compressor := gzip.NewWriter(compressed)
+	compressor.Write([]byte(base64.StdEncoding.EncodeToString(append([]byte{0, 0, 0, 5}, expectedBody...))))
+	compressor.Close()
+	r, err := http.NewRequest("POST", "fooUrl", compressed)
+	assert.Nil(err)
+	r.Header.Set(contentEncodingHeader, GzipCompression)
+	r.Header.Set(httpAcceptEncodingHeader, GzipCompression)
+
+	response := append(writeMarshaler.marshalHeaders(map[string]string{cidHeader: "cid"}),
+		bytes.Repeat([]byte{9}, minCompressionSize)...)
+	mockProcessor := &mockFProcessorForHTTP{expectedPayload: expectedBody, response: response}
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	handler := NewFrugalHandlerFunc(mockProcessor, protocolFactory)
+
+	handler(w, r)
+
+	assert.Equal(http.StatusOK, w.Code)
+	assert.Equal(GzipCompression, w.Header().Get(contentEncodingHeader))
+	assert.Equal("cid", w.Header().Get(requestIDHeader))
+	decompressor, err := gzip.NewReader(w.Body)
+	assert.Nil(err)
+	decompressed, err := ioutil.ReadAll(decompressor)
+	assert.Nil(err)
+	framedResponse := append(make([]byte, 4), response...)
+	binary.BigEndian.PutUint32(framedResponse, uint32(len(response)))
+	assert.Equal(base64.StdEncoding.EncodeToString(framedResponse), string(decompressed))
+}
+
+// Ensures a request with an unsupported Content-Encoding is rejected.
+func TestFrugalHandlerFuncUnsupportedCompression(t *testing.T) {
+	assert := assert.New(t)
+	w := httptest.NewRecorder()
+
+	r, err := http.NewRequest("POST", "fooUrl", strings.NewReader("AAAAAA=="))
+	assert.Nil(err)
+	r.Header.Set(contentEncodingHeader, "br")
+
+	protocolFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	handler := NewFrugalHandlerFunc(&mockFProcessorForHTTP{}, protocolFactory)
+
+	handler(w, r)
+
+	assert.Equal(http.StatusUnsupportedMediaType, w.Code)
+}
+
+// Ensures a client with compression enabled compresses requests and
+// decompresses responses.
+func TestHTTPTransportCompression(t *testing.T) {
+	assert := assert.New(t)
+	requestBytes := bytes.Repeat([]byte("Hello from the other side"), 10)
+	responseBytes := bytes.Repeat([]byte("I must've called a thousand times"), 100)
+
+	for _, encoding := range []string{GzipCompression, SnappyCompression} {
+		processor := &mockFProcessorForHTTP{expectedPayload: requestBytes, response: responseBytes}
+		handler := NewFrugalHandlerFunc(processor, NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()))
+		responseEncoding := make(chan string, 1)
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(encoding, r.Header.Get(contentEncodingHeader))
+			handler(w, r)
+			responseEncoding <- w.Header().Get(contentEncodingHeader)
+		}))
+
+		transport := NewFHTTPTransportBuilder(&http.Client{}, ts.URL).WithCompression(encoding).Build()
+		assert.Nil(transport.Open())
+		result, err := transport.Request(NewFContext(""), prependFrameSize(requestBytes))
+		assert.Nil(err)
+		assert.Equal(responseBytes, result.(*thrift.TMemoryBuffer).Bytes())
+		assert.Equal(encoding, <-responseEncoding)
+		ts.Close()
+	}
+}
+
+// Ensures enabling h2c uses an HTTP/2 transport without modifying the given
+// client.
+func TestHTTPTransportH2C(t *testing.T) {
+	assert := assert.New(t)
+	client := &http.Client{Timeout: time.Second}
+
+	transport := NewFHTTPTransportBuilder(client, "http://localhost").WithH2C().Build().(*fHTTPTransport)
+
+	assert.Nil(client.Transport)
+	assert.Equal(time.Second, transport.client.Timeout)
+	h2Transport, ok := transport.client.Transport.(*http2.Transport)
+	assert.True(ok)
+	assert.True(h2Transport.AllowHTTP)
+
+	// Connections are dialed without TLS.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(err)
+	defer listener.Close()
+	conn, err := h2Transport.DialTLS("tcp", listener.Addr().String(), nil)
+	assert.Nil(err)
+	conn.Close()
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// ServiceMiddleware returns a ServiceMiddleware which records the number of
+// requests, the number of failed requests, and the request latency of every
+// method to the given Sink. Series are labeled by the service type and method
+// name, so the same middleware can be used for clients and processors.
+func ServiceMiddleware(sink Sink) frugal.ServiceMiddleware {
+	return func(next frugal.InvocationHandler) frugal.InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args frugal.Arguments) frugal.Results {
+			labels := Labels{"service": serviceName(service), "method": method.Name}
+			start := time.Now()
+			results := next(service, method, args)
+			sink.ObserveHistogram(RequestDuration, labels, time.Since(start).Seconds())
+			sink.AddCounter(RequestsTotal, labels, 1)
+			if results.Error() != nil {
+				sink.AddCounter(RequestErrorsTotal, labels, 1)
+			}
+			return results
+		}
+	}
+}
+
+// serviceName returns the name of the proxied service's type.
+func serviceName(service reflect.Value) string {
+	if !service.IsValid() {
+		return ""
+	}
+	t := service.Type()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
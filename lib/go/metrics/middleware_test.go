@@ -0,0 +1,55 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures ServiceMiddleware records requests, errors, and latency per method.
+func TestServiceMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	registry := NewRegistry(nil)
+	handler := &testHandler{}
+	middleware := []frugal.ServiceMiddleware{ServiceMiddleware(registry)}
+	ping := frugal.NewMethod(handler, handler.Ping, "Ping", middleware)
+	fail := frugal.NewMethod(handler, handler.Fail, "Fail", middleware)
+
+	ping.Invoke([]interface{}{frugal.NewFContext("")})
+	ping.Invoke([]interface{}{frugal.NewFContext("")})
+	ret := fail.Invoke([]interface{}{frugal.NewFContext("")})
+	assert.Equal(errors.New("boom"), ret.Error())
+
+	pingLabels := Labels{"service": "testHandler", "method": "Ping"}
+	failLabels := Labels{"service": "testHandler", "method": "Fail"}
+	assert.Equal(float64(2), registry.families[RequestsTotal].series[formatLabels(pingLabels)].value)
+	assert.Equal(float64(1), registry.families[RequestsTotal].series[formatLabels(failLabels)].value)
+	assert.Nil(registry.families[RequestErrorsTotal].series[formatLabels(pingLabels)])
+	assert.Equal(float64(1), registry.families[RequestErrorsTotal].series[formatLabels(failLabels)].value)
+	assert.Equal(uint64(2), registry.families[RequestDuration].series[formatLabels(pingLabels)].count)
+}
+
+type testHandler struct{}
+
+func (h *testHandler) Ping(ctx frugal.FContext) error {
+	return nil
+}
+
+func (h *testHandler) Fail(ctx frugal.FContext) error {
+	return errors.New("boom")
+}
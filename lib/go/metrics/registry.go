@@ -0,0 +1,238 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the default histogram bucket upper bounds, in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// Registry is an in-memory Sink which exposes the recorded metrics in the
+// Prometheus text exposition format. It implements http.Handler so it can be
+// served directly as a scrape endpoint.
+type Registry struct {
+	mu       sync.Mutex
+	buckets  []float64
+	families map[string]*family
+}
+
+// family is all the series of a metric.
+type family struct {
+	typ    string
+	series map[string]*series
+}
+
+// series is the value of a metric for a single set of labels.
+type series struct {
+	labels string
+	value  float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// NewRegistry creates a Registry whose histograms use the given bucket upper
+// bounds. DefaultBuckets are used if buckets is empty.
+func NewRegistry(buckets []float64) *Registry {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &Registry{buckets: sorted, families: make(map[string]*family)}
+}
+
+// AddCounter adds delta to the counter with the given name and labels.
+func (r *Registry) AddCounter(name string, labels Labels, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s := r.series(name, counterType, labels); s != nil {
+		s.value += delta
+	}
+}
+
+// SetGauge sets the gauge with the given name and labels to value.
+func (r *Registry) SetGauge(name string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s := r.series(name, gaugeType, labels); s != nil {
+		s.value = value
+	}
+}
+
+// ObserveHistogram records value in the histogram with the given name and
+// labels.
+func (r *Registry) ObserveHistogram(name string, labels Labels, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.series(name, histogramType, labels)
+	if s == nil {
+		return
+	}
+	if s.counts == nil {
+		s.counts = make([]uint64, len(r.buckets))
+	}
+	for i, bound := range r.buckets {
+		if value <= bound {
+			s.counts[i]++
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// series returns the series of the named metric with the given labels,
+// creating it if necessary. Returns nil if the metric was already recorded as
+// a different type. Must be called with the lock held.
+func (r *Registry) series(name, typ string, labels Labels) *series {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{typ: typ, series: make(map[string]*series)}
+		r.families[name] = f
+	}
+	if f.typ != typ {
+		return nil
+	}
+	key := formatLabels(labels)
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{labels: key}
+		f.series[key] = s
+	}
+	return s
+}
+
+// WriteText writes the recorded metrics to w in the Prometheus text
+// exposition format.
+func (r *Registry) WriteText(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	r.mu.Lock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.writeFamily(buf, name, r.families[name])
+	}
+	r.mu.Unlock()
+	return buf.Flush()
+}
+
+// writeFamily writes all series of a metric. Must be called with the lock
+// held.
+func (r *Registry) writeFamily(w io.Writer, name string, f *family) {
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, f.typ)
+	for _, key := range keys {
+		s := f.series[key]
+		if f.typ != histogramType {
+			fmt.Fprintf(w, "%s%s %s\n", name, braces(s.labels), formatValue(s.value))
+			continue
+		}
+		for i, bound := range r.buckets {
+			var count uint64
+			if s.counts != nil {
+				count = s.counts[i]
+			}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", name, braces(withLabel(s.labels, "le", formatValue(bound))), count)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", name, braces(withLabel(s.labels, "le", "+Inf")), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", name, braces(s.labels), formatValue(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", name, braces(s.labels), s.count)
+	}
+}
+
+// ServeHTTP serves the recorded metrics in the Prometheus text exposition
+// format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	r.WriteText(w)
+}
+
+// formatLabels formats labels sorted by name, without the enclosing braces.
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=\"%s\"", name, escapeLabelValue(labels[name]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// withLabel appends a label to formatted labels.
+func withLabel(labels, name, value string) string {
+	pair := fmt.Sprintf("%s=\"%s\"", name, value)
+	if labels == "" {
+		return pair
+	}
+	return labels + "," + pair
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures counters, gauges, and histograms are written in the text
+// exposition format, sorted by name and labels.
+func TestRegistryWriteText(t *testing.T) {
+	assert := assert.New(t)
+	registry := NewRegistry([]float64{1, 0.5})
+	registry.AddCounter("requests_total", Labels{"method": "b"}, 1)
+	registry.AddCounter("requests_total", Labels{"method": "a"}, 2)
+	registry.AddCounter("requests_total", Labels{"method": "a"}, 1)
+	registry.SetGauge("in_flight", nil, 3)
+	registry.SetGauge("in_flight", nil, 2)
+	registry.ObserveHistogram("duration_seconds", Labels{"method": "a"}, 0.25)
+	registry.ObserveHistogram("duration_seconds", Labels{"method": "a"}, 0.75)
+	registry.ObserveHistogram("duration_seconds", Labels{"method": "a"}, 2)
+
+	buf := new(bytes.Buffer)
+	assert.Nil(registry.WriteText(buf))
+
+	assert.Equal(`# TYPE duration_seconds histogram
+duration_seconds_bucket{method="a",le="0.5"} 1
+duration_seconds_bucket{method="a",le="1"} 2
+duration_seconds_bucket{method="a",le="+Inf"} 3
+duration_seconds_sum{method="a"} 3
+duration_seconds_count{method="a"} 3
+# TYPE in_flight gauge
+in_flight 2
+# TYPE requests_total counter
+requests_total{method="a"} 3
+requests_total{method="b"} 1
+`, buf.String())
+}
+
+// Ensures label values are escaped and metrics recorded as a different type
+// are ignored.
+func TestRegistryEscapingAndTypeConflict(t *testing.T) {
+	assert := assert.New(t)
+	registry := NewRegistry(nil)
+	registry.AddCounter("errors_total", Labels{"msg": "a \"b\"\\\n"}, 1)
+	registry.SetGauge("errors_total", Labels{"msg": "x"}, 5)
+
+	buf := new(bytes.Buffer)
+	assert.Nil(registry.WriteText(buf))
+
+	assert.Equal("# TYPE errors_total counter\nerrors_total{msg=\"a \\\"b\\\"\\\\\\n\"} 1\n", buf.String())
+}
+
+// Ensures the Registry serves metrics over HTTP with the exposition content
+// type.
+func TestRegistryServeHTTP(t *testing.T) {
+	assert := assert.New(t)
+	registry := NewRegistry(nil)
+	registry.AddCounter("requests_total", nil, 1)
+
+	w := httptest.NewRecorder()
+	registry.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(contentType, w.Header().Get("Content-Type"))
+	assert.Equal("# TYPE requests_total counter\nrequests_total 1\n", w.Body.String())
+}
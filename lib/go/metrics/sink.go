@@ -0,0 +1,55 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics instruments Frugal services and transports. Request
+// counts, errors, and latencies are recorded per method by ServiceMiddleware,
+// and transport statistics are recorded by installing the FStatsReporter
+// returned by NewStatsReporter with frugal.SetStatsReporter.
+//
+// Metrics are written to a pluggable Sink. Registry is an in-memory Sink which
+// serves the Prometheus text exposition format over HTTP:
+//
+//	registry := metrics.NewRegistry(nil)
+//	frugal.SetStatsReporter(metrics.NewStatsReporter(registry))
+//	client := NewFFooClient(provider, metrics.ServiceMiddleware(registry))
+//	http.Handle("/metrics", registry)
+package metrics
+
+// Metric names recorded by this package.
+const (
+	RequestsTotal       = "frugal_requests_total"
+	RequestErrorsTotal  = "frugal_request_errors_total"
+	RequestDuration     = "frugal_request_duration_seconds"
+	RegistryInFlight    = "frugal_registry_in_flight_requests"
+	ServerQueueDepth    = "frugal_server_queue_depth"
+	HighWatermarkTotal  = "frugal_server_high_watermark_breaches_total"
+	ReopenAttemptsTotal = "frugal_transport_reopen_attempts_total"
+	FramesTooLargeTotal = "frugal_transport_frames_too_large_total"
+)
+
+// Labels are the label names and values identifying a series of a metric.
+type Labels map[string]string
+
+// Sink receives metrics. Implementations must be safe for concurrent use and
+// should not block, since they are called on the request path.
+type Sink interface {
+	// AddCounter adds delta to the counter with the given name and labels.
+	AddCounter(name string, labels Labels, delta float64)
+
+	// SetGauge sets the gauge with the given name and labels to value.
+	SetGauge(name string, labels Labels, value float64)
+
+	// ObserveHistogram records value in the histogram with the given name and
+	// labels.
+	ObserveHistogram(name string, labels Labels, value float64)
+}
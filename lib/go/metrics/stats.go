@@ -0,0 +1,72 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// statsReporter is an FStatsReporter which records transport statistics to a
+// Sink.
+type statsReporter struct {
+	sink Sink
+
+	// mu serializes updates to inFlight with setting its gauge, so the gauge
+	// is never left at a stale value.
+	mu       sync.Mutex
+	inFlight int64
+}
+
+// NewStatsReporter returns an FStatsReporter which records transport
+// statistics to the given Sink. Install it with frugal.SetStatsReporter.
+func NewStatsReporter(sink Sink) frugal.FStatsReporter {
+	return &statsReporter{sink: sink}
+}
+
+// RegistryInFlight records the number of requests awaiting a response.
+func (s *statsReporter) RegistryInFlight(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlight += int64(delta)
+	s.sink.SetGauge(RegistryInFlight, nil, float64(s.inFlight))
+}
+
+// QueueDepth records the number of requests waiting in a server's work queue.
+func (s *statsReporter) QueueDepth(server string, depth int) {
+	s.sink.SetGauge(ServerQueueDepth, Labels{"server": server}, float64(depth))
+}
+
+// HighWatermarkBreached counts requests which waited longer than a server's
+// high watermark.
+func (s *statsReporter) HighWatermarkBreached(server string, wait time.Duration) {
+	s.sink.AddCounter(HighWatermarkTotal, Labels{"server": server}, 1)
+}
+
+// ReopenAttempted counts attempts to reopen a transport by result.
+func (s *statsReporter) ReopenAttempted(success bool) {
+	result := "failure"
+	if success {
+		result = "success"
+	}
+	s.sink.AddCounter(ReopenAttemptsTotal, Labels{"result": result}, 1)
+}
+
+// FrameTooLarge counts frames rejected for exceeding a transport's size
+// limit.
+func (s *statsReporter) FrameTooLarge(transport string) {
+	s.sink.AddCounter(FramesTooLargeTotal, Labels{"transport": transport}, 1)
+}
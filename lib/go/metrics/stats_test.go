@@ -0,0 +1,80 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures transport statistics are recorded to the Sink.
+func TestStatsReporter(t *testing.T) {
+	assert := assert.New(t)
+	registry := NewRegistry(nil)
+	reporter := NewStatsReporter(registry)
+
+	reporter.RegistryInFlight(1)
+	reporter.RegistryInFlight(1)
+	reporter.RegistryInFlight(-1)
+	reporter.QueueDepth("nats", 4)
+	reporter.HighWatermarkBreached("nats", time.Second)
+	reporter.ReopenAttempted(false)
+	reporter.ReopenAttempted(true)
+	reporter.FrameTooLarge("http")
+
+	buf := new(bytes.Buffer)
+	assert.Nil(registry.WriteText(buf))
+	assert.Equal(`# TYPE frugal_registry_in_flight_requests gauge
+frugal_registry_in_flight_requests 1
+# TYPE frugal_server_high_watermark_breaches_total counter
+frugal_server_high_watermark_breaches_total{server="nats"} 1
+# TYPE frugal_server_queue_depth gauge
+frugal_server_queue_depth{server="nats"} 4
+# TYPE frugal_transport_frames_too_large_total counter
+frugal_transport_frames_too_large_total{transport="http"} 1
+# TYPE frugal_transport_reopen_attempts_total counter
+frugal_transport_reopen_attempts_total{result="failure"} 1
+frugal_transport_reopen_attempts_total{result="success"} 1
+`, buf.String())
+}
+
+// Ensures the in-flight gauge ends at the final count when updated
+// concurrently.
+func TestStatsReporterInFlightConcurrent(t *testing.T) {
+	registry := NewRegistry(nil)
+	reporter := NewStatsReporter(registry)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				reporter.RegistryInFlight(1)
+				reporter.RegistryInFlight(-1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	buf := new(bytes.Buffer)
+	assert.Nil(t, registry.WriteText(buf))
+	assert.Equal(t, `# TYPE frugal_registry_in_flight_requests gauge
+frugal_registry_in_flight_requests 0
+`, buf.String())
+}
@@ -14,6 +14,7 @@
 package frugal
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"unicode"
@@ -133,6 +134,9 @@ func composeMiddleware(method reflect.Value, middleware []ServiceMiddleware) Inv
 	return handler
 }
 
+// contextType is the reflect.Type of context.Context.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 // newInvocationHandler returns the base InvocationHandler which calls the
 // actual handler function.
 func newInvocationHandler(method reflect.Value) InvocationHandler {
@@ -141,6 +145,15 @@ func newInvocationHandler(method reflect.Value) InvocationHandler {
 		for i, arg := range args {
 			argValues[i] = reflect.ValueOf(arg)
 		}
+		// Handlers generated with the context option take a context.Context
+		// in place of the FContext.
+		if len(args) > 0 && method.Type().NumIn() > 0 && method.Type().In(0) == contextType {
+			if fctx, ok := args[0].(FContext); ok {
+				if _, ok := fctx.(context.Context); !ok {
+					argValues[0] = reflect.ValueOf(WithFContext(context.Background(), fctx))
+				}
+			}
+		}
 		returnValues := method.Call(argValues)
 		results := make([]interface{}, len(returnValues))
 		for i, ret := range returnValues {
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+const defaultMaxHedges = 1
+
+// HedgeBuilder configures and builds request hedging ServiceMiddleware.
+// Hedging sends another attempt of a slow request without cancelling the
+// outstanding ones and returns whichever result arrives first, which trades
+// extra load for lower tail latency.
+type HedgeBuilder struct {
+	delay          time.Duration
+	maxHedges      uint
+	retryableTypes map[int]bool
+	idempotency    idempotency
+}
+
+// NewHedgeBuilder creates a builder which configures and builds hedging
+// ServiceMiddleware. A hedged attempt of a request to an idempotent method is
+// sent if no result has arrived after the given delay.
+func NewHedgeBuilder(delay time.Duration) *HedgeBuilder {
+	return &HedgeBuilder{
+		delay:     delay,
+		maxHedges: defaultMaxHedges,
+		retryableTypes: map[int]bool{
+			frugal.TRANSPORT_EXCEPTION_TIMED_OUT: true,
+			frugal.TRANSPORT_EXCEPTION_NOT_OPEN:  true,
+		},
+		idempotency: newIdempotency(),
+	}
+}
+
+// WithMaxHedges controls the maximum number of hedged attempts sent in
+// addition to the original request.
+func (h *HedgeBuilder) WithMaxHedges(maxHedges uint) *HedgeBuilder {
+	h.maxHedges = maxHedges
+	return h
+}
+
+// WithRetryableTypes controls which TTransportException types cause a failed
+// attempt to be ignored in favor of the outstanding ones.
+func (h *HedgeBuilder) WithRetryableTypes(types ...int) *HedgeBuilder {
+	h.retryableTypes = make(map[int]bool, len(types))
+	for _, t := range types {
+		h.retryableTypes[t] = true
+	}
+	return h
+}
+
+// WithIdempotentMethods marks the given methods as idempotent in addition to
+// those annotated with (idempotent).
+func (h *HedgeBuilder) WithIdempotentMethods(methods ...string) *HedgeBuilder {
+	h.idempotency.addMethods(methods)
+	return h
+}
+
+// WithAnnotations uses the given method annotations, such as those returned
+// by FProcessor.Annotations, in place of the annotations of the client.
+func (h *HedgeBuilder) WithAnnotations(annotations map[string]map[string]string) *HedgeBuilder {
+	h.idempotency.addAnnotations(annotations)
+	return h
+}
+
+// hedgeResult is the result of a single attempt.
+type hedgeResult struct {
+	results frugal.Results
+	ctx     frugal.FContext
+}
+
+// Build a new configured hedging ServiceMiddleware.
+func (h *HedgeBuilder) Build() frugal.ServiceMiddleware {
+	var (
+		delay          = h.delay
+		maxHedges      = h.maxHedges
+		retryableTypes = h.retryableTypes
+		idempotency    = h.idempotency
+	)
+	return func(next frugal.InvocationHandler) frugal.InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args frugal.Arguments) frugal.Results {
+			if maxHedges == 0 || !idempotency.isIdempotent(service, method) {
+				return next(service, method, args)
+			}
+
+			ctx := args.Context()
+			deadline := time.Now().Add(ctx.Timeout())
+			resultC := make(chan hedgeResult, maxHedges+1)
+			send := func() {
+				attemptArgs, attemptCtx := attemptArgs(args, ctx, time.Until(deadline))
+				go func() {
+					resultC <- hedgeResult{results: next(service, method, attemptArgs), ctx: attemptCtx}
+				}()
+			}
+
+			send()
+			sent, received := uint(1), uint(0)
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			var last hedgeResult
+			for {
+				select {
+				case last = <-resultC:
+					received++
+					if !isRetryable(last.results.Error(), retryableTypes) || received == maxHedges+1 {
+						CopyResponseHeaders(last.ctx, ctx)
+						return last.results
+					}
+					if received == sent {
+						// All outstanding attempts failed, hedge now.
+						send()
+						sent++
+						timer.Reset(delay)
+					}
+				case <-timer.C:
+					if sent <= maxHedges {
+						send()
+						sent++
+						timer.Reset(delay)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures a slow request to an idempotent method is hedged and the first
+// result is returned.
+func TestHedgeSlowRequest(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{delay: time.Second}
+	hedge := NewHedgeBuilder(10 * time.Millisecond).Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{hedge})
+
+	ctx := frugal.NewFContext("")
+	start := time.Now()
+	ret := method.Invoke([]interface{}{ctx})
+
+	assert.Nil(ret.Error())
+	assert.Equal("foo", ret[0])
+	assert.True(time.Since(start) < time.Second)
+	assert.Equal(2, client.callCount())
+	header, _ := ctx.ResponseHeader("attempt")
+	assert.Equal("2", header)
+}
+
+// Ensures requests to methods which are not idempotent are never hedged.
+func TestHedgeNotIdempotent(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{delay: 50 * time.Millisecond}
+	hedge := NewHedgeBuilder(10 * time.Millisecond).Build()
+	method := frugal.NewMethod(client, client.put, "put", []frugal.ServiceMiddleware{hedge})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Nil(ret.Error())
+	assert.Equal(1, client.callCount())
+}
+
+// Ensures a failed attempt is hedged immediately and the last error is
+// returned once all attempts fail.
+func TestHedgeAllAttemptsFail(t *testing.T) {
+	assert := assert.New(t)
+	timedOut := thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")
+	client := &testClient{errs: []error{timedOut, timedOut, timedOut}}
+	hedge := NewHedgeBuilder(time.Second).WithMaxHedges(2).Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{hedge})
+
+	start := time.Now()
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Equal(timedOut, ret.Error())
+	assert.Equal(3, client.callCount())
+	assert.True(time.Since(start) < time.Second)
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package middleware provides frugal.ServiceMiddleware implementations for Go
+// clients, such as retries and request hedging.
+//
+// Retrying or hedging a request which is not idempotent can apply its side
+// effects more than once, so these middleware only act on methods which are
+// known to be idempotent. A method is idempotent if it has the (idempotent)
+// annotation in the IDL, which generated clients expose through their
+// Annotations method, or if it is configured as idempotent on the builder.
+package middleware
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// IdempotentAnnotation is the IDL method annotation marking a method as safe
+// to retry or hedge.
+const IdempotentAnnotation = "idempotent"
+
+// annotated is implemented by generated clients and by FProcessor.
+type annotated interface {
+	Annotations() map[string]map[string]string
+}
+
+// idempotency determines which methods are idempotent from configured method
+// names and annotations, falling back to the annotations of the proxied
+// service.
+type idempotency struct {
+	methods     map[string]bool
+	annotations map[string]map[string]string
+}
+
+func newIdempotency() idempotency {
+	return idempotency{
+		methods:     make(map[string]bool),
+		annotations: make(map[string]map[string]string),
+	}
+}
+
+// addMethods marks the given method names as idempotent.
+func (i idempotency) addMethods(methods []string) {
+	for _, method := range methods {
+		i.methods[methodKey(method)] = true
+	}
+}
+
+// addAnnotations adds the given method annotations, such as those returned
+// by FProcessor.Annotations.
+func (i idempotency) addAnnotations(annotations map[string]map[string]string) {
+	for method, anns := range annotations {
+		i.annotations[methodKey(method)] = anns
+	}
+}
+
+// isIdempotent indicates if the given method of the proxied service is
+// idempotent.
+func (i idempotency) isIdempotent(service reflect.Value, method reflect.Method) bool {
+	key := methodKey(method.Name)
+	if i.methods[key] {
+		return true
+	}
+	if anns, ok := i.annotations[key]; ok {
+		return annotationIdempotent(anns)
+	}
+	if service.IsValid() && service.CanInterface() {
+		if a, ok := service.Interface().(annotated); ok {
+			for name, anns := range a.Annotations() {
+				if methodKey(name) == key {
+					return annotationIdempotent(anns)
+				}
+			}
+		}
+	}
+	return false
+}
+
+// annotationIdempotent indicates if the given annotations mark a method as
+// idempotent. An empty annotation value is treated as true.
+func annotationIdempotent(anns map[string]string) bool {
+	value, ok := anns[IdempotentAnnotation]
+	if !ok {
+		return false
+	}
+	value = strings.ToLower(strings.TrimSpace(value))
+	return value == "" || value == "true"
+}
+
+// methodKey normalizes a method name. Annotations are keyed by the IDL method
+// name, e.g. get_thing, while generated clients proxy Go methods, e.g.
+// getThing, so names are compared ignoring case and underscores.
+func methodKey(name string) string {
+	return strings.ToLower(strings.Replace(name, "_", "", -1))
+}
+
+// isRetryable indicates if the given error is a TTransportException of one of
+// the given types.
+func isRetryable(err error, types map[int]bool) bool {
+	if e, ok := err.(thrift.TTransportException); ok {
+		return types[e.TypeId()]
+	}
+	return false
+}
+
+// attemptArgs returns a copy of args whose FContext is a clone of ctx with
+// the given timeout. Each attempt needs its own operation ID so concurrent
+// attempts don't collide on the transport.
+func attemptArgs(args frugal.Arguments, ctx frugal.FContext, timeout time.Duration) (frugal.Arguments, frugal.FContext) {
+	attemptCtx := frugal.Clone(ctx)
+	attemptCtx.SetTimeout(timeout)
+	attempt := make(frugal.Arguments, len(args))
+	copy(attempt, args)
+	attempt.SetContext(attemptCtx)
+	return attempt, attemptCtx
+}
+
+// CopyResponseHeaders copies the response headers, except the op ID, of a
+// per-call copy of an FContext, such as one made with frugal.Clone, back to
+// the caller's FContext.
+func CopyResponseHeaders(from, to frugal.FContext) {
+	for name, value := range from.ResponseHeaders() {
+		if name == "_opid" {
+			continue
+		}
+		to.AddResponseHeader(name, value)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+const defaultMaxAttempts = 3
+
+// BackoffFunc returns the time to wait before the given retry. The first
+// retry is 1.
+type BackoffFunc func(retry uint) time.Duration
+
+// ConstantBackoff returns a BackoffFunc which always waits the given
+// duration.
+func ConstantBackoff(wait time.Duration) BackoffFunc {
+	return func(uint) time.Duration {
+		return wait
+	}
+}
+
+// ExponentialBackoff returns a BackoffFunc which doubles the wait for every
+// retry, starting at initial and capped at max. A random jitter of up to half
+// the wait is subtracted to avoid synchronized retries.
+func ExponentialBackoff(initial, max time.Duration) BackoffFunc {
+	return func(retry uint) time.Duration {
+		wait := initial
+		for i := uint(1); i < retry && wait < max; i++ {
+			wait *= 2
+		}
+		if wait > max {
+			wait = max
+		}
+		if half := int64(wait / 2); half > 0 {
+			wait -= time.Duration(rand.Int63n(half))
+		}
+		return wait
+	}
+}
+
+// RetryBuilder configures and builds retry ServiceMiddleware.
+type RetryBuilder struct {
+	maxAttempts    uint
+	backoff        BackoffFunc
+	retryableTypes map[int]bool
+	idempotency    idempotency
+	attemptTimeout time.Duration
+}
+
+// NewRetryBuilder creates a builder which configures and builds retry
+// ServiceMiddleware. By default, requests to idempotent methods are attempted
+// up to three times when they fail with a TRANSPORT_EXCEPTION_TIMED_OUT or
+// TRANSPORT_EXCEPTION_NOT_OPEN TTransportException. Each attempt may use the
+// rest of the request's timeout, so a timed out request is only retried if
+// WithAttemptTimeout is set.
+func NewRetryBuilder() *RetryBuilder {
+	return &RetryBuilder{
+		maxAttempts: defaultMaxAttempts,
+		backoff:     ExponentialBackoff(10*time.Millisecond, time.Second),
+		retryableTypes: map[int]bool{
+			frugal.TRANSPORT_EXCEPTION_TIMED_OUT: true,
+			frugal.TRANSPORT_EXCEPTION_NOT_OPEN:  true,
+		},
+		idempotency: newIdempotency(),
+	}
+}
+
+// WithMaxAttempts controls the maximum number of times a request is attempted,
+// including the first attempt.
+func (r *RetryBuilder) WithMaxAttempts(maxAttempts uint) *RetryBuilder {
+	r.maxAttempts = maxAttempts
+	return r
+}
+
+// WithBackoff controls the time to wait between attempts.
+func (r *RetryBuilder) WithBackoff(backoff BackoffFunc) *RetryBuilder {
+	r.backoff = backoff
+	return r
+}
+
+// WithAttemptTimeout limits the time each attempt may take, leaving the rest
+// of the request's timeout for further attempts. By default, each attempt may
+// use all of the time remaining.
+func (r *RetryBuilder) WithAttemptTimeout(timeout time.Duration) *RetryBuilder {
+	r.attemptTimeout = timeout
+	return r
+}
+
+// WithRetryableTypes controls which TTransportException types are retried.
+func (r *RetryBuilder) WithRetryableTypes(types ...int) *RetryBuilder {
+	r.retryableTypes = make(map[int]bool, len(types))
+	for _, t := range types {
+		r.retryableTypes[t] = true
+	}
+	return r
+}
+
+// WithIdempotentMethods marks the given methods as idempotent in addition to
+// those annotated with (idempotent).
+func (r *RetryBuilder) WithIdempotentMethods(methods ...string) *RetryBuilder {
+	r.idempotency.addMethods(methods)
+	return r
+}
+
+// WithAnnotations uses the given method annotations, such as those returned
+// by FProcessor.Annotations, in place of the annotations of the client.
+func (r *RetryBuilder) WithAnnotations(annotations map[string]map[string]string) *RetryBuilder {
+	r.idempotency.addAnnotations(annotations)
+	return r
+}
+
+// Build a new configured retry ServiceMiddleware. Retries stay within the
+// timeout of the request's FContext, which is the budget for all attempts.
+func (r *RetryBuilder) Build() frugal.ServiceMiddleware {
+	var (
+		maxAttempts    = r.maxAttempts
+		backoff        = r.backoff
+		retryableTypes = r.retryableTypes
+		idempotency    = r.idempotency
+		attemptTimeout = r.attemptTimeout
+	)
+	return func(next frugal.InvocationHandler) frugal.InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args frugal.Arguments) frugal.Results {
+			if maxAttempts <= 1 || !idempotency.isIdempotent(service, method) {
+				return next(service, method, args)
+			}
+
+			ctx := args.Context()
+			deadline := time.Now().Add(ctx.Timeout())
+			var results frugal.Results
+			for attempt := uint(1); ; attempt++ {
+				timeout := time.Until(deadline)
+				if attemptTimeout > 0 && attemptTimeout < timeout {
+					timeout = attemptTimeout
+				}
+				attemptArgs, attemptCtx := attemptArgs(args, ctx, timeout)
+				results = next(service, method, attemptArgs)
+				CopyResponseHeaders(attemptCtx, ctx)
+				if attempt >= maxAttempts || !isRetryable(results.Error(), retryableTypes) {
+					return results
+				}
+
+				wait := backoff(attempt)
+				if time.Until(deadline) <= wait {
+					// Not enough budget left for another attempt.
+					return results
+				}
+				select {
+				case <-time.After(wait):
+				case <-frugal.ToContext(ctx).Done():
+					return results
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package middleware
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures requests to methods annotated (idempotent) are retried until they
+// succeed.
+func TestRetryIdempotent(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{
+		errs: []error{
+			thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out"),
+			thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_NOT_OPEN, "not open"),
+		},
+	}
+	retry := NewRetryBuilder().WithBackoff(ConstantBackoff(time.Millisecond)).Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{retry})
+
+	ctx := frugal.NewFContext("")
+	ret := method.Invoke([]interface{}{ctx})
+
+	assert.Nil(ret.Error())
+	assert.Equal("foo", ret[0])
+	assert.Equal(3, client.callCount())
+	header, _ := ctx.ResponseHeader("attempt")
+	assert.Equal("3", header)
+}
+
+// Ensures annotations keyed by snake_case IDL method names apply to the
+// generated Go methods.
+func TestRetryIdempotentSnakeCase(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{
+		errs: []error{thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")},
+	}
+	retry := NewRetryBuilder().WithBackoff(ConstantBackoff(time.Millisecond)).Build()
+	method := frugal.NewMethod(client, client.getThing, "getThing", []frugal.ServiceMiddleware{retry})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Nil(ret.Error())
+	assert.Equal(2, client.callCount())
+
+	client = &testClient{
+		errs: []error{thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")},
+	}
+	retry = NewRetryBuilder().
+		WithBackoff(ConstantBackoff(time.Millisecond)).
+		WithAnnotations(map[string]map[string]string{"put_thing": {"idempotent": "true"}}).
+		Build()
+	method = frugal.NewMethod(client, client.put, "putThing", []frugal.ServiceMiddleware{retry})
+
+	ret = method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Nil(ret.Error())
+	assert.Equal(2, client.callCount())
+}
+
+// Ensures requests to methods which are not idempotent are never retried.
+func TestRetryNotIdempotent(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{
+		errs: []error{thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")},
+	}
+	retry := NewRetryBuilder().WithBackoff(ConstantBackoff(time.Millisecond)).Build()
+	method := frugal.NewMethod(client, client.put, "put", []frugal.ServiceMiddleware{retry})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.NotNil(ret.Error())
+	assert.Equal(1, client.callCount())
+}
+
+// Ensures methods configured as idempotent are retried and errors which are
+// not retryable are returned immediately.
+func TestRetryIdempotentMethodsNotRetryable(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{
+		errs: []error{
+			thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out"),
+			errors.New("application error"),
+		},
+	}
+	retry := NewRetryBuilder().
+		WithBackoff(ConstantBackoff(time.Millisecond)).
+		WithIdempotentMethods("Put").
+		Build()
+	method := frugal.NewMethod(client, client.put, "put", []frugal.ServiceMiddleware{retry})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Equal(errors.New("application error"), ret.Error())
+	assert.Equal(2, client.callCount())
+}
+
+// Ensures retries stop at the maximum number of attempts.
+func TestRetryMaxAttempts(t *testing.T) {
+	assert := assert.New(t)
+	timedOut := thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")
+	client := &testClient{errs: []error{timedOut, timedOut, timedOut, timedOut}}
+	retry := NewRetryBuilder().
+		WithBackoff(ConstantBackoff(time.Millisecond)).
+		WithMaxAttempts(2).
+		Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{retry})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.Equal(timedOut, ret.Error())
+	assert.Equal(2, client.callCount())
+}
+
+// Ensures retries respect the timeout of the FContext.
+func TestRetryTimeoutBudget(t *testing.T) {
+	assert := assert.New(t)
+	timedOut := thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")
+	client := &testClient{errs: []error{timedOut, timedOut, timedOut}}
+	retry := NewRetryBuilder().WithBackoff(ConstantBackoff(50 * time.Millisecond)).Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{retry})
+
+	ctx := frugal.NewFContext("")
+	ctx.SetTimeout(20 * time.Millisecond)
+	ret := method.Invoke([]interface{}{ctx})
+
+	assert.Equal(timedOut, ret.Error())
+	assert.Equal(1, client.callCount())
+	assert.True(client.timeouts()[0] <= 20*time.Millisecond)
+}
+
+// Ensures an attempt timeout leaves time in the FContext's timeout for
+// retrying timed out attempts.
+func TestRetryAttemptTimeout(t *testing.T) {
+	assert := assert.New(t)
+	timedOut := thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")
+	client := &testClient{errs: []error{timedOut}}
+	retry := NewRetryBuilder().
+		WithBackoff(ConstantBackoff(time.Millisecond)).
+		WithAttemptTimeout(100 * time.Millisecond).
+		Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{retry})
+
+	ctx := frugal.NewFContext("")
+	ctx.SetTimeout(time.Second)
+	ret := method.Invoke([]interface{}{ctx})
+
+	assert.Nil(ret.Error())
+	assert.Equal(2, client.callCount())
+	for _, timeout := range client.timeouts() {
+		assert.True(timeout <= 100*time.Millisecond)
+	}
+}
+
+// Ensures annotations passed to the builder override those of the client.
+func TestRetryWithAnnotations(t *testing.T) {
+	assert := assert.New(t)
+	client := &testClient{
+		errs: []error{thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_TIMED_OUT, "timed out")},
+	}
+	retry := NewRetryBuilder().
+		WithBackoff(ConstantBackoff(time.Millisecond)).
+		WithAnnotations(map[string]map[string]string{"get": {"idempotent": "false"}}).
+		Build()
+	method := frugal.NewMethod(client, client.get, "get", []frugal.ServiceMiddleware{retry})
+
+	ret := method.Invoke([]interface{}{frugal.NewFContext("")})
+
+	assert.NotNil(ret.Error())
+	assert.Equal(1, client.callCount())
+}
+
+// Ensures ExponentialBackoff grows and is capped.
+func TestExponentialBackoff(t *testing.T) {
+	assert := assert.New(t)
+	backoff := ExponentialBackoff(10*time.Millisecond, 40*time.Millisecond)
+	for retry, max := range map[uint]time.Duration{1: 10, 2: 20, 3: 40, 4: 40} {
+		wait := backoff(retry)
+		assert.True(wait <= max*time.Millisecond)
+		assert.True(wait > max*time.Millisecond/2)
+	}
+}
+
+// testClient mimics a generated client. The get method is annotated as
+// idempotent. Each call returns the next error in errs, then succeeds.
+type testClient struct {
+	mu           sync.Mutex
+	errs         []error
+	calls        int
+	callTimeouts []time.Duration
+	delay        time.Duration
+}
+
+func (c *testClient) Annotations() map[string]map[string]string {
+	return map[string]map[string]string{
+		"get":       {"idempotent": ""},
+		"get_thing": {"idempotent": ""},
+	}
+}
+
+func (c *testClient) get(ctx frugal.FContext) (string, error) {
+	c.mu.Lock()
+	call := c.calls
+	c.calls++
+	c.callTimeouts = append(c.callTimeouts, ctx.Timeout())
+	var err error
+	if call < len(c.errs) {
+		err = c.errs[call]
+	}
+	c.mu.Unlock()
+	ctx.AddResponseHeader("attempt", string(rune('1'+call)))
+	if c.delay > 0 && call == 0 {
+		time.Sleep(c.delay)
+	}
+	if err != nil {
+		return "", err
+	}
+	return "foo", nil
+}
+
+func (c *testClient) getThing(ctx frugal.FContext) (string, error) {
+	return c.get(ctx)
+}
+
+func (c *testClient) put(ctx frugal.FContext) error {
+	_, err := c.get(ctx)
+	return err
+}
+
+func (c *testClient) callCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *testClient) timeouts() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.callTimeouts
+}
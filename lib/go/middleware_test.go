@@ -14,6 +14,7 @@
 package frugal
 
 import (
+	"context"
 	"reflect"
 	"testing"
 	"time"
@@ -78,6 +79,28 @@ func TestServiceMiddlewareNoMiddleware(t *testing.T) {
 	assert.Equal(arg, handler.calledArg)
 }
 
+// Ensure a proxied method taking a context.Context receives the FContext.
+func TestServiceMiddlewareContextHandler(t *testing.T) {
+	assert := assert.New(t)
+	handler := &testContextHandler{}
+	method := NewMethod(handler, handler.handlerMethod, "handlerMethod", nil)
+
+	ctx := NewFContext("fooid")
+	ret := method.Invoke([]interface{}{ctx, 42})
+
+	assert.Equal("foo", ret[0])
+	assert.Equal(ctx, FContextFromContext(handler.calledContext))
+}
+
+type testContextHandler struct {
+	calledContext context.Context
+}
+
+func (t *testContextHandler) handlerMethod(ctx context.Context, x int) string {
+	t.calledContext = ctx
+	return "foo"
+}
+
 type testHandler struct {
 	calledContext FContext
 	calledArg     int
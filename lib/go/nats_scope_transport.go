@@ -99,6 +99,7 @@ func (n *fNatsPublisherTransport) Publish(topic string, data []byte) error {
 	}
 
 	if len(data) > natsMaxMessageSize {
+		stats().FrameTooLarge("nats")
 		return thrift.NewTTransportException(
 			TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
 			fmt.Sprintf("Message exceeds %d bytes, was %d bytes", natsMaxMessageSize, len(data)))
@@ -15,6 +15,8 @@ package frugal
 
 import (
 	"bytes"
+	"context"
+	"sync"
 	"time"
 
 	"git.apache.org/thrift.git/lib/go/thrift"
@@ -96,6 +98,7 @@ func (f *FNatsServerBuilder) Build() FServer {
 		workerCount:   f.workerCount,
 		workC:         make(chan *frameWrapper, f.queueLen),
 		quit:          make(chan struct{}),
+		draining:      make(chan struct{}),
 		highWatermark: f.highWatermark,
 	}
 }
@@ -111,20 +114,29 @@ type fNatsServer struct {
 	workerCount   uint
 	workC         chan *frameWrapper
 	quit          chan struct{}
+	draining      chan struct{}
+	stopOnce      sync.Once
+	drainOnce     sync.Once
+	workers       sync.WaitGroup
 	highWatermark time.Duration
+
+	mu            sync.Mutex
+	subscriptions []*nats.Subscription
 }
 
 // Serve starts the server.
 func (f *fNatsServer) Serve() error {
-	subscriptions := []*nats.Subscription{}
 	for _, subject := range f.subjects {
 		sub, err := f.conn.QueueSubscribe(subject, f.queue, f.handler)
 		if err != nil {
 			return err
 		}
-		subscriptions = append(subscriptions, sub)
+		f.mu.Lock()
+		f.subscriptions = append(f.subscriptions, sub)
+		f.mu.Unlock()
 	}
 
+	f.workers.Add(int(f.workerCount))
 	for i := uint(0); i < f.workerCount; i++ {
 		go f.worker()
 	}
@@ -133,19 +145,52 @@ func (f *fNatsServer) Serve() error {
 	<-f.quit
 	logger().Info("frugal: server stopping...")
 
-	for _, sub := range subscriptions {
-		sub.Unsubscribe()
-	}
+	f.unsubscribe()
 
 	return nil
 }
 
 // Stop the server.
 func (f *fNatsServer) Stop() error {
-	close(f.quit)
+	f.stopOnce.Do(func() { close(f.quit) })
 	return nil
 }
 
+// Shutdown gracefully stops the server. It unsubscribes so no new requests
+// are received and waits for the workers to process the requests already in
+// the work queue before stopping. If ctx is done first, the server is stopped
+// immediately and ctx.Err() is returned.
+func (f *fNatsServer) Shutdown(ctx context.Context) error {
+	logger().Info("frugal: server draining...")
+	f.unsubscribe()
+	f.drainOnce.Do(func() { close(f.draining) })
+
+	done := make(chan struct{})
+	go func() {
+		f.workers.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	f.Stop()
+	return err
+}
+
+// unsubscribe removes the server's NATS subscriptions.
+func (f *fNatsServer) unsubscribe() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, sub := range f.subscriptions {
+		sub.Unsubscribe()
+	}
+	f.subscriptions = nil
+}
+
 // handler is invoked when a request is received. The request is placed on the
 // work channel which is processed by a worker goroutine.
 func (f *fNatsServer) handler(msg *nats.Msg) {
@@ -155,30 +200,53 @@ func (f *fNatsServer) handler(msg *nats.Msg) {
 	}
 	select {
 	case f.workC <- &frameWrapper{frameBytes: msg.Data, timestamp: time.Now(), reply: msg.Reply}:
+		stats().QueueDepth("nats", len(f.workC))
+	case <-f.draining:
+		logger().Warn("frugal: discarding NATS request received while draining")
 	case <-f.quit:
 		return
 	}
 }
 
 // worker should be called as a goroutine. It reads requests off the work
-// channel and processes them.
+// channel and processes them. Once the server is draining, it processes the
+// requests remaining in the work channel and returns.
 func (f *fNatsServer) worker() {
+	defer f.workers.Done()
 	for {
 		select {
 		case <-f.quit:
 			return
 		case frame := <-f.workC:
-			dur := time.Since(frame.timestamp)
-			if dur > f.highWatermark {
-				logger().Warnf("frugal: request spent %+v in the transport buffer, your consumer might be backed up", dur)
-			}
-			if err := f.processFrame(frame.frameBytes, frame.reply); err != nil {
-				logger().Errorf("frugal: error processing request: %s", err.Error())
+			f.processWork(frame)
+		case <-f.draining:
+			for {
+				select {
+				case <-f.quit:
+					return
+				case frame := <-f.workC:
+					f.processWork(frame)
+				default:
+					return
+				}
 			}
 		}
 	}
 }
 
+// processWork processes a request taken off the work channel.
+func (f *fNatsServer) processWork(frame *frameWrapper) {
+	stats().QueueDepth("nats", len(f.workC))
+	dur := time.Since(frame.timestamp)
+	if dur > f.highWatermark {
+		stats().HighWatermarkBreached("nats", dur)
+		logger().Warnf("frugal: request spent %+v in the transport buffer, your consumer might be backed up", dur)
+	}
+	if err := f.processFrame(frame.frameBytes, frame.reply); err != nil {
+		logger().Errorf("frugal: error processing request: %s", err.Error())
+	}
+}
+
 // processFrame invokes the FProcessor and sends the response on the given
 // subject.
 func (f *fNatsServer) processFrame(frame []byte, reply string) error {
@@ -189,6 +257,9 @@ func (f *fNatsServer) processFrame(frame []byte, reply string) error {
 	iprot := f.protoFactory.GetProtocol(input)
 	oprot := f.protoFactory.GetProtocol(output)
 	if err := f.processor.Process(iprot, oprot); err != nil {
+		if IsErrTooLarge(err) {
+			stats().FrameTooLarge("nats")
+		}
 		return err
 	}
 
@@ -14,7 +14,9 @@
 package frugal
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -62,6 +64,61 @@ func TestFStatelessNatsServer(t *testing.T) {
 	assert.Equal(t, "foo", string(resultBytes))
 }
 
+// Ensures Shutdown waits for queued requests to be processed before
+// stopping the workers.
+func TestFNatsServerShutdownDrainsQueue(t *testing.T) {
+	processor := &countingProcessor{delay: 5 * time.Millisecond}
+	protoFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	server := NewFNatsServerBuilder(nil, processor, protoFactory, nil).
+		WithWorkerCount(2).
+		Build().(*fNatsServer)
+	for i := 0; i < 10; i++ {
+		server.workC <- &frameWrapper{frameBytes: make([]byte, 4), timestamp: time.Now(), reply: "reply"}
+	}
+	server.workers.Add(int(server.workerCount))
+	for i := uint(0); i < server.workerCount; i++ {
+		go server.worker()
+	}
+
+	assert.Nil(t, server.Shutdown(context.Background()))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&processor.count))
+}
+
+// Ensures Shutdown returns the context error if queued requests are not
+// processed before the deadline.
+func TestFNatsServerShutdownDeadline(t *testing.T) {
+	processor := &countingProcessor{delay: 50 * time.Millisecond}
+	protoFactory := NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault())
+	server := NewFNatsServerBuilder(nil, processor, protoFactory, nil).Build().(*fNatsServer)
+	for i := 0; i < 10; i++ {
+		server.workC <- &frameWrapper{frameBytes: make([]byte, 4), timestamp: time.Now(), reply: "reply"}
+	}
+	server.workers.Add(1)
+	go server.worker()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, server.Shutdown(ctx))
+	assert.True(t, atomic.LoadInt32(&processor.count) < 10)
+}
+
+type countingProcessor struct {
+	count int32
+	delay time.Duration
+}
+
+func (p *countingProcessor) Process(in, out *FProtocol) error {
+	time.Sleep(p.delay)
+	atomic.AddInt32(&p.count, 1)
+	return nil
+}
+
+func (p *countingProcessor) AddMiddleware(middleware ServiceMiddleware) {}
+
+func (p *countingProcessor) Annotations() map[string]map[string]string {
+	return nil
+}
+
 type processor struct {
 	t *testing.T
 }
@@ -110,6 +110,7 @@ func (f *fNatsTransport) Close() error {
 
 func (f *fNatsTransport) checkMessageSize(data []byte) error {
 	if len(data) > natsMaxMessageSize {
+		stats().FrameTooLarge("nats")
 		return thrift.NewTTransportException(
 			TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
 			fmt.Sprintf("Message exceeds %d bytes, was %d bytes", natsMaxMessageSize, len(data)))
@@ -166,6 +167,8 @@ func (f *fNatsTransport) Request(ctx FContext, data []byte) (thrift.TTransport,
 	select {
 	case result := <-resultC:
 		return &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(result)}, nil
+	case <-ToContext(ctx).Done():
+		return nil, contextError(ctx)
 	case <-time.After(ctx.Timeout()):
 		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: nats request timed out")
 	}
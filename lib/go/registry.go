@@ -78,6 +78,9 @@ func (c *fRegistryImpl) Register(ctx FContext, resultC chan []byte) error {
 			return fmt.Errorf("frugal: context already registered, opid %d is in-flight for another request", opID)
 		}
 	}
+	if _, ok := c.channels[opID]; !ok {
+		stats().RegistryInFlight(1)
+	}
 	c.channels[opID] = resultC
 	return nil
 }
@@ -90,7 +93,10 @@ func (c *fRegistryImpl) Unregister(ctx FContext) {
 		return
 	}
 	c.mu.Lock()
-	delete(c.channels, opID)
+	if _, ok := c.channels[opID]; ok {
+		delete(c.channels, opID)
+		stats().RegistryInFlight(-1)
+	}
 	c.mu.Unlock()
 }
 
@@ -13,6 +13,8 @@
 
 package frugal
 
+import "context"
+
 // FServer is Frugal's equivalent of Thrift's TServer. It's used to run a Frugal
 // RPC service by executing an FProcessor on client connections.
 type FServer interface {
@@ -22,4 +24,9 @@ type FServer interface {
 	// Stop the server. This is optional on a per-implementation basis. Not all
 	// servers are required to be cleanly stoppable.
 	Stop() error
+
+	// Shutdown gracefully stops the server. It stops accepting new requests
+	// and waits for in-flight requests to complete before returning. If ctx
+	// is done before then, the server is stopped and ctx.Err() is returned.
+	Shutdown(ctx context.Context) error
 }
@@ -14,20 +14,126 @@
 package frugal
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
 	"git.apache.org/thrift.git/lib/go/thrift"
 )
 
+// FSimpleServerBuilder configures and builds FSimpleServer instances.
+type FSimpleServerBuilder struct {
+	processor       FProcessor
+	serverTransport thrift.TServerTransport
+	protocolFactory *FProtocolFactory
+	workerCount     uint
+	queueLen        uint
+	highWatermark   time.Duration
+}
+
+// NewFSimpleServerBuilder creates a builder which configures and builds
+// FSimpleServer instances.
+func NewFSimpleServerBuilder(processor FProcessor, serverTransport thrift.TServerTransport,
+	protocolFactory *FProtocolFactory) *FSimpleServerBuilder {
+	return &FSimpleServerBuilder{
+		processor:       processor,
+		serverTransport: serverTransport,
+		protocolFactory: protocolFactory,
+		queueLen:        defaultWorkQueueLen,
+		highWatermark:   defaultWatermark,
+	}
+}
+
+// WithWorkerCount controls the number of goroutines used to process requests.
+// The workers are shared by all connections, which allows requests
+// multiplexed on a single connection to be processed concurrently while
+// capping the total concurrency of the server. A worker count of zero, the
+// default, processes the requests on each connection sequentially in a
+// goroutine per connection.
+func (f *FSimpleServerBuilder) WithWorkerCount(workerCount uint) *FSimpleServerBuilder {
+	f.workerCount = workerCount
+	return f
+}
+
+// WithQueueLength controls the length of the work queue used to buffer
+// requests. This only applies if a worker count is set.
+func (f *FSimpleServerBuilder) WithQueueLength(queueLength uint) *FSimpleServerBuilder {
+	f.queueLen = queueLength
+	return f
+}
+
+// WithHighWatermark controls the time duration requests wait in queue before
+// triggering slow consumer logic. This only applies if a worker count is set.
+func (f *FSimpleServerBuilder) WithHighWatermark(highWatermark time.Duration) *FSimpleServerBuilder {
+	f.highWatermark = highWatermark
+	return f
+}
+
+// Build a new configured FSimpleServer.
+func (f *FSimpleServerBuilder) Build() FServer {
+	server := NewFSimpleServer(f.processor, f.serverTransport, f.protocolFactory)
+	server.workerCount = f.workerCount
+	server.workC = make(chan *simpleFrame, f.queueLen)
+	server.highWatermark = f.highWatermark
+	return server
+}
+
+// simpleFrame is a request frame read off of a client connection waiting to
+// be processed by a worker.
+type simpleFrame struct {
+	frameBytes []byte
+	timestamp  time.Time
+	client     *simpleClient
+}
+
+// simpleClient serializes writes of responses to a client connection.
+type simpleClient struct {
+	transport thrift.TTransport
+	mu        sync.Mutex
+}
+
+// write sends the given framed response to the client.
+func (c *simpleClient) write(frame []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.transport.Write(frame); err != nil {
+		return err
+	}
+	return c.transport.Flush()
+}
+
 // FSimpleServer is a simple FServer which starts a goroutine for each
-// connection.
+// connection. If built with a worker count, requests are processed by a
+// bounded pool of workers shared by all connections.
 type FSimpleServer struct {
 	quit            chan struct{}
+	stopOnce        sync.Once
 	processor       FProcessor
 	serverTransport thrift.TServerTransport
 	protocolFactory *FProtocolFactory
+
+	workerCount     uint
+	workC           chan *simpleFrame
+	workersQuit     chan struct{}
+	stopWorkersOnce sync.Once
+	highWatermark   time.Duration
+
+	// mu guards clients and shuttingDown. inFlight counts requests being
+	// processed and may only be incremented while holding mu.
+	mu           sync.Mutex
+	clients      map[thrift.TTransport]struct{}
+	shuttingDown bool
+	inFlight     sync.WaitGroup
 }
 
 // NewFSimpleServer creates a new FSimpleServer which is a simple FServer that
-// starts a goroutine for each connection.
+// starts a goroutine for each connection. Use NewFSimpleServerBuilder to
+// process requests with a bounded pool of workers.
 func NewFSimpleServer(
 	processor FProcessor,
 	serverTransport thrift.TServerTransport,
@@ -38,6 +144,8 @@ func NewFSimpleServer(
 		serverTransport: serverTransport,
 		protocolFactory: protocolFactory,
 		quit:            make(chan struct{}, 1),
+		workersQuit:     make(chan struct{}),
+		clients:         make(map[thrift.TTransport]struct{}),
 	}
 }
 
@@ -73,27 +181,120 @@ func (p *FSimpleServer) Serve() error {
 	if err := p.listen(); err != nil {
 		return err
 	}
+	for i := uint(0); i < p.workerCount; i++ {
+		go p.worker()
+	}
 	p.acceptLoop()
 	return nil
 }
 
 // Stop the server.
 func (p *FSimpleServer) Stop() error {
-	close(p.quit)
-	p.serverTransport.Interrupt()
+	p.stopAccepting()
+	p.stopWorkers()
 	return nil
 }
 
+// Shutdown gracefully stops the server. It stops accepting new connections
+// and requests, waits for in-flight requests to complete, and then closes
+// all client connections. If ctx is done first, client connections are
+// closed immediately and ctx.Err() is returned.
+func (p *FSimpleServer) Shutdown(ctx context.Context) error {
+	p.stopAccepting()
+
+	p.mu.Lock()
+	p.shuttingDown = true
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.inFlight.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	p.mu.Lock()
+	for client := range p.clients {
+		client.Close()
+	}
+	p.mu.Unlock()
+	p.stopWorkers()
+	return err
+}
+
+// stopAccepting stops the server from accepting new connections.
+func (p *FSimpleServer) stopAccepting() {
+	p.stopOnce.Do(func() {
+		close(p.quit)
+		p.serverTransport.Interrupt()
+	})
+}
+
+// stopWorkers stops the worker pool, if any.
+func (p *FSimpleServer) stopWorkers() {
+	p.stopWorkersOnce.Do(func() { close(p.workersQuit) })
+}
+
+// startRequest marks a request on a connection as in-flight. It returns false
+// if the server is shutting down and the request should not be processed.
+func (p *FSimpleServer) startRequest() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.shuttingDown {
+		return false
+	}
+	p.inFlight.Add(1)
+	return true
+}
+
+func (p *FSimpleServer) isShuttingDown() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.shuttingDown
+}
+
 func (p *FSimpleServer) accept(client thrift.TTransport) error {
+	p.mu.Lock()
+	if p.shuttingDown {
+		p.mu.Unlock()
+		return client.Close()
+	}
+	p.clients[client] = struct{}{}
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.clients, client)
+		p.mu.Unlock()
+	}()
+
+	logger().Debug("frugal: client connection accepted")
+
+	if p.workerCount > 0 {
+		return p.readLoop(client)
+	}
+
 	framed := NewTFramedTransport(client)
 	iprot := p.protocolFactory.GetProtocol(framed)
 	oprot := p.protocolFactory.GetProtocol(framed)
 	processor := p.processor
 
-	logger().Debug("frugal: client connection accepted")
-
 	for {
+		// Wait for the next request to arrive before marking it in-flight so
+		// idle connections don't hold up Shutdown.
+		if _, err := framed.reader.Peek(1); err != nil && p.isShuttingDown() {
+			return nil
+		}
+		if !p.startRequest() {
+			return client.Close()
+		}
 		err := processor.Process(iprot, oprot)
+		p.inFlight.Done()
 		if err, ok := err.(thrift.TTransportException); ok && err.TypeId() == TRANSPORT_EXCEPTION_END_OF_FILE {
 			return nil
 		} else if err != nil {
@@ -105,3 +306,130 @@ func (p *FSimpleServer) accept(client thrift.TTransport) error {
 		}
 	}
 }
+
+// readLoop reads frames off the client connection and places them on the
+// work channel to be processed by the worker pool. It blocks when the work
+// channel is full.
+func (p *FSimpleServer) readLoop(transport thrift.TTransport) error {
+	client := &simpleClient{transport: transport}
+	reader := newFrameReader(transport)
+	for {
+		// Wait for the next request to arrive before marking it in-flight so
+		// idle connections don't hold up Shutdown.
+		if _, err := reader.Peek(1); err != nil {
+			if isEOF(err) || p.isShuttingDown() {
+				return nil
+			}
+			return err
+		}
+		if !p.startRequest() {
+			return transport.Close()
+		}
+		frame, err := reader.readFrame()
+		if err != nil {
+			p.inFlight.Done()
+			if isEOF(err) {
+				return nil
+			}
+			return err
+		}
+		select {
+		case p.workC <- &simpleFrame{frameBytes: frame, timestamp: time.Now(), client: client}:
+			stats().QueueDepth("simple", len(p.workC))
+		case <-p.workersQuit:
+			p.inFlight.Done()
+			return nil
+		}
+	}
+}
+
+// worker should be called as a goroutine. It reads requests off the work
+// channel and processes them. Requests still queued when the workers are
+// stopped are discarded.
+func (p *FSimpleServer) worker() {
+	for {
+		select {
+		case frame := <-p.workC:
+			p.processWork(frame)
+		case <-p.workersQuit:
+			for {
+				select {
+				case <-p.workC:
+					p.inFlight.Done()
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// processWork processes a request taken off the work channel and writes the
+// response to the client connection it was read from.
+func (p *FSimpleServer) processWork(frame *simpleFrame) {
+	defer p.inFlight.Done()
+	stats().QueueDepth("simple", len(p.workC))
+	dur := time.Since(frame.timestamp)
+	if dur > p.highWatermark {
+		stats().HighWatermarkBreached("simple", dur)
+		logger().Warnf("frugal: request spent %+v in the transport buffer, your consumer might be backed up", dur)
+	}
+
+	input := &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(frame.frameBytes)}
+	output := NewTMemoryOutputBuffer(0)
+	iprot := p.protocolFactory.GetProtocol(input)
+	oprot := p.protocolFactory.GetProtocol(output)
+	if err := p.processor.Process(iprot, oprot); err != nil {
+		logger().Errorf("frugal: error processing request: %s", err.Error())
+		return
+	}
+
+	if !output.HasWriteData() {
+		return
+	}
+
+	if err := frame.client.write(output.Bytes()); err != nil {
+		logger().Errorf("frugal: error writing response: %s", err.Error())
+	}
+}
+
+// frameReader reads whole frames off of a connection.
+type frameReader struct {
+	*bufio.Reader
+	maxLength uint32
+}
+
+func newFrameReader(transport io.Reader) *frameReader {
+	return &frameReader{Reader: bufio.NewReader(transport), maxLength: defaultMaxLength}
+}
+
+// readFrame reads the next frame, returning it without the frame size.
+func (r *frameReader) readFrame() ([]byte, error) {
+	var sizeBuf [4]byte
+	if _, err := io.ReadFull(r, sizeBuf[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(sizeBuf[:])
+	if size > r.maxLength {
+		stats().FrameTooLarge("simple")
+		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_UNKNOWN,
+			fmt.Sprintf("frugal: incorrect frame size (%d)", size))
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// isEOF indicates if the given error signals that the client closed the
+// connection.
+func isEOF(err error) bool {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	if e, ok := err.(thrift.TTransportException); ok {
+		return e.TypeId() == TRANSPORT_EXCEPTION_END_OF_FILE
+	}
+	return false
+}
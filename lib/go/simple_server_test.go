@@ -14,6 +14,10 @@
 package frugal
 
 import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
 	"testing"
 	"time"
 
@@ -67,3 +71,116 @@ func TestSimpleServer(t *testing.T) {
 	mockFProcessor.AssertExpectations(t)
 	mockFProcessor.AssertExpectations(t)
 }
+
+// Ensures FSimpleServer Shutdown does not wait on idle connections.
+func TestSimpleServerShutdownIdleConnection(t *testing.T) {
+	mockFProcessor := new(mockFProcessor)
+	protoFactory := thrift.NewTJSONProtocolFactory()
+	fTransportFactory := NewAdapterTransportFactory()
+	serverTr, err := thrift.NewTServerSocket(simpleServerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewFSimpleServer(
+		mockFProcessor,
+		serverTr,
+		NewFProtocolFactory(protoFactory),
+	)
+
+	go func() {
+		assert.Nil(t, server.Serve())
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	transport, err := thrift.NewTSocket(simpleServerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fTransport := fTransportFactory.GetTransport(transport)
+	defer fTransport.Close()
+	if err := fTransport.Open(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, server.Shutdown(ctx))
+
+	mockFProcessor.AssertExpectations(t)
+}
+
+// Ensures FSimpleServer built with a worker count processes requests
+// multiplexed on a single connection concurrently.
+func TestSimpleServerWorkerPoolConcurrentRequests(t *testing.T) {
+	assert := assert.New(t)
+	processor := &orderingProcessor{release: make(chan struct{})}
+	server := NewFSimpleServerBuilder(
+		processor,
+		nil,
+		NewFProtocolFactory(thrift.NewTBinaryProtocolFactoryDefault()),
+	).WithWorkerCount(2).Build().(*FSimpleServer)
+	for i := uint(0); i < server.workerCount; i++ {
+		go server.worker()
+	}
+	defer server.stopWorkers()
+
+	serverConn, clientConn := net.Pipe()
+	go server.accept(&pipeTransport{serverConn})
+	defer clientConn.Close()
+
+	// The first request blocks until the second is processed.
+	for _, b := range []byte{1, 2} {
+		_, err := clientConn.Write([]byte{0, 0, 0, 1, b})
+		assert.Nil(err)
+	}
+
+	for _, expected := range []byte{2, 1} {
+		clientConn.SetReadDeadline(time.Now().Add(time.Second))
+		var frame [5]byte
+		_, err := io.ReadFull(clientConn, frame[:])
+		assert.Nil(err)
+		assert.Equal(uint32(1), binary.BigEndian.Uint32(frame[:4]))
+		assert.Equal(expected, frame[4])
+	}
+}
+
+// orderingProcessor echoes the single byte request it receives. Request 1
+// blocks until request 2 has been processed.
+type orderingProcessor struct {
+	release chan struct{}
+}
+
+func (p *orderingProcessor) Process(in, out *FProtocol) error {
+	b := make([]byte, 1)
+	if _, err := in.Transport().Read(b); err != nil {
+		return err
+	}
+	switch b[0] {
+	case 1:
+		select {
+		case <-p.release:
+		case <-time.After(time.Second):
+		}
+	case 2:
+		defer close(p.release)
+	}
+	_, err := out.Transport().Write(b)
+	return err
+}
+
+func (p *orderingProcessor) AddMiddleware(middleware ServiceMiddleware) {}
+
+func (p *orderingProcessor) Annotations() map[string]map[string]string {
+	return nil
+}
+
+// pipeTransport adapts a net.Conn to thrift.TTransport.
+type pipeTransport struct {
+	net.Conn
+}
+
+func (p *pipeTransport) Open() error            { return nil }
+func (p *pipeTransport) IsOpen() bool           { return true }
+func (p *pipeTransport) Flush() error           { return nil }
+func (p *pipeTransport) RemainingBytes() uint64 { return ^uint64(0) }
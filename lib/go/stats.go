@@ -0,0 +1,75 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"sync"
+	"time"
+)
+
+// FStatsReporter receives transport-level statistics from Frugal.
+// Implementations must be safe for concurrent use and should not block.
+type FStatsReporter interface {
+	// RegistryInFlight is called with +1 when a request is registered with a
+	// client registry and -1 when it's unregistered.
+	RegistryInFlight(delta int)
+
+	// QueueDepth is called with the number of requests waiting in the work
+	// queue of the given server whenever a request is added or removed.
+	QueueDepth(server string, depth int)
+
+	// HighWatermarkBreached is called when a request waited longer than the
+	// high watermark of the given server before being processed.
+	HighWatermarkBreached(server string, wait time.Duration)
+
+	// ReopenAttempted is called after an FTransportMonitor attempts to reopen
+	// a transport.
+	ReopenAttempted(success bool)
+
+	// FrameTooLarge is called when the given transport rejects a frame which
+	// exceeds its size limit.
+	FrameTooLarge(transport string)
+}
+
+var (
+	packageStats FStatsReporter = noopStatsReporter{}
+	statsMu      sync.RWMutex
+)
+
+// SetStatsReporter sets the FStatsReporter used by Frugal. Passing nil
+// disables reporting.
+func SetStatsReporter(reporter FStatsReporter) {
+	if reporter == nil {
+		reporter = noopStatsReporter{}
+	}
+	statsMu.Lock()
+	packageStats = reporter
+	statsMu.Unlock()
+}
+
+// stats returns the global FStatsReporter.
+func stats() FStatsReporter {
+	statsMu.RLock()
+	reporter := packageStats
+	statsMu.RUnlock()
+	return reporter
+}
+
+type noopStatsReporter struct{}
+
+func (noopStatsReporter) RegistryInFlight(int)                        {}
+func (noopStatsReporter) QueueDepth(string, int)                      {}
+func (noopStatsReporter) HighWatermarkBreached(string, time.Duration) {}
+func (noopStatsReporter) ReopenAttempted(bool)                        {}
+func (noopStatsReporter) FrameTooLarge(string)                        {}
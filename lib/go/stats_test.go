@@ -0,0 +1,65 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures the client registry reports requests in flight to the installed
+// FStatsReporter.
+func TestStatsReporterRegistryInFlight(t *testing.T) {
+	assert := assert.New(t)
+	reporter := &testStatsReporter{}
+	SetStatsReporter(reporter)
+	defer SetStatsReporter(nil)
+
+	registry := newFRegistry()
+	ctx := NewFContext("")
+	assert.Nil(registry.Register(ctx, make(chan []byte, 1)))
+	assert.Equal(1, reporter.getInFlight())
+	assert.NotNil(registry.Register(ctx, make(chan []byte, 1)))
+	assert.Equal(1, reporter.getInFlight())
+	registry.Unregister(ctx)
+	assert.Equal(0, reporter.getInFlight())
+	registry.Unregister(ctx)
+	assert.Equal(0, reporter.getInFlight())
+}
+
+// Ensures setting a nil FStatsReporter disables reporting.
+func TestSetStatsReporterNil(t *testing.T) {
+	SetStatsReporter(nil)
+	assert.Equal(t, noopStatsReporter{}, stats())
+}
+
+type testStatsReporter struct {
+	noopStatsReporter
+	mu       sync.Mutex
+	inFlight int
+}
+
+func (r *testStatsReporter) RegistryInFlight(delta int) {
+	r.mu.Lock()
+	r.inFlight += delta
+	r.mu.Unlock()
+}
+
+func (r *testStatsReporter) getInFlight() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.inFlight
+}
@@ -0,0 +1,399 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+const (
+	defaultTCPDialTimeout = 5 * time.Second
+	defaultTCPKeepAlive   = 30 * time.Second
+)
+
+// FTCPTransportBuilder configures and builds TCP FTransport instances.
+type FTCPTransportBuilder struct {
+	addr             string
+	dialTimeout      time.Duration
+	keepAlive        time.Duration
+	tlsConfig        *tls.Config
+	poolSize         uint
+	requestSizeLimit uint
+	monitor          FTransportMonitor
+}
+
+// NewFTCPTransportBuilder creates a builder which configures and builds TCP
+// FTransport instances which connect to the given address. The FTransport
+// speaks the framed protocol served by FSimpleServer.
+func NewFTCPTransportBuilder(addr string) *FTCPTransportBuilder {
+	return &FTCPTransportBuilder{
+		addr:        addr,
+		dialTimeout: defaultTCPDialTimeout,
+		keepAlive:   defaultTCPKeepAlive,
+		poolSize:    1,
+	}
+}
+
+// WithDialTimeout controls how long to wait for each connection to be
+// established. Defaults to 5 seconds.
+func (t *FTCPTransportBuilder) WithDialTimeout(dialTimeout time.Duration) *FTCPTransportBuilder {
+	t.dialTimeout = dialTimeout
+	return t
+}
+
+// WithKeepAlive controls the TCP keepalive period of the connections. If set
+// to 0, keepalives are disabled. Defaults to 30 seconds.
+func (t *FTCPTransportBuilder) WithKeepAlive(keepAlive time.Duration) *FTCPTransportBuilder {
+	t.keepAlive = keepAlive
+	return t
+}
+
+// WithTLSConfig enables TLS on the connections using the given config. If set
+// to nil (the default), connections are not encrypted.
+func (t *FTCPTransportBuilder) WithTLSConfig(tlsConfig *tls.Config) *FTCPTransportBuilder {
+	t.tlsConfig = tlsConfig
+	return t
+}
+
+// WithPoolSize controls the number of connections requests are spread across.
+// Defaults to 1.
+func (t *FTCPTransportBuilder) WithPoolSize(poolSize uint) *FTCPTransportBuilder {
+	if poolSize == 0 {
+		poolSize = 1
+	}
+	t.poolSize = poolSize
+	return t
+}
+
+// WithRequestSizeLimit adds a request size limit. If set to 0 (the default),
+// there is no size limit on requests.
+func (t *FTCPTransportBuilder) WithRequestSizeLimit(requestSizeLimit uint) *FTCPTransportBuilder {
+	t.requestSizeLimit = requestSizeLimit
+	return t
+}
+
+// WithMonitor sets an FTransportMonitor which reopens the transport when it's
+// closed uncleanly, such as when a connection is lost.
+func (t *FTCPTransportBuilder) WithMonitor(monitor FTransportMonitor) *FTCPTransportBuilder {
+	t.monitor = monitor
+	return t
+}
+
+// Build a new configured TCP FTransport.
+func (t *FTCPTransportBuilder) Build() FTransport {
+	transport := &fTCPTransport{
+		addr: t.addr,
+		dialer: &net.Dialer{
+			Timeout:   t.dialTimeout,
+			KeepAlive: t.keepAlive,
+		},
+		tlsConfig:        t.tlsConfig,
+		poolSize:         t.poolSize,
+		requestSizeLimit: t.requestSizeLimit,
+		registry:         newFRegistry(),
+	}
+	if t.monitor != nil {
+		transport.SetMonitor(t.monitor)
+	}
+	return transport
+}
+
+// tcpConn is a pooled connection. Writes are serialized so frames from
+// concurrent requests are not interleaved.
+type tcpConn struct {
+	net.Conn
+	mu sync.Mutex
+}
+
+// write writes a whole frame to the connection.
+func (c *tcpConn) write(frame []byte, deadline time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.SetWriteDeadline(deadline); err != nil {
+		return err
+	}
+	_, err := c.Write(frame)
+	return err
+}
+
+// fTCPTransport implements FTransport over a pool of TCP connections.
+// Requests are spread across the connections and responses are multiplexed
+// back to their requests by the registry using the operation ID. If any
+// connection fails, the whole transport is closed uncleanly so the monitor
+// can reopen it.
+type fTCPTransport struct {
+	addr             string
+	dialer           *net.Dialer
+	tlsConfig        *tls.Config
+	poolSize         uint
+	requestSizeLimit uint
+	registry         fRegistry
+	next             uint64
+
+	mu                 sync.RWMutex
+	conns              []*tcpConn
+	closeSignal        chan struct{}
+	closeChan          chan error
+	monitorCloseSignal chan<- error
+}
+
+// Open connects the pool of connections.
+func (f *fTCPTransport) Open() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conns != nil {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_ALREADY_OPEN,
+			"frugal: transport already open")
+	}
+
+	conns := make([]*tcpConn, 0, f.poolSize)
+	for i := uint(0); i < f.poolSize; i++ {
+		conn, err := f.dial()
+		if err != nil {
+			for _, conn := range conns {
+				conn.Close()
+			}
+			return thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+				fmt.Sprintf("frugal: failed to connect to %s: %s", f.addr, err))
+		}
+		conns = append(conns, &tcpConn{Conn: conn})
+	}
+
+	f.conns = conns
+	f.closeSignal = make(chan struct{})
+	f.closeChan = make(chan error, 1)
+	for _, conn := range conns {
+		go f.readLoop(conn, f.closeSignal)
+	}
+	return nil
+}
+
+func (f *fTCPTransport) dial() (net.Conn, error) {
+	if f.tlsConfig != nil {
+		return tls.DialWithDialer(f.dialer, "tcp", f.addr, f.tlsConfig)
+	}
+	return f.dialer.Dial("tcp", f.addr)
+}
+
+// readLoop reads responses off of a connection and dispatches them to the
+// registry until the connection fails or the transport is closed.
+func (f *fTCPTransport) readLoop(conn *tcpConn, closeSignal chan struct{}) {
+	reader := newFrameReader(conn)
+	for {
+		frame, err := reader.readFrame()
+		if err != nil {
+			// First check if the transport was closed.
+			select {
+			case <-closeSignal:
+				return
+			default:
+			}
+
+			if isEOF(err) {
+				err = thrift.NewTTransportException(TRANSPORT_EXCEPTION_END_OF_FILE,
+					"frugal: connection closed by server")
+			}
+			logger().Error("frugal: error reading protocol frame, closing transport: ", err)
+			f.close(err, closeSignal)
+			return
+		}
+
+		if err := f.registry.Execute(frame); err != nil {
+			// An error here indicates an unrecoverable error, teardown transport.
+			logger().Error("frugal: closing transport due to unrecoverable error processing frame: ", err)
+			f.close(err, closeSignal)
+			return
+		}
+	}
+}
+
+// IsOpen returns true if the transport is open, false otherwise.
+func (f *fTCPTransport) IsOpen() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.conns != nil
+}
+
+// Close closes the transport.
+func (f *fTCPTransport) Close() error {
+	f.mu.RLock()
+	closeSignal := f.closeSignal
+	f.mu.RUnlock()
+	return f.close(nil, closeSignal)
+}
+
+// close closes the connections opened along with the given close signal. This
+// keeps a failing connection from closing the pool of a later Open.
+func (f *fTCPTransport) close(cause error, closeSignal chan struct{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conns == nil || f.closeSignal != closeSignal {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN, "frugal: transport not open")
+	}
+
+	close(f.closeSignal)
+	for _, conn := range f.conns {
+		conn.Close()
+	}
+	f.conns = nil
+
+	f.closeChan <- cause
+	close(f.closeChan)
+
+	if cause == nil {
+		logger().Debug("frugal: transport closed")
+	} else {
+		logger().Debugf("frugal: transport closed with cause: %s", cause)
+	}
+
+	// Signal transport monitor of close.
+	select {
+	case f.monitorCloseSignal <- cause:
+	default:
+	}
+	return nil
+}
+
+// conn returns the next connection of the pool along with the close signal of
+// the pool.
+func (f *fTCPTransport) conn() (*tcpConn, chan struct{}, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.conns == nil {
+		return nil, nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: transport not open")
+	}
+	i := atomic.AddUint64(&f.next, 1) % uint64(len(f.conns))
+	return f.conns[i], f.closeSignal, nil
+}
+
+// Oneway transmits the given data and doesn't wait for a response.
+// Implementations of oneway should be threadsafe and respect the timeout
+// present on the context.
+func (f *fTCPTransport) Oneway(ctx FContext, payload []byte) error {
+	conn, closeSignal, err := f.conn()
+	if err != nil {
+		return err
+	}
+	if err := f.checkRequestSize(payload); err != nil {
+		return err
+	}
+	return f.write(conn, closeSignal, ctx, payload)
+}
+
+// Request transmits the given data and waits for a response.
+// Implementations of request should be threadsafe and respect the timeout
+// present on the context.
+func (f *fTCPTransport) Request(ctx FContext, payload []byte) (thrift.TTransport, error) {
+	conn, closeSignal, err := f.conn()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.checkRequestSize(payload); err != nil {
+		return nil, err
+	}
+
+	resultC := make(chan []byte, 1)
+	if err := f.registry.Register(ctx, resultC); err != nil {
+		return nil, thrift.NewTTransportExceptionFromError(err)
+	}
+	defer f.registry.Unregister(ctx)
+
+	if err := f.write(conn, closeSignal, ctx, payload); err != nil {
+		return nil, err
+	}
+
+	select {
+	case result := <-resultC:
+		return &thrift.TMemoryBuffer{Buffer: bytes.NewBuffer(result)}, nil
+	case <-closeSignal:
+		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_NOT_OPEN,
+			"frugal: transport closed before a response was received")
+	case <-ToContext(ctx).Done():
+		return nil, contextError(ctx)
+	case <-time.After(ctx.Timeout()):
+		return nil, thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
+	}
+}
+
+// write sends a frame on the given connection. A failed write may leave a
+// partial frame on the connection, so the transport is closed uncleanly.
+func (f *fTCPTransport) write(conn *tcpConn, closeSignal chan struct{}, ctx FContext, payload []byte) error {
+	err := conn.write(payload, time.Now().Add(ctx.Timeout()))
+	if err == nil {
+		return nil
+	}
+	f.close(err, closeSignal)
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return thrift.NewTTransportException(TRANSPORT_EXCEPTION_TIMED_OUT, "frugal: request timed out")
+	}
+	return thrift.NewTTransportExceptionFromError(err)
+}
+
+func (f *fTCPTransport) checkRequestSize(payload []byte) error {
+	if f.requestSizeLimit > 0 && uint(len(payload)) > f.requestSizeLimit {
+		stats().FrameTooLarge("tcp")
+		return thrift.NewTTransportException(
+			TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE,
+			fmt.Sprintf("Message exceeds %d bytes, was %d bytes", f.requestSizeLimit, len(payload)))
+	}
+	return nil
+}
+
+// GetRequestSizeLimit returns the maximum number of bytes that can be
+// transmitted. Returns a non-positive number to indicate an unbounded
+// allowable size.
+func (f *fTCPTransport) GetRequestSizeLimit() uint {
+	return f.requestSizeLimit
+}
+
+// SetMonitor starts a monitor that can watch the health of, and reopen,
+// the transport.
+func (f *fTCPTransport) SetMonitor(monitor FTransportMonitor) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Stop the previous monitor, if any.
+	select {
+	case f.monitorCloseSignal <- nil:
+	default:
+	}
+
+	// Start the new monitor.
+	monitorClosedSignal := make(chan error, 1)
+	runner := &monitorRunner{
+		monitor:       monitor,
+		transport:     f,
+		closedChannel: monitorClosedSignal,
+	}
+	f.monitorCloseSignal = monitorClosedSignal
+	go runner.run()
+}
+
+// Closed channel receives the cause of an FTransport close (nil if clean
+// close).
+func (f *fTCPTransport) Closed() <-chan error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.closeChan
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package frugal
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures requests are spread across the pool of connections and responses
+// are multiplexed back to their requests.
+func TestTCPTransportPoolRequests(t *testing.T) {
+	assert := assert.New(t)
+	server := newEchoServer(t)
+	defer server.Close()
+
+	transport := NewFTCPTransportBuilder(server.Addr()).WithPoolSize(2).Build()
+	assert.Nil(transport.Open())
+	defer transport.Close()
+	assert.True(transport.IsOpen())
+	assert.True(server.WaitAccepted(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := NewFContext("")
+			frame := requestFrame(ctx)
+			result, err := transport.Request(ctx, frame)
+			if assert.Nil(err) {
+				assert.Equal(frame[4:], result.(*thrift.TMemoryBuffer).Bytes())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Ensures Open fails if the server can't be reached.
+func TestTCPTransportOpenFailed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	transport := NewFTCPTransportBuilder(addr).WithDialTimeout(time.Second).Build()
+	err = transport.Open()
+	assert.Equal(t, TRANSPORT_EXCEPTION_NOT_OPEN, err.(thrift.TTransportException).TypeId())
+	assert.False(t, transport.IsOpen())
+}
+
+// Ensures requests time out if the server doesn't respond and fail if they
+// exceed the request size limit.
+func TestTCPTransportRequestTimeoutAndSizeLimit(t *testing.T) {
+	assert := assert.New(t)
+	server := newEchoServer(t)
+	server.silent = true
+	defer server.Close()
+
+	transport := NewFTCPTransportBuilder(server.Addr()).WithRequestSizeLimit(256).Build()
+	assert.Nil(transport.Open())
+	defer transport.Close()
+
+	ctx := NewFContext("")
+	ctx.SetTimeout(20 * time.Millisecond)
+	_, err := transport.Request(ctx, requestFrame(ctx))
+	assert.Equal(TRANSPORT_EXCEPTION_TIMED_OUT, err.(thrift.TTransportException).TypeId())
+
+	_, err = transport.Request(ctx, make([]byte, 257))
+	assert.Equal(TRANSPORT_EXCEPTION_REQUEST_TOO_LARGE, err.(thrift.TTransportException).TypeId())
+}
+
+// Ensures the monitor reopens the transport when the server drops the
+// connection.
+func TestTCPTransportMonitorReconnects(t *testing.T) {
+	assert := assert.New(t)
+	server := newEchoServer(t)
+	defer server.Close()
+
+	monitor := &BaseFTransportMonitor{
+		MaxReopenAttempts: 10,
+		InitialWait:       10 * time.Millisecond,
+		MaxWait:           10 * time.Millisecond,
+	}
+	transport := NewFTCPTransportBuilder(server.Addr()).WithMonitor(monitor).Build()
+	assert.Nil(transport.Open())
+	defer transport.Close()
+	closed := transport.Closed()
+
+	server.DropConnections()
+	select {
+	case err := <-closed:
+		assert.Equal(TRANSPORT_EXCEPTION_END_OF_FILE, err.(thrift.TTransportException).TypeId())
+	case <-time.After(time.Second):
+		t.Fatal("Expected transport to close")
+	}
+
+	assert.True(server.WaitAccepted(2))
+	for i := 0; i < 100 && !transport.IsOpen(); i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.True(transport.IsOpen())
+
+	ctx := NewFContext("")
+	_, err := transport.Request(ctx, requestFrame(ctx))
+	assert.Nil(err)
+}
+
+// requestFrame returns a framed request carrying the headers of ctx.
+func requestFrame(ctx FContext) []byte {
+	return prependFrameSize(writeMarshaler.marshalHeaders(ctx.RequestHeaders()))
+}
+
+// echoServer writes every frame it receives back to the client.
+type echoServer struct {
+	t        *testing.T
+	listener net.Listener
+	silent   bool
+	mu       sync.Mutex
+	accepted int
+	conns    []net.Conn
+}
+
+func newEchoServer(t *testing.T) *echoServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &echoServer{t: t, listener: listener}
+	go server.accept()
+	return server
+}
+
+func (s *echoServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// WaitAccepted waits for the server to have accepted n connections.
+func (s *echoServer) WaitAccepted(n int) bool {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		accepted := s.accepted
+		s.mu.Unlock()
+		if accepted >= n {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+func (s *echoServer) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *echoServer) Close() {
+	s.listener.Close()
+	s.DropConnections()
+}
+
+func (s *echoServer) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.accepted++
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.echo(conn)
+	}
+}
+
+func (s *echoServer) echo(conn net.Conn) {
+	reader := newFrameReader(conn)
+	for {
+		frame, err := reader.readFrame()
+		if err != nil {
+			return
+		}
+		if s.silent {
+			continue
+		}
+		if _, err := conn.Write(prependFrameSize(frame)); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/Workiva/frugal/lib/go/middleware"
+)
+
+// Tracer creates spans around frugal requests and messages and exports them
+// to an Exporter.
+type Tracer struct {
+	exporter Exporter
+}
+
+// NewTracer creates a Tracer exporting finished spans to the given Exporter.
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// ClientMiddleware returns ServiceMiddleware for RPC clients. It starts a
+// client span for each request and injects it into the request headers of a
+// per-request copy of the FContext. The parent span is taken from the
+// context.Context backing the FContext (see ContextWithSpan) or from a
+// traceparent header already on the FContext, such as one received by a
+// server.
+func (t *Tracer) ClientMiddleware() frugal.ServiceMiddleware {
+	return t.outgoing(SpanKindClient)
+}
+
+// ServerMiddleware returns ServiceMiddleware for FProcessors. It starts a
+// server span for each request as a child of the span extracted from the
+// request headers. The traceparent header of the FContext passed to the
+// handler is updated to the server span so that requests the handler makes
+// with it are traced as its children.
+func (t *Tracer) ServerMiddleware() frugal.ServiceMiddleware {
+	return t.incoming(SpanKindServer)
+}
+
+// PublisherMiddleware returns ServiceMiddleware for scope publishers. It
+// starts a producer span for each published message and injects it into the
+// headers of a per-message copy of the FContext.
+func (t *Tracer) PublisherMiddleware() frugal.ServiceMiddleware {
+	return t.outgoing(SpanKindProducer)
+}
+
+// SubscriberMiddleware returns ServiceMiddleware for scope subscribers. It
+// starts a consumer span for each received message as a child of the span
+// extracted from the message headers.
+func (t *Tracer) SubscriberMiddleware() frugal.ServiceMiddleware {
+	return t.incoming(SpanKindConsumer)
+}
+
+// outgoing returns middleware which starts a span and injects it into the
+// request headers of a copy of the FContext.
+func (t *Tracer) outgoing(kind SpanKind) frugal.ServiceMiddleware {
+	return func(next frugal.InvocationHandler) frugal.InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args frugal.Arguments) frugal.Results {
+			ctx := args.Context()
+			parent, _ := parentSpanContext(ctx)
+			span := newSpan(spanName(service, method), kind, parent)
+
+			// Inject into a per-call copy of the FContext so that
+			// concurrent requests made with the same FContext don't race
+			// on the headers and the caller's headers are left untouched.
+			callCtx := frugal.Clone(ctx)
+			inject(callCtx, span.SpanContext)
+			callArgs := make(frugal.Arguments, len(args))
+			copy(callArgs, args)
+			callArgs.SetContext(callCtx)
+			results := next(service, method, callArgs)
+			middleware.CopyResponseHeaders(callCtx, ctx)
+
+			t.end(span, ctx, results)
+			return results
+		}
+	}
+}
+
+// incoming returns middleware which starts a span as a child of the one
+// extracted from the request headers.
+func (t *Tracer) incoming(kind SpanKind) frugal.ServiceMiddleware {
+	return func(next frugal.InvocationHandler) frugal.InvocationHandler {
+		return func(service reflect.Value, method reflect.Method, args frugal.Arguments) frugal.Results {
+			ctx := args.Context()
+			parent, _ := extract(ctx)
+			span := newSpan(spanName(service, method), kind, parent)
+			inject(ctx, span.SpanContext)
+			results := next(service, method, args)
+			t.end(span, ctx, results)
+			return results
+		}
+	}
+}
+
+// end finishes the span and exports it if sampled.
+func (t *Tracer) end(span *Span, ctx frugal.FContext, results frugal.Results) {
+	span.End = time.Now()
+	if len(results) > 0 {
+		span.Err = results.Error()
+	}
+	span.SetAttribute("frugal.correlation_id", ctx.CorrelationID())
+	if span.SpanContext.Sampled && t.exporter != nil {
+		t.exporter.ExportSpan(span)
+	}
+}
+
+// parentSpanContext returns the SpanContext of the parent of an outgoing
+// request.
+func parentSpanContext(ctx frugal.FContext) (SpanContext, bool) {
+	if span, ok := SpanFromContext(frugal.ToContext(ctx)); ok {
+		return span.SpanContext, true
+	}
+	return extract(ctx)
+}
+
+// extract reads the SpanContext from the request headers of the FContext.
+func extract(ctx frugal.FContext) (SpanContext, bool) {
+	traceparent, ok := ctx.RequestHeader(TraceparentHeader)
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState, _ = ctx.RequestHeader(TracestateHeader)
+	return sc, true
+}
+
+// inject writes the SpanContext to the request headers of the FContext.
+func inject(ctx frugal.FContext, sc SpanContext) {
+	ctx.AddRequestHeader(TraceparentHeader, sc.Traceparent())
+	if sc.TraceState != "" {
+		ctx.AddRequestHeader(TracestateHeader, sc.TraceState)
+	}
+}
+
+// SpanContextFromFContext returns the SpanContext carried in the request
+// headers of the FContext. Within a handler wrapped by ServerMiddleware, this
+// is the server span.
+func SpanContextFromFContext(ctx frugal.FContext) (SpanContext, bool) {
+	return extract(ctx)
+}
+
+// spanName returns the name of a span for the given method, such as
+// "FFooClient.ping".
+func spanName(service reflect.Value, method reflect.Method) string {
+	if !service.IsValid() {
+		return method.Name
+	}
+	typ := service.Type()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Name() == "" {
+		return method.Name
+	}
+	return typ.Name() + "." + method.Name
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Workiva/frugal/lib/go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures a client span is propagated to the server and the server span is
+// a child of it.
+func TestClientServerPropagation(t *testing.T) {
+	assert := assert.New(t)
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+
+	server := &testHandler{}
+	serverMethod := frugal.NewMethod(server, server.Ping, "Ping",
+		[]frugal.ServiceMiddleware{tracer.ServerMiddleware()})
+	client := &testClient{server: serverMethod}
+	clientMethod := frugal.NewMethod(client, client.ping, "ping",
+		[]frugal.ServiceMiddleware{tracer.ClientMiddleware()})
+
+	ctx := frugal.NewFContext("cid")
+	ret := clientMethod.Invoke([]interface{}{ctx})
+	assert.Nil(ret.Error())
+
+	spans := exporter.Spans()
+	assert.Len(spans, 2)
+	serverSpan, clientSpan := spans[0], spans[1]
+	assert.Equal(SpanKindServer, serverSpan.Kind)
+	assert.Equal("testHandler.Ping", serverSpan.Name)
+	assert.Equal(SpanKindClient, clientSpan.Kind)
+	assert.Equal("testClient.ping", clientSpan.Name)
+	assert.Equal(clientSpan.SpanContext.TraceID, serverSpan.SpanContext.TraceID)
+	assert.Equal(clientSpan.SpanContext.SpanID, serverSpan.ParentSpanID)
+	assert.Equal("cid", serverSpan.Attributes()["frugal.correlation_id"])
+
+	// The handler sees the server span.
+	assert.Equal(serverSpan.SpanContext.SpanID, server.spanContext.SpanID)
+
+	// The caller's headers are left untouched.
+	_, ok := ctx.RequestHeader(TraceparentHeader)
+	assert.False(ok)
+}
+
+// Ensures concurrent requests made with the same FContext each carry their
+// own client span.
+func TestClientConcurrentRequests(t *testing.T) {
+	assert := assert.New(t)
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+	var (
+		mu           sync.Mutex
+		traceparents = make(map[string]bool)
+	)
+	client := &testClient{}
+	clientMethod := frugal.NewMethod(client, func(ctx frugal.FContext) error {
+		traceparent, _ := ctx.RequestHeader(TraceparentHeader)
+		ctx.AddResponseHeader("foo", "bar")
+		mu.Lock()
+		traceparents[traceparent] = true
+		mu.Unlock()
+		return nil
+	}, "ping", []frugal.ServiceMiddleware{tracer.ClientMiddleware()})
+
+	ctx := frugal.NewFContext("cid")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(clientMethod.Invoke([]interface{}{ctx}).Error())
+		}()
+	}
+	wg.Wait()
+
+	spans := exporter.Spans()
+	assert.Len(spans, 10)
+	for _, span := range spans {
+		assert.True(traceparents[span.SpanContext.Traceparent()])
+	}
+	_, ok := ctx.RequestHeader(TraceparentHeader)
+	assert.False(ok)
+	foo, _ := ctx.ResponseHeader("foo")
+	assert.Equal("bar", foo)
+}
+
+// Ensures client spans use the span carried by the context.Context backing
+// the FContext as parent and record errors.
+func TestClientParentFromContext(t *testing.T) {
+	assert := assert.New(t)
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+	client := &testClient{err: errors.New("boom")}
+	clientMethod := frugal.NewMethod(client, client.ping, "ping",
+		[]frugal.ServiceMiddleware{tracer.ClientMiddleware()})
+
+	parent := newSpan("parent", SpanKindServer, SpanContext{})
+	parent.SpanContext.TraceState = "vendor=value"
+	ctx := frugal.NewFContextFromContext(ContextWithSpan(context.Background(), parent), "")
+	ret := clientMethod.Invoke([]interface{}{ctx})
+
+	assert.Equal(errors.New("boom"), ret.Error())
+	spans := exporter.Spans()
+	assert.Len(spans, 1)
+	assert.Equal(parent.SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	assert.Equal(parent.SpanContext.SpanID, spans[0].ParentSpanID)
+	assert.Equal(errors.New("boom"), spans[0].Err)
+	assert.Equal("vendor=value", client.tracestate)
+}
+
+// Ensures a published message is traced and its consumer span is a child of
+// the producer span.
+func TestPubSubPropagation(t *testing.T) {
+	assert := assert.New(t)
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+
+	var headers map[string]string
+	publisher := &testClient{}
+	publish := frugal.NewMethod(publisher, func(ctx frugal.FContext) error {
+		headers = ctx.RequestHeaders()
+		return nil
+	}, "publishEvent", []frugal.ServiceMiddleware{tracer.PublisherMiddleware()})
+	assert.Nil(publish.Invoke([]interface{}{frugal.NewFContext("")}).Error())
+
+	received := frugal.NewFContext("")
+	received.AddRequestHeader(TraceparentHeader, headers[TraceparentHeader])
+	subscriber := &testHandler{}
+	subscribe := frugal.NewMethod(subscriber, func(ctx frugal.FContext) error {
+		return nil
+	}, "subscribeEvent", []frugal.ServiceMiddleware{tracer.SubscriberMiddleware()})
+	assert.Nil(subscribe.Invoke([]interface{}{received}).Error())
+
+	spans := exporter.Spans()
+	assert.Len(spans, 2)
+	assert.Equal(SpanKindProducer, spans[0].Kind)
+	assert.Equal(SpanKindConsumer, spans[1].Kind)
+	assert.Equal(spans[0].SpanContext.SpanID, spans[1].ParentSpanID)
+}
+
+// Ensures spans of unsampled traces are not exported.
+func TestUnsampledNotExported(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	server := &testHandler{}
+	serverMethod := frugal.NewMethod(server, server.Ping, "Ping",
+		[]frugal.ServiceMiddleware{NewTracer(exporter).ServerMiddleware()})
+
+	ctx := frugal.NewFContext("")
+	ctx.AddRequestHeader(TraceparentHeader, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+	serverMethod.Invoke([]interface{}{ctx})
+
+	assert.Len(t, exporter.Spans(), 0)
+}
+
+type testHandler struct {
+	spanContext SpanContext
+}
+
+func (h *testHandler) Ping(ctx frugal.FContext) error {
+	h.spanContext, _ = SpanContextFromFContext(ctx)
+	return nil
+}
+
+// testClient forwards requests to the server method, copying the request
+// headers as a transport would.
+type testClient struct {
+	server     *frugal.Method
+	err        error
+	tracestate string
+}
+
+func (c *testClient) ping(ctx frugal.FContext) error {
+	c.tracestate, _ = ctx.RequestHeader(TracestateHeader)
+	if c.server == nil {
+		return c.err
+	}
+	serverCtx := frugal.NewFContext(ctx.CorrelationID())
+	for name, value := range ctx.RequestHeaders() {
+		serverCtx.AddRequestHeader(name, value)
+	}
+	return c.server.Invoke([]interface{}{serverCtx}).Error()
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing provides ServiceMiddleware which traces frugal requests and
+// pub/sub messages. Trace context is propagated in FContext request headers
+// using the W3C Trace Context format (https://www.w3.org/TR/trace-context/),
+// and finished spans are handed to a pluggable Exporter.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TraceparentHeader is the request header carrying the trace and parent
+	// span IDs.
+	TraceparentHeader = "traceparent"
+
+	// TracestateHeader is the request header carrying vendor-specific trace
+	// state.
+	TracestateHeader = "tracestate"
+
+	traceparentVersion = "00"
+	sampledFlag        = 0x01
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the hex encoding of the TraceID.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid indicates if the TraceID is not all zeros.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns the hex encoding of the SpanID.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid indicates if the SpanID is not all zeros.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// SpanContext is the part of a span which is propagated to other processes.
+type SpanContext struct {
+	TraceID    TraceID
+	SpanID     SpanID
+	Sampled    bool
+	TraceState string
+}
+
+// IsValid indicates if the SpanContext has both a trace and span ID.
+func (s SpanContext) IsValid() bool {
+	return s.TraceID.IsValid() && s.SpanID.IsValid()
+}
+
+// Traceparent returns the SpanContext formatted as a traceparent header
+// value.
+func (s SpanContext) Traceparent() string {
+	flags := 0
+	if s.Sampled {
+		flags |= sampledFlag
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceparentVersion, s.TraceID, s.SpanID, flags)
+}
+
+// ParseTraceparent parses a traceparent header value.
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return SpanContext{}, fmt.Errorf("tracing: invalid traceparent %q", traceparent)
+	}
+	if parts[0] == traceparentVersion && len(parts) != 4 {
+		return SpanContext{}, fmt.Errorf("tracing: invalid traceparent %q", traceparent)
+	}
+	var sc SpanContext
+	if err := decodeHex(parts[1], sc.TraceID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("tracing: invalid trace id in traceparent %q", traceparent)
+	}
+	if err := decodeHex(parts[2], sc.SpanID[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("tracing: invalid span id in traceparent %q", traceparent)
+	}
+	var flags [1]byte
+	if err := decodeHex(parts[3], flags[:]); err != nil {
+		return SpanContext{}, fmt.Errorf("tracing: invalid flags in traceparent %q", traceparent)
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("tracing: invalid traceparent %q", traceparent)
+	}
+	sc.Sampled = flags[0]&sampledFlag != 0
+	return sc, nil
+}
+
+// decodeHex decodes lowercase hex of exactly the length of dst.
+func decodeHex(s string, dst []byte) error {
+	if len(s) != hex.EncodedLen(len(dst)) || strings.ToLower(s) != s {
+		return fmt.Errorf("tracing: invalid hex %q", s)
+	}
+	_, err := hex.Decode(dst, []byte(s))
+	return err
+}
+
+// SpanKind describes the relationship of a span to the remote side of a
+// request or message.
+type SpanKind int
+
+const (
+	// SpanKindClient is a span around an outgoing RPC request.
+	SpanKindClient SpanKind = iota + 1
+
+	// SpanKindServer is a span around the processing of an RPC request.
+	SpanKindServer
+
+	// SpanKindProducer is a span around publishing a message.
+	SpanKindProducer
+
+	// SpanKindConsumer is a span around receiving a published message.
+	SpanKindConsumer
+)
+
+// String returns the name of the SpanKind.
+func (k SpanKind) String() string {
+	switch k {
+	case SpanKindClient:
+		return "client"
+	case SpanKindServer:
+		return "server"
+	case SpanKindProducer:
+		return "producer"
+	case SpanKindConsumer:
+		return "consumer"
+	}
+	return "unknown"
+}
+
+// Span is a traced operation.
+type Span struct {
+	Name         string
+	Kind         SpanKind
+	SpanContext  SpanContext
+	ParentSpanID SpanID
+	Start        time.Time
+	End          time.Time
+	Err          error
+
+	mu         sync.Mutex
+	attributes map[string]string
+}
+
+// SetAttribute sets an attribute on the span.
+func (s *Span) SetAttribute(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attributes == nil {
+		s.attributes = make(map[string]string)
+	}
+	s.attributes[key] = value
+}
+
+// Attributes returns a copy of the span attributes.
+func (s *Span) Attributes() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attributes := make(map[string]string, len(s.attributes))
+	for key, value := range s.attributes {
+		attributes[key] = value
+	}
+	return attributes
+}
+
+// newSpan starts a span which is a child of the given parent. A new trace is
+// started if the parent is not valid.
+func newSpan(name string, kind SpanKind, parent SpanContext) *Span {
+	span := &Span{Name: name, Kind: kind, Start: time.Now()}
+	if parent.IsValid() {
+		span.SpanContext = parent
+		span.ParentSpanID = parent.SpanID
+	} else {
+		rand.Read(span.SpanContext.TraceID[:])
+		span.SpanContext.Sampled = true
+	}
+	rand.Read(span.SpanContext.SpanID[:])
+	return span
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of parent carrying the given span. Requests
+// made with an FContext derived from the returned context.Context are traced
+// as children of the span.
+func ContextWithSpan(parent context.Context, span *Span) context.Context {
+	return context.WithValue(parent, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, if any.
+func SpanFromContext(ctx context.Context) (*Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(*Span)
+	return span, ok
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Ensures traceparent header values round trip through ParseTraceparent.
+func TestParseTraceparent(t *testing.T) {
+	assert := assert.New(t)
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+
+	sc, err := ParseTraceparent(traceparent)
+
+	assert.Nil(err)
+	assert.Equal("0af7651916cd43dd8448eb211c80319c", sc.TraceID.String())
+	assert.Equal("b7ad6b7169203331", sc.SpanID.String())
+	assert.True(sc.Sampled)
+	assert.Equal(traceparent, sc.Traceparent())
+
+	sc, err = ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+	assert.Nil(err)
+	assert.False(sc.Sampled)
+}
+
+// Ensures invalid traceparent header values are rejected.
+func TestParseTraceparentInvalid(t *testing.T) {
+	for _, traceparent := range []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c8031-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-zz",
+	} {
+		_, err := ParseTraceparent(traceparent)
+		assert.NotNil(t, err, traceparent)
+	}
+}
+
+// Ensures newSpan continues the trace of a valid parent and starts a new
+// trace otherwise.
+func TestNewSpan(t *testing.T) {
+	assert := assert.New(t)
+	root := newSpan("root", SpanKindClient, SpanContext{})
+	assert.True(root.SpanContext.IsValid())
+	assert.True(root.SpanContext.Sampled)
+	assert.False(root.ParentSpanID.IsValid())
+
+	root.SpanContext.TraceState = "vendor=value"
+	child := newSpan("child", SpanKindServer, root.SpanContext)
+	assert.Equal(root.SpanContext.TraceID, child.SpanContext.TraceID)
+	assert.Equal(root.SpanContext.SpanID, child.ParentSpanID)
+	assert.NotEqual(root.SpanContext.SpanID, child.SpanContext.SpanID)
+	assert.Equal("vendor=value", child.SpanContext.TraceState)
+}
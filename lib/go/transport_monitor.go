@@ -147,12 +147,14 @@ func (r *monitorRunner) attemptReopen(InitialWait time.Duration) bool {
 		time.Sleep(wait)
 
 		if err := r.transport.Open(); err != nil {
+			stats().ReopenAttempted(false)
 			logger().Errorf("frugal: FTransportMonitor failed to re-open transport due to: %v", err)
 			prevAttempts++
 
 			reopen, wait = r.monitor.OnReopenFailed(prevAttempts, wait)
 			continue
 		}
+		stats().ReopenAttempted(true)
 		logger().Info("frugal: FTransportMonitor successfully re-opened!")
 		// Do a sanity check. TODO: Remove this once the "transport not open"
 		// bug is fixed.
@@ -41,4 +41,9 @@ public class TApplicationExceptionType {
      * Indicates the response was too large for the transport.
      */
     public static final int RESPONSE_TOO_LARGE = 100;
+
+    /**
+     * Indicates the request arguments violated their declared constraints.
+     */
+    public static final int INVALID_ARGUMENT = 101;
 }
@@ -0,0 +1,702 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.workiva.frugal.util;
+
+import org.apache.thrift.TBaseHelper;
+import org.apache.thrift.TEnum;
+import org.apache.thrift.protocol.TProtocolException;
+
+import java.math.BigDecimal;
+import java.math.MathContext;
+import java.math.RoundingMode;
+import java.nio.ByteBuffer;
+import java.util.ArrayList;
+import java.util.Base64;
+import java.util.Collections;
+import java.util.Comparator;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.regex.Pattern;
+
+/**
+ * Helpers for the canonical JSON form of Frugal types, used by code generated
+ * with the canonical_json option. See documentation/json.md for the format.
+ * <p>
+ * Generated types convert themselves to and from JSON values, which are maps
+ * with string keys, lists, strings, booleans, numbers and null. Use
+ * {@link #encode} and {@link #decode} to convert JSON values to and from JSON
+ * text, e.g. <code>CanonicalJson.encode(struct.toJsonValue())</code> and
+ * <code>new Struct().fromJsonValue(CanonicalJson.decode(json))</code>.
+ */
+public final class CanonicalJson {
+
+    private static final Pattern INT_PATTERN = Pattern.compile("[+-]?[0-9]+");
+    private static final Pattern DOUBLE_PATTERN =
+            Pattern.compile("[+-]?([0-9]+\\.?[0-9]*|\\.[0-9]+)([eE][+-]?[0-9]+)?");
+
+    /**
+     * Orders strings by their code points, which is the order of their UTF-8 bytes.
+     */
+    private static final Comparator<String> UTF8_ORDER = (a, b) -> {
+        int i = 0;
+        int j = 0;
+        while (i < a.length() && j < b.length()) {
+            int ca = a.codePointAt(i);
+            int cb = b.codePointAt(j);
+            if (ca != cb) {
+                return Integer.compare(ca, cb);
+            }
+            i += Character.charCount(ca);
+            j += Character.charCount(cb);
+        }
+        return Boolean.compare(i < a.length(), j < b.length());
+    };
+
+    private CanonicalJson() {
+    }
+
+    /**
+     * Encodes a JSON value as canonical JSON.
+     *
+     * @param value the JSON value
+     * @return the canonical JSON text
+     */
+    public static String encode(Object value) {
+        StringBuilder out = new StringBuilder();
+        encode(value, out);
+        return out.toString();
+    }
+
+    /**
+     * Decodes JSON text into a JSON value. Objects are decoded as maps, arrays
+     * as lists and numbers as {@link BigDecimal}s.
+     *
+     * @param json the JSON text
+     * @return the JSON value
+     * @throws TProtocolException if the text is not valid JSON
+     */
+    public static Object decode(String json) throws TProtocolException {
+        Parser parser = new Parser(json);
+        Object value = parser.parseValue();
+        parser.skipWhitespace();
+        if (parser.pos != json.length()) {
+            throw parser.error("unexpected data after JSON value");
+        }
+        return value;
+    }
+
+    @SuppressWarnings("unchecked")
+    private static void encode(Object value, StringBuilder out) {
+        if (value == null) {
+            out.append("null");
+        } else if (value instanceof String) {
+            encodeString((String) value, out);
+        } else if (value instanceof Boolean || value instanceof Byte || value instanceof Short
+                || value instanceof Integer || value instanceof Long) {
+            out.append(value);
+        } else if (value instanceof Double) {
+            out.append(formatDouble((Double) value));
+        } else if (value instanceof Map) {
+            Map<String, Object> map = (Map<String, Object>) value;
+            List<String> keys = new ArrayList<>(map.keySet());
+            Collections.sort(keys, UTF8_ORDER);
+            out.append('{');
+            for (int i = 0; i < keys.size(); i++) {
+                if (i > 0) {
+                    out.append(',');
+                }
+                encodeString(keys.get(i), out);
+                out.append(':');
+                encode(map.get(keys.get(i)), out);
+            }
+            out.append('}');
+        } else if (value instanceof List) {
+            List<Object> list = (List<Object>) value;
+            out.append('[');
+            for (int i = 0; i < list.size(); i++) {
+                if (i > 0) {
+                    out.append(',');
+                }
+                encode(list.get(i), out);
+            }
+            out.append(']');
+        } else {
+            throw new IllegalArgumentException("cannot encode " + value.getClass() + " as JSON");
+        }
+    }
+
+    private static void encodeString(String s, StringBuilder out) {
+        out.append('"');
+        for (int i = 0; i < s.length(); i++) {
+            char c = s.charAt(i);
+            switch (c) {
+                case '"':
+                    out.append("\\\"");
+                    break;
+                case '\\':
+                    out.append("\\\\");
+                    break;
+                case '\b':
+                    out.append("\\b");
+                    break;
+                case '\f':
+                    out.append("\\f");
+                    break;
+                case '\n':
+                    out.append("\\n");
+                    break;
+                case '\r':
+                    out.append("\\r");
+                    break;
+                case '\t':
+                    out.append("\\t");
+                    break;
+                default:
+                    if (c < ' ' || c == '<' || c == '>' || c == '&' || c == '\u2028' || c == '\u2029') {
+                        out.append(String.format("\\u%04x", (int) c));
+                    } else if (Character.isHighSurrogate(c) && i + 1 < s.length()
+                            && Character.isLowSurrogate(s.charAt(i + 1))) {
+                        out.append(c).append(s.charAt(++i));
+                    } else if (Character.isSurrogate(c)) {
+                        out.append('\ufffd');
+                    } else {
+                        out.append(c);
+                    }
+            }
+        }
+        out.append('"');
+    }
+
+    /**
+     * Formats a finite double as JavaScript's Number.prototype.toString would,
+     * using the shortest digits which read back as the same value.
+     */
+    private static String formatDouble(double value) {
+        if (value == 0) {
+            return 1 / value < 0 ? "-0" : "0";
+        }
+        String sign = value < 0 ? "-" : "";
+        double abs = Math.abs(value);
+        BigDecimal exact = new BigDecimal(abs);
+        BigDecimal shortest = exact;
+        for (int precision = 1; precision <= 17; precision++) {
+            BigDecimal down = exact.round(new MathContext(precision, RoundingMode.DOWN));
+            BigDecimal up = exact.round(new MathContext(precision, RoundingMode.UP));
+            boolean downOk = down.doubleValue() == abs;
+            boolean upOk = up.doubleValue() == abs;
+            if (downOk && upOk) {
+                shortest = exact.subtract(down).compareTo(up.subtract(exact)) <= 0 ? down : up;
+                break;
+            } else if (downOk || upOk) {
+                shortest = downOk ? down : up;
+                break;
+            }
+        }
+        shortest = shortest.stripTrailingZeros();
+        String digits = shortest.unscaledValue().toString();
+        // The value is 0.<digits> * 10^point.
+        int point = digits.length() - shortest.scale();
+        StringBuilder out = new StringBuilder(sign);
+        if (digits.length() <= point && point <= 21) {
+            out.append(digits);
+            for (int i = digits.length(); i < point; i++) {
+                out.append('0');
+            }
+        } else if (0 < point && point <= 21) {
+            out.append(digits, 0, point).append('.').append(digits, point, digits.length());
+        } else if (-6 < point && point <= 0) {
+            out.append("0.");
+            for (int i = point; i < 0; i++) {
+                out.append('0');
+            }
+            out.append(digits);
+        } else {
+            int exp = point - 1;
+            out.append(digits.charAt(0));
+            if (digits.length() > 1) {
+                out.append('.').append(digits, 1, digits.length());
+            }
+            out.append('e').append(exp >= 0 ? "+" : "-").append(Math.abs(exp));
+        }
+        return out.toString();
+    }
+
+    /**
+     * Returns the JSON value of a double.
+     *
+     * @param value the double
+     * @return the JSON value
+     */
+    public static Object doubleValue(double value) {
+        if (Double.isNaN(value)) {
+            return "NaN";
+        }
+        if (Double.isInfinite(value)) {
+            return value > 0 ? "Infinity" : "-Infinity";
+        }
+        return value;
+    }
+
+    /**
+     * Returns the JSON value of a binary.
+     *
+     * @param value the binary
+     * @return the JSON value
+     */
+    public static String binaryValue(ByteBuffer value) {
+        return Base64.getEncoder().encodeToString(TBaseHelper.byteBufferToByteArray(value));
+    }
+
+    /**
+     * Returns the JSON value of an enum, its name. A null enum, as read for an
+     * undeclared value, is written as the value 0.
+     *
+     * @param value the enum
+     * @param type  the enum class
+     * @param <E>   the enum type
+     * @return the JSON value
+     */
+    public static <E extends Enum<E> & TEnum> Object enumValue(E value, Class<E> type) {
+        if (value != null) {
+            return value.name();
+        }
+        for (E e : type.getEnumConstants()) {
+            if (e.getValue() == 0) {
+                return e.name();
+            }
+        }
+        return 0;
+    }
+
+    /**
+     * Returns the object key for the JSON value of a map key.
+     *
+     * @param value the JSON value of the key
+     * @return the object key
+     */
+    public static String key(Object value) {
+        return String.valueOf(value);
+    }
+
+    /**
+     * Returns the JSON values sorted by their encoding.
+     *
+     * @param values the JSON values
+     * @return the sorted values
+     */
+    public static List<Object> sorted(List<Object> values) {
+        List<Pair<String, Object>> encoded = new ArrayList<>(values.size());
+        for (Object value : values) {
+            encoded.add(Pair.of(encode(value), value));
+        }
+        Collections.sort(encoded, (a, b) -> UTF8_ORDER.compare(a.getLeft(), b.getLeft()));
+        List<Object> sorted = new ArrayList<>(values.size());
+        for (Pair<String, Object> pair : encoded) {
+            sorted.add(pair.getRight());
+        }
+        return sorted;
+    }
+
+    /**
+     * Returns the JSON value if it is an object.
+     *
+     * @param value the JSON value
+     * @return the object
+     * @throws TProtocolException if the value is not an object
+     */
+    @SuppressWarnings("unchecked")
+    public static Map<String, Object> parseObject(Object value) throws TProtocolException {
+        if (!(value instanceof Map)) {
+            throw invalid("a JSON object", value);
+        }
+        return (Map<String, Object>) value;
+    }
+
+    /**
+     * Returns the JSON value if it is an array.
+     *
+     * @param value the JSON value
+     * @return the array
+     * @throws TProtocolException if the value is not an array
+     */
+    @SuppressWarnings("unchecked")
+    public static List<Object> parseList(Object value) throws TProtocolException {
+        if (!(value instanceof List)) {
+            throw invalid("a JSON array", value);
+        }
+        return (List<Object>) value;
+    }
+
+    /**
+     * Returns the key and value of each entry of a map written as entries.
+     *
+     * @param value the JSON value
+     * @return the keys and values
+     * @throws TProtocolException if the value is not an array of objects
+     */
+    public static List<Pair<Object, Object>> parseEntries(Object value) throws TProtocolException {
+        List<Pair<Object, Object>> entries = new ArrayList<>();
+        for (Object entry : parseList(value)) {
+            Map<String, Object> object = parseObject(entry);
+            entries.add(Pair.of(object.get("key"), object.get("value")));
+        }
+        return entries;
+    }
+
+    /**
+     * Returns the boolean held by the JSON value.
+     *
+     * @param value the JSON value
+     * @return the boolean
+     * @throws TProtocolException if the value is not a boolean
+     */
+    public static boolean parseBool(Object value) throws TProtocolException {
+        if (!(value instanceof Boolean)) {
+            throw invalid("a bool", value);
+        }
+        return (Boolean) value;
+    }
+
+    /**
+     * Returns the boolean held by an object key.
+     *
+     * @param value the object key
+     * @return the boolean
+     * @throws TProtocolException if the key is not "true" or "false"
+     */
+    public static boolean parseBoolKey(String value) throws TProtocolException {
+        if (!"true".equals(value) && !"false".equals(value)) {
+            throw invalid("a bool", value);
+        }
+        return "true".equals(value);
+    }
+
+    /**
+     * Returns the integer held by the JSON value, a number or a string, if it
+     * fits in the given number of bits.
+     *
+     * @param value the JSON value
+     * @param bits  the size of the integer type
+     * @return the integer
+     * @throws TProtocolException if the value is not an integer in range
+     */
+    public static long parseInt(Object value, int bits) throws TProtocolException {
+        BigDecimal number;
+        if (value instanceof String && INT_PATTERN.matcher((String) value).matches()) {
+            number = new BigDecimal((String) value);
+        } else if (value instanceof BigDecimal && ((BigDecimal) value).scale() == 0) {
+            number = (BigDecimal) value;
+        } else {
+            throw invalid("an integer", value);
+        }
+        if (number.toBigInteger().bitLength() >= bits) {
+            throw new TProtocolException(TProtocolException.INVALID_DATA, value + " out of range for i" + bits);
+        }
+        return number.longValue();
+    }
+
+    /**
+     * Returns the double held by the JSON value, a number or a string.
+     *
+     * @param value the JSON value
+     * @return the double
+     * @throws TProtocolException if the value is not a double
+     */
+    public static double parseDouble(Object value) throws TProtocolException {
+        if (value instanceof BigDecimal) {
+            return ((BigDecimal) value).doubleValue();
+        }
+        if (value instanceof String) {
+            String s = (String) value;
+            switch (s) {
+                case "NaN":
+                    return Double.NaN;
+                case "Infinity":
+                    return Double.POSITIVE_INFINITY;
+                case "-Infinity":
+                    return Double.NEGATIVE_INFINITY;
+                default:
+                    if (DOUBLE_PATTERN.matcher(s).matches()) {
+                        return Double.parseDouble(s);
+                    }
+            }
+        }
+        throw invalid("a double", value);
+    }
+
+    /**
+     * Returns the string held by the JSON value.
+     *
+     * @param value the JSON value
+     * @return the string
+     * @throws TProtocolException if the value is not a string
+     */
+    public static String parseString(Object value) throws TProtocolException {
+        if (!(value instanceof String)) {
+            throw invalid("a string", value);
+        }
+        return (String) value;
+    }
+
+    /**
+     * Returns the bytes held by the base64 JSON value.
+     *
+     * @param value the JSON value
+     * @return the bytes
+     * @throws TProtocolException if the value is not a base64 string
+     */
+    public static ByteBuffer parseBinary(Object value) throws TProtocolException {
+        try {
+            return ByteBuffer.wrap(Base64.getDecoder().decode(parseString(value)));
+        } catch (IllegalArgumentException e) {
+            throw invalid("base64", value);
+        }
+    }
+
+    /**
+     * Returns the enum held by the JSON value, a name or a number. Undeclared
+     * numbers are read as null.
+     *
+     * @param value the JSON value
+     * @param type  the enum class
+     * @param <E>   the enum type
+     * @return the enum
+     * @throws TProtocolException if the value is not a declared name or a number
+     */
+    public static <E extends Enum<E> & TEnum> E parseEnum(Object value, Class<E> type) throws TProtocolException {
+        if (value instanceof String && !INT_PATTERN.matcher((String) value).matches()) {
+            for (E e : type.getEnumConstants()) {
+                if (e.name().equals(value)) {
+                    return e;
+                }
+            }
+            throw new TProtocolException(TProtocolException.INVALID_DATA,
+                    value + " is not a valid " + type.getSimpleName());
+        }
+        int v = (int) parseInt(value, 32);
+        for (E e : type.getEnumConstants()) {
+            if (e.getValue() == v) {
+                return e;
+            }
+        }
+        return null;
+    }
+
+    private static TProtocolException invalid(String expected, Object value) {
+        return new TProtocolException(TProtocolException.INVALID_DATA, "expected " + expected + ", got " + value);
+    }
+
+    /**
+     * A JSON parser producing maps, lists, strings, booleans, BigDecimals and
+     * nulls.
+     */
+    private static class Parser {
+        private final String json;
+        private int pos;
+
+        Parser(String json) {
+            this.json = json;
+        }
+
+        Object parseValue() throws TProtocolException {
+            skipWhitespace();
+            if (pos >= json.length()) {
+                throw error("unexpected end of JSON");
+            }
+            char c = json.charAt(pos);
+            switch (c) {
+                case '{':
+                    return parseObject();
+                case '[':
+                    return parseArray();
+                case '"':
+                    return parseString();
+                case 't':
+                    return parseLiteral("true", Boolean.TRUE);
+                case 'f':
+                    return parseLiteral("false", Boolean.FALSE);
+                case 'n':
+                    return parseLiteral("null", null);
+                default:
+                    if (c == '-' || (c >= '0' && c <= '9')) {
+                        return parseNumber();
+                    }
+                    throw error("unexpected character " + c);
+            }
+        }
+
+        private Map<String, Object> parseObject() throws TProtocolException {
+            Map<String, Object> object = new LinkedHashMap<>();
+            pos++;
+            skipWhitespace();
+            if (consume('}')) {
+                return object;
+            }
+            do {
+                skipWhitespace();
+                if (pos >= json.length() || json.charAt(pos) != '"') {
+                    throw error("expected an object key");
+                }
+                String key = parseString();
+                skipWhitespace();
+                if (!consume(':')) {
+                    throw error("expected ':'");
+                }
+                object.put(key, parseValue());
+                skipWhitespace();
+            } while (consume(','));
+            if (!consume('}')) {
+                throw error("expected ',' or '}'");
+            }
+            return object;
+        }
+
+        private List<Object> parseArray() throws TProtocolException {
+            List<Object> array = new ArrayList<>();
+            pos++;
+            skipWhitespace();
+            if (consume(']')) {
+                return array;
+            }
+            do {
+                array.add(parseValue());
+                skipWhitespace();
+            } while (consume(','));
+            if (!consume(']')) {
+                throw error("expected ',' or ']'");
+            }
+            return array;
+        }
+
+        private String parseString() throws TProtocolException {
+            StringBuilder s = new StringBuilder();
+            pos++;
+            while (pos < json.length()) {
+                char c = json.charAt(pos++);
+                if (c == '"') {
+                    return s.toString();
+                }
+                if (c < ' ') {
+                    throw error("control character in string");
+                }
+                if (c != '\\') {
+                    s.append(c);
+                    continue;
+                }
+                if (pos >= json.length()) {
+                    break;
+                }
+                char escape = json.charAt(pos++);
+                switch (escape) {
+                    case '"':
+                    case '\\':
+                    case '/':
+                        s.append(escape);
+                        break;
+                    case 'b':
+                        s.append('\b');
+                        break;
+                    case 'f':
+                        s.append('\f');
+                        break;
+                    case 'n':
+                        s.append('\n');
+                        break;
+                    case 'r':
+                        s.append('\r');
+                        break;
+                    case 't':
+                        s.append('\t');
+                        break;
+                    case 'u':
+                        if (pos + 4 > json.length()) {
+                            throw error("invalid unicode escape");
+                        }
+                        try {
+                            s.append((char) Integer.parseInt(json.substring(pos, pos + 4), 16));
+                        } catch (NumberFormatException e) {
+                            throw error("invalid unicode escape");
+                        }
+                        pos += 4;
+                        break;
+                    default:
+                        throw error("invalid escape \\" + escape);
+                }
+            }
+            throw error("unterminated string");
+        }
+
+        private Object parseLiteral(String literal, Object value) throws TProtocolException {
+            if (!json.startsWith(literal, pos)) {
+                throw error("unexpected character " + json.charAt(pos));
+            }
+            pos += literal.length();
+            return value;
+        }
+
+        private BigDecimal parseNumber() throws TProtocolException {
+            int start = pos;
+            consume('-');
+            if (!consume('0')) {
+                if (!consumeDigits()) {
+                    throw error("invalid number");
+                }
+            }
+            if (consume('.') && !consumeDigits()) {
+                throw error("invalid number");
+            }
+            if (consume('e') || consume('E')) {
+                if (!consume('+')) {
+                    consume('-');
+                }
+                if (!consumeDigits()) {
+                    throw error("invalid number");
+                }
+            }
+            return new BigDecimal(json.substring(start, pos));
+        }
+
+        private boolean consumeDigits() {
+            int start = pos;
+            while (pos < json.length() && json.charAt(pos) >= '0' && json.charAt(pos) <= '9') {
+                pos++;
+            }
+            return pos > start;
+        }
+
+        private boolean consume(char c) {
+            if (pos < json.length() && json.charAt(pos) == c) {
+                pos++;
+                return true;
+            }
+            return false;
+        }
+
+        void skipWhitespace() {
+            while (pos < json.length()) {
+                char c = json.charAt(pos);
+                if (c != ' ' && c != '\t' && c != '\n' && c != '\r') {
+                    return;
+                }
+                pos++;
+            }
+        }
+
+        TProtocolException error(String message) {
+            return new TProtocolException(TProtocolException.INVALID_DATA,
+                    "invalid JSON at offset " + pos + ": " + message);
+        }
+    }
+}
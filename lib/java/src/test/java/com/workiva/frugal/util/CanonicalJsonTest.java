@@ -0,0 +1,114 @@
+package com.workiva.frugal.util;
+
+import org.apache.thrift.protocol.TProtocolException;
+import org.junit.Test;
+import org.junit.runner.RunWith;
+import org.junit.runners.JUnit4;
+
+import java.math.BigDecimal;
+import java.util.ArrayList;
+import java.util.Arrays;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.assertTrue;
+import static org.junit.Assert.fail;
+
+/**
+ * Tests for {@link CanonicalJson}.
+ */
+@RunWith(JUnit4.class)
+public class CanonicalJsonTest {
+
+    @Test
+    public void testEncodeDoubles() {
+        Object[][] cases = {
+                {0.0, "0"},
+                {-0.0, "-0"},
+                {100.0, "100"},
+                {-1.5, "-1.5"},
+                {0.1, "0.1"},
+                {0.000001, "0.000001"},
+                {1e-7, "1e-7"},
+                {123456789012345680000.0, "123456789012345680000"},
+                {1e21, "1e+21"},
+                {1.2345e-300, "1.2345e-300"},
+                {Double.NaN, "\"NaN\""},
+                {Double.NEGATIVE_INFINITY, "\"-Infinity\""},
+        };
+        for (Object[] c : cases) {
+            assertEquals(c[1], CanonicalJson.encode(CanonicalJson.doubleValue((Double) c[0])));
+        }
+    }
+
+    @Test
+    public void testEncodeStrings() {
+        assertEquals("\"\\\"\\\\\\b\\f\\n\\r\\t\\u0001\\u003c\\u2028\u00e9\ud83d\ude00\\ufffd\"",
+                CanonicalJson.encode("\"\\\b\f\n\r\t\u0001<\u2028\u00e9\ud83d\ude00\ud83d"));
+    }
+
+    @Test
+    public void testEncodeSortsKeys() {
+        Map<String, Object> inner = new HashMap<>();
+        inner.put("b", true);
+        inner.put("a", null);
+        Map<String, Object> outer = new HashMap<>();
+        outer.put("2", inner);
+        outer.put("10", new ArrayList<>());
+        assertEquals("{\"10\":[],\"2\":{\"a\":null,\"b\":true}}", CanonicalJson.encode(outer));
+    }
+
+    @Test
+    public void testSorted() {
+        List<Object> values = Arrays.asList("b", 10L, "a", 2L);
+        assertEquals(Arrays.asList("a", "b", 10L, 2L), CanonicalJson.sorted(values));
+    }
+
+    @Test
+    public void testDecode() throws TProtocolException {
+        Map<String, Object> object = CanonicalJson.parseObject(
+                CanonicalJson.decode(" {\"a\": [1.5, \"\\u00e9\", true, null], \"b\": {}} "));
+        assertEquals(Arrays.asList(new BigDecimal("1.5"), "\u00e9", true, null), object.get("a"));
+        assertEquals(new HashMap<>(), object.get("b"));
+        for (String json : new String[]{"", "{", "[1,]", "01", "NaN", "{\"a\":1}x", "\"\\x\""}) {
+            try {
+                CanonicalJson.decode(json);
+                fail("decoded " + json);
+            } catch (TProtocolException e) {
+                // expected
+            }
+        }
+    }
+
+    @Test
+    public void testParseInt() throws TProtocolException {
+        assertEquals(-5, CanonicalJson.parseInt("-5", 8));
+        assertEquals(9007199254740993L, CanonicalJson.parseInt(new BigDecimal("9007199254740993"), 64));
+        assertEquals(-128, CanonicalJson.parseInt(new BigDecimal("-128"), 8));
+        for (Object value : new Object[]{new BigDecimal("128"), "1.0", " 1", new BigDecimal("1.0"), true, null}) {
+            try {
+                CanonicalJson.parseInt(value, 8);
+                fail("parsed " + value);
+            } catch (TProtocolException e) {
+                // expected
+            }
+        }
+    }
+
+    @Test
+    public void testParseDouble() throws TProtocolException {
+        assertEquals(1.5, CanonicalJson.parseDouble("1.5"), 0);
+        assertEquals(2.0, CanonicalJson.parseDouble(new BigDecimal("2")), 0);
+        assertTrue(Double.isNaN(CanonicalJson.parseDouble("NaN")));
+        for (Object value : new Object[]{"inf", "", true}) {
+            try {
+                CanonicalJson.parseDouble(value);
+                fail("parsed " + value);
+            } catch (TProtocolException e) {
+                // expected
+            }
+        }
+    }
+}
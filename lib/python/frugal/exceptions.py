@@ -40,3 +40,4 @@ class TApplicationExceptionType(object):
     UNSUPPORTED_CLIENT_TYPE = TApplicationException.UNSUPPORTED_CLIENT_TYPE
 
     RESPONSE_TOO_LARGE = 100
+    INVALID_ARGUMENT = 101
@@ -0,0 +1,68 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import math
+import unittest
+
+import mock
+
+from frugal.util import canonical_json
+
+
+class TestCanonicalJSON(unittest.TestCase):
+
+    def test_dumps_doubles(self):
+        for value, expected in [
+            (0.0, u'0'),
+            (-0.0, u'-0'),
+            (100.0, u'100'),
+            (-1.5, u'-1.5'),
+            (0.1, u'0.1'),
+            (0.000001, u'0.000001'),
+            (1e-7, u'1e-7'),
+            (123456789012345680000.0, u'123456789012345680000'),
+            (1e21, u'1e+21'),
+            (1.2345e-300, u'1.2345e-300'),
+            (float('nan'), u'"NaN"'),
+            (float('-inf'), u'"-Infinity"'),
+        ]:
+            self.assertEqual(
+                expected, canonical_json.dumps(canonical_json.double(value)))
+
+    def test_dumps_strings(self):
+        self.assertEqual(
+            u'"\\"\\\\\\b\\f\\n\\r\\t\\u0001\\u003c\\u2028\xe9\U0001f600"',
+            canonical_json.dumps(u'"\\\b\f\n\r\t\x01<\u2028\xe9\U0001f600'))
+
+    def test_dumps_sorts_keys(self):
+        self.assertEqual(u'{"10":[],"2":{"a":null,"b":true}}',
+                         canonical_json.dumps({u'2': {u'b': True, u'a': None},
+                                               u'10': []}))
+
+    def test_parse_int(self):
+        self.assertEqual(-5, canonical_json.parse_int(u'-5', 8))
+        self.assertEqual(9007199254740993,
+                         canonical_json.parse_int(9007199254740993, 64))
+        for value in [128, u'1.0', u' 1', 1.0, True, None]:
+            with self.assertRaises(ValueError):
+                canonical_json.parse_int(value, 8)
+
+    def test_parse_double(self):
+        self.assertEqual(1.5, canonical_json.parse_double(u'1.5'))
+        self.assertEqual(2.0, canonical_json.parse_double(2))
+        self.assertTrue(math.isnan(canonical_json.parse_double(u'NaN')))
+        for value in [u'inf', u'', True]:
+            with self.assertRaises(ValueError):
+                canonical_json.parse_double(value)
+
+    def test_loads_rejects_constants(self):
+        with self.assertRaises(ValueError):
+            canonical_json.loads(mock.Mock(), u'{"scale":NaN}')
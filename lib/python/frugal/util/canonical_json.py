@@ -0,0 +1,267 @@
+# Copyright 2017 Workiva
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#     http://www.apache.org/licenses/LICENSE-2.0
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+"""
+Helpers for the canonical JSON form of Frugal types, used by code generated
+with the canonical_json option. See documentation/json.md for the format.
+
+Generated types convert themselves to and from JSON values, which are dicts
+with string keys, lists, strings, bools, ints, floats and None. Use dumps and
+loads to convert generated types to and from JSON text.
+"""
+
+import base64
+import binascii
+import json
+import math
+import re
+
+import six
+
+_INT_PATTERN = re.compile(r'^[+-]?[0-9]+\Z')
+_DOUBLE_PATTERN = re.compile(
+    r'^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?\Z')
+_SHORT_ESCAPES = {
+    u'"': u'\\"',
+    u'\\': u'\\\\',
+    u'\b': u'\\b',
+    u'\f': u'\\f',
+    u'\n': u'\\n',
+    u'\r': u'\\r',
+    u'\t': u'\\t',
+}
+
+
+def dumps(obj):
+    """
+    Returns the canonical JSON encoding of a generated struct, union or
+    exception, or of a JSON value.
+    """
+    out = []
+    _encode(obj, out)
+    return u''.join(out)
+
+
+def loads(base, data):
+    """
+    Decodes canonical JSON into a base instance of a generated struct, union
+    or exception, and returns it. Raises ValueError if the data is invalid.
+    """
+    if isinstance(data, six.binary_type):
+        data = data.decode('utf-8')
+    return base.from_json_value(json.loads(data, parse_constant=_reject))
+
+
+def _reject(constant):
+    raise ValueError('invalid JSON value {}'.format(constant))
+
+
+def _encode(value, out):
+    if hasattr(value, 'to_json_value'):
+        value = value.to_json_value()
+    if value is None:
+        out.append(u'null')
+    elif value is True:
+        out.append(u'true')
+    elif value is False:
+        out.append(u'false')
+    elif isinstance(value, six.integer_types):
+        out.append(six.text_type(value))
+    elif isinstance(value, float):
+        out.append(_format_double(value))
+    elif isinstance(value, (six.text_type, six.binary_type)):
+        _encode_string(value, out)
+    elif isinstance(value, dict):
+        out.append(u'{')
+        for i, key in enumerate(sorted(value)):
+            if i > 0:
+                out.append(u',')
+            _encode_string(key, out)
+            out.append(u':')
+            _encode(value[key], out)
+        out.append(u'}')
+    elif isinstance(value, (list, tuple)):
+        out.append(u'[')
+        for i, elem in enumerate(value):
+            if i > 0:
+                out.append(u',')
+            _encode(elem, out)
+        out.append(u']')
+    else:
+        raise TypeError('cannot encode {!r} as JSON'.format(value))
+
+
+def _encode_string(value, out):
+    if isinstance(value, six.binary_type):
+        value = value.decode('utf-8')
+    out.append(u'"')
+    for c in value:
+        if c in _SHORT_ESCAPES:
+            out.append(_SHORT_ESCAPES[c])
+        elif c < u' ' or c in u'<>&\u2028\u2029':
+            out.append(u'\\u{:04x}'.format(ord(c)))
+        elif u'\ud800' <= c <= u'\udfff':
+            # Python 2 narrow builds hold non-BMP characters as surrogate
+            # pairs, anything else is not valid UTF-16.
+            out.append(c if six.PY2 else u'\ufffd')
+        else:
+            out.append(c)
+    out.append(u'"')
+
+
+def _format_double(value):
+    # Finite doubles are written as JavaScript's Number.prototype.toString
+    # would, using the shortest digits which read back as the same value.
+    if value == 0:
+        return u'-0' if math.copysign(1.0, value) < 0 else u'0'
+    sign = u'-' if value < 0 else u''
+    mantissa, _, exp = repr(abs(value)).partition('e')
+    whole, _, frac = mantissa.partition('.')
+    digits = (whole + frac).lstrip('0')
+    # The value is 0.<digits> * 10**point.
+    point = len(whole) + int(exp or 0) - (len(whole + frac) - len(digits))
+    digits = digits.rstrip('0')
+    if len(digits) <= point <= 21:
+        return sign + digits + u'0' * (point - len(digits))
+    if 0 < point <= 21:
+        return sign + digits[:point] + u'.' + digits[point:]
+    if -6 < point <= 0:
+        return sign + u'0.' + u'0' * -point + digits
+    exp = point - 1
+    if len(digits) > 1:
+        digits = digits[0] + u'.' + digits[1:]
+    return u'{}{}e{}{}'.format(sign, digits, u'+' if exp >= 0 else u'-',
+                               abs(exp))
+
+
+def double(value):
+    """Returns the JSON value of a double."""
+    if math.isnan(value):
+        return u'NaN'
+    if math.isinf(value):
+        return u'Infinity' if value > 0 else u'-Infinity'
+    return float(value)
+
+
+def binary(value):
+    """Returns the JSON value of a binary."""
+    return base64.b64encode(value).decode('ascii')
+
+
+def enum(value, enum_class):
+    """Returns the JSON value of an enum, its name if it is declared."""
+    return enum_class._VALUES_TO_NAMES.get(value, value)
+
+
+def key(value):
+    """Returns the object key for the JSON value of a map key."""
+    if value is True:
+        return u'true'
+    if value is False:
+        return u'false'
+    return six.text_type(value)
+
+
+def sorted_values(values):
+    """Returns the JSON values sorted by their encoding."""
+    return sorted(values, key=dumps)
+
+
+def parse_object(value):
+    """Returns the JSON value if it is an object."""
+    if not isinstance(value, dict):
+        raise ValueError('expected a JSON object, got {!r}'.format(value))
+    return value
+
+
+def parse_list(value):
+    """Returns the JSON value if it is an array."""
+    if not isinstance(value, list):
+        raise ValueError('expected a JSON array, got {!r}'.format(value))
+    return value
+
+
+def parse_entries(value):
+    """Returns the key and value of each entry of a map written as entries."""
+    return [(parse_object(entry).get('key'), entry.get('value'))
+            for entry in parse_list(value)]
+
+
+def parse_bool(value):
+    """Returns the bool held by the JSON value."""
+    if not isinstance(value, bool):
+        raise ValueError('expected a bool, got {!r}'.format(value))
+    return value
+
+
+def parse_bool_key(value):
+    """Returns the bool held by an object key."""
+    if value not in (u'true', u'false'):
+        raise ValueError('expected a bool, got {!r}'.format(value))
+    return value == u'true'
+
+
+def parse_int(value, bits):
+    """
+    Returns the integer held by the JSON value, a number or a string, if it
+    fits in the given number of bits.
+    """
+    if isinstance(value, six.string_types) and _INT_PATTERN.match(value):
+        value = int(value)
+    elif isinstance(value, bool) or \
+            not isinstance(value, six.integer_types):
+        raise ValueError('expected an integer, got {!r}'.format(value))
+    if not -(1 << (bits - 1)) <= value < 1 << (bits - 1):
+        raise ValueError('{} out of range for i{}'.format(value, bits))
+    return value
+
+
+def parse_double(value):
+    """Returns the double held by the JSON value, a number or a string."""
+    if isinstance(value, six.string_types):
+        if value == u'NaN':
+            return float('nan')
+        if value == u'Infinity':
+            return float('inf')
+        if value == u'-Infinity':
+            return float('-inf')
+        if _DOUBLE_PATTERN.match(value):
+            return float(value)
+    elif not isinstance(value, bool) and \
+            isinstance(value, six.integer_types + (float,)):
+        return float(value)
+    raise ValueError('expected a double, got {!r}'.format(value))
+
+
+def parse_string(value):
+    """Returns the string held by the JSON value."""
+    if not isinstance(value, six.string_types):
+        raise ValueError('expected a string, got {!r}'.format(value))
+    return value
+
+
+def parse_binary(value):
+    """Returns the bytes held by the base64 JSON value."""
+    try:
+        return base64.b64decode(parse_string(value).encode('ascii'))
+    except (binascii.Error, UnicodeEncodeError, TypeError):
+        raise ValueError('expected base64, got {!r}'.format(value))
+
+
+def parse_enum(value, enum_class):
+    """Returns the enum value held by the JSON value, a name or a number."""
+    if isinstance(value, six.string_types) and \
+            not _INT_PATTERN.match(value):
+        if value not in enum_class._NAMES_TO_VALUES:
+            raise ValueError('{!r} is not a valid {}'.format(
+                value, enum_class.__name__))
+        return enum_class._NAMES_TO_VALUES[value]
+    return parse_int(value, 32)
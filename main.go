@@ -1,28 +1,48 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/format"
 	"github.com/Workiva/frugal/compiler/generator"
 	"github.com/Workiva/frugal/compiler/globals"
+	"github.com/Workiva/frugal/compiler/lsp"
 	"github.com/Workiva/frugal/compiler/parser"
 	"github.com/urfave/cli"
 )
 
 const defaultTopicDelim = "."
 
+// Audit output formats.
+const (
+	auditFormatText  = "text"
+	auditFormatJSON  = "json"
+	auditFormatSARIF = "sarif"
+)
+
 var (
-	help    bool
-	gen     string
-	out     string
-	delim   string
-	audit   string
-	recurse bool
-	verbose bool
-	version bool
+	help        bool
+	gen         cli.StringSlice
+	out         cli.StringSlice
+	genConfig   string
+	delim       string
+	audit       string
+	against     string
+	auditFormat string
+	config      string
+	recurse     bool
+	include     cli.StringSlice
+	cacheFile   string
+	check       bool
+	write       bool
+	verbose     bool
+	version     bool
 )
 
 func main() {
@@ -39,15 +59,20 @@ func main() {
 			Usage:       "show help",
 			Destination: &help,
 		},
-		cli.StringFlag{
-			Name:        "gen",
-			Usage:       genUsage(),
-			Destination: &gen,
+		cli.StringSliceFlag{
+			Name:  "gen",
+			Usage: genUsage(),
+			Value: &gen,
+		},
+		cli.StringSliceFlag{
+			Name:  "out",
+			Usage: "set the output location for generated files (no gen-* folder will be created), once for all languages or once per -gen",
+			Value: &out,
 		},
 		cli.StringFlag{
-			Name:        "out",
-			Usage:       "set the output location for generated files (no gen-* folder will be created)",
-			Destination: &out,
+			Name:        "gen-config",
+			Usage:       "YAML file listing targets to generate, each with a gen and an optional out",
+			Destination: &genConfig,
 		},
 		cli.StringFlag{
 			Name:        "delim",
@@ -55,6 +80,16 @@ func main() {
 			Usage:       "set the delimiter for pub/sub topic tokens",
 			Destination: &delim,
 		},
+		cli.StringSliceFlag{
+			Name:  "I",
+			Usage: "add a directory to the list of directories searched for includes (repeatable, also read from " + parser.IncludePathEnv + ")",
+			Value: &include,
+		},
+		cli.StringFlag{
+			Name:        "cache-file",
+			Usage:       "file recording input hashes so that unchanged files are not regenerated",
+			Destination: &cacheFile,
+		},
 		cli.BoolFlag{
 			Name:        "recurse, r",
 			Usage:       "generate included files",
@@ -74,6 +109,134 @@ func main() {
 			Usage:       "frugal file to run audit against",
 			Destination: &audit,
 		},
+		cli.StringFlag{
+			Name:        "audit-format",
+			Value:       auditFormatText,
+			Usage:       "output format of the audit (text, json, or sarif)",
+			Destination: &auditFormat,
+		},
+		cli.StringFlag{
+			Name:        "audit-config",
+			Usage:       "YAML file listing audit rules to suppress",
+			Destination: &config,
+		},
+	}
+
+	app.Commands = []cli.Command{
+		{
+			Name:      "audit",
+			Usage:     "audit frugal files for breaking changes against a git revision",
+			ArgsUsage: "file...",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:        "against",
+					Usage:       "git revision to audit against, e.g. origin/master",
+					Destination: &against,
+				},
+				cli.StringFlag{
+					Name:        "format",
+					Value:       auditFormatText,
+					Usage:       "output format of the audit (text, json, or sarif)",
+					Destination: &auditFormat,
+				},
+				cli.StringFlag{
+					Name:        "config",
+					Usage:       "YAML file listing audit rules to suppress",
+					Destination: &config,
+				},
+				cli.StringSliceFlag{
+					Name:  "I",
+					Usage: "add a directory to the list of directories searched for includes (repeatable, also read from " + parser.IncludePathEnv + ")",
+					Value: &include,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if against == "" || len(c.Args()) == 0 {
+					fmt.Printf("Usage: %s audit --against <git-ref> file...\n", app.Name)
+					os.Exit(1)
+				}
+				if !runAudit(c.Args(), func(auditor *parser.Auditor, file string) error {
+					return auditor.AuditRevision(against, file)
+				}) {
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "lint",
+			Usage:     "check frugal files for style and safety issues",
+			ArgsUsage: "file...",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:        "config",
+					Usage:       "YAML file setting the severity of lint rules",
+					Destination: &config,
+				},
+				cli.StringSliceFlag{
+					Name:  "I",
+					Usage: "add a directory to the list of directories searched for includes (repeatable, also read from " + parser.IncludePathEnv + ")",
+					Value: &include,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if len(c.Args()) == 0 {
+					fmt.Printf("Usage: %s lint [--config file] file...\n", app.Name)
+					os.Exit(1)
+				}
+				if !runLint(c.Args()) {
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "lsp",
+			Usage: "run a language server for frugal files over stdio",
+			Flags: []cli.Flag{
+				cli.StringSliceFlag{
+					Name:  "I",
+					Usage: "add a directory to the list of directories searched for includes (repeatable, also read from " + parser.IncludePathEnv + ")",
+					Value: &include,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				server := lsp.NewServer(os.Stdin, os.Stdout)
+				server.SetIncludePaths(include)
+				if err := server.Run(); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
+		{
+			Name:      "fmt",
+			Usage:     "print frugal files in canonical form",
+			ArgsUsage: "file...",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:        "check",
+					Usage:       "list files which are not formatted and exit with a non-zero status",
+					Destination: &check,
+				},
+				cli.BoolFlag{
+					Name:        "w",
+					Usage:       "write the result to the source file instead of stdout",
+					Destination: &write,
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if len(c.Args()) == 0 {
+					fmt.Printf("Usage: %s fmt [-check] [-w] file...\n", app.Name)
+					os.Exit(1)
+				}
+				if !runFormat(c.Args()) {
+					os.Exit(1)
+				}
+				return nil
+			},
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
@@ -93,42 +256,52 @@ func main() {
 			os.Exit(1)
 		}
 
-		if gen == "" && audit == "" {
+		if len(gen) == 0 && genConfig == "" && audit == "" {
 			fmt.Println("No output language specified")
 			fmt.Printf("Usage: %s [options] file\n\n", app.Name)
 			fmt.Printf("Use %s -help for a list of options\n", app.Name)
 			os.Exit(1)
 		}
 
-		options := compiler.Options{
-			Gen:     gen,
-			Out:     out,
-			Delim:   delim,
-			Recurse: recurse,
-			Verbose: verbose,
-		}
-
-		// Handle panics for graceful error messages.
-		defer func() {
-			if r := recover(); r != nil {
-				fmt.Printf("Failed to generate %s:\n\t%s\n", options.File, r)
+		if audit != "" {
+			if !runAudit(c.Args(), func(auditor *parser.Auditor, file string) error {
+				return auditor.Audit(audit, file)
+			}) {
 				os.Exit(1)
 			}
-		}()
+			return nil
+		}
 
-		var err error
-		auditor := parser.NewAuditor()
-		for _, options.File = range c.Args() {
-			if audit == "" {
-				err = compiler.Compile(options)
-			} else {
-				err = auditor.Audit(audit, options.File)
-			}
-			if err != nil {
-				fmt.Printf("Failed to generate %s:\n\t%s\n", options.File, err.Error())
-				os.Exit(1)
+		targets, err := loadTargets()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		results, err := compiler.Build(compiler.BuildOptions{
+			Files:        c.Args(),
+			Targets:      targets,
+			Delim:        delim,
+			Recurse:      recurse,
+			Verbose:      verbose,
+			IncludePaths: include,
+			CacheFile:    cacheFile,
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		failed := false
+		for _, result := range results {
+			if result.Err != nil {
+				fmt.Printf("Failed to generate %s for %s:\n%s\n", result.File, result.Gen, indentError(result.Err))
+				failed = true
 			}
 		}
+		if failed {
+			os.Exit(1)
+		}
 
 		return nil
 	}
@@ -136,6 +309,138 @@ func main() {
 	app.Run(os.Args)
 }
 
+// loadTargets returns the targets listed in the gen config followed by those
+// given with -gen and -out.
+func loadTargets() ([]compiler.Target, error) {
+	var targets []compiler.Target
+	if genConfig != "" {
+		var err error
+		if targets, err = compiler.LoadTargets(genConfig); err != nil {
+			return nil, err
+		}
+	}
+	flagTargets, err := compiler.PairTargets(gen, out)
+	if err != nil {
+		return nil, err
+	}
+	return append(targets, flagTargets...), nil
+}
+
+// runAudit audits each of the given files with the given function and writes
+// the findings in the configured format. Returns true if no breaking changes
+// were found.
+func runAudit(files []string, auditFile func(*parser.Auditor, string) error) bool {
+	var auditConfig *parser.AuditConfig
+	if config != "" {
+		var err error
+		if auditConfig, err = parser.LoadAuditConfig(config); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+	}
+
+	var logger parser.ValidationLogger
+	switch auditFormat {
+	case auditFormatText:
+		logger = parser.NewStdOutLogger()
+	case auditFormatJSON, auditFormatSARIF:
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid audit format '%s'\n", auditFormat)
+		return false
+	}
+
+	auditor := parser.NewAuditorWithConfig(logger, auditConfig)
+	auditor.SetIncludePaths(include)
+	passed := true
+	for _, file := range files {
+		if err := auditFile(auditor, file); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to audit %s:\n%s\n", file, indentError(err))
+			passed = false
+		}
+	}
+
+	var err error
+	switch auditFormat {
+	case auditFormatJSON:
+		err = parser.WriteAuditJSON(os.Stdout, auditor.Findings())
+	case auditFormatSARIF:
+		err = parser.WriteAuditSARIF(os.Stdout, globals.Version, auditor.Findings())
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	return passed
+}
+
+// runLint lints each of the given files. Returns true if no errors were
+// found.
+func runLint(files []string) bool {
+	var lintConfig *parser.LintConfig
+	if config != "" {
+		var err error
+		if lintConfig, err = parser.LoadLintConfig(config); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return false
+		}
+	}
+
+	linter := parser.NewLinter(parser.NewStdOutLogger(), lintConfig)
+	linter.SetIncludePaths(include)
+	passed := true
+	for _, file := range files {
+		if err := linter.Lint(file); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to lint %s:\n%s\n", file, indentError(err))
+			passed = false
+		}
+	}
+	return passed
+}
+
+// runFormat formats each of the given files. Returns false if a file could not
+// be formatted or, in check mode, is not already formatted.
+func runFormat(files []string) bool {
+	passed := true
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			passed = false
+			continue
+		}
+		formatted, err := format.Format(file, contents)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to format %s:\n%s\n", file, indentError(err))
+			passed = false
+			continue
+		}
+
+		switch {
+		case check:
+			if !bytes.Equal(contents, formatted) {
+				fmt.Println(file)
+				passed = false
+			}
+		case write:
+			if !bytes.Equal(contents, formatted) {
+				if err := ioutil.WriteFile(file, formatted, 0644); err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					passed = false
+				}
+			}
+		default:
+			os.Stdout.Write(formatted)
+		}
+	}
+	return passed
+}
+
+// indentError returns the error message with each line indented. Validation
+// errors have one "file:line:col: message" diagnostic per line.
+func indentError(err error) string {
+	return "\t" + strings.Replace(err.Error(), "\n", "\n\t", -1)
+}
+
 func genUsage() string {
 	usage := "generate code with a registered generator and optional parameters " +
 		"(lang[:key1=val1[,key2[,key3=val3]]])\n"
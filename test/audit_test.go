@@ -14,7 +14,13 @@
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -138,3 +144,211 @@ func TestScopeBreakingChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestAuditFindings(t *testing.T) {
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	badFile := "idl/breaking_changes/break2.thrift"
+	assert.Error(t, auditor.Audit(testFileThrift, badFile))
+	assert.Equal(t, []*parser.AuditFinding{{
+		Severity: parser.AuditError,
+		RuleID:   parser.RuleFieldTypeChanged,
+		Message:  "struct test_struct1: field struct1_member1: types not equal: 'i16' -> 'i32'",
+		File:     badFile,
+		Line:     63,
+		Column:   5,
+		Old:      "1: i16 struct1_member1",
+		New:      "1: i32 struct1_member1",
+	}}, auditor.Findings())
+}
+
+func TestAuditFindingsRemoved(t *testing.T) {
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	assert.Error(t, auditor.Audit(scopeFile, "idl/breaking_changes/scope3.frugal"))
+	findings := auditor.Findings()
+	assert.Len(t, findings, 1)
+	assert.Equal(t, parser.RuleScopeRemoved, findings[0].RuleID)
+	assert.Equal(t, scopeFile, findings[0].File)
+	assert.Equal(t, 32, findings[0].Line)
+	assert.Equal(t, 1, findings[0].Column)
+	assert.Equal(t, "scope blah", findings[0].Old)
+	assert.Equal(t, "", findings[0].New)
+}
+
+func TestAuditSuppressedByAnnotation(t *testing.T) {
+	logger := &MockValidationLogger{}
+	auditor := parser.NewAuditorWithLogger(logger)
+	assert.Nil(t, auditor.Audit(testFileThrift, "idl/breaking_changes/suppressed.thrift"))
+	assert.Empty(t, auditor.Findings())
+	assert.Empty(t, logger.errors)
+}
+
+func TestAuditSuppressedByConfig(t *testing.T) {
+	config, err := parser.LoadAuditConfig("idl/breaking_changes/audit_config.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{parser.RuleFieldTypeChanged}, config.Suppress)
+
+	auditor := parser.NewAuditorWithConfig(nil, config)
+	assert.Nil(t, auditor.Audit(testFileThrift, "idl/breaking_changes/break2.thrift"))
+	assert.Empty(t, auditor.Findings())
+
+	// Other rules are still reported.
+	assert.Error(t, auditor.Audit(testFileThrift, "idl/breaking_changes/break1.thrift"))
+	assert.Len(t, auditor.Findings(), 1)
+}
+
+func TestLoadAuditConfigUnknownRule(t *testing.T) {
+	_, err := parser.LoadAuditConfig("idl/breaking_changes/break1.thrift")
+	assert.Error(t, err)
+}
+
+func TestWriteAuditJSON(t *testing.T) {
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	auditor.Audit(testFileThrift, "idl/breaking_changes/break2.thrift")
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, parser.WriteAuditJSON(buf, auditor.Findings()))
+	var findings []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &findings))
+	assert.Len(t, findings, 1)
+	assert.Equal(t, "field-type-changed", findings[0]["ruleId"])
+	assert.Equal(t, "error", findings[0]["severity"])
+	assert.Equal(t, float64(63), findings[0]["line"])
+	assert.Equal(t, float64(5), findings[0]["column"])
+
+	buf.Reset()
+	assert.Nil(t, parser.WriteAuditJSON(buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestWriteAuditSARIF(t *testing.T) {
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	auditor.Audit(testFileThrift, "idl/breaking_changes/break2.thrift")
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, parser.WriteAuditSARIF(buf, "1.2.3", auditor.Findings()))
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name    string `json:"name"`
+					Version string `json:"version"`
+					Rules   []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine   int `json:"startLine"`
+							StartColumn int `json:"startColumn"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+				Properties map[string]string `json:"properties"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "frugal", run.Tool.Driver.Name)
+	assert.Equal(t, "1.2.3", run.Tool.Driver.Version)
+	assert.Len(t, run.Tool.Driver.Rules, len(parser.AuditRules))
+	assert.Len(t, run.Results, 1)
+	result := run.Results[0]
+	assert.Equal(t, "field-type-changed", result.RuleID)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "idl/breaking_changes/break2.thrift", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 63, result.Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Equal(t, 5, result.Locations[0].PhysicalLocation.Region.StartColumn)
+	assert.Equal(t, "1: i16 struct1_member1", result.Properties["old"])
+	assert.Equal(t, "1: i32 struct1_member1", result.Properties["new"])
+}
+
+func TestIncludeBreakingChanges(t *testing.T) {
+	logger := &MockValidationLogger{}
+	auditor := parser.NewAuditorWithLogger(logger)
+	err := auditor.Audit("idl/breaking_changes/include/old/main.thrift", "idl/breaking_changes/include/new/main.thrift")
+	assert.Error(t, err)
+
+	type finding struct {
+		rule    string
+		file    string
+		message string
+	}
+	mainFile := "idl/breaking_changes/include/new/main.thrift"
+	baseFile := "idl/breaking_changes/include/new/base.thrift"
+	expected := []finding{
+		{parser.RuleTypedefTypeChanged, mainFile, "typedef UserId: types not equal: 'i32' -> 'string'"},
+		{parser.RuleFieldTypeChanged, mainFile, "struct User: field id: types not equal: 'i32' -> 'string'"},
+		{parser.RuleDeprecatedChanged, mainFile, "struct User: field name: deprecated added"},
+		{parser.RuleFieldTypeChanged, mainFile, "service Users: method get: field id: types not equal: 'i32' -> 'string'"},
+		{parser.RuleVendorChanged, baseFile, "namespace go: vendor changed: 'github.com/foo/base' -> 'github.com/bar/base'"},
+		{parser.RuleTypedefTypeChanged, baseFile, "typedef Id: types not equal: 'i32' -> 'string'"},
+		{parser.RuleFieldTypeChanged, baseFile, "struct Thing: field count: types not equal: 'i64' -> 'i32'"},
+	}
+	actual := make([]finding, len(auditor.Findings()))
+	for i, f := range auditor.Findings() {
+		actual[i] = finding{f.RuleID, f.File, f.Message}
+	}
+	assert.ElementsMatch(t, expected, actual)
+
+	// Logged messages name the include the finding is about.
+	assert.Contains(t, logger.errors, baseFile+": typedef Id: types not equal: 'i32' -> 'string'")
+}
+
+func TestAuditRevision(t *testing.T) {
+	dir, err := ioutil.TempDir("", "frugal-audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=frugal", "-c", "user.email=frugal@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		assert.Nil(t, err, string(out))
+	}
+	copyFile := func(from, to string) {
+		contents, err := ioutil.ReadFile(from)
+		assert.Nil(t, err)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, to)), 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, to), contents, 0644))
+	}
+
+	git("init", "-q")
+	copyFile("idl/breaking_changes/include/old/main.thrift", "main.thrift")
+	copyFile("idl/breaking_changes/include/old/base.thrift", "base.thrift")
+	git("add", "-A")
+	git("commit", "-q", "-m", "old")
+	copyFile("idl/breaking_changes/include/new/main.thrift", "main.thrift")
+	copyFile("idl/breaking_changes/include/new/base.thrift", "base.thrift")
+
+	file := filepath.Join(dir, "main.thrift")
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	assert.Error(t, auditor.AuditRevision("HEAD", file))
+	assert.Len(t, auditor.Findings(), 7)
+
+	git("add", "-A")
+	git("commit", "-q", "-m", "new")
+	auditor = parser.NewAuditorWithConfig(nil, nil)
+	assert.Nil(t, auditor.AuditRevision("HEAD", file))
+	assert.Empty(t, auditor.Findings())
+
+	assert.Error(t, auditor.AuditRevision("no-such-revision", file))
+
+	// Revisions are never passed to git as options.
+	assert.Error(t, auditor.AuditRevision("--output="+filepath.Join(dir, "out"), file))
+	_, err = parser.GitFileReader("--help")(file)
+	assert.Error(t, err)
+	assert.False(t, parser.GitFileChecker("--help")(file))
+	assert.True(t, parser.GitFileChecker("HEAD")(file))
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuild(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "frugal-build")
+	assert.Nil(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	options := compiler.BuildOptions{
+		Files: []string{frugalGenFile, "idl/base.frugal"},
+		Targets: []compiler.Target{
+			{Gen: "go:package_prefix=github.com/Workiva/frugal/test/out/async/,async", Out: outputDir + "/async"},
+			{Gen: "go:package_prefix=github.com/Workiva/frugal/test/out/context/,context", Out: outputDir + "/context"},
+		},
+		Delim:       delim,
+		Recurse:     true,
+		Parallelism: 4,
+		CacheFile:   filepath.Join(cacheDir, "cache.json"),
+	}
+	results, err := compiler.Build(options)
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+		assert.False(t, result.Skipped)
+	}
+	assert.Equal(t, frugalGenFile, results[0].File)
+	assert.Equal(t, options.Targets[1].Gen, results[1].Gen)
+
+	files := []FileComparisonPair{
+		{"expected/go/variety_async/f_foo_service.txt", filepath.Join(outputDir, "async", "variety", "f_foo_service.go")},
+		{"expected/go/variety_context/f_foo_service.txt", filepath.Join(outputDir, "context", "variety", "f_foo_service.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+
+	results, err = compiler.Build(options)
+	assert.Nil(t, err)
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+		assert.True(t, result.Skipped)
+	}
+
+	options.Targets = options.Targets[:1]
+	options.Recurse = false
+	results, err = compiler.Build(options)
+	assert.Nil(t, err)
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+		assert.False(t, result.Skipped)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	results, err := compiler.Build(compiler.BuildOptions{
+		Files:   []string{validFile, invalidFile, "idl/missing.frugal"},
+		Targets: []compiler.Target{{Gen: "go", Out: outputDir}},
+		Delim:   delim,
+		DryRun:  true,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Err)
+	assert.Error(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+}
+
+func TestBuildMultipleLanguages(t *testing.T) {
+	targets, err := compiler.LoadTargets("idl/gen_config.yaml")
+	assert.Nil(t, err)
+	assert.Len(t, targets, 2)
+
+	results, err := compiler.Build(compiler.BuildOptions{
+		Files:   []string{frugalGenFile},
+		Targets: targets,
+		Delim:   delim,
+	})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/variety_async/f_foo_service.txt", filepath.Join(outputDir, "async", "variety", "f_foo_service.go")},
+		{"expected/python.asyncio/variety/f_Foo.py", filepath.Join(outputDir, "asyncio", "variety", "python", "f_Foo.py")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures generating a file for one language does not change the parse tree
+// used by the next, e.g. java making union fields default requiredness.
+func TestBuildSharedParseTree(t *testing.T) {
+	goTarget := compiler.Target{Gen: "go", Out: filepath.Join(outputDir, "shared", "go")}
+	goFile := filepath.Join(outputDir, "shared", "go", "variety", "f_types.go")
+	options := compiler.BuildOptions{
+		Files:       []string{frugalGenFile},
+		Targets:     []compiler.Target{goTarget},
+		Delim:       delim,
+		Parallelism: 1,
+	}
+	results, err := compiler.Build(options)
+	assert.Nil(t, err)
+	assert.Nil(t, results[0].Err)
+	goOnly, err := ioutil.ReadFile(goFile)
+	assert.Nil(t, err)
+
+	options.Targets = []compiler.Target{
+		{Gen: "java", Out: filepath.Join(outputDir, "shared", "java")},
+		goTarget,
+	}
+	results, err = compiler.Build(options)
+	assert.Nil(t, err)
+	for _, result := range results {
+		assert.Nil(t, result.Err)
+	}
+	withJava, err := ioutil.ReadFile(goFile)
+	assert.Nil(t, err)
+	assert.Equal(t, string(goOnly), string(withJava))
+}
+
+func TestLoadTargetsInvalid(t *testing.T) {
+	_, err := compiler.LoadTargets("idl/gen_config_invalid.yaml")
+	assert.Error(t, err)
+}
+
+func TestPairTargets(t *testing.T) {
+	targets, err := compiler.PairTargets([]string{"go", "py"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []compiler.Target{{Gen: "go"}, {Gen: "py"}}, targets)
+
+	targets, err = compiler.PairTargets([]string{"go", "py"}, []string{"gen"})
+	assert.Nil(t, err)
+	assert.Equal(t, []compiler.Target{{Gen: "go", Out: "gen"}, {Gen: "py", Out: "gen"}}, targets)
+
+	targets, err = compiler.PairTargets([]string{"go", "py"}, []string{"gen-go", "gen-py"})
+	assert.Nil(t, err)
+	assert.Equal(t, []compiler.Target{{Gen: "go", Out: "gen-go"}, {Gen: "py", Out: "gen-py"}}, targets)
+
+	_, err = compiler.PairTargets([]string{"go", "py", "java"}, []string{"gen-go", "gen-py"})
+	assert.Error(t, err)
+}
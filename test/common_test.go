@@ -17,12 +17,19 @@ import (
 	"bufio"
 	"flag"
 	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 )
 
 const (
 	outputDir               = "out"
+	outputPackage           = "github.com/Workiva/frugal/test/out"
 	delim                   = "."
 	validFile               = "idl/valid.frugal"
 	invalidFile             = "idl/invalid.frugal"
@@ -37,6 +44,9 @@ const (
 	includeVendor           = "idl/include_vendor.frugal"
 	includeVendorNoPath     = "idl/include_vendor_no_path.frugal"
 	vendorNamespace         = "idl/vendor_namespace.frugal"
+	multipleErrors          = "idl/multiple_errors.frugal"
+	invalidConstraints      = "idl/invalid_constraints.frugal"
+	invalidUnknownValue     = "idl/invalid_unknown_value.frugal"
 )
 
 var copyFiles bool
@@ -133,3 +143,119 @@ func assertFilesNotExist(t *testing.T, filePaths []string) {
 		}
 	}
 }
+
+// goRuntimeDependencies are the import path prefixes of the packages generated
+// Go code and the Frugal Go library depend on.
+var goRuntimeDependencies = []string{
+	"git.apache.org/thrift.git",
+	"github.com/Sirupsen/logrus",
+	"github.com/Workiva/frugal/lib/go",
+	"github.com/golang/snappy",
+	"github.com/mattrobenolt/gocql",
+	"github.com/nats-io",
+	"golang.org/x/net",
+}
+
+var missingGoPackage = regexp.MustCompile(`cannot find package "([^"]+)"`)
+
+// runGoTest copies the given test source into the generated Go package in dir
+// and runs it with go test, checking the behavior of the generated code rather
+// than only its text. The test is skipped if the go tool or the runtime
+// dependencies of the generated code are not available.
+func runGoTest(t *testing.T, dir, testFile string) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	src, err := ioutil.ReadFile(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "frugal_runtime_test.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rel, err := filepath.Rel(outputDir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := path.Join(outputPackage, filepath.ToSlash(rel))
+	output, err := exec.Command(goTool, "test", pkg).CombinedOutput()
+	if err != nil {
+		if onlyMissing(missingGoPackage, string(output), goRuntimeDependencies) {
+			t.Skipf("dependencies of %s not available:\n%s", pkg, output)
+		}
+		t.Fatalf("go test %s failed: %s\n%s", pkg, err, output)
+	}
+}
+
+// pythonRuntimeDependencies are the modules generated Python code and the
+// Frugal Python library depend on.
+var pythonRuntimeDependencies = []string{"thrift", "six", "frugal"}
+
+var missingPythonModule = regexp.MustCompile(`No module named '?([\w.]+)`)
+
+// runPythonTest copies the given unittest module into the generated Python
+// output in dir and runs it, with dir and the Frugal Python library on the
+// path. The test is skipped if Python or the runtime dependencies of the
+// generated code are not available.
+func runPythonTest(t *testing.T, dir, testFile string) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		if python, err = exec.LookPath("python"); err != nil {
+			t.Skip("python not found")
+		}
+	}
+	src, err := ioutil.ReadFile(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Base(testFile)
+	if err := ioutil.WriteFile(filepath.Join(dir, name), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lib, err := filepath.Abs(filepath.Join("..", "lib", "python"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pythonPath := []string{".", lib}
+	if existing := os.Getenv("PYTHONPATH"); existing != "" {
+		pythonPath = append(pythonPath, existing)
+	}
+	cmd := exec.Command(python, "-m", "unittest", strings.TrimSuffix(name, ".py"))
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "PYTHONPATH="+strings.Join(pythonPath, string(os.PathListSeparator)))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if onlyMissing(missingPythonModule, string(output), pythonRuntimeDependencies) {
+			t.Skipf("dependencies of %s not available:\n%s", dir, output)
+		}
+		t.Fatalf("%s %s failed: %s\n%s", python, name, err, output)
+	}
+}
+
+// onlyMissing indicates if the output reports missing packages or modules,
+// matched by the first group of pattern, and all of them are among the given
+// dependencies or their subpackages.
+func onlyMissing(pattern *regexp.Regexp, output string, dependencies []string) bool {
+	matches := pattern.FindAllStringSubmatch(output, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	for _, match := range matches {
+		if !isDependency(match[1], dependencies) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDependency(name string, dependencies []string) bool {
+	for _, dependency := range dependencies {
+		if name == dependency || strings.HasPrefix(name, dependency+"/") || strings.HasPrefix(name, dependency+".") {
+			return true
+		}
+	}
+	return false
+}
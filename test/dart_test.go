@@ -158,3 +158,42 @@ func TestValidDartVendorNamespaceTargetGenerate(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+func TestValidDartCanonicalJSON(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/canonical_json.frugal",
+		Gen:   "dart:canonical_json",
+		Out:   outputDir + "/dart.canonical_json",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/canonical_json/f_drawing.dart", filepath.Join(outputDir, "dart.canonical_json", "canonical_json", "lib", "src", "f_drawing.dart")},
+		{"expected/dart/canonical_json/f_shape.dart", filepath.Join(outputDir, "dart.canonical_json", "canonical_json", "lib", "src", "f_shape.dart")},
+		{"expected/dart/canonical_json/f_drawing_error.dart", filepath.Join(outputDir, "dart.canonical_json", "canonical_json", "lib", "src", "f_drawing_error.dart")},
+		{"expected/dart/canonical_json/pubspec.yaml", filepath.Join(outputDir, "dart.canonical_json", "canonical_json", "pubspec.yaml")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+func TestValidDartCanonicalJSONEnums(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/canonical_json.frugal",
+		Gen:   "dart:canonical_json,use_enums",
+		Out:   outputDir + "/dart.canonical_json_enums",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/dart/canonical_json_enums/f_drawing.dart", filepath.Join(outputDir, "dart.canonical_json_enums", "canonical_json", "lib", "src", "f_drawing.dart")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
@@ -0,0 +1,1218 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:canonical_json/canonical_json.dart' as t_canonical_json;
+
+import 'package:frugal/frugal.dart' as frugal;
+
+class Drawing implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Drawing");
+  static final thrift.TField _ID_FIELD_DESC = new thrift.TField("id", thrift.TType.I64, 1);
+  static final thrift.TField _NAME_FIELD_DESC = new thrift.TField("name", thrift.TType.STRING, 2);
+  static final thrift.TField _NOTE_FIELD_DESC = new thrift.TField("note", thrift.TType.STRING, 3);
+  static final thrift.TField _VISIBLE_FIELD_DESC = new thrift.TField("visible", thrift.TType.BOOL, 4);
+  static final thrift.TField _LAYER_FIELD_DESC = new thrift.TField("layer", thrift.TType.BYTE, 5);
+  static final thrift.TField _DEPTH_FIELD_DESC = new thrift.TField("depth", thrift.TType.I16, 6);
+  static final thrift.TField _SCALE_FIELD_DESC = new thrift.TField("scale", thrift.TType.DOUBLE, 7);
+  static final thrift.TField _THUMBNAIL_FIELD_DESC = new thrift.TField("thumbnail", thrift.TType.STRING, 8);
+  static final thrift.TField _KIND_FIELD_DESC = new thrift.TField("kind", thrift.TType.I32, 9);
+  static final thrift.TField _SIZE_FIELD_DESC = new thrift.TField("size", thrift.TType.I32, 10);
+  static final thrift.TField _SHAPES_FIELD_DESC = new thrift.TField("shapes", thrift.TType.LIST, 11);
+  static final thrift.TField _TAGS_FIELD_DESC = new thrift.TField("tags", thrift.TType.SET, 12);
+  static final thrift.TField _COUNTS_FIELD_DESC = new thrift.TField("counts", thrift.TType.MAP, 13);
+  static final thrift.TField _NAMES_FIELD_DESC = new thrift.TField("names", thrift.TType.MAP, 14);
+  static final thrift.TField _LABELS_FIELD_DESC = new thrift.TField("labels", thrift.TType.MAP, 15);
+  static final thrift.TField _FLAGS_FIELD_DESC = new thrift.TField("flags", thrift.TType.MAP, 16);
+  static final thrift.TField _ORIGIN_FIELD_DESC = new thrift.TField("origin", thrift.TType.STRUCT, 17);
+  static final thrift.TField _VERSION_FIELD_DESC = new thrift.TField("version", thrift.TType.I64, 18);
+
+  int _id = 0;
+  static const int ID = 1;
+  String _name;
+  static const int NAME = 2;
+  String _note;
+  static const int NOTE = 3;
+  bool _visible = false;
+  static const int VISIBLE = 4;
+  int _layer = 0;
+  static const int LAYER = 5;
+  int _depth = 0;
+  static const int DEPTH = 6;
+  double _scale = 0.0;
+  static const int SCALE = 7;
+  Uint8List _thumbnail;
+  static const int THUMBNAIL = 8;
+  int _kind;
+  static const int KIND = 9;
+  int _size;
+  static const int SIZE = 10;
+  List<t_canonical_json.Shape> _shapes;
+  static const int SHAPES = 11;
+  Set<String> _tags;
+  static const int TAGS = 12;
+  Map<int, List<int>> _counts;
+  static const int COUNTS = 13;
+  Map<int, String> _names;
+  static const int NAMES = 14;
+  Map<t_canonical_json.Point, String> _labels;
+  static const int LABELS = 15;
+  Map<bool, double> _flags;
+  static const int FLAGS = 16;
+  t_canonical_json.Point _origin;
+  static const int ORIGIN = 17;
+  int _version;
+  static const int VERSION = 18;
+
+  bool __isset_id = false;
+  bool __isset_visible = false;
+  bool __isset_layer = false;
+  bool __isset_depth = false;
+  bool __isset_scale = false;
+  bool __isset_kind = false;
+  bool __isset_size = false;
+  bool __isset_version = false;
+
+  Drawing() {
+    this.version = 3;
+  }
+
+  int get id => this._id;
+
+  set id(int id) {
+    this._id = id;
+    this.__isset_id = true;
+  }
+
+  bool isSetId() => this.__isset_id;
+
+  unsetId() {
+    this.__isset_id = false;
+  }
+
+  String get name => this._name;
+
+  set name(String name) {
+    this._name = name;
+  }
+
+  bool isSetName() => this.name != null;
+
+  unsetName() {
+    this.name = null;
+  }
+
+  String get note => this._note;
+
+  set note(String note) {
+    this._note = note;
+  }
+
+  bool isSetNote() => this.note != null;
+
+  unsetNote() {
+    this.note = null;
+  }
+
+  bool get visible => this._visible;
+
+  set visible(bool visible) {
+    this._visible = visible;
+    this.__isset_visible = true;
+  }
+
+  bool isSetVisible() => this.__isset_visible;
+
+  unsetVisible() {
+    this.__isset_visible = false;
+  }
+
+  int get layer => this._layer;
+
+  set layer(int layer) {
+    this._layer = layer;
+    this.__isset_layer = true;
+  }
+
+  bool isSetLayer() => this.__isset_layer;
+
+  unsetLayer() {
+    this.__isset_layer = false;
+  }
+
+  int get depth => this._depth;
+
+  set depth(int depth) {
+    this._depth = depth;
+    this.__isset_depth = true;
+  }
+
+  bool isSetDepth() => this.__isset_depth;
+
+  unsetDepth() {
+    this.__isset_depth = false;
+  }
+
+  double get scale => this._scale;
+
+  set scale(double scale) {
+    this._scale = scale;
+    this.__isset_scale = true;
+  }
+
+  bool isSetScale() => this.__isset_scale;
+
+  unsetScale() {
+    this.__isset_scale = false;
+  }
+
+  Uint8List get thumbnail => this._thumbnail;
+
+  set thumbnail(Uint8List thumbnail) {
+    this._thumbnail = thumbnail;
+  }
+
+  bool isSetThumbnail() => this.thumbnail != null;
+
+  unsetThumbnail() {
+    this.thumbnail = null;
+  }
+
+  int get kind => this._kind;
+
+  set kind(int kind) {
+    this._kind = kind;
+    this.__isset_kind = true;
+  }
+
+  bool isSetKind() => this.__isset_kind;
+
+  unsetKind() {
+    this.__isset_kind = false;
+  }
+
+  int get size => this._size;
+
+  set size(int size) {
+    this._size = size;
+    this.__isset_size = true;
+  }
+
+  bool isSetSize() => this.__isset_size;
+
+  unsetSize() {
+    this.__isset_size = false;
+  }
+
+  List<t_canonical_json.Shape> get shapes => this._shapes;
+
+  set shapes(List<t_canonical_json.Shape> shapes) {
+    this._shapes = shapes;
+  }
+
+  bool isSetShapes() => this.shapes != null;
+
+  unsetShapes() {
+    this.shapes = null;
+  }
+
+  Set<String> get tags => this._tags;
+
+  set tags(Set<String> tags) {
+    this._tags = tags;
+  }
+
+  bool isSetTags() => this.tags != null;
+
+  unsetTags() {
+    this.tags = null;
+  }
+
+  Map<int, List<int>> get counts => this._counts;
+
+  set counts(Map<int, List<int>> counts) {
+    this._counts = counts;
+  }
+
+  bool isSetCounts() => this.counts != null;
+
+  unsetCounts() {
+    this.counts = null;
+  }
+
+  Map<int, String> get names => this._names;
+
+  set names(Map<int, String> names) {
+    this._names = names;
+  }
+
+  bool isSetNames() => this.names != null;
+
+  unsetNames() {
+    this.names = null;
+  }
+
+  Map<t_canonical_json.Point, String> get labels => this._labels;
+
+  set labels(Map<t_canonical_json.Point, String> labels) {
+    this._labels = labels;
+  }
+
+  bool isSetLabels() => this.labels != null;
+
+  unsetLabels() {
+    this.labels = null;
+  }
+
+  Map<bool, double> get flags => this._flags;
+
+  set flags(Map<bool, double> flags) {
+    this._flags = flags;
+  }
+
+  bool isSetFlags() => this.flags != null;
+
+  unsetFlags() {
+    this.flags = null;
+  }
+
+  t_canonical_json.Point get origin => this._origin;
+
+  set origin(t_canonical_json.Point origin) {
+    this._origin = origin;
+  }
+
+  bool isSetOrigin() => this.origin != null;
+
+  unsetOrigin() {
+    this.origin = null;
+  }
+
+  int get version => this._version;
+
+  set version(int version) {
+    this._version = version;
+    this.__isset_version = true;
+  }
+
+  bool isSetVersion() => this.__isset_version;
+
+  unsetVersion() {
+    this.__isset_version = false;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case ID:
+        return this.id;
+      case NAME:
+        return this.name;
+      case NOTE:
+        return this.note;
+      case VISIBLE:
+        return this.visible;
+      case LAYER:
+        return this.layer;
+      case DEPTH:
+        return this.depth;
+      case SCALE:
+        return this.scale;
+      case THUMBNAIL:
+        return this.thumbnail;
+      case KIND:
+        return this.kind;
+      case SIZE:
+        return this.size;
+      case SHAPES:
+        return this.shapes;
+      case TAGS:
+        return this.tags;
+      case COUNTS:
+        return this.counts;
+      case NAMES:
+        return this.names;
+      case LABELS:
+        return this.labels;
+      case FLAGS:
+        return this.flags;
+      case ORIGIN:
+        return this.origin;
+      case VERSION:
+        return this.version;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case ID:
+        if(value == null) {
+          unsetId();
+        } else {
+          this.id = value as int;
+        }
+        break;
+
+      case NAME:
+        if(value == null) {
+          unsetName();
+        } else {
+          this.name = value as String;
+        }
+        break;
+
+      case NOTE:
+        if(value == null) {
+          unsetNote();
+        } else {
+          this.note = value as String;
+        }
+        break;
+
+      case VISIBLE:
+        if(value == null) {
+          unsetVisible();
+        } else {
+          this.visible = value as bool;
+        }
+        break;
+
+      case LAYER:
+        if(value == null) {
+          unsetLayer();
+        } else {
+          this.layer = value as int;
+        }
+        break;
+
+      case DEPTH:
+        if(value == null) {
+          unsetDepth();
+        } else {
+          this.depth = value as int;
+        }
+        break;
+
+      case SCALE:
+        if(value == null) {
+          unsetScale();
+        } else {
+          this.scale = value as double;
+        }
+        break;
+
+      case THUMBNAIL:
+        if(value == null) {
+          unsetThumbnail();
+        } else {
+          this.thumbnail = value as Uint8List;
+        }
+        break;
+
+      case KIND:
+        if(value == null) {
+          unsetKind();
+        } else {
+          this.kind = value as int;
+        }
+        break;
+
+      case SIZE:
+        if(value == null) {
+          unsetSize();
+        } else {
+          this.size = value as int;
+        }
+        break;
+
+      case SHAPES:
+        if(value == null) {
+          unsetShapes();
+        } else {
+          this.shapes = value as List<t_canonical_json.Shape>;
+        }
+        break;
+
+      case TAGS:
+        if(value == null) {
+          unsetTags();
+        } else {
+          this.tags = value as Set<String>;
+        }
+        break;
+
+      case COUNTS:
+        if(value == null) {
+          unsetCounts();
+        } else {
+          this.counts = value as Map<int, List<int>>;
+        }
+        break;
+
+      case NAMES:
+        if(value == null) {
+          unsetNames();
+        } else {
+          this.names = value as Map<int, String>;
+        }
+        break;
+
+      case LABELS:
+        if(value == null) {
+          unsetLabels();
+        } else {
+          this.labels = value as Map<t_canonical_json.Point, String>;
+        }
+        break;
+
+      case FLAGS:
+        if(value == null) {
+          unsetFlags();
+        } else {
+          this.flags = value as Map<bool, double>;
+        }
+        break;
+
+      case ORIGIN:
+        if(value == null) {
+          unsetOrigin();
+        } else {
+          this.origin = value as t_canonical_json.Point;
+        }
+        break;
+
+      case VERSION:
+        if(value == null) {
+          unsetVersion();
+        } else {
+          this.version = value as int;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case ID:
+        return isSetId();
+      case NAME:
+        return isSetName();
+      case NOTE:
+        return isSetNote();
+      case VISIBLE:
+        return isSetVisible();
+      case LAYER:
+        return isSetLayer();
+      case DEPTH:
+        return isSetDepth();
+      case SCALE:
+        return isSetScale();
+      case THUMBNAIL:
+        return isSetThumbnail();
+      case KIND:
+        return isSetKind();
+      case SIZE:
+        return isSetSize();
+      case SHAPES:
+        return isSetShapes();
+      case TAGS:
+        return isSetTags();
+      case COUNTS:
+        return isSetCounts();
+      case NAMES:
+        return isSetNames();
+      case LABELS:
+        return isSetLabels();
+      case FLAGS:
+        return isSetFlags();
+      case ORIGIN:
+        return isSetOrigin();
+      case VERSION:
+        return isSetVersion();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case ID:
+          if(field.type == thrift.TType.I64) {
+            id = iprot.readI64();
+            this.__isset_id = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NAME:
+          if(field.type == thrift.TType.STRING) {
+            name = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NOTE:
+          if(field.type == thrift.TType.STRING) {
+            note = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case VISIBLE:
+          if(field.type == thrift.TType.BOOL) {
+            visible = iprot.readBool();
+            this.__isset_visible = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LAYER:
+          if(field.type == thrift.TType.BYTE) {
+            layer = iprot.readByte();
+            this.__isset_layer = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case DEPTH:
+          if(field.type == thrift.TType.I16) {
+            depth = iprot.readI16();
+            this.__isset_depth = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case SCALE:
+          if(field.type == thrift.TType.DOUBLE) {
+            scale = iprot.readDouble();
+            this.__isset_scale = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case THUMBNAIL:
+          if(field.type == thrift.TType.STRING) {
+            thumbnail = iprot.readBinary();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case KIND:
+          if(field.type == thrift.TType.I32) {
+            kind = iprot.readI32();
+            this.__isset_kind = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case SIZE:
+          if(field.type == thrift.TType.I32) {
+            size = iprot.readI32();
+            this.__isset_size = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case SHAPES:
+          if(field.type == thrift.TType.LIST) {
+            thrift.TList elem2 = iprot.readListBegin();
+            shapes = new List<t_canonical_json.Shape>();
+            for(int elem4 = 0; elem4 < elem2.length; ++elem4) {
+              t_canonical_json.Shape elem3 = new t_canonical_json.Shape();
+              elem3.read(iprot);
+              shapes.add(elem3);
+            }
+            iprot.readListEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case TAGS:
+          if(field.type == thrift.TType.SET) {
+            thrift.TSet elem5 = iprot.readSetBegin();
+            tags = new Set<String>();
+            for(int elem7 = 0; elem7 < elem5.length; ++elem7) {
+              String elem6 = iprot.readString();
+              tags.add(elem6);
+            }
+            iprot.readSetEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case COUNTS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem8 = iprot.readMapBegin();
+            counts = new Map<int, List<int>>();
+            for(int elem13 = 0; elem13 < elem8.length; ++elem13) {
+              int elem14 = iprot.readI32();
+              thrift.TList elem10 = iprot.readListBegin();
+              List<int> elem9 = new List<int>();
+              for(int elem12 = 0; elem12 < elem10.length; ++elem12) {
+                int elem11 = iprot.readI64();
+                elem9.add(elem11);
+              }
+              iprot.readListEnd();
+              counts[elem14] = elem9;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case NAMES:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem15 = iprot.readMapBegin();
+            names = new Map<int, String>();
+            for(int elem17 = 0; elem17 < elem15.length; ++elem17) {
+              int elem18 = iprot.readI64();
+              String elem16 = iprot.readString();
+              names[elem18] = elem16;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case LABELS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem19 = iprot.readMapBegin();
+            labels = new Map<t_canonical_json.Point, String>();
+            for(int elem21 = 0; elem21 < elem19.length; ++elem21) {
+              t_canonical_json.Point elem22 = new t_canonical_json.Point();
+              elem22.read(iprot);
+              String elem20 = iprot.readString();
+              labels[elem22] = elem20;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case FLAGS:
+          if(field.type == thrift.TType.MAP) {
+            thrift.TMap elem23 = iprot.readMapBegin();
+            flags = new Map<bool, double>();
+            for(int elem25 = 0; elem25 < elem23.length; ++elem25) {
+              bool elem26 = iprot.readBool();
+              double elem24 = iprot.readDouble();
+              flags[elem26] = elem24;
+            }
+            iprot.readMapEnd();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case ORIGIN:
+          if(field.type == thrift.TType.STRUCT) {
+            origin = new t_canonical_json.Point();
+            origin.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case VERSION:
+          if(field.type == thrift.TType.I64) {
+            version = iprot.readI64();
+            this.__isset_version = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    if(!__isset_id) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.UNKNOWN, "Required field 'id' is not present in struct 'Drawing'");
+    }
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    oprot.writeFieldBegin(_ID_FIELD_DESC);
+    oprot.writeI64(id);
+    oprot.writeFieldEnd();
+    if(this.name != null) {
+      oprot.writeFieldBegin(_NAME_FIELD_DESC);
+      oprot.writeString(name);
+      oprot.writeFieldEnd();
+    }
+    if(isSetNote() && this.note != null) {
+      oprot.writeFieldBegin(_NOTE_FIELD_DESC);
+      oprot.writeString(note);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_VISIBLE_FIELD_DESC);
+    oprot.writeBool(visible);
+    oprot.writeFieldEnd();
+    oprot.writeFieldBegin(_LAYER_FIELD_DESC);
+    oprot.writeByte(layer);
+    oprot.writeFieldEnd();
+    oprot.writeFieldBegin(_DEPTH_FIELD_DESC);
+    oprot.writeI16(depth);
+    oprot.writeFieldEnd();
+    oprot.writeFieldBegin(_SCALE_FIELD_DESC);
+    oprot.writeDouble(scale);
+    oprot.writeFieldEnd();
+    if(this.thumbnail != null) {
+      oprot.writeFieldBegin(_THUMBNAIL_FIELD_DESC);
+      oprot.writeBinary(thumbnail);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldBegin(_KIND_FIELD_DESC);
+    oprot.writeI32(kind);
+    oprot.writeFieldEnd();
+    if(isSetSize()) {
+      oprot.writeFieldBegin(_SIZE_FIELD_DESC);
+      oprot.writeI32(size);
+      oprot.writeFieldEnd();
+    }
+    if(this.shapes != null) {
+      oprot.writeFieldBegin(_SHAPES_FIELD_DESC);
+      oprot.writeListBegin(new thrift.TList(thrift.TType.STRUCT, shapes.length));
+      for(var elem27 in shapes) {
+        elem27.write(oprot);
+      }
+      oprot.writeListEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.tags != null) {
+      oprot.writeFieldBegin(_TAGS_FIELD_DESC);
+      oprot.writeSetBegin(new thrift.TSet(thrift.TType.STRING, tags.length));
+      for(var elem28 in tags) {
+        oprot.writeString(elem28);
+      }
+      oprot.writeSetEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.counts != null) {
+      oprot.writeFieldBegin(_COUNTS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I32, thrift.TType.LIST, counts.length));
+      for(var elem29 in counts.keys) {
+        oprot.writeI32(elem29);
+        oprot.writeListBegin(new thrift.TList(thrift.TType.I64, counts[elem29].length));
+        for(var elem30 in counts[elem29]) {
+          oprot.writeI64(elem30);
+        }
+        oprot.writeListEnd();
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.names != null) {
+      oprot.writeFieldBegin(_NAMES_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.I64, thrift.TType.STRING, names.length));
+      for(var elem31 in names.keys) {
+        oprot.writeI64(elem31);
+        oprot.writeString(names[elem31]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.labels != null) {
+      oprot.writeFieldBegin(_LABELS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.STRUCT, thrift.TType.STRING, labels.length));
+      for(var elem32 in labels.keys) {
+        elem32.write(oprot);
+        oprot.writeString(labels[elem32]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(isSetFlags() && this.flags != null) {
+      oprot.writeFieldBegin(_FLAGS_FIELD_DESC);
+      oprot.writeMapBegin(new thrift.TMap(thrift.TType.BOOL, thrift.TType.DOUBLE, flags.length));
+      for(var elem33 in flags.keys) {
+        oprot.writeBool(elem33);
+        oprot.writeDouble(flags[elem33]);
+      }
+      oprot.writeMapEnd();
+      oprot.writeFieldEnd();
+    }
+    if(this.origin != null) {
+      oprot.writeFieldBegin(_ORIGIN_FIELD_DESC);
+      origin.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(isSetVersion()) {
+      oprot.writeFieldBegin(_VERSION_FIELD_DESC);
+      oprot.writeI64(version);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Drawing(");
+
+    ret.write("id:");
+    ret.write(this.id);
+
+    ret.write(", ");
+    ret.write("name:");
+    if(this.name == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.name);
+    }
+
+    if(isSetNote()) {
+      ret.write(", ");
+      ret.write("note:");
+      if(this.note == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.note);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("visible:");
+    ret.write(this.visible);
+
+    ret.write(", ");
+    ret.write("layer:");
+    ret.write(this.layer);
+
+    ret.write(", ");
+    ret.write("depth:");
+    ret.write(this.depth);
+
+    ret.write(", ");
+    ret.write("scale:");
+    ret.write(this.scale);
+
+    ret.write(", ");
+    ret.write("thumbnail:");
+    if(this.thumbnail == null) {
+      ret.write("null");
+    } else {
+      ret.write("BINARY");
+    }
+
+    ret.write(", ");
+    ret.write("kind:");
+    String kind_name = t_canonical_json.Kind.VALUES_TO_NAMES[this.kind];
+    if(kind_name != null) {
+      ret.write(kind_name);
+      ret.write(" (");
+    }
+    ret.write(this.kind);
+    if(kind_name != null) {
+      ret.write(")");
+    }
+
+    if(isSetSize()) {
+      ret.write(", ");
+      ret.write("size:");
+      String size_name = t_canonical_json.Kind.VALUES_TO_NAMES[this.size];
+      if(size_name != null) {
+        ret.write(size_name);
+        ret.write(" (");
+      }
+      ret.write(this.size);
+      if(size_name != null) {
+        ret.write(")");
+      }
+    }
+
+    ret.write(", ");
+    ret.write("shapes:");
+    if(this.shapes == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.shapes);
+    }
+
+    ret.write(", ");
+    ret.write("tags:");
+    if(this.tags == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.tags);
+    }
+
+    ret.write(", ");
+    ret.write("counts:");
+    if(this.counts == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.counts);
+    }
+
+    ret.write(", ");
+    ret.write("names:");
+    if(this.names == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.names);
+    }
+
+    ret.write(", ");
+    ret.write("labels:");
+    if(this.labels == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.labels);
+    }
+
+    if(isSetFlags()) {
+      ret.write(", ");
+      ret.write("flags:");
+      if(this.flags == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.flags);
+      }
+    }
+
+    ret.write(", ");
+    ret.write("origin:");
+    if(this.origin == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.origin);
+    }
+
+    if(isSetVersion()) {
+      ret.write(", ");
+      ret.write("version:");
+      ret.write(this.version);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Drawing)) {
+      return false;
+    }
+    Drawing other = o as Drawing;
+    return this.id == other.id
+      && this.name == other.name
+      && this.note == other.note
+      && this.visible == other.visible
+      && this.layer == other.layer
+      && this.depth == other.depth
+      && this.scale == other.scale
+      && this.thumbnail == other.thumbnail
+      && this.kind == other.kind
+      && this.size == other.size
+      && this.shapes == other.shapes
+      && this.tags == other.tags
+      && this.counts == other.counts
+      && this.names == other.names
+      && this.labels == other.labels
+      && this.flags == other.flags
+      && this.origin == other.origin
+      && this.version == other.version;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ id.hashCode;
+    value = (value * 31) ^ name.hashCode;
+    value = (value * 31) ^ note.hashCode;
+    value = (value * 31) ^ visible.hashCode;
+    value = (value * 31) ^ layer.hashCode;
+    value = (value * 31) ^ depth.hashCode;
+    value = (value * 31) ^ scale.hashCode;
+    value = (value * 31) ^ thumbnail.hashCode;
+    value = (value * 31) ^ kind.hashCode;
+    value = (value * 31) ^ size.hashCode;
+    value = (value * 31) ^ shapes.hashCode;
+    value = (value * 31) ^ tags.hashCode;
+    value = (value * 31) ^ counts.hashCode;
+    value = (value * 31) ^ names.hashCode;
+    value = (value * 31) ^ labels.hashCode;
+    value = (value * 31) ^ flags.hashCode;
+    value = (value * 31) ^ origin.hashCode;
+    value = (value * 31) ^ version.hashCode;
+    return value;
+  }
+
+  Drawing clone({
+    int id: null,
+    String name: null,
+    String note: null,
+    bool visible: null,
+    int layer: null,
+    int depth: null,
+    double scale: null,
+    Uint8List thumbnail: null,
+    int kind: null,
+    int size: null,
+    List<t_canonical_json.Shape> shapes: null,
+    Set<String> tags: null,
+    Map<int, List<int>> counts: null,
+    Map<int, String> names: null,
+    Map<t_canonical_json.Point, String> labels: null,
+    Map<bool, double> flags: null,
+    t_canonical_json.Point origin: null,
+    int version: null,
+  }) {
+    return new Drawing()
+      ..id = id ?? this.id
+      ..name = name ?? this.name
+      ..note = note ?? this.note
+      ..visible = visible ?? this.visible
+      ..layer = layer ?? this.layer
+      ..depth = depth ?? this.depth
+      ..scale = scale ?? this.scale
+      ..thumbnail = thumbnail ?? this.thumbnail
+      ..kind = kind ?? this.kind
+      ..size = size ?? this.size
+      ..shapes = shapes ?? this.shapes
+      ..tags = tags ?? this.tags
+      ..counts = counts ?? this.counts
+      ..names = names ?? this.names
+      ..labels = labels ?? this.labels
+      ..flags = flags ?? this.flags
+      ..origin = origin ?? this.origin
+      ..version = version ?? this.version;
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+    if(isSetKind() && !t_canonical_json.Kind.VALID_VALUES.contains(kind)) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The field 'kind' has been assigned the invalid value $kind");
+    }
+  }
+
+  Map<String, Object> toJsonValue() {
+    Map<String, Object> value = {};
+    value['id'] = this.id != null ? this.id.toString() : '0';
+    value['name'] = this.name != null ? this.name : '';
+    if(isSetNote()) {
+      value['note'] = this.note;
+    }
+    value['visible'] = this.visible != null ? this.visible : false;
+    value['layer'] = this.layer != null ? this.layer : 0;
+    value['depth'] = this.depth != null ? this.depth : 0;
+    value['scale'] = this.scale != null ? frugal.CanonicalJson.doubleValue(this.scale) : 0;
+    value['thumbnail'] = this.thumbnail != null ? frugal.CanonicalJson.binaryValue(this.thumbnail) : '';
+    value['kind'] = this.kind != null ? frugal.CanonicalJson.enumValue(this.kind, t_canonical_json.Kind.VALUES_TO_NAMES) : 0;
+    if(isSetSize()) {
+      value['size'] = frugal.CanonicalJson.enumValue(this.size, t_canonical_json.Kind.VALUES_TO_NAMES);
+    }
+    value['shapes'] = this.shapes != null ? this.shapes.map((elem34) => elem34.toJsonValue()).toList() : [];
+    value['tags'] = this.tags != null ? frugal.CanonicalJson.sorted(this.tags.toList()) : [];
+    value['counts'] = this.counts != null ? frugal.CanonicalJson.mapValue(this.counts, (elem36) => frugal.CanonicalJson.enumValue(elem36, t_canonical_json.Kind.VALUES_TO_NAMES), (elem37) => elem37.map((elem38) => elem38.toString()).toList()) : {};
+    value['names'] = this.names != null ? frugal.CanonicalJson.mapValue(this.names, (elem39) => elem39.toString(), (elem40) => elem40) : {};
+    value['labels'] = this.labels != null ? frugal.CanonicalJson.entriesValue(this.labels, (elem41) => elem41.toJsonValue(), (elem42) => elem42) : [];
+    if(isSetFlags()) {
+      value['flags'] = frugal.CanonicalJson.mapValue(this.flags, (elem43) => elem43, (elem44) => frugal.CanonicalJson.doubleValue(elem44));
+    }
+    if(isSetOrigin()) {
+      value['origin'] = this.origin.toJsonValue();
+    }
+    if(isSetVersion() && this.version != 3) {
+      value['version'] = this.version.toString();
+    }
+    return value;
+  }
+
+  fromJsonValue(Object value) {
+    Map<String, Object> object = frugal.CanonicalJson.parseObject(value);
+    Object elem45 = object['id'];
+    if(elem45 != null) {
+      this.id = frugal.CanonicalJson.parseInt(elem45, 64);
+    } else {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "Required field 'id' is not present in struct 'Drawing'");
+    }
+    Object elem46 = object['name'];
+    if(elem46 != null) {
+      this.name = frugal.CanonicalJson.parseString(elem46);
+    }
+    Object elem47 = object['note'];
+    if(elem47 != null) {
+      this.note = frugal.CanonicalJson.parseString(elem47);
+    }
+    Object elem48 = object['visible'];
+    if(elem48 != null) {
+      this.visible = frugal.CanonicalJson.parseBool(elem48);
+    }
+    Object elem49 = object['layer'];
+    if(elem49 != null) {
+      this.layer = frugal.CanonicalJson.parseInt(elem49, 8);
+    }
+    Object elem50 = object['depth'];
+    if(elem50 != null) {
+      this.depth = frugal.CanonicalJson.parseInt(elem50, 16);
+    }
+    Object elem51 = object['scale'];
+    if(elem51 != null) {
+      this.scale = frugal.CanonicalJson.parseDouble(elem51);
+    }
+    Object elem52 = object['thumbnail'];
+    if(elem52 != null) {
+      this.thumbnail = frugal.CanonicalJson.parseBinary(elem52);
+    }
+    Object elem53 = object['kind'];
+    if(elem53 != null) {
+      this.kind = frugal.CanonicalJson.parseEnum(elem53, t_canonical_json.Kind.VALUES_TO_NAMES);
+    }
+    Object elem54 = object['size'];
+    if(elem54 != null) {
+      this.size = frugal.CanonicalJson.parseEnum(elem54, t_canonical_json.Kind.VALUES_TO_NAMES);
+    }
+    Object elem55 = object['shapes'];
+    if(elem55 != null) {
+      this.shapes = new List<t_canonical_json.Shape>.from(frugal.CanonicalJson.parseList(elem55).map((elem56) => (new t_canonical_json.Shape()..fromJsonValue(elem56))));
+    }
+    Object elem57 = object['tags'];
+    if(elem57 != null) {
+      this.tags = new Set<String>.from(frugal.CanonicalJson.parseList(elem57).map((elem58) => frugal.CanonicalJson.parseString(elem58)));
+    }
+    Object elem59 = object['counts'];
+    if(elem59 != null) {
+      this.counts = new Map<int, List<int>>.from(frugal.CanonicalJson.parseMap(elem59, (elem60) => frugal.CanonicalJson.parseEnum(elem60, t_canonical_json.Kind.VALUES_TO_NAMES), (elem61) => new List<int>.from(frugal.CanonicalJson.parseList(elem61).map((elem62) => frugal.CanonicalJson.parseInt(elem62, 64)))));
+    }
+    Object elem63 = object['names'];
+    if(elem63 != null) {
+      this.names = new Map<int, String>.from(frugal.CanonicalJson.parseMap(elem63, (elem64) => frugal.CanonicalJson.parseInt(elem64, 64), (elem65) => frugal.CanonicalJson.parseString(elem65)));
+    }
+    Object elem66 = object['labels'];
+    if(elem66 != null) {
+      this.labels = new Map<t_canonical_json.Point, String>.from(frugal.CanonicalJson.parseEntries(elem66, (elem67) => (new t_canonical_json.Point()..fromJsonValue(elem67)), (elem68) => frugal.CanonicalJson.parseString(elem68)));
+    }
+    Object elem69 = object['flags'];
+    if(elem69 != null) {
+      this.flags = new Map<bool, double>.from(frugal.CanonicalJson.parseMap(elem69, (elem70) => frugal.CanonicalJson.parseBoolKey(elem70), (elem71) => frugal.CanonicalJson.parseDouble(elem71)));
+    }
+    Object elem72 = object['origin'];
+    if(elem72 != null) {
+      this.origin = (new t_canonical_json.Point()..fromJsonValue(elem72));
+    }
+    Object elem73 = object['version'];
+    if(elem73 != null) {
+      this.version = frugal.CanonicalJson.parseInt(elem73, 64);
+    }
+  }
+}
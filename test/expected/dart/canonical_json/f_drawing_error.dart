@@ -0,0 +1,162 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:canonical_json/canonical_json.dart' as t_canonical_json;
+
+import 'package:frugal/frugal.dart' as frugal;
+
+class DrawingError extends Error implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("DrawingError");
+  static final thrift.TField _MESSAGE_FIELD_DESC = new thrift.TField("message", thrift.TType.STRING, 1);
+
+  String _message;
+  static const int MESSAGE = 1;
+
+
+  DrawingError() {
+  }
+
+  String get message => this._message;
+
+  set message(String message) {
+    this._message = message;
+  }
+
+  bool isSetMessage() => this.message != null;
+
+  unsetMessage() {
+    this.message = null;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case MESSAGE:
+        return this.message;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case MESSAGE:
+        if(value == null) {
+          unsetMessage();
+        } else {
+          this.message = value as String;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case MESSAGE:
+        return isSetMessage();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case MESSAGE:
+          if(field.type == thrift.TType.STRING) {
+            message = iprot.readString();
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(this.message != null) {
+      oprot.writeFieldBegin(_MESSAGE_FIELD_DESC);
+      oprot.writeString(message);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("DrawingError(");
+
+    ret.write("message:");
+    if(this.message == null) {
+      ret.write("null");
+    } else {
+      ret.write(this.message);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is DrawingError)) {
+      return false;
+    }
+    DrawingError other = o as DrawingError;
+    return this.message == other.message;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ message.hashCode;
+    return value;
+  }
+
+  DrawingError clone({
+    String message: null,
+  }) {
+    return new DrawingError()
+      ..message = message ?? this.message;
+  }
+
+  validate() {
+    // check for required fields
+    // check that fields of type enum have valid values
+  }
+
+  Map<String, Object> toJsonValue() {
+    Map<String, Object> value = {};
+    value['message'] = this.message != null ? this.message : '';
+    return value;
+  }
+
+  fromJsonValue(Object value) {
+    Map<String, Object> object = frugal.CanonicalJson.parseObject(value);
+    Object elem76 = object['message'];
+    if(elem76 != null) {
+      this.message = frugal.CanonicalJson.parseString(elem76);
+    }
+  }
+}
@@ -0,0 +1,242 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+import 'dart:typed_data' show Uint8List;
+import 'package:thrift/thrift.dart' as thrift;
+import 'package:canonical_json/canonical_json.dart' as t_canonical_json;
+
+import 'package:frugal/frugal.dart' as frugal;
+
+class Shape implements thrift.TBase {
+  static final thrift.TStruct _STRUCT_DESC = new thrift.TStruct("Shape");
+  static final thrift.TField _POINT_FIELD_DESC = new thrift.TField("point", thrift.TType.STRUCT, 1);
+  static final thrift.TField _RADIUS_FIELD_DESC = new thrift.TField("radius", thrift.TType.DOUBLE, 2);
+
+  t_canonical_json.Point _point;
+  static const int POINT = 1;
+  double _radius;
+  static const int RADIUS = 2;
+
+  bool __isset_radius = false;
+
+  Shape() {
+  }
+
+  t_canonical_json.Point get point => this._point;
+
+  set point(t_canonical_json.Point point) {
+    this._point = point;
+  }
+
+  bool isSetPoint() => this.point != null;
+
+  unsetPoint() {
+    this.point = null;
+  }
+
+  double get radius => this._radius;
+
+  set radius(double radius) {
+    this._radius = radius;
+    this.__isset_radius = true;
+  }
+
+  bool isSetRadius() => this.__isset_radius;
+
+  unsetRadius() {
+    this.__isset_radius = false;
+  }
+
+  getFieldValue(int fieldID) {
+    switch (fieldID) {
+      case POINT:
+        return this.point;
+      case RADIUS:
+        return this.radius;
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  setFieldValue(int fieldID, Object value) {
+    switch(fieldID) {
+      case POINT:
+        if(value == null) {
+          unsetPoint();
+        } else {
+          this.point = value as t_canonical_json.Point;
+        }
+        break;
+
+      case RADIUS:
+        if(value == null) {
+          unsetRadius();
+        } else {
+          this.radius = value as double;
+        }
+        break;
+
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  // Returns true if the field corresponding to fieldID is set (has been assigned a value) and false otherwise
+  bool isSet(int fieldID) {
+    switch(fieldID) {
+      case POINT:
+        return isSetPoint();
+      case RADIUS:
+        return isSetRadius();
+      default:
+        throw new ArgumentError("Field $fieldID doesn't exist!");
+    }
+  }
+
+  read(thrift.TProtocol iprot) {
+    thrift.TField field;
+    iprot.readStructBegin();
+    while(true) {
+      field = iprot.readFieldBegin();
+      if(field.type == thrift.TType.STOP) {
+        break;
+      }
+      switch(field.id) {
+        case POINT:
+          if(field.type == thrift.TType.STRUCT) {
+            point = new t_canonical_json.Point();
+            point.read(iprot);
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        case RADIUS:
+          if(field.type == thrift.TType.DOUBLE) {
+            radius = iprot.readDouble();
+            this.__isset_radius = true;
+          } else {
+            thrift.TProtocolUtil.skip(iprot, field.type);
+          }
+          break;
+        default:
+          thrift.TProtocolUtil.skip(iprot, field.type);
+          break;
+      }
+      iprot.readFieldEnd();
+    }
+    iprot.readStructEnd();
+
+    // check for required fields of primitive type, which can't be checked in the validate method
+    validate();
+  }
+
+  write(thrift.TProtocol oprot) {
+    validate();
+
+    oprot.writeStructBegin(_STRUCT_DESC);
+    if(isSetPoint() && this.point != null) {
+      oprot.writeFieldBegin(_POINT_FIELD_DESC);
+      point.write(oprot);
+      oprot.writeFieldEnd();
+    }
+    if(isSetRadius()) {
+      oprot.writeFieldBegin(_RADIUS_FIELD_DESC);
+      oprot.writeDouble(radius);
+      oprot.writeFieldEnd();
+    }
+    oprot.writeFieldStop();
+    oprot.writeStructEnd();
+  }
+
+  String toString() {
+    StringBuffer ret = new StringBuffer("Shape(");
+
+    if(isSetPoint()) {
+      ret.write("point:");
+      if(this.point == null) {
+        ret.write("null");
+      } else {
+        ret.write(this.point);
+      }
+    }
+
+    if(isSetRadius()) {
+      ret.write(", ");
+      ret.write("radius:");
+      ret.write(this.radius);
+    }
+
+    ret.write(")");
+
+    return ret.toString();
+  }
+
+  bool operator ==(Object o) {
+    if(o == null || !(o is Shape)) {
+      return false;
+    }
+    Shape other = o as Shape;
+    return this.point == other.point
+      && this.radius == other.radius;
+  }
+
+  int get hashCode {
+    var value = 17;
+    value = (value * 31) ^ point.hashCode;
+    value = (value * 31) ^ radius.hashCode;
+    return value;
+  }
+
+  Shape clone({
+    t_canonical_json.Point point: null,
+    double radius: null,
+  }) {
+    return new Shape()
+      ..point = point ?? this.point
+      ..radius = radius ?? this.radius;
+  }
+
+  validate() {
+    // check exactly one field is set
+    int setFields = 0;
+    if(isSetPoint()) {
+      setFields++;
+    }
+    if(isSetRadius()) {
+      setFields++;
+    }
+    if(setFields != 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The union did not have exactly one field set, $setFields were set");
+    }
+    // check that fields of type enum have valid values
+  }
+
+  Map<String, Object> toJsonValue() {
+    Map<String, Object> value = {};
+    if(isSetPoint()) {
+      value['point'] = this.point.toJsonValue();
+    }
+    if(isSetRadius()) {
+      value['radius'] = frugal.CanonicalJson.doubleValue(this.radius);
+    }
+    return value;
+  }
+
+  fromJsonValue(Object value) {
+    Map<String, Object> object = frugal.CanonicalJson.parseObject(value);
+    int setFields = 0;
+    Object elem74 = object['point'];
+    if(elem74 != null) {
+      this.point = (new t_canonical_json.Point()..fromJsonValue(elem74));
+      setFields++;
+    }
+    Object elem75 = object['radius'];
+    if(elem75 != null) {
+      this.radius = frugal.CanonicalJson.parseDouble(elem75);
+      setFields++;
+    }
+    if(setFields > 1) {
+      throw new thrift.TProtocolError(thrift.TProtocolErrorType.INVALID_DATA, "The union had more than one field set, $setFields were set");
+    }
+  }
+}
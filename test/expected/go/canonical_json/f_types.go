@@ -0,0 +1,1977 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package canonical_json
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+
+var GoUnusedProtection__ int
+
+type frugalJSONEntry struct {
+	Key   json.RawMessage `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type frugalJSONValues []json.RawMessage
+
+func (v frugalJSONValues) Len() int           { return len(v) }
+func (v frugalJSONValues) Less(i, j int) bool { return bytes.Compare(v[i], v[j]) < 0 }
+func (v frugalJSONValues) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+
+func frugalJSONSorted(values []interface{}) (frugalJSONValues, error) {
+	sorted := make(frugalJSONValues, 0, len(values))
+	for _, v := range values {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		sorted = append(sorted, b)
+	}
+	sort.Sort(sorted)
+	return sorted, nil
+}
+
+func frugalJSONIsNull(raw json.RawMessage) bool {
+	return string(bytes.TrimSpace(raw)) == "null"
+}
+
+func frugalJSONKey(key string) json.RawMessage {
+	raw, _ := json.Marshal(key)
+	return raw
+}
+
+func frugalJSONDouble(v float64) interface{} {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	}
+	return v
+}
+
+func frugalJSONParseDouble(raw json.RawMessage) (float64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		var v float64
+		err = json.Unmarshal(raw, &v)
+		return v, err
+	}
+	switch s {
+	case "NaN":
+		return math.NaN(), nil
+	case "Infinity":
+		return math.Inf(1), nil
+	case "-Infinity":
+		return math.Inf(-1), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func frugalJSONParseInt(raw json.RawMessage, bitSize int) (int64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		// Parse the number literal directly so 64-bit values keep their precision.
+		s = string(bytes.TrimSpace(raw))
+	}
+	return strconv.ParseInt(s, 10, bitSize)
+}
+
+func frugalJSONParseEnum(raw json.RawMessage) (string, int64, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		v, err := frugalJSONParseInt(raw, 32)
+		return "", v, err
+	}
+	if v, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return "", v, nil
+	}
+	return s, 0, nil
+}
+
+func init() {
+}
+
+type ID int64
+type Size Kind
+type Kind int64
+
+const (
+	Kind_SMALL Kind = 1
+	Kind_LARGE Kind = 2
+)
+
+func (p Kind) String() string {
+	switch p {
+	case Kind_SMALL:
+		return "SMALL"
+	case Kind_LARGE:
+		return "LARGE"
+	}
+	return "<UNSET>"
+}
+
+// KindValues returns all values of Kind in declaration order.
+func KindValues() []Kind {
+	return []Kind{
+		Kind_SMALL,
+		Kind_LARGE,
+	}
+}
+
+// KindNames returns the names of all values of Kind in declaration order.
+func KindNames() []string {
+	return []string{
+		"SMALL",
+		"LARGE",
+	}
+}
+
+// IsValid returns true if p is a declared value of Kind.
+func (p Kind) IsValid() bool {
+	switch p {
+	case Kind_SMALL:
+		return true
+	case Kind_LARGE:
+		return true
+	}
+	return false
+}
+
+func KindFromString(s string) (Kind, error) {
+	switch s {
+	case "SMALL":
+		return Kind_SMALL, nil
+	case "LARGE":
+		return Kind_LARGE, nil
+	}
+	return Kind(0), fmt.Errorf("not a valid Kind string")
+}
+
+func (p Kind) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Kind) UnmarshalText(text []byte) error {
+	q, err := KindFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+func (p *Kind) Scan(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return errors.New("Scan value is not int64")
+	}
+	*p = Kind(v)
+	return nil
+}
+
+func (p *Kind) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
+type Point struct {
+	X int32 `thrift:"x,1" db:"x" json:"x"`
+	Y int32 `thrift:"y,2" db:"y" json:"y"`
+}
+
+func NewPoint() *Point {
+	return &Point{}
+}
+
+func (p *Point) GetX() int32 {
+	return p.X
+}
+
+func (p *Point) GetY() int32 {
+	return p.Y
+}
+
+func (p *Point) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.X = v
+	}
+	return nil
+}
+
+func (p *Point) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Y = v
+	}
+	return nil
+}
+
+func (p *Point) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Point"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Point) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("x", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:x: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.X)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.x (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:x: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) writeField2(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("y", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:y: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Y)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.y (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:y: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Point(%+v)", *p)
+}
+
+func (p *Point) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, 2)
+	var elem0 interface{}
+	elem0 = p.X
+	m["x"] = elem0
+	var elem1 interface{}
+	elem1 = p.Y
+	m["y"] = elem1
+	return json.Marshal(m)
+}
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if raw, ok := m["x"]; ok && !frugalJSONIsNull(raw) {
+		var elem2 int32
+		if v, err := frugalJSONParseInt(raw, 32); err != nil {
+			return err
+		} else {
+			elem2 = int32(v)
+		}
+		p.X = elem2
+	}
+	if raw, ok := m["y"]; ok && !frugalJSONIsNull(raw) {
+		var elem3 int32
+		if v, err := frugalJSONParseInt(raw, 32); err != nil {
+			return err
+		} else {
+			elem3 = int32(v)
+		}
+		p.Y = elem3
+	}
+	return nil
+}
+
+type Drawing struct {
+	ID        ID                `thrift:"id,1,required" db:"id" json:"id"`
+	Name      string            `thrift:"name,2" db:"name" json:"name"`
+	Note      *string           `thrift:"note,3" db:"note" json:"note,omitempty"`
+	Visible   bool              `thrift:"visible,4" db:"visible" json:"visible"`
+	Layer     int8              `thrift:"layer,5" db:"layer" json:"layer"`
+	Depth     int16             `thrift:"depth,6" db:"depth" json:"depth"`
+	Scale     float64           `thrift:"scale,7" db:"scale" json:"scale"`
+	Thumbnail []byte            `thrift:"thumbnail,8" db:"thumbnail" json:"thumbnail"`
+	Kind      Kind              `thrift:"kind,9" db:"kind" json:"kind"`
+	Size      *Size             `thrift:"size,10" db:"size" json:"size,omitempty"`
+	Shapes    []*Shape          `thrift:"shapes,11" db:"shapes" json:"shapes"`
+	Tags      map[string]bool   `thrift:"tags,12" db:"tags" json:"tags"`
+	Counts    map[Kind][]int64  `thrift:"counts,13" db:"counts" json:"counts"`
+	Names     map[int64]string  `thrift:"names,14" db:"names" json:"names"`
+	Labels    map[*Point]string `thrift:"labels,15" db:"labels" json:"labels"`
+	Flags     map[bool]float64  `thrift:"flags,16" db:"flags" json:"flags,omitempty"`
+	Origin    *Point            `thrift:"origin,17" db:"origin" json:"origin"`
+	Version   int64             `thrift:"version,18" db:"version" json:"version,omitempty"`
+}
+
+func NewDrawing() *Drawing {
+	return &Drawing{
+		Version: 3,
+	}
+}
+
+func (p *Drawing) GetID() ID {
+	return p.ID
+}
+
+func (p *Drawing) GetName() string {
+	return p.Name
+}
+
+var Drawing_Note_DEFAULT string
+
+func (p *Drawing) IsSetNote() bool {
+	return p.Note != nil
+}
+
+func (p *Drawing) GetNote() string {
+	if !p.IsSetNote() {
+		return Drawing_Note_DEFAULT
+	}
+	return *p.Note
+}
+
+func (p *Drawing) GetVisible() bool {
+	return p.Visible
+}
+
+func (p *Drawing) GetLayer() int8 {
+	return p.Layer
+}
+
+func (p *Drawing) GetDepth() int16 {
+	return p.Depth
+}
+
+func (p *Drawing) GetScale() float64 {
+	return p.Scale
+}
+
+func (p *Drawing) GetThumbnail() []byte {
+	return p.Thumbnail
+}
+
+func (p *Drawing) GetKind() Kind {
+	return p.Kind
+}
+
+var Drawing_Size_DEFAULT Size
+
+func (p *Drawing) IsSetSize() bool {
+	return p.Size != nil
+}
+
+func (p *Drawing) GetSize() Size {
+	if !p.IsSetSize() {
+		return Drawing_Size_DEFAULT
+	}
+	return *p.Size
+}
+
+func (p *Drawing) GetShapes() []*Shape {
+	return p.Shapes
+}
+
+func (p *Drawing) GetTags() map[string]bool {
+	return p.Tags
+}
+
+func (p *Drawing) GetCounts() map[Kind][]int64 {
+	return p.Counts
+}
+
+func (p *Drawing) GetNames() map[int64]string {
+	return p.Names
+}
+
+func (p *Drawing) GetLabels() map[*Point]string {
+	return p.Labels
+}
+
+var Drawing_Flags_DEFAULT map[bool]float64
+
+func (p *Drawing) IsSetFlags() bool {
+	return p.Flags != nil
+}
+
+func (p *Drawing) GetFlags() map[bool]float64 {
+	return p.Flags
+}
+
+var Drawing_Origin_DEFAULT *Point
+
+func (p *Drawing) IsSetOrigin() bool {
+	return p.Origin != nil
+}
+
+func (p *Drawing) GetOrigin() *Point {
+	if !p.IsSetOrigin() {
+		return Drawing_Origin_DEFAULT
+	}
+	return p.Origin
+}
+
+var Drawing_Version_DEFAULT int64 = 3
+
+func (p *Drawing) IsSetVersion() bool {
+	return p.Version != Drawing_Version_DEFAULT
+}
+
+func (p *Drawing) GetVersion() int64 {
+	return p.Version
+}
+
+func (p *Drawing) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	issetID := false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetID = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.ReadField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.ReadField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.ReadField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.ReadField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.ReadField13(iprot); err != nil {
+				return err
+			}
+		case 14:
+			if err := p.ReadField14(iprot); err != nil {
+				return err
+			}
+		case 15:
+			if err := p.ReadField15(iprot); err != nil {
+				return err
+			}
+		case 16:
+			if err := p.ReadField16(iprot); err != nil {
+				return err
+			}
+		case 17:
+			if err := p.ReadField17(iprot); err != nil {
+				return err
+			}
+		case 18:
+			if err := p.ReadField18(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field 'ID' is not present in struct 'Drawing'"))
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := ID(v)
+		p.ID = temp
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Name = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Note = &v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Visible = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadByte(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Layer = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI16(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.Depth = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Scale = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Thumbnail = v
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		temp := Kind(v)
+		p.Kind = temp
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		temp := Size(v)
+		p.Size = &temp
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField11(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Shapes = make([]*Shape, 0, size)
+	for i := 0; i < size; i++ {
+		elem4 := NewShape()
+		if err := elem4.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem4), err)
+		}
+		p.Shapes = append(p.Shapes, elem4)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField12(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadSetBegin()
+	if err != nil {
+		return thrift.PrependError("error reading set begin: ", err)
+	}
+	p.Tags = make(map[string]bool, size)
+	for i := 0; i < size; i++ {
+		var elem5 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem5 = v
+		}
+		(p.Tags)[elem5] = true
+	}
+	if err := iprot.ReadSetEnd(); err != nil {
+		return thrift.PrependError("error reading set end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField13(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Counts = make(map[Kind][]int64, size)
+	for i := 0; i < size; i++ {
+		var elem6 Kind
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Kind(v)
+			elem6 = temp
+		}
+		_, size, err := iprot.ReadListBegin()
+		if err != nil {
+			return thrift.PrependError("error reading list begin: ", err)
+		}
+		elem7 := make([]int64, 0, size)
+		for i := 0; i < size; i++ {
+			var elem8 int64
+			if v, err := iprot.ReadI64(); err != nil {
+				return thrift.PrependError("error reading field 0: ", err)
+			} else {
+				elem8 = v
+			}
+			elem7 = append(elem7, elem8)
+		}
+		if err := iprot.ReadListEnd(); err != nil {
+			return thrift.PrependError("error reading list end: ", err)
+		}
+		(p.Counts)[elem6] = elem7
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField14(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Names = make(map[int64]string, size)
+	for i := 0; i < size; i++ {
+		var elem9 int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem9 = v
+		}
+		var elem10 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem10 = v
+		}
+		(p.Names)[elem9] = elem10
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField15(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Labels = make(map[*Point]string, size)
+	for i := 0; i < size; i++ {
+		elem11 := NewPoint()
+		if err := elem11.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem11), err)
+		}
+		var elem12 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem12 = v
+		}
+		(p.Labels)[elem11] = elem12
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField16(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Flags = make(map[bool]float64, size)
+	for i := 0; i < size; i++ {
+		var elem13 bool
+		if v, err := iprot.ReadBool(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem13 = v
+		}
+		var elem14 float64
+		if v, err := iprot.ReadDouble(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem14 = v
+		}
+		(p.Flags)[elem13] = elem14
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField17(iprot thrift.TProtocol) error {
+	p.Origin = NewPoint()
+	if err := p.Origin.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Origin), err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField18(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 18: ", err)
+	} else {
+		p.Version = v
+	}
+	return nil
+}
+
+func (p *Drawing) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Drawing"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField14(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField15(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField16(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField17(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField18(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("id", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField2(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:name: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField3(oprot thrift.TProtocol) error {
+	if p.IsSetNote() {
+		if err := oprot.WriteFieldBegin("note", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:note: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Note)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.note (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:note: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) writeField4(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("visible", thrift.BOOL, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:visible: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Visible)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.visible (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:visible: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField5(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("layer", thrift.BYTE, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:layer: ", p), err)
+	}
+	if err := oprot.WriteByte(int8(p.Layer)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.layer (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:layer: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField6(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("depth", thrift.I16, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:depth: ", p), err)
+	}
+	if err := oprot.WriteI16(int16(p.Depth)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.depth (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:depth: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField7(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("scale", thrift.DOUBLE, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:scale: ", p), err)
+	}
+	if err := oprot.WriteDouble(float64(p.Scale)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.scale (7) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:scale: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField8(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("thumbnail", thrift.STRING, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:thumbnail: ", p), err)
+	}
+	if err := oprot.WriteBinary([]byte(p.Thumbnail)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.thumbnail (8) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:thumbnail: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField9(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("kind", thrift.I32, 9); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:kind: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Kind)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.kind (9) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 9:kind: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField10(oprot thrift.TProtocol) error {
+	if p.IsSetSize() {
+		if err := oprot.WriteFieldBegin("size", thrift.I32, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:size: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Size)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.size (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:size: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) writeField11(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("shapes", thrift.LIST, 11); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:shapes: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Shapes)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Shapes {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 11:shapes: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField12(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("tags", thrift.SET, 12); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:tags: ", p), err)
+	}
+	if err := oprot.WriteSetBegin(thrift.STRING, len(p.Tags)); err != nil {
+		return thrift.PrependError("error writing set begin: ", err)
+	}
+	for v, _ := range p.Tags {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteSetEnd(); err != nil {
+		return thrift.PrependError("error writing set end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 12:tags: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField13(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("counts", thrift.MAP, 13); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:counts: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.I32, thrift.LIST, len(p.Counts)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Counts {
+		if err := oprot.WriteI32(int32(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I64, len(v)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range v {
+			if err := oprot.WriteI64(int64(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 13:counts: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField14(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("names", thrift.MAP, 14); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 14:names: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.I64, thrift.STRING, len(p.Names)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Names {
+		if err := oprot.WriteI64(int64(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 14:names: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField15(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("labels", thrift.MAP, 15); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 15:labels: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRUCT, thrift.STRING, len(p.Labels)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Labels {
+		if err := k.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", k), err)
+		}
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 15:labels: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField16(oprot thrift.TProtocol) error {
+	if p.IsSetFlags() {
+		if err := oprot.WriteFieldBegin("flags", thrift.MAP, 16); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 16:flags: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.BOOL, thrift.DOUBLE, len(p.Flags)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Flags {
+			if err := oprot.WriteBool(bool(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteDouble(float64(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 16:flags: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) writeField17(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("origin", thrift.STRUCT, 17); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 17:origin: ", p), err)
+	}
+	if err := p.Origin.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Origin), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 17:origin: ", p), err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField18(oprot thrift.TProtocol) error {
+	if p.IsSetVersion() {
+		if err := oprot.WriteFieldBegin("version", thrift.I64, 18); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 18:version: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(p.Version)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.version (18) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 18:version: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Drawing(%+v)", *p)
+}
+
+func (p *Drawing) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, 18)
+	var elem15 interface{}
+	elem15 = strconv.FormatInt(int64(p.ID), 10)
+	m["id"] = elem15
+	var elem16 interface{}
+	elem16 = p.Name
+	m["name"] = elem16
+	if p.IsSetNote() {
+		var elem17 interface{}
+		elem17 = *p.Note
+		m["note"] = elem17
+	}
+	var elem18 interface{}
+	elem18 = p.Visible
+	m["visible"] = elem18
+	var elem19 interface{}
+	elem19 = p.Layer
+	m["layer"] = elem19
+	var elem20 interface{}
+	elem20 = p.Depth
+	m["depth"] = elem20
+	var elem21 interface{}
+	elem21 = frugalJSONDouble(float64(p.Scale))
+	m["scale"] = elem21
+	var elem22 interface{}
+	elem22 = base64.StdEncoding.EncodeToString(p.Thumbnail)
+	m["thumbnail"] = elem22
+	var elem23 interface{}
+	if p.Kind.IsValid() {
+		elem23 = p.Kind.String()
+	} else {
+		elem23 = int64(p.Kind)
+	}
+	m["kind"] = elem23
+	if p.IsSetSize() {
+		var elem24 interface{}
+		if Kind(*p.Size).IsValid() {
+			elem24 = Kind(*p.Size).String()
+		} else {
+			elem24 = int64(*p.Size)
+		}
+		m["size"] = elem24
+	}
+	var elem25 interface{}
+	elem26 := make([]interface{}, 0, len(p.Shapes))
+	for _, elem27 := range p.Shapes {
+		var elem28 interface{}
+		elem28 = elem27
+		elem26 = append(elem26, elem28)
+	}
+	elem25 = elem26
+	m["shapes"] = elem25
+	var elem29 interface{}
+	elem30 := make([]interface{}, 0, len(p.Tags))
+	for elem31 := range p.Tags {
+		var elem32 interface{}
+		elem32 = elem31
+		elem30 = append(elem30, elem32)
+	}
+	elem33, err := frugalJSONSorted(elem30)
+	if err != nil {
+		return nil, err
+	}
+	elem29 = elem33
+	m["tags"] = elem29
+	var elem34 interface{}
+	elem35 := make(map[string]interface{}, len(p.Counts))
+	for elem36, elem37 := range p.Counts {
+		var elem38 interface{}
+		if elem36.IsValid() {
+			elem38 = elem36.String()
+		} else {
+			elem38 = int64(elem36)
+		}
+		var elem39 interface{}
+		elem40 := make([]interface{}, 0, len(elem37))
+		for _, elem41 := range elem37 {
+			var elem42 interface{}
+			elem42 = strconv.FormatInt(int64(elem41), 10)
+			elem40 = append(elem40, elem42)
+		}
+		elem39 = elem40
+		elem35[fmt.Sprint(elem38)] = elem39
+	}
+	elem34 = elem35
+	m["counts"] = elem34
+	var elem43 interface{}
+	elem44 := make(map[string]interface{}, len(p.Names))
+	for elem45, elem46 := range p.Names {
+		var elem47 interface{}
+		elem47 = strconv.FormatInt(int64(elem45), 10)
+		var elem48 interface{}
+		elem48 = elem46
+		elem44[fmt.Sprint(elem47)] = elem48
+	}
+	elem43 = elem44
+	m["names"] = elem43
+	var elem49 interface{}
+	elem50 := make([]interface{}, 0, len(p.Labels))
+	for elem51, elem52 := range p.Labels {
+		var elem53, elem54 interface{}
+		elem53 = elem51
+		elem54 = elem52
+		elem50 = append(elem50, map[string]interface{}{"key": elem53, "value": elem54})
+	}
+	elem55, err := frugalJSONSorted(elem50)
+	if err != nil {
+		return nil, err
+	}
+	elem49 = elem55
+	m["labels"] = elem49
+	if p.IsSetFlags() {
+		var elem56 interface{}
+		elem57 := make(map[string]interface{}, len(p.Flags))
+		for elem58, elem59 := range p.Flags {
+			var elem60 interface{}
+			elem60 = elem58
+			var elem61 interface{}
+			elem61 = frugalJSONDouble(float64(elem59))
+			elem57[fmt.Sprint(elem60)] = elem61
+		}
+		elem56 = elem57
+		m["flags"] = elem56
+	}
+	if p.Origin != nil {
+		var elem62 interface{}
+		elem62 = p.Origin
+		m["origin"] = elem62
+	}
+	if p.IsSetVersion() {
+		var elem63 interface{}
+		elem63 = strconv.FormatInt(int64(p.Version), 10)
+		m["version"] = elem63
+	}
+	return json.Marshal(m)
+}
+
+func (p *Drawing) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if raw, ok := m["id"]; ok && !frugalJSONIsNull(raw) {
+		var elem64 ID
+		if v, err := frugalJSONParseInt(raw, 64); err != nil {
+			return err
+		} else {
+			elem64 = ID(v)
+		}
+		p.ID = elem64
+	} else {
+		return fmt.Errorf("Required field 'ID' is not present in struct 'Drawing'")
+	}
+	if raw, ok := m["name"]; ok && !frugalJSONIsNull(raw) {
+		var elem65 string
+		if err := json.Unmarshal(raw, &elem65); err != nil {
+			return err
+		}
+		p.Name = elem65
+	}
+	if raw, ok := m["note"]; ok && !frugalJSONIsNull(raw) {
+		var elem66 string
+		if err := json.Unmarshal(raw, &elem66); err != nil {
+			return err
+		}
+		p.Note = &elem66
+	}
+	if raw, ok := m["visible"]; ok && !frugalJSONIsNull(raw) {
+		var elem67 bool
+		if err := json.Unmarshal(raw, &elem67); err != nil {
+			return err
+		}
+		p.Visible = elem67
+	}
+	if raw, ok := m["layer"]; ok && !frugalJSONIsNull(raw) {
+		var elem68 int8
+		if v, err := frugalJSONParseInt(raw, 8); err != nil {
+			return err
+		} else {
+			elem68 = int8(v)
+		}
+		p.Layer = elem68
+	}
+	if raw, ok := m["depth"]; ok && !frugalJSONIsNull(raw) {
+		var elem69 int16
+		if v, err := frugalJSONParseInt(raw, 16); err != nil {
+			return err
+		} else {
+			elem69 = int16(v)
+		}
+		p.Depth = elem69
+	}
+	if raw, ok := m["scale"]; ok && !frugalJSONIsNull(raw) {
+		var elem70 float64
+		if v, err := frugalJSONParseDouble(raw); err != nil {
+			return err
+		} else {
+			elem70 = float64(v)
+		}
+		p.Scale = elem70
+	}
+	if raw, ok := m["thumbnail"]; ok && !frugalJSONIsNull(raw) {
+		var elem71 []byte
+		if err := json.Unmarshal(raw, &elem71); err != nil {
+			return err
+		}
+		p.Thumbnail = elem71
+	}
+	if raw, ok := m["kind"]; ok && !frugalJSONIsNull(raw) {
+		var elem72 Kind
+		if name, v, err := frugalJSONParseEnum(raw); err != nil {
+			return err
+		} else if name != "" {
+			e, err := KindFromString(name)
+			if err != nil {
+				return err
+			}
+			elem72 = Kind(e)
+		} else {
+			elem72 = Kind(v)
+		}
+		p.Kind = elem72
+	}
+	if raw, ok := m["size"]; ok && !frugalJSONIsNull(raw) {
+		var elem73 Size
+		if name, v, err := frugalJSONParseEnum(raw); err != nil {
+			return err
+		} else if name != "" {
+			e, err := KindFromString(name)
+			if err != nil {
+				return err
+			}
+			elem73 = Size(e)
+		} else {
+			elem73 = Size(v)
+		}
+		p.Size = &elem73
+	}
+	if raw, ok := m["shapes"]; ok && !frugalJSONIsNull(raw) {
+		var elem74 []*Shape
+		var elem75 []json.RawMessage
+		if err := json.Unmarshal(raw, &elem75); err != nil {
+			return err
+		}
+		elem74 = make([]*Shape, 0, len(elem75))
+		for _, elem76 := range elem75 {
+			var elem77 *Shape
+			elem77 = NewShape()
+			if err := elem77.UnmarshalJSON(elem76); err != nil {
+				return err
+			}
+			elem74 = append(elem74, elem77)
+		}
+		p.Shapes = elem74
+	}
+	if raw, ok := m["tags"]; ok && !frugalJSONIsNull(raw) {
+		var elem78 map[string]bool
+		var elem79 []json.RawMessage
+		if err := json.Unmarshal(raw, &elem79); err != nil {
+			return err
+		}
+		elem78 = make(map[string]bool, len(elem79))
+		for _, elem80 := range elem79 {
+			var elem81 string
+			if err := json.Unmarshal(elem80, &elem81); err != nil {
+				return err
+			}
+			elem78[elem81] = true
+		}
+		p.Tags = elem78
+	}
+	if raw, ok := m["counts"]; ok && !frugalJSONIsNull(raw) {
+		var elem82 map[Kind][]int64
+		var elem83 map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elem83); err != nil {
+			return err
+		}
+		elem82 = make(map[Kind][]int64, len(elem83))
+		for elem88, elem85 := range elem83 {
+			elem84 := frugalJSONKey(elem88)
+			var elem86 Kind
+			if name, v, err := frugalJSONParseEnum(elem84); err != nil {
+				return err
+			} else if name != "" {
+				e, err := KindFromString(name)
+				if err != nil {
+					return err
+				}
+				elem86 = Kind(e)
+			} else {
+				elem86 = Kind(v)
+			}
+			var elem87 []int64
+			var elem89 []json.RawMessage
+			if err := json.Unmarshal(elem85, &elem89); err != nil {
+				return err
+			}
+			elem87 = make([]int64, 0, len(elem89))
+			for _, elem90 := range elem89 {
+				var elem91 int64
+				if v, err := frugalJSONParseInt(elem90, 64); err != nil {
+					return err
+				} else {
+					elem91 = int64(v)
+				}
+				elem87 = append(elem87, elem91)
+			}
+			elem82[elem86] = elem87
+		}
+		p.Counts = elem82
+	}
+	if raw, ok := m["names"]; ok && !frugalJSONIsNull(raw) {
+		var elem92 map[int64]string
+		var elem93 map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elem93); err != nil {
+			return err
+		}
+		elem92 = make(map[int64]string, len(elem93))
+		for elem98, elem95 := range elem93 {
+			elem94 := frugalJSONKey(elem98)
+			var elem96 int64
+			if v, err := frugalJSONParseInt(elem94, 64); err != nil {
+				return err
+			} else {
+				elem96 = int64(v)
+			}
+			var elem97 string
+			if err := json.Unmarshal(elem95, &elem97); err != nil {
+				return err
+			}
+			elem92[elem96] = elem97
+		}
+		p.Names = elem92
+	}
+	if raw, ok := m["labels"]; ok && !frugalJSONIsNull(raw) {
+		var elem99 map[*Point]string
+		var elem100 []frugalJSONEntry
+		if err := json.Unmarshal(raw, &elem100); err != nil {
+			return err
+		}
+		elem99 = make(map[*Point]string, len(elem100))
+		for _, elem105 := range elem100 {
+			elem101, elem102 := elem105.Key, elem105.Value
+			var elem103 *Point
+			elem103 = NewPoint()
+			if err := elem103.UnmarshalJSON(elem101); err != nil {
+				return err
+			}
+			var elem104 string
+			if err := json.Unmarshal(elem102, &elem104); err != nil {
+				return err
+			}
+			elem99[elem103] = elem104
+		}
+		p.Labels = elem99
+	}
+	if raw, ok := m["flags"]; ok && !frugalJSONIsNull(raw) {
+		var elem106 map[bool]float64
+		var elem107 map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elem107); err != nil {
+			return err
+		}
+		elem106 = make(map[bool]float64, len(elem107))
+		for elem112, elem109 := range elem107 {
+			elem108 := json.RawMessage(elem112)
+			var elem110 bool
+			if err := json.Unmarshal(elem108, &elem110); err != nil {
+				return err
+			}
+			var elem111 float64
+			if v, err := frugalJSONParseDouble(elem109); err != nil {
+				return err
+			} else {
+				elem111 = float64(v)
+			}
+			elem106[elem110] = elem111
+		}
+		p.Flags = elem106
+	}
+	if raw, ok := m["origin"]; ok && !frugalJSONIsNull(raw) {
+		var elem113 *Point
+		elem113 = NewPoint()
+		if err := elem113.UnmarshalJSON(raw); err != nil {
+			return err
+		}
+		p.Origin = elem113
+	}
+	if raw, ok := m["version"]; ok && !frugalJSONIsNull(raw) {
+		var elem114 int64
+		if v, err := frugalJSONParseInt(raw, 64); err != nil {
+			return err
+		} else {
+			elem114 = int64(v)
+		}
+		p.Version = elem114
+	}
+	return nil
+}
+
+type Shape struct {
+	Point  *Point   `thrift:"point,1" db:"point" json:"point,omitempty"`
+	Radius *float64 `thrift:"radius,2" db:"radius" json:"radius,omitempty"`
+}
+
+func NewShape() *Shape {
+	return &Shape{}
+}
+
+var Shape_Point_DEFAULT *Point
+
+func (p *Shape) IsSetPoint() bool {
+	return p.Point != nil
+}
+
+func (p *Shape) GetPoint() *Point {
+	if !p.IsSetPoint() {
+		return Shape_Point_DEFAULT
+	}
+	return p.Point
+}
+
+var Shape_Radius_DEFAULT float64
+
+func (p *Shape) IsSetRadius() bool {
+	return p.Radius != nil
+}
+
+func (p *Shape) GetRadius() float64 {
+	if !p.IsSetRadius() {
+		return Shape_Radius_DEFAULT
+	}
+	return *p.Radius
+}
+
+func (p *Shape) CountSetFieldsShape() int {
+	count := 0
+	if p.IsSetPoint() {
+		count++
+	}
+	if p.IsSetRadius() {
+		count++
+	}
+	return count
+}
+
+func (p *Shape) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if c := p.CountSetFieldsShape(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T read union: exactly one field must be set (%d set).", p, c))
+	}
+	return nil
+}
+
+func (p *Shape) ReadField1(iprot thrift.TProtocol) error {
+	p.Point = NewPoint()
+	if err := p.Point.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Point), err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Radius = &v
+	}
+	return nil
+}
+
+func (p *Shape) Write(oprot thrift.TProtocol) error {
+	if c := p.CountSetFieldsShape(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T write union: exactly one field must be set (%d set).", p, c))
+	}
+	if err := oprot.WriteStructBegin("Shape"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField1(oprot thrift.TProtocol) error {
+	if p.IsSetPoint() {
+		if err := oprot.WriteFieldBegin("point", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:point: ", p), err)
+		}
+		if err := p.Point.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Point), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:point: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetRadius() {
+		if err := oprot.WriteFieldBegin("radius", thrift.DOUBLE, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:radius: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.Radius)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.radius (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:radius: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Shape(%+v)", *p)
+}
+
+func (p *Shape) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, 2)
+	if p.IsSetPoint() {
+		var elem115 interface{}
+		elem115 = p.Point
+		m["point"] = elem115
+	}
+	if p.IsSetRadius() {
+		var elem116 interface{}
+		elem116 = frugalJSONDouble(float64(*p.Radius))
+		m["radius"] = elem116
+	}
+	return json.Marshal(m)
+}
+
+func (p *Shape) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if raw, ok := m["point"]; ok && !frugalJSONIsNull(raw) {
+		var elem117 *Point
+		elem117 = NewPoint()
+		if err := elem117.UnmarshalJSON(raw); err != nil {
+			return err
+		}
+		p.Point = elem117
+	}
+	if raw, ok := m["radius"]; ok && !frugalJSONIsNull(raw) {
+		var elem118 float64
+		if v, err := frugalJSONParseDouble(raw); err != nil {
+			return err
+		} else {
+			elem118 = float64(v)
+		}
+		p.Radius = &elem118
+	}
+	if c := p.CountSetFieldsShape(); c > 1 {
+		return fmt.Errorf("%T unmarshal union: at most one field may be set (%d set).", p, c)
+	}
+	return nil
+}
+
+type DrawingError struct {
+	Message string `thrift:"message,1" db:"message" json:"message"`
+}
+
+func NewDrawingError() *DrawingError {
+	return &DrawingError{}
+}
+
+func (p *DrawingError) GetMessage() string {
+	return p.Message
+}
+
+func (p *DrawingError) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DrawingError) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Message = v
+	}
+	return nil
+}
+
+func (p *DrawingError) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DrawingError"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DrawingError) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("message", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:message: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Message)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.message (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:message: ", p), err)
+	}
+	return nil
+}
+
+func (p *DrawingError) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DrawingError(%+v)", *p)
+}
+
+func (p *DrawingError) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, 1)
+	var elem119 interface{}
+	elem119 = p.Message
+	m["message"] = elem119
+	return json.Marshal(m)
+}
+
+func (p *DrawingError) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if raw, ok := m["message"]; ok && !frugalJSONIsNull(raw) {
+		var elem120 string
+		if err := json.Unmarshal(raw, &elem120); err != nil {
+			return err
+		}
+		p.Message = elem120
+	}
+	return nil
+}
+
+func (p *DrawingError) Error() string {
+	return p.String()
+}
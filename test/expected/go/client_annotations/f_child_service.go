@@ -0,0 +1,673 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package client_annotations
+
+import (
+	"bytes"
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+var _ = logrus.DebugLevel
+
+type FChild interface {
+	FBase
+
+	Put(ctx frugal.FContext) (err error)
+	Get(ctx frugal.FContext) (r string, err error)
+}
+
+type FChildClient struct {
+	*FBaseClient
+	transport       frugal.FTransport
+	protocolFactory *frugal.FProtocolFactory
+	methods         map[string]*frugal.Method
+}
+
+func NewFChildClient(provider *frugal.FServiceProvider, middleware ...frugal.ServiceMiddleware) *FChildClient {
+	methods := make(map[string]*frugal.Method)
+	client := &FChildClient{
+		FBaseClient:     NewFBaseClient(provider, middleware...),
+		transport:       provider.GetTransport(),
+		protocolFactory: provider.GetProtocolFactory(),
+		methods:         methods,
+	}
+	middleware = append(middleware, provider.GetMiddleware()...)
+	methods["put"] = frugal.NewMethod(client, client.put, "put", middleware)
+	methods["get"] = frugal.NewMethod(client, client.get, "get", middleware)
+	return client
+}
+
+// Annotations returns the IDL annotations of the service methods keyed by
+// method name.
+func (f *FChildClient) Annotations() map[string]map[string]string {
+	annotations := make(map[string]map[string]string)
+	if base, ok := interface{}(f.FBaseClient).(interface {
+		Annotations() map[string]map[string]string
+	}); ok {
+		for method, methodAnnotations := range base.Annotations() {
+			annotations[method] = methodAnnotations
+		}
+	}
+	annotations["get"] = map[string]string{
+		"idempotent": "true",
+	}
+	return annotations
+}
+
+func (f *FChildClient) Put(ctx frugal.FContext) (err error) {
+	ret := f.methods["put"].Invoke([]interface{}{ctx})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err = ret[0].(error)
+	}
+	return err
+}
+
+func (f *FChildClient) put(ctx frugal.FContext) (err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("put", thrift.CALL, 0); err != nil {
+		return
+	}
+	args := ChildPutArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	var resultTransport thrift.TTransport
+	resultTransport, err = f.transport.Request(ctx, buffer.Bytes())
+	if err != nil {
+		return
+	}
+	iprot := f.protocolFactory.GetProtocol(resultTransport)
+	if err = iprot.ReadResponseHeader(ctx); err != nil {
+		return
+	}
+	method, mTypeId, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "put" {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_WRONG_METHOD_NAME, "put failed: wrong method name")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error0 := thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		var error1 thrift.TApplicationException
+		error1, err = error0.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		if error1.TypeId() == frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE {
+			err = thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, error1.Error())
+			return
+		}
+		err = error1
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_INVALID_MESSAGE_TYPE, "put failed: invalid message type")
+		return
+	}
+	result := ChildPutResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	return
+}
+
+func (f *FChildClient) Get(ctx frugal.FContext) (r string, err error) {
+	ret := f.methods["get"].Invoke([]interface{}{ctx})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[0] != nil {
+		r = ret[0].(string)
+	}
+	if ret[1] != nil {
+		err = ret[1].(error)
+	}
+	return r, err
+}
+
+func (f *FChildClient) get(ctx frugal.FContext) (r string, err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("get", thrift.CALL, 0); err != nil {
+		return
+	}
+	args := ChildGetArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	var resultTransport thrift.TTransport
+	resultTransport, err = f.transport.Request(ctx, buffer.Bytes())
+	if err != nil {
+		return
+	}
+	iprot := f.protocolFactory.GetProtocol(resultTransport)
+	if err = iprot.ReadResponseHeader(ctx); err != nil {
+		return
+	}
+	method, mTypeId, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "get" {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_WRONG_METHOD_NAME, "get failed: wrong method name")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error0 := thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		var error1 thrift.TApplicationException
+		error1, err = error0.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		if error1.TypeId() == frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE {
+			err = thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, error1.Error())
+			return
+		}
+		err = error1
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_INVALID_MESSAGE_TYPE, "get failed: invalid message type")
+		return
+	}
+	result := ChildGetResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	r = result.GetSuccess()
+	return
+}
+
+type FChildProcessor struct {
+	*FBaseProcessor
+}
+
+func NewFChildProcessor(handler FChild, middleware ...frugal.ServiceMiddleware) *FChildProcessor {
+	p := &FChildProcessor{NewFBaseProcessor(handler, middleware...)}
+	p.AddToProcessorMap("put", &childFPut{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Put, "Put", middleware))})
+	p.AddToProcessorMap("get", &childFGet{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Get, "Get", middleware))})
+	p.AddToAnnotationsMap("get", map[string]string{
+		"idempotent": "true",
+	})
+	return p
+}
+
+type childFPut struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *childFPut) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := ChildPutArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		p.GetWriteMutex().Lock()
+		err = childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_PROTOCOL_ERROR, "put", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	result := ChildPutResult{}
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err2 = ret[0].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("put", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		p.GetWriteMutex().Lock()
+		err2 := childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INTERNAL_ERROR, "put", "Internal error processing put: "+err2.Error())
+		p.GetWriteMutex().Unlock()
+		return err2
+	}
+	p.GetWriteMutex().Lock()
+	defer p.GetWriteMutex().Unlock()
+	if err2 = oprot.WriteResponseHeader(ctx); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "put", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageBegin("put", thrift.REPLY, 0); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "put", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "put", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "put", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "put", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	return err
+}
+
+type childFGet struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *childFGet) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := ChildGetArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		p.GetWriteMutex().Lock()
+		err = childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_PROTOCOL_ERROR, "get", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	result := ChildGetResult{}
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[1] != nil {
+		err2 = ret[1].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("get", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		p.GetWriteMutex().Lock()
+		err2 := childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INTERNAL_ERROR, "get", "Internal error processing get: "+err2.Error())
+		p.GetWriteMutex().Unlock()
+		return err2
+	} else {
+		var retval string = ret[0].(string)
+		result.Success = &retval
+	}
+	p.GetWriteMutex().Lock()
+	defer p.GetWriteMutex().Unlock()
+	if err2 = oprot.WriteResponseHeader(ctx); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageBegin("get", thrift.REPLY, 0); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			childWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	return err
+}
+
+func childWriteApplicationError(ctx frugal.FContext, oprot *frugal.FProtocol, type_ int32, method, message string) error {
+	x := thrift.NewTApplicationException(type_, message)
+	oprot.WriteResponseHeader(ctx)
+	oprot.WriteMessageBegin(method, thrift.EXCEPTION, 0)
+	x.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return x
+}
+
+type ChildPutArgs struct {
+}
+
+func NewChildPutArgs() *ChildPutArgs {
+	return &ChildPutArgs{}
+}
+
+func (p *ChildPutArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ChildPutArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("put_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ChildPutArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ChildPutArgs(%+v)", *p)
+}
+
+type ChildPutResult struct {
+}
+
+func NewChildPutResult() *ChildPutResult {
+	return &ChildPutResult{}
+}
+
+func (p *ChildPutResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ChildPutResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("put_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ChildPutResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ChildPutResult(%+v)", *p)
+}
+
+type ChildGetArgs struct {
+}
+
+func NewChildGetArgs() *ChildGetArgs {
+	return &ChildGetArgs{}
+}
+
+func (p *ChildGetArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ChildGetArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("get_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ChildGetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ChildGetArgs(%+v)", *p)
+}
+
+type ChildGetResult struct {
+	Success *string `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewChildGetResult() *ChildGetResult {
+	return &ChildGetResult{}
+}
+
+var ChildGetResult_Success_DEFAULT string
+
+func (p *ChildGetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *ChildGetResult) GetSuccess() string {
+	if !p.IsSetSuccess() {
+		return ChildGetResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+
+func (p *ChildGetResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *ChildGetResult) ReadField0(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		p.Success = &v
+	}
+	return nil
+}
+
+func (p *ChildGetResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("get_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *ChildGetResult) writeField0(oprot thrift.TProtocol) error {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRING, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *ChildGetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("ChildGetResult(%+v)", *p)
+}
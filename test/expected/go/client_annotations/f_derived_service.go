@@ -0,0 +1,388 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package client_annotations
+
+import (
+	"bytes"
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+var _ = logrus.DebugLevel
+
+type FDerived interface {
+	FLegacy
+
+	Get(ctx frugal.FContext) (r string, err error)
+}
+
+type FDerivedClient struct {
+	*FLegacyClient
+	transport       frugal.FTransport
+	protocolFactory *frugal.FProtocolFactory
+	methods         map[string]*frugal.Method
+}
+
+func NewFDerivedClient(provider *frugal.FServiceProvider, middleware ...frugal.ServiceMiddleware) *FDerivedClient {
+	methods := make(map[string]*frugal.Method)
+	client := &FDerivedClient{
+		FLegacyClient:   NewFLegacyClient(provider, middleware...),
+		transport:       provider.GetTransport(),
+		protocolFactory: provider.GetProtocolFactory(),
+		methods:         methods,
+	}
+	middleware = append(middleware, provider.GetMiddleware()...)
+	methods["get"] = frugal.NewMethod(client, client.get, "get", middleware)
+	return client
+}
+
+func (f *FDerivedClient) Get(ctx frugal.FContext) (r string, err error) {
+	ret := f.methods["get"].Invoke([]interface{}{ctx})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[0] != nil {
+		r = ret[0].(string)
+	}
+	if ret[1] != nil {
+		err = ret[1].(error)
+	}
+	return r, err
+}
+
+func (f *FDerivedClient) get(ctx frugal.FContext) (r string, err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("get", thrift.CALL, 0); err != nil {
+		return
+	}
+	args := DerivedGetArgs{}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	var resultTransport thrift.TTransport
+	resultTransport, err = f.transport.Request(ctx, buffer.Bytes())
+	if err != nil {
+		return
+	}
+	iprot := f.protocolFactory.GetProtocol(resultTransport)
+	if err = iprot.ReadResponseHeader(ctx); err != nil {
+		return
+	}
+	method, mTypeId, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "get" {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_WRONG_METHOD_NAME, "get failed: wrong method name")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error0 := thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		var error1 thrift.TApplicationException
+		error1, err = error0.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		if error1.TypeId() == frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE {
+			err = thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, error1.Error())
+			return
+		}
+		err = error1
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_INVALID_MESSAGE_TYPE, "get failed: invalid message type")
+		return
+	}
+	result := DerivedGetResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	r = result.GetSuccess()
+	return
+}
+
+type FDerivedProcessor struct {
+	*FLegacyProcessor
+}
+
+func NewFDerivedProcessor(handler FDerived, middleware ...frugal.ServiceMiddleware) *FDerivedProcessor {
+	p := &FDerivedProcessor{NewFLegacyProcessor(handler, middleware...)}
+	p.AddToProcessorMap("get", &derivedFGet{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Get, "Get", middleware))})
+	p.AddToAnnotationsMap("get", map[string]string{
+		"idempotent": "",
+	})
+	return p
+}
+
+type derivedFGet struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *derivedFGet) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := DerivedGetArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		p.GetWriteMutex().Lock()
+		err = derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_PROTOCOL_ERROR, "get", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	result := DerivedGetResult{}
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[1] != nil {
+		err2 = ret[1].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("get", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		p.GetWriteMutex().Lock()
+		err2 := derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INTERNAL_ERROR, "get", "Internal error processing get: "+err2.Error())
+		p.GetWriteMutex().Unlock()
+		return err2
+	} else {
+		var retval string = ret[0].(string)
+		result.Success = &retval
+	}
+	p.GetWriteMutex().Lock()
+	defer p.GetWriteMutex().Unlock()
+	if err2 = oprot.WriteResponseHeader(ctx); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageBegin("get", thrift.REPLY, 0); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			derivedWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "get", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	return err
+}
+
+func derivedWriteApplicationError(ctx frugal.FContext, oprot *frugal.FProtocol, type_ int32, method, message string) error {
+	x := thrift.NewTApplicationException(type_, message)
+	oprot.WriteResponseHeader(ctx)
+	oprot.WriteMessageBegin(method, thrift.EXCEPTION, 0)
+	x.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return x
+}
+
+type DerivedGetArgs struct {
+}
+
+func NewDerivedGetArgs() *DerivedGetArgs {
+	return &DerivedGetArgs{}
+}
+
+func (p *DerivedGetArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DerivedGetArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("get_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DerivedGetArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DerivedGetArgs(%+v)", *p)
+}
+
+type DerivedGetResult struct {
+	Success *string `thrift:"success,0" db:"success" json:"success,omitempty"`
+}
+
+func NewDerivedGetResult() *DerivedGetResult {
+	return &DerivedGetResult{}
+}
+
+var DerivedGetResult_Success_DEFAULT string
+
+func (p *DerivedGetResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *DerivedGetResult) GetSuccess() string {
+	if !p.IsSetSuccess() {
+		return DerivedGetResult_Success_DEFAULT
+	}
+	return *p.Success
+}
+
+func (p *DerivedGetResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DerivedGetResult) ReadField0(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 0: ", err)
+	} else {
+		p.Success = &v
+	}
+	return nil
+}
+
+func (p *DerivedGetResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("get_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DerivedGetResult) writeField0(oprot thrift.TProtocol) error {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRING, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Success)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.success (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *DerivedGetResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DerivedGetResult(%+v)", *p)
+}
@@ -0,0 +1,1092 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package constraints
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Sirupsen/logrus"
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+var _ = logrus.DebugLevel
+
+type FAccounts interface {
+	Create(ctx frugal.FContext, name Username, age int32, home *Address) (r *Account, err error)
+	Touch(ctx frugal.FContext, account *Account) (err error)
+	Ping(ctx frugal.FContext, message string) (err error)
+}
+
+type FAccountsClient struct {
+	transport       frugal.FTransport
+	protocolFactory *frugal.FProtocolFactory
+	methods         map[string]*frugal.Method
+}
+
+func NewFAccountsClient(provider *frugal.FServiceProvider, middleware ...frugal.ServiceMiddleware) *FAccountsClient {
+	methods := make(map[string]*frugal.Method)
+	client := &FAccountsClient{
+		transport:       provider.GetTransport(),
+		protocolFactory: provider.GetProtocolFactory(),
+		methods:         methods,
+	}
+	middleware = append(middleware, provider.GetMiddleware()...)
+	methods["create"] = frugal.NewMethod(client, client.create, "create", middleware)
+	methods["touch"] = frugal.NewMethod(client, client.touch, "touch", middleware)
+	methods["ping"] = frugal.NewMethod(client, client.ping, "ping", middleware)
+	return client
+}
+
+// Annotations returns the IDL annotations of the service methods keyed by
+// method name.
+func (f *FAccountsClient) Annotations() map[string]map[string]string {
+	annotations := make(map[string]map[string]string)
+	return annotations
+}
+
+func (f *FAccountsClient) Create(ctx frugal.FContext, name Username, age int32, home *Address) (r *Account, err error) {
+	ret := f.methods["create"].Invoke([]interface{}{ctx, name, age, home})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[0] != nil {
+		r = ret[0].(*Account)
+	}
+	if ret[1] != nil {
+		err = ret[1].(error)
+	}
+	return r, err
+}
+
+func (f *FAccountsClient) create(ctx frugal.FContext, name Username, age int32, home *Address) (r *Account, err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("create", thrift.CALL, 0); err != nil {
+		return
+	}
+	args := AccountsCreateArgs{
+		Name: name,
+		Age:  age,
+		Home: home,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	var resultTransport thrift.TTransport
+	resultTransport, err = f.transport.Request(ctx, buffer.Bytes())
+	if err != nil {
+		return
+	}
+	iprot := f.protocolFactory.GetProtocol(resultTransport)
+	if err = iprot.ReadResponseHeader(ctx); err != nil {
+		return
+	}
+	method, mTypeId, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "create" {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_WRONG_METHOD_NAME, "create failed: wrong method name")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error0 := thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		var error1 thrift.TApplicationException
+		error1, err = error0.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		if error1.TypeId() == frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE {
+			err = thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, error1.Error())
+			return
+		}
+		err = error1
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_INVALID_MESSAGE_TYPE, "create failed: invalid message type")
+		return
+	}
+	result := AccountsCreateResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Invalid != nil {
+		err = result.Invalid
+		return
+	}
+	r = result.GetSuccess()
+	return
+}
+
+func (f *FAccountsClient) Touch(ctx frugal.FContext, account *Account) (err error) {
+	ret := f.methods["touch"].Invoke([]interface{}{ctx, account})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err = ret[0].(error)
+	}
+	return err
+}
+
+func (f *FAccountsClient) touch(ctx frugal.FContext, account *Account) (err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("touch", thrift.ONEWAY, 0); err != nil {
+		return
+	}
+	args := AccountsTouchArgs{
+		Account: account,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	err = f.transport.Oneway(ctx, buffer.Bytes())
+	return
+}
+
+func (f *FAccountsClient) Ping(ctx frugal.FContext, message string) (err error) {
+	ret := f.methods["ping"].Invoke([]interface{}{ctx, message})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err = ret[0].(error)
+	}
+	return err
+}
+
+func (f *FAccountsClient) ping(ctx frugal.FContext, message string) (err error) {
+	buffer := frugal.NewTMemoryOutputBuffer(f.transport.GetRequestSizeLimit())
+	oprot := f.protocolFactory.GetProtocol(buffer)
+	if err = oprot.WriteRequestHeader(ctx); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageBegin("ping", thrift.CALL, 0); err != nil {
+		return
+	}
+	args := AccountsPingArgs{
+		Message: message,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	if err = oprot.Flush(); err != nil {
+		return
+	}
+	var resultTransport thrift.TTransport
+	resultTransport, err = f.transport.Request(ctx, buffer.Bytes())
+	if err != nil {
+		return
+	}
+	iprot := f.protocolFactory.GetProtocol(resultTransport)
+	if err = iprot.ReadResponseHeader(ctx); err != nil {
+		return
+	}
+	method, mTypeId, _, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "ping" {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_WRONG_METHOD_NAME, "ping failed: wrong method name")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error0 := thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_UNKNOWN, "Unknown Exception")
+		var error1 thrift.TApplicationException
+		error1, err = error0.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		if error1.TypeId() == frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE {
+			err = thrift.NewTTransportException(frugal.TRANSPORT_EXCEPTION_RESPONSE_TOO_LARGE, error1.Error())
+			return
+		}
+		err = error1
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(frugal.APPLICATION_EXCEPTION_INVALID_MESSAGE_TYPE, "ping failed: invalid message type")
+		return
+	}
+	result := AccountsPingResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	return
+}
+
+type FAccountsProcessor struct {
+	*frugal.FBaseProcessor
+}
+
+func NewFAccountsProcessor(handler FAccounts, middleware ...frugal.ServiceMiddleware) *FAccountsProcessor {
+	p := &FAccountsProcessor{frugal.NewFBaseProcessor()}
+	p.AddToProcessorMap("create", &accountsFCreate{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Create, "Create", middleware))})
+	p.AddToProcessorMap("touch", &accountsFTouch{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Touch, "Touch", middleware))})
+	p.AddToProcessorMap("ping", &accountsFPing{frugal.NewFBaseProcessorFunction(p.GetWriteMutex(), frugal.NewMethod(handler, handler.Ping, "Ping", middleware))})
+	return p
+}
+
+type accountsFCreate struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *accountsFCreate) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := AccountsCreateArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		p.GetWriteMutex().Lock()
+		err = accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_PROTOCOL_ERROR, "create", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	if err = args.Validate(); err != nil {
+		p.GetWriteMutex().Lock()
+		err = accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INVALID_ARGUMENT, "create", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	result := AccountsCreateResult{}
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx, args.Name, args.Age, args.Home})
+	if len(ret) != 2 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 2", len(ret)))
+	}
+	if ret[1] != nil {
+		err2 = ret[1].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("create", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		switch v := err2.(type) {
+		case *InvalidAccount:
+			result.Invalid = v
+		default:
+			p.GetWriteMutex().Lock()
+			err2 := accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INTERNAL_ERROR, "create", "Internal error processing create: "+err2.Error())
+			p.GetWriteMutex().Unlock()
+			return err2
+		}
+	} else {
+		var retval *Account = ret[0].(*Account)
+		result.Success = retval
+	}
+	p.GetWriteMutex().Lock()
+	defer p.GetWriteMutex().Unlock()
+	if err2 = oprot.WriteResponseHeader(ctx); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "create", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageBegin("create", thrift.REPLY, 0); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "create", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "create", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "create", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "create", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	return err
+}
+
+type accountsFTouch struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *accountsFTouch) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := AccountsTouchArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	if err = args.Validate(); err != nil {
+		return err
+	}
+
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx, args.Account})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err2 = ret[0].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("touch", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		return err2
+	}
+	return err
+}
+
+type accountsFPing struct {
+	*frugal.FBaseProcessorFunction
+}
+
+func (p *accountsFPing) Process(ctx frugal.FContext, iprot, oprot *frugal.FProtocol) error {
+	args := AccountsPingArgs{}
+	var err error
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		p.GetWriteMutex().Lock()
+		err = accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_PROTOCOL_ERROR, "ping", err.Error())
+		p.GetWriteMutex().Unlock()
+		return err
+	}
+
+	iprot.ReadMessageEnd()
+	result := AccountsPingResult{}
+	var err2 error
+	ret := p.InvokeMethod([]interface{}{ctx, args.Message})
+	if len(ret) != 1 {
+		panic(fmt.Sprintf("Middleware returned %d arguments, expected 1", len(ret)))
+	}
+	if ret[0] != nil {
+		err2 = ret[0].(error)
+	}
+	if err2 != nil {
+		if err3, ok := err2.(thrift.TApplicationException); ok {
+			p.GetWriteMutex().Lock()
+			oprot.WriteResponseHeader(ctx)
+			oprot.WriteMessageBegin("ping", thrift.EXCEPTION, 0)
+			err3.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			p.GetWriteMutex().Unlock()
+			return nil
+		}
+		p.GetWriteMutex().Lock()
+		err2 := accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_INTERNAL_ERROR, "ping", "Internal error processing ping: "+err2.Error())
+		p.GetWriteMutex().Unlock()
+		return err2
+	}
+	p.GetWriteMutex().Lock()
+	defer p.GetWriteMutex().Unlock()
+	if err2 = oprot.WriteResponseHeader(ctx); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "ping", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageBegin("ping", thrift.REPLY, 0); err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "ping", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "ping", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "ping", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		if frugal.IsErrTooLarge(err2) {
+			accountsWriteApplicationError(ctx, oprot, frugal.APPLICATION_EXCEPTION_RESPONSE_TOO_LARGE, "ping", err2.Error())
+			return nil
+		}
+		err = err2
+	}
+	return err
+}
+
+func accountsWriteApplicationError(ctx frugal.FContext, oprot *frugal.FProtocol, type_ int32, method, message string) error {
+	x := thrift.NewTApplicationException(type_, message)
+	oprot.WriteResponseHeader(ctx)
+	oprot.WriteMessageBegin(method, thrift.EXCEPTION, 0)
+	x.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return x
+}
+
+type AccountsCreateArgs struct {
+	Name Username `thrift:"name,1" db:"name" json:"name"`
+	Age  int32    `thrift:"age,2" db:"age" json:"age"`
+	Home *Address `thrift:"home,3" db:"home" json:"home"`
+}
+
+func NewAccountsCreateArgs() *AccountsCreateArgs {
+	return &AccountsCreateArgs{}
+}
+
+func (p *AccountsCreateArgs) GetName() Username {
+	return p.Name
+}
+
+func (p *AccountsCreateArgs) GetAge() int32 {
+	return p.Age
+}
+
+var AccountsCreateArgs_Home_DEFAULT *Address
+
+func (p *AccountsCreateArgs) IsSetHome() bool {
+	return p.Home != nil
+}
+
+func (p *AccountsCreateArgs) GetHome() *Address {
+	if !p.IsSetHome() {
+		return AccountsCreateArgs_Home_DEFAULT
+	}
+	return p.Home
+}
+
+func (p *AccountsCreateArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := Username(v)
+		p.Name = temp
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Age = v
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) ReadField3(iprot thrift.TProtocol) error {
+	p.Home = NewAddress()
+	if err := p.Home.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Home), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("create_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) writeField2(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("age", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:age: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Age)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.age (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:age: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) writeField3(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("home", thrift.STRUCT, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:home: ", p), err)
+	}
+	if err := p.Home.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Home), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:home: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AccountsCreateArgs(%+v)", *p)
+}
+
+var AccountsCreateArgs_Name_PATTERN = regexp.MustCompile("^[a-z]+$")
+
+func (p *AccountsCreateArgs) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if !AccountsCreateArgs_Name_PATTERN.MatchString(string(p.Name)) {
+		return fmt.Errorf("create_args.name must match %s, got %q", AccountsCreateArgs_Name_PATTERN, p.Name)
+	}
+	if p.Age < 18 {
+		return fmt.Errorf("create_args.age must be at least 18, got %d", p.Age)
+	}
+	if err := p.Home.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type AccountsCreateResult struct {
+	Success *Account        `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Invalid *InvalidAccount `thrift:"invalid,1" db:"invalid" json:"invalid,omitempty"`
+}
+
+func NewAccountsCreateResult() *AccountsCreateResult {
+	return &AccountsCreateResult{}
+}
+
+var AccountsCreateResult_Success_DEFAULT *Account
+
+func (p *AccountsCreateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *AccountsCreateResult) GetSuccess() *Account {
+	if !p.IsSetSuccess() {
+		return AccountsCreateResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var AccountsCreateResult_Invalid_DEFAULT *InvalidAccount
+
+func (p *AccountsCreateResult) IsSetInvalid() bool {
+	return p.Invalid != nil
+}
+
+func (p *AccountsCreateResult) GetInvalid() *InvalidAccount {
+	if !p.IsSetInvalid() {
+		return AccountsCreateResult_Invalid_DEFAULT
+	}
+	return p.Invalid
+}
+
+func (p *AccountsCreateResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = NewAccount()
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Invalid = NewInvalidAccount()
+	if err := p.Invalid.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Invalid), err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("create_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) writeField0(oprot thrift.TProtocol) error {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) writeField1(oprot thrift.TProtocol) error {
+	if p.IsSetInvalid() {
+		if err := oprot.WriteFieldBegin("invalid", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:invalid: ", p), err)
+		}
+		if err := p.Invalid.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Invalid), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:invalid: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *AccountsCreateResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AccountsCreateResult(%+v)", *p)
+}
+
+func (p *AccountsCreateResult) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := p.Success.Validate(); err != nil {
+		return err
+	}
+	if err := p.Invalid.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type AccountsTouchArgs struct {
+	Account *Account `thrift:"account,1" db:"account" json:"account"`
+}
+
+func NewAccountsTouchArgs() *AccountsTouchArgs {
+	return &AccountsTouchArgs{}
+}
+
+var AccountsTouchArgs_Account_DEFAULT *Account
+
+func (p *AccountsTouchArgs) IsSetAccount() bool {
+	return p.Account != nil
+}
+
+func (p *AccountsTouchArgs) GetAccount() *Account {
+	if !p.IsSetAccount() {
+		return AccountsTouchArgs_Account_DEFAULT
+	}
+	return p.Account
+}
+
+func (p *AccountsTouchArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsTouchArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Account = NewAccount()
+	if err := p.Account.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Account), err)
+	}
+	return nil
+}
+
+func (p *AccountsTouchArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("touch_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AccountsTouchArgs) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("account", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:account: ", p), err)
+	}
+	if err := p.Account.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Account), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:account: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsTouchArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AccountsTouchArgs(%+v)", *p)
+}
+
+func (p *AccountsTouchArgs) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := p.Account.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+type AccountsPingArgs struct {
+	Message string `thrift:"message,1" db:"message" json:"message"`
+}
+
+func NewAccountsPingArgs() *AccountsPingArgs {
+	return &AccountsPingArgs{}
+}
+
+func (p *AccountsPingArgs) GetMessage() string {
+	return p.Message
+}
+
+func (p *AccountsPingArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsPingArgs) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Message = v
+	}
+	return nil
+}
+
+func (p *AccountsPingArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ping_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AccountsPingArgs) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("message", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:message: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Message)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.message (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:message: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsPingArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AccountsPingArgs(%+v)", *p)
+}
+
+type AccountsPingResult struct {
+}
+
+func NewAccountsPingResult() *AccountsPingResult {
+	return &AccountsPingResult{}
+}
+
+func (p *AccountsPingResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *AccountsPingResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ping_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *AccountsPingResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AccountsPingResult(%+v)", *p)
+}
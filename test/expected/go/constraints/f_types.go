@@ -0,0 +1,1064 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package constraints
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/test/out/constraints/actual_base/golang"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+
+var _ = golang.GoUnusedProtection__
+var GoUnusedProtection__ int
+
+func init() {
+}
+
+type Username string
+type Address struct {
+	Street string  `thrift:"street,1" db:"street" json:"street"`
+	Zip    *string `thrift:"zip,2" db:"zip" json:"zip,omitempty"`
+}
+
+func NewAddress() *Address {
+	return &Address{}
+}
+
+func (p *Address) GetStreet() string {
+	return p.Street
+}
+
+var Address_Zip_DEFAULT string
+
+func (p *Address) IsSetZip() bool {
+	return p.Zip != nil
+}
+
+func (p *Address) GetZip() string {
+	if !p.IsSetZip() {
+		return Address_Zip_DEFAULT
+	}
+	return *p.Zip
+}
+
+func (p *Address) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Address) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Street = v
+	}
+	return nil
+}
+
+func (p *Address) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Zip = &v
+	}
+	return nil
+}
+
+func (p *Address) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("address"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Address) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("street", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:street: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Street)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.street (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:street: ", p), err)
+	}
+	return nil
+}
+
+func (p *Address) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetZip() {
+		if err := oprot.WriteFieldBegin("zip", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:zip: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Zip)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.zip (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:zip: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Address) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Address(%+v)", *p)
+}
+
+var Address_Zip_PATTERN = regexp.MustCompile("^[0-9]{5}$")
+
+func (p *Address) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Street) == 0 {
+		return fmt.Errorf("address.street must not be empty")
+	}
+	if p.Zip != nil {
+		if !Address_Zip_PATTERN.MatchString(string(*p.Zip)) {
+			return fmt.Errorf("address.zip must match %s, got %q", Address_Zip_PATTERN, *p.Zip)
+		}
+	}
+	return nil
+}
+
+type Account struct {
+	Name    Username            `thrift:"name,1,required" db:"name" json:"name"`
+	Age     int32               `thrift:"age,2" db:"age" json:"age"`
+	Balance *float64            `thrift:"balance,3" db:"balance" json:"balance,omitempty"`
+	Emails  []string            `thrift:"emails,4" db:"emails" json:"emails"`
+	Tags    map[int64]bool      `thrift:"tags,5" db:"tags" json:"tags,omitempty"`
+	Home    *Address            `thrift:"home,6" db:"home" json:"home"`
+	Others  map[string]*Address `thrift:"others,7" db:"others" json:"others"`
+	Owner   *golang.Thing       `thrift:"owner,8" db:"owner" json:"owner"`
+	History *[]*Address         `thrift:"history,9" db:"history" json:"history,omitempty"`
+	Rank    *int16              `thrift:"rank,10" db:"rank" json:"rank,omitempty"`
+}
+
+func NewAccount() *Account {
+	return &Account{}
+}
+
+func (p *Account) GetName() Username {
+	return p.Name
+}
+
+func (p *Account) GetAge() int32 {
+	return p.Age
+}
+
+var Account_Balance_DEFAULT float64
+
+func (p *Account) IsSetBalance() bool {
+	return p.Balance != nil
+}
+
+func (p *Account) GetBalance() float64 {
+	if !p.IsSetBalance() {
+		return Account_Balance_DEFAULT
+	}
+	return *p.Balance
+}
+
+func (p *Account) GetEmails() []string {
+	return p.Emails
+}
+
+var Account_Tags_DEFAULT map[int64]bool
+
+func (p *Account) IsSetTags() bool {
+	return p.Tags != nil
+}
+
+func (p *Account) GetTags() map[int64]bool {
+	return p.Tags
+}
+
+var Account_Home_DEFAULT *Address
+
+func (p *Account) IsSetHome() bool {
+	return p.Home != nil
+}
+
+func (p *Account) GetHome() *Address {
+	if !p.IsSetHome() {
+		return Account_Home_DEFAULT
+	}
+	return p.Home
+}
+
+func (p *Account) GetOthers() map[string]*Address {
+	return p.Others
+}
+
+var Account_Owner_DEFAULT *golang.Thing
+
+func (p *Account) IsSetOwner() bool {
+	return p.Owner != nil
+}
+
+func (p *Account) GetOwner() *golang.Thing {
+	if !p.IsSetOwner() {
+		return Account_Owner_DEFAULT
+	}
+	return p.Owner
+}
+
+var Account_History_DEFAULT []*Address = []*Address{}
+
+func (p *Account) IsSetHistory() bool {
+	return p.History != nil
+}
+
+func (p *Account) GetHistory() []*Address {
+	if !p.IsSetHistory() {
+		return Account_History_DEFAULT
+	}
+	return *p.History
+}
+
+var Account_Rank_DEFAULT int16
+
+func (p *Account) IsSetRank() bool {
+	return p.Rank != nil
+}
+
+func (p *Account) GetRank() int16 {
+	if !p.IsSetRank() {
+		return Account_Rank_DEFAULT
+	}
+	return *p.Rank
+}
+
+func (p *Account) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	issetName := false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetName = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.ReadField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.ReadField10(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field 'Name' is not present in struct 'account'"))
+	}
+	return nil
+}
+
+func (p *Account) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := Username(v)
+		p.Name = temp
+	}
+	return nil
+}
+
+func (p *Account) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Age = v
+	}
+	return nil
+}
+
+func (p *Account) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Balance = &v
+	}
+	return nil
+}
+
+func (p *Account) ReadField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Emails = make([]string, 0, size)
+	for i := 0; i < size; i++ {
+		var elem0 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem0 = v
+		}
+		p.Emails = append(p.Emails, elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadSetBegin()
+	if err != nil {
+		return thrift.PrependError("error reading set begin: ", err)
+	}
+	p.Tags = make(map[int64]bool, size)
+	for i := 0; i < size; i++ {
+		var elem1 int64
+		if v, err := iprot.ReadI64(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem1 = v
+		}
+		(p.Tags)[elem1] = true
+	}
+	if err := iprot.ReadSetEnd(); err != nil {
+		return thrift.PrependError("error reading set end: ", err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField6(iprot thrift.TProtocol) error {
+	p.Home = NewAddress()
+	if err := p.Home.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Home), err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField7(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Others = make(map[string]*Address, size)
+	for i := 0; i < size; i++ {
+		var elem2 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem2 = v
+		}
+		elem3 := NewAddress()
+		if err := elem3.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem3), err)
+		}
+		(p.Others)[elem2] = elem3
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField8(iprot thrift.TProtocol) error {
+	p.Owner = golang.NewThing()
+	if err := p.Owner.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Owner), err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField9(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	temp := make([]*Address, 0, size)
+	p.History = &temp
+	for i := 0; i < size; i++ {
+		elem4 := NewAddress()
+		if err := elem4.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem4), err)
+		}
+		*p.History = append(*p.History, elem4)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Account) ReadField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI16(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.Rank = &v
+	}
+	return nil
+}
+
+func (p *Account) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("account"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Account) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField2(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("age", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:age: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Age)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.age (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:age: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField3(oprot thrift.TProtocol) error {
+	if p.IsSetBalance() {
+		if err := oprot.WriteFieldBegin("balance", thrift.DOUBLE, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:balance: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.Balance)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.balance (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:balance: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Account) writeField4(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("emails", thrift.LIST, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:emails: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRING, len(p.Emails)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Emails {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:emails: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField5(oprot thrift.TProtocol) error {
+	if p.IsSetTags() {
+		if err := oprot.WriteFieldBegin("tags", thrift.SET, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:tags: ", p), err)
+		}
+		if err := oprot.WriteSetBegin(thrift.I64, len(p.Tags)); err != nil {
+			return thrift.PrependError("error writing set begin: ", err)
+		}
+		for v, _ := range p.Tags {
+			if err := oprot.WriteI64(int64(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteSetEnd(); err != nil {
+			return thrift.PrependError("error writing set end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:tags: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Account) writeField6(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("home", thrift.STRUCT, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:home: ", p), err)
+	}
+	if err := p.Home.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Home), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:home: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField7(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("others", thrift.MAP, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:others: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRUCT, len(p.Others)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Others {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:others: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField8(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("owner", thrift.STRUCT, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:owner: ", p), err)
+	}
+	if err := p.Owner.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Owner), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:owner: ", p), err)
+	}
+	return nil
+}
+
+func (p *Account) writeField9(oprot thrift.TProtocol) error {
+	if p.IsSetHistory() {
+		if err := oprot.WriteFieldBegin("history", thrift.LIST, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:history: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(*p.History)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range *p.History {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:history: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Account) writeField10(oprot thrift.TProtocol) error {
+	if p.IsSetRank() {
+		if err := oprot.WriteFieldBegin("rank", thrift.I16, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:rank: ", p), err)
+		}
+		if err := oprot.WriteI16(int16(*p.Rank)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rank (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:rank: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Account) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Account(%+v)", *p)
+}
+
+var Account_Name_PATTERN = regexp.MustCompile("^[a-z]+$")
+
+func (p *Account) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Name) < 3 {
+		return fmt.Errorf("account.name must have a length of at least 3, got %d", len(p.Name))
+	}
+	if len(p.Name) > 16 {
+		return fmt.Errorf("account.name must have a length of at most 16, got %d", len(p.Name))
+	}
+	if !Account_Name_PATTERN.MatchString(string(p.Name)) {
+		return fmt.Errorf("account.name must match %s, got %q", Account_Name_PATTERN, p.Name)
+	}
+	if p.Age < 0 {
+		return fmt.Errorf("account.age must be at least 0, got %d", p.Age)
+	}
+	if p.Age > 150 {
+		return fmt.Errorf("account.age must be at most 150, got %d", p.Age)
+	}
+	if p.Balance != nil {
+		if *p.Balance < -1.5 {
+			return fmt.Errorf("account.balance must be at least -1.5, got %v", *p.Balance)
+		}
+	}
+	if len(p.Emails) == 0 {
+		return fmt.Errorf("account.emails must not be empty")
+	}
+	if len(p.Emails) > 4 {
+		return fmt.Errorf("account.emails must have a length of at most 4, got %d", len(p.Emails))
+	}
+	if p.Tags != nil {
+		if len(p.Tags) > 8 {
+			return fmt.Errorf("account.tags must have a length of at most 8, got %d", len(p.Tags))
+		}
+	}
+	if err := p.Home.Validate(); err != nil {
+		return err
+	}
+	for _, elem5 := range p.Others {
+		if err := elem5.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.History != nil {
+		for _, elem6 := range *p.History {
+			if err := elem6.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	if p.Rank != nil {
+		if *p.Rank > 10 {
+			return fmt.Errorf("account.rank must be at most 10, got %d", *p.Rank)
+		}
+	}
+	return nil
+}
+
+type Contact struct {
+	Address *Address `thrift:"address,1" db:"address" json:"address,omitempty"`
+	Phone   *string  `thrift:"phone,2" db:"phone" json:"phone,omitempty"`
+}
+
+func NewContact() *Contact {
+	return &Contact{}
+}
+
+var Contact_Address_DEFAULT *Address
+
+func (p *Contact) IsSetAddress() bool {
+	return p.Address != nil
+}
+
+func (p *Contact) GetAddress() *Address {
+	if !p.IsSetAddress() {
+		return Contact_Address_DEFAULT
+	}
+	return p.Address
+}
+
+var Contact_Phone_DEFAULT string
+
+func (p *Contact) IsSetPhone() bool {
+	return p.Phone != nil
+}
+
+func (p *Contact) GetPhone() string {
+	if !p.IsSetPhone() {
+		return Contact_Phone_DEFAULT
+	}
+	return *p.Phone
+}
+
+func (p *Contact) CountSetFieldsContact() int {
+	count := 0
+	if p.IsSetAddress() {
+		count++
+	}
+	if p.IsSetPhone() {
+		count++
+	}
+	return count
+}
+
+func (p *Contact) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if c := p.CountSetFieldsContact(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T read union: exactly one field must be set (%d set).", p, c))
+	}
+	return nil
+}
+
+func (p *Contact) ReadField1(iprot thrift.TProtocol) error {
+	p.Address = NewAddress()
+	if err := p.Address.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Address), err)
+	}
+	return nil
+}
+
+func (p *Contact) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Phone = &v
+	}
+	return nil
+}
+
+func (p *Contact) Write(oprot thrift.TProtocol) error {
+	if c := p.CountSetFieldsContact(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T write union: exactly one field must be set (%d set).", p, c))
+	}
+	if err := oprot.WriteStructBegin("contact"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Contact) writeField1(oprot thrift.TProtocol) error {
+	if p.IsSetAddress() {
+		if err := oprot.WriteFieldBegin("address", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:address: ", p), err)
+		}
+		if err := p.Address.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Address), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:address: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Contact) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetPhone() {
+		if err := oprot.WriteFieldBegin("phone", thrift.STRING, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:phone: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Phone)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.phone (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:phone: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Contact) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Contact(%+v)", *p)
+}
+
+func (p *Contact) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := p.Address.Validate(); err != nil {
+		return err
+	}
+	if p.Phone != nil {
+		if len(*p.Phone) < 7 {
+			return fmt.Errorf("contact.phone must have a length of at least 7, got %d", len(*p.Phone))
+		}
+	}
+	return nil
+}
+
+type InvalidAccount struct {
+	Reason string `thrift:"reason,1" db:"reason" json:"reason"`
+}
+
+func NewInvalidAccount() *InvalidAccount {
+	return &InvalidAccount{}
+}
+
+func (p *InvalidAccount) GetReason() string {
+	return p.Reason
+}
+
+func (p *InvalidAccount) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *InvalidAccount) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Reason = v
+	}
+	return nil
+}
+
+func (p *InvalidAccount) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("invalid_account"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *InvalidAccount) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("reason", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:reason: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Reason)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.reason (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:reason: ", p), err)
+	}
+	return nil
+}
+
+func (p *InvalidAccount) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("InvalidAccount(%+v)", *p)
+}
+
+func (p *InvalidAccount) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if len(p.Reason) == 0 {
+		return fmt.Errorf("invalid_account.reason must not be empty")
+	}
+	return nil
+}
+
+func (p *InvalidAccount) Error() string {
+	return p.String()
+}
@@ -0,0 +1,154 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package mocks
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// AlertsFake is an in-memory fake of the Alerts scope for use in tests. It
+// implements the publisher and both subscriber interfaces. Published messages
+// are recorded and delivered synchronously, without serialization, to
+// subscribers of the same topic.
+type AlertsFake struct {
+	mu          sync.Mutex
+	published   []*AlertsFakeMessage
+	subscribers map[string][]*alertsFakeSubscription
+}
+
+var (
+	_ AlertsPublisher           = (*AlertsFake)(nil)
+	_ AlertsSubscriber          = (*AlertsFake)(nil)
+	_ AlertsErrorableSubscriber = (*AlertsFake)(nil)
+)
+
+// AlertsFakeMessage records a message published to the fake.
+type AlertsFakeMessage struct {
+	Op    string
+	Topic string
+	Ctx   frugal.FContext
+	Req   interface{}
+}
+
+// NewAlertsFake creates a new AlertsFake with no published messages or subscribers.
+func NewAlertsFake() *AlertsFake {
+	return &AlertsFake{subscribers: make(map[string][]*alertsFakeSubscription)}
+}
+
+func (f *AlertsFake) Open() error {
+	return nil
+}
+
+func (f *AlertsFake) Close() error {
+	return nil
+}
+
+// Published returns every message published in the order they were published.
+func (f *AlertsFake) Published() []*AlertsFakeMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*AlertsFakeMessage(nil), f.published...)
+}
+
+// Reset clears the published messages. Subscribers are kept.
+func (f *AlertsFake) Reset() {
+	f.mu.Lock()
+	f.published = nil
+	f.mu.Unlock()
+}
+
+func (f *AlertsFake) publish(ctx frugal.FContext, op, topic string, req interface{}) {
+	f.mu.Lock()
+	f.published = append(f.published, &AlertsFakeMessage{Op: op, Topic: topic, Ctx: ctx, Req: req})
+	subs := append([]*alertsFakeSubscription(nil), f.subscribers[topic]...)
+	f.mu.Unlock()
+	for _, sub := range subs {
+		// As with a real subscriber, handler errors are not returned to the publisher.
+		sub.handler(ctx, req)
+	}
+}
+
+func (f *AlertsFake) subscribe(topic string, handler func(frugal.FContext, interface{}) error) *frugal.FSubscription {
+	sub := &alertsFakeSubscription{fake: f, handler: handler}
+	sub.Subscribe(topic, nil)
+	return frugal.NewFSubscription(topic, sub)
+}
+
+func (f *AlertsFake) PublishRaised(ctx frugal.FContext, req string) error {
+	op := "Raised"
+	prefix := ""
+	topic := fmt.Sprintf("%sAlerts%s%s", prefix, delimiter, op)
+	f.publish(ctx, op, topic, req)
+	return nil
+}
+
+// PublishedRaised returns the requests published with PublishRaised.
+func (f *AlertsFake) PublishedRaised() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var reqs []string
+	for _, msg := range f.published {
+		if msg.Op == "Raised" {
+			reqs = append(reqs, msg.Req.(string))
+		}
+	}
+	return reqs
+}
+
+func (f *AlertsFake) SubscribeRaised(handler func(frugal.FContext, string)) (*frugal.FSubscription, error) {
+	return f.SubscribeRaisedErrorable(func(fctx frugal.FContext, arg string) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (f *AlertsFake) SubscribeRaisedErrorable(handler func(frugal.FContext, string) error) (*frugal.FSubscription, error) {
+	op := "Raised"
+	prefix := ""
+	topic := fmt.Sprintf("%sAlerts%s%s", prefix, delimiter, op)
+	return f.subscribe(topic, func(fctx frugal.FContext, req interface{}) error {
+		return handler(fctx, req.(string))
+	}), nil
+}
+
+type alertsFakeSubscription struct {
+	fake    *AlertsFake
+	topic   string
+	handler func(frugal.FContext, interface{}) error
+}
+
+func (s *alertsFakeSubscription) Subscribe(topic string, callback frugal.FAsyncCallback) error {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	s.topic = topic
+	s.fake.subscribers[topic] = append(s.fake.subscribers[topic], s)
+	return nil
+}
+
+func (s *alertsFakeSubscription) Unsubscribe() error {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	subs := s.fake.subscribers[s.topic]
+	for i, sub := range subs {
+		if sub == s {
+			s.fake.subscribers[s.topic] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *alertsFakeSubscription) IsSubscribed() bool {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	for _, sub := range s.fake.subscribers[s.topic] {
+		if sub == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package mocks
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// FBaseMock is a programmable FBase for use in tests. Use Expect<Method> to
+// expect calls with given arguments, or set the <Method>Func fields to define
+// how each method behaves. Every call is recorded, and calls which are neither
+// expected nor handled by a Func return an error.
+type FBaseMock struct {
+	mu sync.Mutex
+
+	BasePingFunc func(ctx frugal.FContext) error
+
+	basePingCalls []*FBaseMockBasePingCall
+
+	basePingExpectations []*FBaseMockBasePingExpectation
+}
+
+var _ FBase = (*FBaseMock)(nil)
+
+// NewFBaseMock creates a new FBaseMock with no method behavior set.
+func NewFBaseMock() *FBaseMock {
+	return &FBaseMock{}
+}
+
+// FBaseMockBasePingCall records a call to FBaseMock.BasePing.
+type FBaseMockBasePingCall struct {
+	Ctx frugal.FContext
+}
+
+func (m *FBaseMock) BasePing(ctx frugal.FContext) (err error) {
+	m.mu.Lock()
+	m.basePingCalls = append(m.basePingCalls, &FBaseMockBasePingCall{
+		Ctx: ctx,
+	})
+	for _, e := range m.basePingExpectations {
+		if e.calls < e.times {
+			e.calls++
+			m.mu.Unlock()
+			return e.err
+		}
+	}
+	fn := m.BasePingFunc
+	m.mu.Unlock()
+	if fn == nil {
+		return fmt.Errorf("FBaseMock: unexpected call to BasePing")
+	}
+	return fn(ctx)
+}
+
+// BasePingCalls returns the calls made to BasePing in the order they were made.
+func (m *FBaseMock) BasePingCalls() []*FBaseMockBasePingCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*FBaseMockBasePingCall(nil), m.basePingCalls...)
+}
+
+// FBaseMockBasePingExpectation is an expected call to FBaseMock.BasePing.
+type FBaseMockBasePingExpectation struct {
+	mu    *sync.Mutex
+	args  *FBaseMockBasePingCall
+	err   error
+	times int
+	calls int
+}
+
+// ExpectBasePing expects a call to BasePing with the given arguments. The call
+// is expected once unless changed with Times and takes precedence over
+// BasePingFunc.
+func (m *FBaseMock) ExpectBasePing() *FBaseMockBasePingExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &FBaseMockBasePingExpectation{mu: &m.mu, times: 1, args: &FBaseMockBasePingCall{}}
+	m.basePingExpectations = append(m.basePingExpectations, e)
+	return e
+}
+
+// Return sets the results of the expected call.
+func (e *FBaseMockBasePingExpectation) Return(err error) *FBaseMockBasePingExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+	return e
+}
+
+// Times sets the number of times the call is expected.
+func (e *FBaseMockBasePingExpectation) Times(n int) *FBaseMockBasePingExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.times = n
+	return e
+}
+
+// AssertExpectations reports an error to t for each expected call which was
+// not made the expected number of times and returns whether all were.
+func (m *FBaseMock) AssertExpectations(t interface {
+	Errorf(format string, args ...interface{})
+}) bool {
+	ok := true
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.basePingExpectations {
+		if e.calls != e.times {
+			t.Errorf("FBaseMock: expected %d calls to BasePing(), got %d", e.times, e.calls)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,191 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package mocks
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// EventsFake is an in-memory fake of the Events scope for use in tests. It
+// implements the publisher and both subscriber interfaces. Published messages
+// are recorded and delivered synchronously, without serialization, to
+// subscribers of the same topic.
+type EventsFake struct {
+	mu          sync.Mutex
+	published   []*EventsFakeMessage
+	subscribers map[string][]*eventsFakeSubscription
+}
+
+var (
+	_ EventsPublisher           = (*EventsFake)(nil)
+	_ EventsSubscriber          = (*EventsFake)(nil)
+	_ EventsErrorableSubscriber = (*EventsFake)(nil)
+)
+
+// EventsFakeMessage records a message published to the fake.
+type EventsFakeMessage struct {
+	Op    string
+	Topic string
+	Ctx   frugal.FContext
+	Req   interface{}
+}
+
+// NewEventsFake creates a new EventsFake with no published messages or subscribers.
+func NewEventsFake() *EventsFake {
+	return &EventsFake{subscribers: make(map[string][]*eventsFakeSubscription)}
+}
+
+func (f *EventsFake) Open() error {
+	return nil
+}
+
+func (f *EventsFake) Close() error {
+	return nil
+}
+
+// Published returns every message published in the order they were published.
+func (f *EventsFake) Published() []*EventsFakeMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*EventsFakeMessage(nil), f.published...)
+}
+
+// Reset clears the published messages. Subscribers are kept.
+func (f *EventsFake) Reset() {
+	f.mu.Lock()
+	f.published = nil
+	f.mu.Unlock()
+}
+
+func (f *EventsFake) publish(ctx frugal.FContext, op, topic string, req interface{}) {
+	f.mu.Lock()
+	f.published = append(f.published, &EventsFakeMessage{Op: op, Topic: topic, Ctx: ctx, Req: req})
+	subs := append([]*eventsFakeSubscription(nil), f.subscribers[topic]...)
+	f.mu.Unlock()
+	for _, sub := range subs {
+		// As with a real subscriber, handler errors are not returned to the publisher.
+		sub.handler(ctx, req)
+	}
+}
+
+func (f *EventsFake) subscribe(topic string, handler func(frugal.FContext, interface{}) error) *frugal.FSubscription {
+	sub := &eventsFakeSubscription{fake: f, handler: handler}
+	sub.Subscribe(topic, nil)
+	return frugal.NewFSubscription(topic, sub)
+}
+
+func (f *EventsFake) PublishCreated(ctx frugal.FContext, user string, req *Event) error {
+	op := "Created"
+	prefix := fmt.Sprintf("foo.%s.", user)
+	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	f.publish(ctx, op, topic, req)
+	return nil
+}
+
+// PublishedCreated returns the requests published with PublishCreated.
+func (f *EventsFake) PublishedCreated() []*Event {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var reqs []*Event
+	for _, msg := range f.published {
+		if msg.Op == "Created" {
+			reqs = append(reqs, msg.Req.(*Event))
+		}
+	}
+	return reqs
+}
+
+func (f *EventsFake) SubscribeCreated(user string, handler func(frugal.FContext, *Event)) (*frugal.FSubscription, error) {
+	return f.SubscribeCreatedErrorable(user, func(fctx frugal.FContext, arg *Event) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (f *EventsFake) SubscribeCreatedErrorable(user string, handler func(frugal.FContext, *Event) error) (*frugal.FSubscription, error) {
+	op := "Created"
+	prefix := fmt.Sprintf("foo.%s.", user)
+	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	return f.subscribe(topic, func(fctx frugal.FContext, req interface{}) error {
+		return handler(fctx, req.(*Event))
+	}), nil
+}
+
+func (f *EventsFake) PublishCount(ctx frugal.FContext, user string, req int64) error {
+	op := "Count"
+	prefix := fmt.Sprintf("foo.%s.", user)
+	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	f.publish(ctx, op, topic, req)
+	return nil
+}
+
+// PublishedCount returns the requests published with PublishCount.
+func (f *EventsFake) PublishedCount() []int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var reqs []int64
+	for _, msg := range f.published {
+		if msg.Op == "Count" {
+			reqs = append(reqs, msg.Req.(int64))
+		}
+	}
+	return reqs
+}
+
+func (f *EventsFake) SubscribeCount(user string, handler func(frugal.FContext, int64)) (*frugal.FSubscription, error) {
+	return f.SubscribeCountErrorable(user, func(fctx frugal.FContext, arg int64) error {
+		handler(fctx, arg)
+		return nil
+	})
+}
+
+func (f *EventsFake) SubscribeCountErrorable(user string, handler func(frugal.FContext, int64) error) (*frugal.FSubscription, error) {
+	op := "Count"
+	prefix := fmt.Sprintf("foo.%s.", user)
+	topic := fmt.Sprintf("%sEvents%s%s", prefix, delimiter, op)
+	return f.subscribe(topic, func(fctx frugal.FContext, req interface{}) error {
+		return handler(fctx, req.(int64))
+	}), nil
+}
+
+type eventsFakeSubscription struct {
+	fake    *EventsFake
+	topic   string
+	handler func(frugal.FContext, interface{}) error
+}
+
+func (s *eventsFakeSubscription) Subscribe(topic string, callback frugal.FAsyncCallback) error {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	s.topic = topic
+	s.fake.subscribers[topic] = append(s.fake.subscribers[topic], s)
+	return nil
+}
+
+func (s *eventsFakeSubscription) Unsubscribe() error {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	subs := s.fake.subscribers[s.topic]
+	for i, sub := range subs {
+		if sub == s {
+			s.fake.subscribers[s.topic] = append(subs[:i:i], subs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *eventsFakeSubscription) IsSubscribed() bool {
+	s.fake.mu.Lock()
+	defer s.fake.mu.Unlock()
+	for _, sub := range s.fake.subscribers[s.topic] {
+		if sub == s {
+			return true
+		}
+	}
+	return false
+}
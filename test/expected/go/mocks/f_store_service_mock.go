@@ -0,0 +1,291 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package mocks
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/Workiva/frugal/lib/go"
+)
+
+// FStoreMock is a programmable FStore for use in tests. Use Expect<Method> to
+// expect calls with given arguments, or set the <Method>Func fields to define
+// how each method behaves. Every call is recorded, and calls which are neither
+// expected nor handled by a Func return an error.
+type FStoreMock struct {
+	*FBaseMock
+
+	mu sync.Mutex
+
+	GetEventFunc func(ctx frugal.FContext, id int64) (*Event, error)
+	PutEventFunc func(ctx frugal.FContext, event *Event) error
+	CountsFunc   func(ctx frugal.FContext, keys []string, ids map[int32]bool) (map[string]int64, error)
+
+	getEventCalls []*FStoreMockGetEventCall
+	putEventCalls []*FStoreMockPutEventCall
+	countsCalls   []*FStoreMockCountsCall
+
+	getEventExpectations []*FStoreMockGetEventExpectation
+	putEventExpectations []*FStoreMockPutEventExpectation
+	countsExpectations   []*FStoreMockCountsExpectation
+}
+
+var _ FStore = (*FStoreMock)(nil)
+
+// NewFStoreMock creates a new FStoreMock with no method behavior set.
+func NewFStoreMock() *FStoreMock {
+	return &FStoreMock{FBaseMock: NewFBaseMock()}
+}
+
+// FStoreMockGetEventCall records a call to FStoreMock.GetEvent.
+type FStoreMockGetEventCall struct {
+	Ctx frugal.FContext
+	ID  int64
+}
+
+func (m *FStoreMock) GetEvent(ctx frugal.FContext, id int64) (r *Event, err error) {
+	m.mu.Lock()
+	m.getEventCalls = append(m.getEventCalls, &FStoreMockGetEventCall{
+		Ctx: ctx,
+		ID:  id,
+	})
+	for _, e := range m.getEventExpectations {
+		if e.calls < e.times && reflect.DeepEqual(e.args.ID, id) {
+			e.calls++
+			m.mu.Unlock()
+			return e.r, e.err
+		}
+	}
+	fn := m.GetEventFunc
+	m.mu.Unlock()
+	if fn == nil {
+		return r, fmt.Errorf("FStoreMock: unexpected call to GetEvent")
+	}
+	return fn(ctx, id)
+}
+
+// GetEventCalls returns the calls made to GetEvent in the order they were made.
+func (m *FStoreMock) GetEventCalls() []*FStoreMockGetEventCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*FStoreMockGetEventCall(nil), m.getEventCalls...)
+}
+
+// FStoreMockGetEventExpectation is an expected call to FStoreMock.GetEvent.
+type FStoreMockGetEventExpectation struct {
+	mu    *sync.Mutex
+	args  *FStoreMockGetEventCall
+	r     *Event
+	err   error
+	times int
+	calls int
+}
+
+// ExpectGetEvent expects a call to GetEvent with the given arguments. The call
+// is expected once unless changed with Times and takes precedence over
+// GetEventFunc.
+func (m *FStoreMock) ExpectGetEvent(id int64) *FStoreMockGetEventExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &FStoreMockGetEventExpectation{mu: &m.mu, times: 1, args: &FStoreMockGetEventCall{
+		ID: id,
+	}}
+	m.getEventExpectations = append(m.getEventExpectations, e)
+	return e
+}
+
+// Return sets the results of the expected call.
+func (e *FStoreMockGetEventExpectation) Return(r *Event, err error) *FStoreMockGetEventExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.r, e.err = r, err
+	return e
+}
+
+// Times sets the number of times the call is expected.
+func (e *FStoreMockGetEventExpectation) Times(n int) *FStoreMockGetEventExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.times = n
+	return e
+}
+
+// FStoreMockPutEventCall records a call to FStoreMock.PutEvent.
+type FStoreMockPutEventCall struct {
+	Ctx   frugal.FContext
+	Event *Event
+}
+
+func (m *FStoreMock) PutEvent(ctx frugal.FContext, event *Event) (err error) {
+	m.mu.Lock()
+	m.putEventCalls = append(m.putEventCalls, &FStoreMockPutEventCall{
+		Ctx:   ctx,
+		Event: event,
+	})
+	for _, e := range m.putEventExpectations {
+		if e.calls < e.times && reflect.DeepEqual(e.args.Event, event) {
+			e.calls++
+			m.mu.Unlock()
+			return e.err
+		}
+	}
+	fn := m.PutEventFunc
+	m.mu.Unlock()
+	if fn == nil {
+		return fmt.Errorf("FStoreMock: unexpected call to PutEvent")
+	}
+	return fn(ctx, event)
+}
+
+// PutEventCalls returns the calls made to PutEvent in the order they were made.
+func (m *FStoreMock) PutEventCalls() []*FStoreMockPutEventCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*FStoreMockPutEventCall(nil), m.putEventCalls...)
+}
+
+// FStoreMockPutEventExpectation is an expected call to FStoreMock.PutEvent.
+type FStoreMockPutEventExpectation struct {
+	mu    *sync.Mutex
+	args  *FStoreMockPutEventCall
+	err   error
+	times int
+	calls int
+}
+
+// ExpectPutEvent expects a call to PutEvent with the given arguments. The call
+// is expected once unless changed with Times and takes precedence over
+// PutEventFunc.
+func (m *FStoreMock) ExpectPutEvent(event *Event) *FStoreMockPutEventExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &FStoreMockPutEventExpectation{mu: &m.mu, times: 1, args: &FStoreMockPutEventCall{
+		Event: event,
+	}}
+	m.putEventExpectations = append(m.putEventExpectations, e)
+	return e
+}
+
+// Return sets the results of the expected call.
+func (e *FStoreMockPutEventExpectation) Return(err error) *FStoreMockPutEventExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+	return e
+}
+
+// Times sets the number of times the call is expected.
+func (e *FStoreMockPutEventExpectation) Times(n int) *FStoreMockPutEventExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.times = n
+	return e
+}
+
+// FStoreMockCountsCall records a call to FStoreMock.Counts.
+type FStoreMockCountsCall struct {
+	Ctx  frugal.FContext
+	Keys []string
+	Ids  map[int32]bool
+}
+
+func (m *FStoreMock) Counts(ctx frugal.FContext, keys []string, ids map[int32]bool) (r map[string]int64, err error) {
+	m.mu.Lock()
+	m.countsCalls = append(m.countsCalls, &FStoreMockCountsCall{
+		Ctx:  ctx,
+		Keys: keys,
+		Ids:  ids,
+	})
+	for _, e := range m.countsExpectations {
+		if e.calls < e.times && reflect.DeepEqual(e.args.Keys, keys) && reflect.DeepEqual(e.args.Ids, ids) {
+			e.calls++
+			m.mu.Unlock()
+			return e.r, e.err
+		}
+	}
+	fn := m.CountsFunc
+	m.mu.Unlock()
+	if fn == nil {
+		return r, fmt.Errorf("FStoreMock: unexpected call to Counts")
+	}
+	return fn(ctx, keys, ids)
+}
+
+// CountsCalls returns the calls made to Counts in the order they were made.
+func (m *FStoreMock) CountsCalls() []*FStoreMockCountsCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*FStoreMockCountsCall(nil), m.countsCalls...)
+}
+
+// FStoreMockCountsExpectation is an expected call to FStoreMock.Counts.
+type FStoreMockCountsExpectation struct {
+	mu    *sync.Mutex
+	args  *FStoreMockCountsCall
+	r     map[string]int64
+	err   error
+	times int
+	calls int
+}
+
+// ExpectCounts expects a call to Counts with the given arguments. The call
+// is expected once unless changed with Times and takes precedence over
+// CountsFunc.
+func (m *FStoreMock) ExpectCounts(keys []string, ids map[int32]bool) *FStoreMockCountsExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := &FStoreMockCountsExpectation{mu: &m.mu, times: 1, args: &FStoreMockCountsCall{
+		Keys: keys,
+		Ids:  ids,
+	}}
+	m.countsExpectations = append(m.countsExpectations, e)
+	return e
+}
+
+// Return sets the results of the expected call.
+func (e *FStoreMockCountsExpectation) Return(r map[string]int64, err error) *FStoreMockCountsExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.r, e.err = r, err
+	return e
+}
+
+// Times sets the number of times the call is expected.
+func (e *FStoreMockCountsExpectation) Times(n int) *FStoreMockCountsExpectation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.times = n
+	return e
+}
+
+// AssertExpectations reports an error to t for each expected call which was
+// not made the expected number of times and returns whether all were.
+func (m *FStoreMock) AssertExpectations(t interface {
+	Errorf(format string, args ...interface{})
+}) bool {
+	ok := m.FBaseMock.AssertExpectations(t)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.getEventExpectations {
+		if e.calls != e.times {
+			t.Errorf("FStoreMock: expected %d calls to GetEvent(%v), got %d", e.times, e.args.ID, e.calls)
+			ok = false
+		}
+	}
+	for _, e := range m.putEventExpectations {
+		if e.calls != e.times {
+			t.Errorf("FStoreMock: expected %d calls to PutEvent(%v), got %d", e.times, e.args.Event, e.calls)
+			ok = false
+		}
+	}
+	for _, e := range m.countsExpectations {
+		if e.calls != e.times {
+			t.Errorf("FStoreMock: expected %d calls to Counts(%v, %v), got %d", e.times, e.args.Keys, e.args.Ids, e.calls)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -78,6 +78,23 @@ func NewFFooClient(provider *frugal.FServiceProvider, middleware ...frugal.Servi
 	return client
 }
 
+// Annotations returns the IDL annotations of the service methods keyed by
+// method name.
+func (f *FFooClient) Annotations() map[string]map[string]string {
+	annotations := make(map[string]map[string]string)
+	if base, ok := interface{}(f.FBaseFooClient).(interface {
+		Annotations() map[string]map[string]string
+	}); ok {
+		for method, methodAnnotations := range base.Annotations() {
+			annotations[method] = methodAnnotations
+		}
+	}
+	annotations["ping"] = map[string]string{
+		"deprecated": "don't use this; use \"something else\"",
+	}
+	return annotations
+}
+
 // Ping the server.
 // Deprecated: don't use this; use "something else"
 func (f *FFooClient) Ping(ctx frugal.FContext) (err error) {
@@ -145,6 +145,41 @@ func (p HealthCondition) String() string {
 	return "<UNSET>"
 }
 
+// HealthConditionValues returns all values of HealthCondition in declaration order.
+func HealthConditionValues() []HealthCondition {
+	return []HealthCondition{
+		HealthCondition_PASS,
+		HealthCondition_WARN,
+		HealthCondition_FAIL,
+		HealthCondition_UNKNOWN,
+	}
+}
+
+// HealthConditionNames returns the names of all values of HealthCondition in declaration order.
+func HealthConditionNames() []string {
+	return []string{
+		"PASS",
+		"WARN",
+		"FAIL",
+		"UNKNOWN",
+	}
+}
+
+// IsValid returns true if p is a declared value of HealthCondition.
+func (p HealthCondition) IsValid() bool {
+	switch p {
+	case HealthCondition_PASS:
+		return true
+	case HealthCondition_WARN:
+		return true
+	case HealthCondition_FAIL:
+		return true
+	case HealthCondition_UNKNOWN:
+		return true
+	}
+	return false
+}
+
 func HealthConditionFromString(s string) (HealthCondition, error) {
 	switch s {
 	case "PASS":
@@ -217,6 +252,49 @@ func (p ItsAnEnum) String() string {
 	return "<UNSET>"
 }
 
+// ItsAnEnumValues returns all values of ItsAnEnum in declaration order.
+func ItsAnEnumValues() []ItsAnEnum {
+	return []ItsAnEnum{
+		ItsAnEnum_FIRST,
+		ItsAnEnum_SECOND,
+		ItsAnEnum_THIRD,
+		ItsAnEnum_fourth,
+		ItsAnEnum_Fifth,
+		ItsAnEnum_sIxItH,
+	}
+}
+
+// ItsAnEnumNames returns the names of all values of ItsAnEnum in declaration order.
+func ItsAnEnumNames() []string {
+	return []string{
+		"FIRST",
+		"SECOND",
+		"THIRD",
+		"fourth",
+		"Fifth",
+		"sIxItH",
+	}
+}
+
+// IsValid returns true if p is a declared value of ItsAnEnum.
+func (p ItsAnEnum) IsValid() bool {
+	switch p {
+	case ItsAnEnum_FIRST:
+		return true
+	case ItsAnEnum_SECOND:
+		return true
+	case ItsAnEnum_THIRD:
+		return true
+	case ItsAnEnum_fourth:
+		return true
+	case ItsAnEnum_Fifth:
+		return true
+	case ItsAnEnum_sIxItH:
+		return true
+	}
+	return false
+}
+
 func ItsAnEnumFromString(s string) (ItsAnEnum, error) {
 	switch s {
 	case "FIRST":
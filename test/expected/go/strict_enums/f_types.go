@@ -0,0 +1,509 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package strict_enums
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+
+var GoUnusedProtection__ int
+
+func init() {
+}
+
+type Paint Color
+type Color int64
+
+const (
+	Color_RED   Color = 1
+	Color_GREEN Color = 2
+)
+
+func (p Color) String() string {
+	switch p {
+	case Color_RED:
+		return "RED"
+	case Color_GREEN:
+		return "GREEN"
+	}
+	return "<UNSET>"
+}
+
+// ColorValues returns all values of Color in declaration order.
+func ColorValues() []Color {
+	return []Color{
+		Color_RED,
+		Color_GREEN,
+	}
+}
+
+// ColorNames returns the names of all values of Color in declaration order.
+func ColorNames() []string {
+	return []string{
+		"RED",
+		"GREEN",
+	}
+}
+
+// IsValid returns true if p is a declared value of Color.
+func (p Color) IsValid() bool {
+	switch p {
+	case Color_RED:
+		return true
+	case Color_GREEN:
+		return true
+	}
+	return false
+}
+
+func ColorFromString(s string) (Color, error) {
+	switch s {
+	case "RED":
+		return Color_RED, nil
+	case "GREEN":
+		return Color_GREEN, nil
+	}
+	return Color(0), fmt.Errorf("not a valid Color string")
+}
+
+func (p Color) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Color) UnmarshalText(text []byte) error {
+	q, err := ColorFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+func (p *Color) Scan(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return errors.New("Scan value is not int64")
+	}
+	*p = Color(v)
+	return nil
+}
+
+func (p *Color) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
+type Status int64
+
+const (
+	Status_UNKNOWN  Status = 0
+	Status_ACTIVE   Status = 1
+	Status_DISABLED Status = 2
+)
+
+func (p Status) String() string {
+	switch p {
+	case Status_UNKNOWN:
+		return "UNKNOWN"
+	case Status_ACTIVE:
+		return "ACTIVE"
+	case Status_DISABLED:
+		return "DISABLED"
+	}
+	return "<UNSET>"
+}
+
+// StatusValues returns all values of Status in declaration order.
+func StatusValues() []Status {
+	return []Status{
+		Status_UNKNOWN,
+		Status_ACTIVE,
+		Status_DISABLED,
+	}
+}
+
+// StatusNames returns the names of all values of Status in declaration order.
+func StatusNames() []string {
+	return []string{
+		"UNKNOWN",
+		"ACTIVE",
+		"DISABLED",
+	}
+}
+
+// IsValid returns true if p is a declared value of Status.
+func (p Status) IsValid() bool {
+	switch p {
+	case Status_UNKNOWN:
+		return true
+	case Status_ACTIVE:
+		return true
+	case Status_DISABLED:
+		return true
+	}
+	return false
+}
+
+func StatusFromString(s string) (Status, error) {
+	switch s {
+	case "UNKNOWN":
+		return Status_UNKNOWN, nil
+	case "ACTIVE":
+		return Status_ACTIVE, nil
+	case "DISABLED":
+		return Status_DISABLED, nil
+	}
+	return Status(0), fmt.Errorf("not a valid Status string")
+}
+
+func (p Status) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Status) UnmarshalText(text []byte) error {
+	q, err := StatusFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+func (p *Status) Scan(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return errors.New("Scan value is not int64")
+	}
+	*p = Status(v)
+	return nil
+}
+
+func (p *Status) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
+type Widget struct {
+	Color    Color            `thrift:"color,1" db:"color" json:"color"`
+	Status   *Status          `thrift:"status,2" db:"status" json:"status,omitempty"`
+	Paint    Paint            `thrift:"paint,3" db:"paint" json:"paint"`
+	History  []Status         `thrift:"history,4" db:"history" json:"history"`
+	Statuses map[Color]Status `thrift:"statuses,5" db:"statuses" json:"statuses"`
+}
+
+func NewWidget() *Widget {
+	return &Widget{}
+}
+
+func (p *Widget) GetColor() Color {
+	return p.Color
+}
+
+var Widget_Status_DEFAULT Status
+
+func (p *Widget) IsSetStatus() bool {
+	return p.Status != nil
+}
+
+func (p *Widget) GetStatus() Status {
+	if !p.IsSetStatus() {
+		return Widget_Status_DEFAULT
+	}
+	return *p.Status
+}
+
+func (p *Widget) GetPaint() Paint {
+	return p.Paint
+}
+
+func (p *Widget) GetHistory() []Status {
+	return p.History
+}
+
+func (p *Widget) GetStatuses() map[Color]Status {
+	return p.Statuses
+}
+
+func (p *Widget) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Widget) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := Color(v)
+		if !temp.IsValid() {
+			return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("invalid value %d for enum Color", v))
+		}
+		p.Color = temp
+	}
+	return nil
+}
+
+func (p *Widget) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := Status(v)
+		if !temp.IsValid() {
+			temp = Status_UNKNOWN
+		}
+		p.Status = &temp
+	}
+	return nil
+}
+
+func (p *Widget) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		temp := Paint(v)
+		if !Color(temp).IsValid() {
+			return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("invalid value %d for enum Color", v))
+		}
+		p.Paint = temp
+	}
+	return nil
+}
+
+func (p *Widget) ReadField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.History = make([]Status, 0, size)
+	for i := 0; i < size; i++ {
+		var elem0 Status
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Status(v)
+			if !temp.IsValid() {
+				temp = Status_UNKNOWN
+			}
+			elem0 = temp
+		}
+		p.History = append(p.History, elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Widget) ReadField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Statuses = make(map[Color]Status, size)
+	for i := 0; i < size; i++ {
+		var elem1 Color
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Color(v)
+			if !temp.IsValid() {
+				return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("invalid value %d for enum Color", v))
+			}
+			elem1 = temp
+		}
+		var elem2 Status
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			temp := Status(v)
+			if !temp.IsValid() {
+				temp = Status_UNKNOWN
+			}
+			elem2 = temp
+		}
+		(p.Statuses)[elem1] = elem2
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Widget) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Widget"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Widget) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("color", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:color: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Color)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.color (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:color: ", p), err)
+	}
+	return nil
+}
+
+func (p *Widget) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetStatus() {
+		if err := oprot.WriteFieldBegin("status", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Status)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Widget) writeField3(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("paint", thrift.I32, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:paint: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Paint)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.paint (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:paint: ", p), err)
+	}
+	return nil
+}
+
+func (p *Widget) writeField4(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("history", thrift.LIST, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:history: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.I32, len(p.History)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.History {
+		if err := oprot.WriteI32(int32(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:history: ", p), err)
+	}
+	return nil
+}
+
+func (p *Widget) writeField5(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("statuses", thrift.MAP, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:statuses: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.I32, thrift.I32, len(p.Statuses)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Statuses {
+		if err := oprot.WriteI32(int32(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:statuses: ", p), err)
+	}
+	return nil
+}
+
+func (p *Widget) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Widget(%+v)", *p)
+}
@@ -0,0 +1,2162 @@
+// Autogenerated by Frugal Compiler (2.23.0)
+// DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+
+package values
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"io"
+	"math"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	"github.com/Workiva/frugal/test/out/values/actual_base/golang"
+)
+
+// (needed to ensure safety because of naive import list construction.)
+var _ = thrift.ZERO
+var _ = fmt.Printf
+var _ = bytes.Equal
+
+var _ = golang.GoUnusedProtection__
+var GoUnusedProtection__ int
+
+func frugalHashInt(h hash.Hash64, v int64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(v))
+	h.Write(b[:])
+}
+
+func frugalHashBool(h hash.Hash64, v bool) {
+	if v {
+		frugalHashInt(h, 1)
+	} else {
+		frugalHashInt(h, 0)
+	}
+}
+
+func frugalHashDouble(h hash.Hash64, v float64) {
+	if v == 0 {
+		// Hash negative zero the same as zero since they are equal.
+		v = 0
+	}
+	frugalHashInt(h, int64(math.Float64bits(v)))
+}
+
+func frugalHashString(h hash.Hash64, v string) {
+	frugalHashInt(h, int64(len(v)))
+	io.WriteString(h, v)
+}
+
+func frugalHashBytes(h hash.Hash64, v []byte) {
+	frugalHashInt(h, int64(len(v)))
+	h.Write(v)
+}
+
+func init() {
+}
+
+type Blob []byte
+type Names []string
+type Color int64
+
+const (
+	Color_RED   Color = 1
+	Color_GREEN Color = 2
+)
+
+func (p Color) String() string {
+	switch p {
+	case Color_RED:
+		return "RED"
+	case Color_GREEN:
+		return "GREEN"
+	}
+	return "<UNSET>"
+}
+
+// ColorValues returns all values of Color in declaration order.
+func ColorValues() []Color {
+	return []Color{
+		Color_RED,
+		Color_GREEN,
+	}
+}
+
+// ColorNames returns the names of all values of Color in declaration order.
+func ColorNames() []string {
+	return []string{
+		"RED",
+		"GREEN",
+	}
+}
+
+// IsValid returns true if p is a declared value of Color.
+func (p Color) IsValid() bool {
+	switch p {
+	case Color_RED:
+		return true
+	case Color_GREEN:
+		return true
+	}
+	return false
+}
+
+func ColorFromString(s string) (Color, error) {
+	switch s {
+	case "RED":
+		return Color_RED, nil
+	case "GREEN":
+		return Color_GREEN, nil
+	}
+	return Color(0), fmt.Errorf("not a valid Color string")
+}
+
+func (p Color) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Color) UnmarshalText(text []byte) error {
+	q, err := ColorFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*p = q
+	return nil
+}
+
+func (p *Color) Scan(value interface{}) error {
+	v, ok := value.(int64)
+	if !ok {
+		return errors.New("Scan value is not int64")
+	}
+	*p = Color(v)
+	return nil
+}
+
+func (p *Color) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return int64(*p), nil
+}
+
+type Point struct {
+	X int32 `thrift:"x,1" db:"x" json:"x"`
+	Y int32 `thrift:"y,2" db:"y" json:"y"`
+}
+
+func NewPoint() *Point {
+	return &Point{}
+}
+
+func (p *Point) GetX() int32 {
+	return p.X
+}
+
+func (p *Point) GetY() int32 {
+	return p.Y
+}
+
+func (p *Point) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.X = v
+	}
+	return nil
+}
+
+func (p *Point) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Y = v
+	}
+	return nil
+}
+
+func (p *Point) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("point"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Point) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("x", thrift.I32, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:x: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.X)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.x (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:x: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) writeField2(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("y", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:y: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Y)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.y (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:y: ", p), err)
+	}
+	return nil
+}
+
+func (p *Point) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Point(%+v)", *p)
+}
+
+func (p *Point) Equals(other *Point) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if p.X != other.X {
+		return false
+	}
+	if p.Y != other.Y {
+		return false
+	}
+	return true
+}
+
+func (p *Point) DeepCopy() *Point {
+	if p == nil {
+		return nil
+	}
+	c := new(Point)
+	c.X = p.X
+	c.Y = p.Y
+	return c
+}
+
+func (p *Point) Hash() uint64 {
+	if p == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	frugalHashInt(h, 1)
+	frugalHashInt(h, int64(p.X))
+	frugalHashInt(h, 2)
+	frugalHashInt(h, int64(p.Y))
+	return h.Sum64()
+}
+
+type Shape struct {
+	Name    string                                `thrift:"name,1,required" db:"name" json:"name"`
+	ID      *int64                                `thrift:"id,2" db:"id" json:"id,omitempty"`
+	Area    float64                               `thrift:"area,3" db:"area" json:"area,omitempty"`
+	Data    []byte                                `thrift:"data,4" db:"data" json:"data"`
+	Raw     Blob                                  `thrift:"raw,5" db:"raw" json:"raw,omitempty"`
+	Points  []*Point                              `thrift:"points,6" db:"points" json:"points"`
+	Tags    map[string]bool                       `thrift:"tags,7" db:"tags" json:"tags"`
+	Corners map[*Point]bool                       `thrift:"corners,8" db:"corners" json:"corners"`
+	Paths   map[string][]*Point                   `thrift:"paths,9" db:"paths" json:"paths"`
+	Labels  map[*Point]string                     `thrift:"labels,10" db:"labels" json:"labels"`
+	Sizes   *[]int32                              `thrift:"sizes,11" db:"sizes" json:"sizes,omitempty"`
+	Aliases Names                                 `thrift:"aliases,12" db:"aliases" json:"aliases,omitempty"`
+	Fill    Color                                 `thrift:"fill,13" db:"fill" json:"fill"`
+	Border  *Color                                `thrift:"border,14" db:"border" json:"border,omitempty"`
+	Owner   *golang.Thing                         `thrift:"owner,15" db:"owner" json:"owner"`
+	Health  []map[golang.BaseHealthCondition]bool `thrift:"health,16" db:"health" json:"health"`
+	Visible bool                                  `thrift:"visible,17" db:"visible" json:"visible"`
+	Chunks  map[int16][]byte                      `thrift:"chunks,18" db:"chunks" json:"chunks"`
+}
+
+func NewShape() *Shape {
+	return &Shape{
+		Area: 1.5,
+	}
+}
+
+func (p *Shape) GetName() string {
+	return p.Name
+}
+
+var Shape_ID_DEFAULT int64
+
+func (p *Shape) IsSetID() bool {
+	return p.ID != nil
+}
+
+func (p *Shape) GetID() int64 {
+	if !p.IsSetID() {
+		return Shape_ID_DEFAULT
+	}
+	return *p.ID
+}
+
+var Shape_Area_DEFAULT float64 = 1.5
+
+func (p *Shape) IsSetArea() bool {
+	return p.Area != Shape_Area_DEFAULT
+}
+
+func (p *Shape) GetArea() float64 {
+	return p.Area
+}
+
+func (p *Shape) GetData() []byte {
+	return p.Data
+}
+
+var Shape_Raw_DEFAULT Blob
+
+func (p *Shape) IsSetRaw() bool {
+	return p.Raw != nil
+}
+
+func (p *Shape) GetRaw() Blob {
+	return p.Raw
+}
+
+func (p *Shape) GetPoints() []*Point {
+	return p.Points
+}
+
+func (p *Shape) GetTags() map[string]bool {
+	return p.Tags
+}
+
+func (p *Shape) GetCorners() map[*Point]bool {
+	return p.Corners
+}
+
+func (p *Shape) GetPaths() map[string][]*Point {
+	return p.Paths
+}
+
+func (p *Shape) GetLabels() map[*Point]string {
+	return p.Labels
+}
+
+var Shape_Sizes_DEFAULT []int32 = []int32{
+	1,
+	2,
+}
+
+func (p *Shape) IsSetSizes() bool {
+	return p.Sizes != nil
+}
+
+func (p *Shape) GetSizes() []int32 {
+	if !p.IsSetSizes() {
+		return Shape_Sizes_DEFAULT
+	}
+	return *p.Sizes
+}
+
+var Shape_Aliases_DEFAULT Names
+
+func (p *Shape) IsSetAliases() bool {
+	return p.Aliases != nil
+}
+
+func (p *Shape) GetAliases() Names {
+	return p.Aliases
+}
+
+func (p *Shape) GetFill() Color {
+	return p.Fill
+}
+
+var Shape_Border_DEFAULT Color
+
+func (p *Shape) IsSetBorder() bool {
+	return p.Border != nil
+}
+
+func (p *Shape) GetBorder() Color {
+	if !p.IsSetBorder() {
+		return Shape_Border_DEFAULT
+	}
+	return *p.Border
+}
+
+var Shape_Owner_DEFAULT *golang.Thing
+
+func (p *Shape) IsSetOwner() bool {
+	return p.Owner != nil
+}
+
+func (p *Shape) GetOwner() *golang.Thing {
+	if !p.IsSetOwner() {
+		return Shape_Owner_DEFAULT
+	}
+	return p.Owner
+}
+
+func (p *Shape) GetHealth() []map[golang.BaseHealthCondition]bool {
+	return p.Health
+}
+
+func (p *Shape) GetVisible() bool {
+	return p.Visible
+}
+
+func (p *Shape) GetChunks() map[int16][]byte {
+	return p.Chunks
+}
+
+func (p *Shape) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	issetName := false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetName = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.ReadField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.ReadField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.ReadField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.ReadField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.ReadField13(iprot); err != nil {
+				return err
+			}
+		case 14:
+			if err := p.ReadField14(iprot); err != nil {
+				return err
+			}
+		case 15:
+			if err := p.ReadField15(iprot); err != nil {
+				return err
+			}
+		case 16:
+			if err := p.ReadField16(iprot); err != nil {
+				return err
+			}
+		case 17:
+			if err := p.ReadField17(iprot); err != nil {
+				return err
+			}
+		case 18:
+			if err := p.ReadField18(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field 'Name' is not present in struct 'shape'"))
+	}
+	return nil
+}
+
+func (p *Shape) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Name = v
+	}
+	return nil
+}
+
+func (p *Shape) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.ID = &v
+	}
+	return nil
+}
+
+func (p *Shape) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Area = v
+	}
+	return nil
+}
+
+func (p *Shape) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Data = v
+	}
+	return nil
+}
+
+func (p *Shape) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		temp := Blob(v)
+		p.Raw = temp
+	}
+	return nil
+}
+
+func (p *Shape) ReadField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Points = make([]*Point, 0, size)
+	for i := 0; i < size; i++ {
+		elem0 := NewPoint()
+		if err := elem0.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem0), err)
+		}
+		p.Points = append(p.Points, elem0)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField7(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadSetBegin()
+	if err != nil {
+		return thrift.PrependError("error reading set begin: ", err)
+	}
+	p.Tags = make(map[string]bool, size)
+	for i := 0; i < size; i++ {
+		var elem1 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem1 = v
+		}
+		(p.Tags)[elem1] = true
+	}
+	if err := iprot.ReadSetEnd(); err != nil {
+		return thrift.PrependError("error reading set end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField8(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadSetBegin()
+	if err != nil {
+		return thrift.PrependError("error reading set begin: ", err)
+	}
+	p.Corners = make(map[*Point]bool, size)
+	for i := 0; i < size; i++ {
+		elem2 := NewPoint()
+		if err := elem2.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem2), err)
+		}
+		(p.Corners)[elem2] = true
+	}
+	if err := iprot.ReadSetEnd(); err != nil {
+		return thrift.PrependError("error reading set end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField9(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Paths = make(map[string][]*Point, size)
+	for i := 0; i < size; i++ {
+		var elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem3 = v
+		}
+		_, size, err := iprot.ReadListBegin()
+		if err != nil {
+			return thrift.PrependError("error reading list begin: ", err)
+		}
+		elem4 := make([]*Point, 0, size)
+		for i := 0; i < size; i++ {
+			elem5 := NewPoint()
+			if err := elem5.Read(iprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem5), err)
+			}
+			elem4 = append(elem4, elem5)
+		}
+		if err := iprot.ReadListEnd(); err != nil {
+			return thrift.PrependError("error reading list end: ", err)
+		}
+		(p.Paths)[elem3] = elem4
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField10(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Labels = make(map[*Point]string, size)
+	for i := 0; i < size; i++ {
+		elem6 := NewPoint()
+		if err := elem6.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", elem6), err)
+		}
+		var elem7 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem7 = v
+		}
+		(p.Labels)[elem6] = elem7
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField11(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	temp := make([]int32, 0, size)
+	p.Sizes = &temp
+	for i := 0; i < size; i++ {
+		var elem8 int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem8 = v
+		}
+		*p.Sizes = append(*p.Sizes, elem8)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField12(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Aliases = make(Names, 0, size)
+	for i := 0; i < size; i++ {
+		var elem9 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem9 = v
+		}
+		p.Aliases = append(p.Aliases, elem9)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		temp := Color(v)
+		p.Fill = temp
+	}
+	return nil
+}
+
+func (p *Shape) ReadField14(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 14: ", err)
+	} else {
+		temp := Color(v)
+		p.Border = &temp
+	}
+	return nil
+}
+
+func (p *Shape) ReadField15(iprot thrift.TProtocol) error {
+	p.Owner = golang.NewThing()
+	if err := p.Owner.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Owner), err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField16(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Health = make([]map[golang.BaseHealthCondition]bool, 0, size)
+	for i := 0; i < size; i++ {
+		_, size, err := iprot.ReadSetBegin()
+		if err != nil {
+			return thrift.PrependError("error reading set begin: ", err)
+		}
+		elem10 := make(map[golang.BaseHealthCondition]bool, size)
+		for i := 0; i < size; i++ {
+			var elem11 golang.BaseHealthCondition
+			if v, err := iprot.ReadI32(); err != nil {
+				return thrift.PrependError("error reading field 0: ", err)
+			} else {
+				temp := golang.BaseHealthCondition(v)
+				elem11 = temp
+			}
+			(elem10)[elem11] = true
+		}
+		if err := iprot.ReadSetEnd(); err != nil {
+			return thrift.PrependError("error reading set end: ", err)
+		}
+		p.Health = append(p.Health, elem10)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) ReadField17(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 17: ", err)
+	} else {
+		p.Visible = v
+	}
+	return nil
+}
+
+func (p *Shape) ReadField18(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	p.Chunks = make(map[int16][]byte, size)
+	for i := 0; i < size; i++ {
+		var elem12 int16
+		if v, err := iprot.ReadI16(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem12 = v
+		}
+		var elem13 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem13 = v
+		}
+		(p.Chunks)[elem12] = elem13
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("shape"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField14(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField15(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField16(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField17(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField18(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetID() {
+		if err := oprot.WriteFieldBegin("id", thrift.I64, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:id: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.ID)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.id (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:id: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField3(oprot thrift.TProtocol) error {
+	if p.IsSetArea() {
+		if err := oprot.WriteFieldBegin("area", thrift.DOUBLE, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:area: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(p.Area)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.area (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:area: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField4(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("data", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:data: ", p), err)
+	}
+	if err := oprot.WriteBinary([]byte(p.Data)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.data (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:data: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField5(oprot thrift.TProtocol) error {
+	if p.IsSetRaw() {
+		if err := oprot.WriteFieldBegin("raw", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:raw: ", p), err)
+		}
+		if err := oprot.WriteBinary([]byte(p.Raw)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.raw (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:raw: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField6(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("points", thrift.LIST, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:points: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Points)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Points {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:points: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField7(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("tags", thrift.SET, 7); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:tags: ", p), err)
+	}
+	if err := oprot.WriteSetBegin(thrift.STRING, len(p.Tags)); err != nil {
+		return thrift.PrependError("error writing set begin: ", err)
+	}
+	for v, _ := range p.Tags {
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteSetEnd(); err != nil {
+		return thrift.PrependError("error writing set end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 7:tags: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField8(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("corners", thrift.SET, 8); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:corners: ", p), err)
+	}
+	if err := oprot.WriteSetBegin(thrift.STRUCT, len(p.Corners)); err != nil {
+		return thrift.PrependError("error writing set begin: ", err)
+	}
+	for v, _ := range p.Corners {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteSetEnd(); err != nil {
+		return thrift.PrependError("error writing set end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 8:corners: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField9(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("paths", thrift.MAP, 9); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:paths: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRING, thrift.LIST, len(p.Paths)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Paths {
+		if err := oprot.WriteString(string(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(v)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range v {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 9:paths: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField10(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("labels", thrift.MAP, 10); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:labels: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.STRUCT, thrift.STRING, len(p.Labels)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Labels {
+		if err := k.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", k), err)
+		}
+		if err := oprot.WriteString(string(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 10:labels: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField11(oprot thrift.TProtocol) error {
+	if p.IsSetSizes() {
+		if err := oprot.WriteFieldBegin("sizes", thrift.LIST, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:sizes: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(*p.Sizes)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range *p.Sizes {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:sizes: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField12(oprot thrift.TProtocol) error {
+	if p.IsSetAliases() {
+		if err := oprot.WriteFieldBegin("aliases", thrift.LIST, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:aliases: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Aliases)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Aliases {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:aliases: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField13(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("fill", thrift.I32, 13); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:fill: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.Fill)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.fill (13) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 13:fill: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField14(oprot thrift.TProtocol) error {
+	if p.IsSetBorder() {
+		if err := oprot.WriteFieldBegin("border", thrift.I32, 14); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 14:border: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Border)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.border (14) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 14:border: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Shape) writeField15(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("owner", thrift.STRUCT, 15); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 15:owner: ", p), err)
+	}
+	if err := p.Owner.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Owner), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 15:owner: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField16(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("health", thrift.LIST, 16); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 16:health: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.SET, len(p.Health)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Health {
+		if err := oprot.WriteSetBegin(thrift.I32, len(v)); err != nil {
+			return thrift.PrependError("error writing set begin: ", err)
+		}
+		for v, _ := range v {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteSetEnd(); err != nil {
+			return thrift.PrependError("error writing set end: ", err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 16:health: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField17(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("visible", thrift.BOOL, 17); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 17:visible: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Visible)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.visible (17) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 17:visible: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) writeField18(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("chunks", thrift.MAP, 18); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 18:chunks: ", p), err)
+	}
+	if err := oprot.WriteMapBegin(thrift.I16, thrift.STRING, len(p.Chunks)); err != nil {
+		return thrift.PrependError("error writing map begin: ", err)
+	}
+	for k, v := range p.Chunks {
+		if err := oprot.WriteI16(int16(k)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+		if err := oprot.WriteBinary([]byte(v)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		}
+	}
+	if err := oprot.WriteMapEnd(); err != nil {
+		return thrift.PrependError("error writing map end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 18:chunks: ", p), err)
+	}
+	return nil
+}
+
+func (p *Shape) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Shape(%+v)", *p)
+}
+
+func (p *Shape) Equals(other *Shape) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if p.Name != other.Name {
+		return false
+	}
+	if (p.ID == nil) != (other.ID == nil) {
+		return false
+	}
+	if p.ID != nil {
+		if *p.ID != *other.ID {
+			return false
+		}
+	}
+	if p.Area != other.Area {
+		return false
+	}
+	if !bytes.Equal(p.Data, other.Data) {
+		return false
+	}
+	if (p.Raw == nil) != (other.Raw == nil) {
+		return false
+	}
+	if !bytes.Equal(p.Raw, other.Raw) {
+		return false
+	}
+	if len(p.Points) != len(other.Points) {
+		return false
+	}
+	for elem14, elem15 := range p.Points {
+		elem16 := other.Points[elem14]
+		if !elem15.Equals(elem16) {
+			return false
+		}
+	}
+	if len(p.Tags) != len(other.Tags) {
+		return false
+	}
+	for elem17 := range p.Tags {
+		if _, ok := other.Tags[elem17]; !ok {
+			return false
+		}
+	}
+	if len(p.Corners) != len(other.Corners) {
+		return false
+	}
+	for elem18 := range p.Corners {
+		elem20 := false
+		for elem19 := range other.Corners {
+			if elem18.Equals(elem19) {
+				elem20 = true
+				break
+			}
+		}
+		if !elem20 {
+			return false
+		}
+	}
+	if len(p.Paths) != len(other.Paths) {
+		return false
+	}
+	for elem21, elem22 := range p.Paths {
+		elem23, ok := other.Paths[elem21]
+		if !ok {
+			return false
+		}
+		if len(elem22) != len(elem23) {
+			return false
+		}
+		for elem24, elem25 := range elem22 {
+			elem26 := elem23[elem24]
+			if !elem25.Equals(elem26) {
+				return false
+			}
+		}
+	}
+	if len(p.Labels) != len(other.Labels) {
+		return false
+	}
+	for elem27, elem28 := range p.Labels {
+		elem31 := false
+		for elem30, elem29 := range other.Labels {
+			if !elem27.Equals(elem30) {
+				continue
+			}
+			if elem28 != elem29 {
+				return false
+			}
+			elem31 = true
+			break
+		}
+		if !elem31 {
+			return false
+		}
+	}
+	if (p.Sizes == nil) != (other.Sizes == nil) {
+		return false
+	}
+	if p.Sizes != nil {
+		if len(*p.Sizes) != len(*other.Sizes) {
+			return false
+		}
+		for elem32, elem33 := range *p.Sizes {
+			elem34 := (*other.Sizes)[elem32]
+			if elem33 != elem34 {
+				return false
+			}
+		}
+	}
+	if (p.Aliases == nil) != (other.Aliases == nil) {
+		return false
+	}
+	if len(p.Aliases) != len(other.Aliases) {
+		return false
+	}
+	for elem35, elem36 := range p.Aliases {
+		elem37 := other.Aliases[elem35]
+		if elem36 != elem37 {
+			return false
+		}
+	}
+	if p.Fill != other.Fill {
+		return false
+	}
+	if (p.Border == nil) != (other.Border == nil) {
+		return false
+	}
+	if p.Border != nil {
+		if *p.Border != *other.Border {
+			return false
+		}
+	}
+	if !p.Owner.Equals(other.Owner) {
+		return false
+	}
+	if len(p.Health) != len(other.Health) {
+		return false
+	}
+	for elem38, elem39 := range p.Health {
+		elem40 := other.Health[elem38]
+		if len(elem39) != len(elem40) {
+			return false
+		}
+		for elem41 := range elem39 {
+			if _, ok := elem40[elem41]; !ok {
+				return false
+			}
+		}
+	}
+	if p.Visible != other.Visible {
+		return false
+	}
+	if len(p.Chunks) != len(other.Chunks) {
+		return false
+	}
+	for elem42, elem43 := range p.Chunks {
+		elem44, ok := other.Chunks[elem42]
+		if !ok {
+			return false
+		}
+		if !bytes.Equal(elem43, elem44) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Shape) DeepCopy() *Shape {
+	if p == nil {
+		return nil
+	}
+	c := new(Shape)
+	c.Name = p.Name
+	if p.ID != nil {
+		var elem45 int64
+		elem45 = *p.ID
+		c.ID = &elem45
+	}
+	c.Area = p.Area
+	if p.Data != nil {
+		c.Data = append([]byte{}, p.Data...)
+	}
+	if p.Raw != nil {
+		c.Raw = append(Blob{}, p.Raw...)
+	}
+	if p.Points != nil {
+		c.Points = make([]*Point, 0, len(p.Points))
+		for _, elem46 := range p.Points {
+			var elem47 *Point
+			elem47 = elem46.DeepCopy()
+			c.Points = append(c.Points, elem47)
+		}
+	}
+	if p.Tags != nil {
+		c.Tags = make(map[string]bool, len(p.Tags))
+		for elem48 := range p.Tags {
+			c.Tags[elem48] = true
+		}
+	}
+	if p.Corners != nil {
+		c.Corners = make(map[*Point]bool, len(p.Corners))
+		for elem49 := range p.Corners {
+			var elem50 *Point
+			elem50 = elem49.DeepCopy()
+			c.Corners[elem50] = true
+		}
+	}
+	if p.Paths != nil {
+		c.Paths = make(map[string][]*Point, len(p.Paths))
+		for elem51, elem52 := range p.Paths {
+			var elem53 []*Point
+			if elem52 != nil {
+				elem53 = make([]*Point, 0, len(elem52))
+				for _, elem54 := range elem52 {
+					var elem55 *Point
+					elem55 = elem54.DeepCopy()
+					elem53 = append(elem53, elem55)
+				}
+			}
+			c.Paths[elem51] = elem53
+		}
+	}
+	if p.Labels != nil {
+		c.Labels = make(map[*Point]string, len(p.Labels))
+		for elem56, elem57 := range p.Labels {
+			var elem58 *Point
+			elem58 = elem56.DeepCopy()
+			c.Labels[elem58] = elem57
+		}
+	}
+	if p.Sizes != nil {
+		var elem59 []int32
+		if *p.Sizes != nil {
+			elem59 = append(make([]int32, 0, len(*p.Sizes)), *p.Sizes...)
+		}
+		c.Sizes = &elem59
+	}
+	if p.Aliases != nil {
+		c.Aliases = append(make(Names, 0, len(p.Aliases)), p.Aliases...)
+	}
+	c.Fill = p.Fill
+	if p.Border != nil {
+		var elem60 Color
+		elem60 = *p.Border
+		c.Border = &elem60
+	}
+	c.Owner = p.Owner.DeepCopy()
+	if p.Health != nil {
+		c.Health = make([]map[golang.BaseHealthCondition]bool, 0, len(p.Health))
+		for _, elem61 := range p.Health {
+			var elem62 map[golang.BaseHealthCondition]bool
+			if elem61 != nil {
+				elem62 = make(map[golang.BaseHealthCondition]bool, len(elem61))
+				for elem63 := range elem61 {
+					elem62[elem63] = true
+				}
+			}
+			c.Health = append(c.Health, elem62)
+		}
+	}
+	c.Visible = p.Visible
+	if p.Chunks != nil {
+		c.Chunks = make(map[int16][]byte, len(p.Chunks))
+		for elem64, elem65 := range p.Chunks {
+			var elem66 []byte
+			if elem65 != nil {
+				elem66 = append([]byte{}, elem65...)
+			}
+			c.Chunks[elem64] = elem66
+		}
+	}
+	return c
+}
+
+func (p *Shape) Hash() uint64 {
+	if p == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	frugalHashInt(h, 1)
+	frugalHashString(h, string(p.Name))
+	frugalHashInt(h, 2)
+	frugalHashBool(h, p.ID != nil)
+	if p.ID != nil {
+		frugalHashInt(h, int64(*p.ID))
+	}
+	frugalHashInt(h, 3)
+	frugalHashDouble(h, float64(p.Area))
+	frugalHashInt(h, 4)
+	frugalHashBytes(h, p.Data)
+	frugalHashInt(h, 5)
+	frugalHashBool(h, p.Raw != nil)
+	if p.Raw != nil {
+		frugalHashBytes(h, p.Raw)
+	}
+	frugalHashInt(h, 6)
+	frugalHashInt(h, int64(len(p.Points)))
+	for _, elem67 := range p.Points {
+		frugalHashInt(h, int64(elem67.Hash()))
+	}
+	frugalHashInt(h, 7)
+	frugalHashInt(h, int64(len(p.Tags)))
+	var elem68 uint64
+	for elem69 := range p.Tags {
+		elem70 := fnv.New64a()
+		frugalHashString(elem70, string(elem69))
+		elem68 += elem70.Sum64()
+	}
+	frugalHashInt(h, int64(elem68))
+	frugalHashInt(h, 8)
+	frugalHashInt(h, int64(len(p.Corners)))
+	var elem71 uint64
+	for elem72 := range p.Corners {
+		elem73 := fnv.New64a()
+		frugalHashInt(elem73, int64(elem72.Hash()))
+		elem71 += elem73.Sum64()
+	}
+	frugalHashInt(h, int64(elem71))
+	frugalHashInt(h, 9)
+	frugalHashInt(h, int64(len(p.Paths)))
+	var elem74 uint64
+	for elem75, elem77 := range p.Paths {
+		elem76 := fnv.New64a()
+		frugalHashString(elem76, string(elem75))
+		frugalHashInt(elem76, int64(len(elem77)))
+		for _, elem78 := range elem77 {
+			frugalHashInt(elem76, int64(elem78.Hash()))
+		}
+		elem74 += elem76.Sum64()
+	}
+	frugalHashInt(h, int64(elem74))
+	frugalHashInt(h, 10)
+	frugalHashInt(h, int64(len(p.Labels)))
+	var elem79 uint64
+	for elem80, elem82 := range p.Labels {
+		elem81 := fnv.New64a()
+		frugalHashInt(elem81, int64(elem80.Hash()))
+		frugalHashString(elem81, string(elem82))
+		elem79 += elem81.Sum64()
+	}
+	frugalHashInt(h, int64(elem79))
+	frugalHashInt(h, 11)
+	frugalHashBool(h, p.Sizes != nil)
+	if p.Sizes != nil {
+		frugalHashInt(h, int64(len(*p.Sizes)))
+		for _, elem83 := range *p.Sizes {
+			frugalHashInt(h, int64(elem83))
+		}
+	}
+	frugalHashInt(h, 12)
+	frugalHashBool(h, p.Aliases != nil)
+	if p.Aliases != nil {
+		frugalHashInt(h, int64(len(p.Aliases)))
+		for _, elem84 := range p.Aliases {
+			frugalHashString(h, string(elem84))
+		}
+	}
+	frugalHashInt(h, 13)
+	frugalHashInt(h, int64(p.Fill))
+	frugalHashInt(h, 14)
+	frugalHashBool(h, p.Border != nil)
+	if p.Border != nil {
+		frugalHashInt(h, int64(*p.Border))
+	}
+	frugalHashInt(h, 15)
+	frugalHashInt(h, int64(p.Owner.Hash()))
+	frugalHashInt(h, 16)
+	frugalHashInt(h, int64(len(p.Health)))
+	for _, elem85 := range p.Health {
+		frugalHashInt(h, int64(len(elem85)))
+		var elem86 uint64
+		for elem87 := range elem85 {
+			elem88 := fnv.New64a()
+			frugalHashInt(elem88, int64(elem87))
+			elem86 += elem88.Sum64()
+		}
+		frugalHashInt(h, int64(elem86))
+	}
+	frugalHashInt(h, 17)
+	frugalHashBool(h, bool(p.Visible))
+	frugalHashInt(h, 18)
+	frugalHashInt(h, int64(len(p.Chunks)))
+	var elem89 uint64
+	for elem90, elem92 := range p.Chunks {
+		elem91 := fnv.New64a()
+		frugalHashInt(elem91, int64(elem90))
+		frugalHashBytes(elem91, elem92)
+		elem89 += elem91.Sum64()
+	}
+	frugalHashInt(h, int64(elem89))
+	return h.Sum64()
+}
+
+type Drawing struct {
+	Shape  *Shape   `thrift:"shape,1" db:"shape" json:"shape,omitempty"`
+	Point  *Point   `thrift:"point,2" db:"point" json:"point,omitempty"`
+	Layers [][]byte `thrift:"layers,3" db:"layers" json:"layers,omitempty"`
+}
+
+func NewDrawing() *Drawing {
+	return &Drawing{}
+}
+
+var Drawing_Shape_DEFAULT *Shape
+
+func (p *Drawing) IsSetShape() bool {
+	return p.Shape != nil
+}
+
+func (p *Drawing) GetShape() *Shape {
+	if !p.IsSetShape() {
+		return Drawing_Shape_DEFAULT
+	}
+	return p.Shape
+}
+
+var Drawing_Point_DEFAULT *Point
+
+func (p *Drawing) IsSetPoint() bool {
+	return p.Point != nil
+}
+
+func (p *Drawing) GetPoint() *Point {
+	if !p.IsSetPoint() {
+		return Drawing_Point_DEFAULT
+	}
+	return p.Point
+}
+
+var Drawing_Layers_DEFAULT [][]byte
+
+func (p *Drawing) IsSetLayers() bool {
+	return p.Layers != nil
+}
+
+func (p *Drawing) GetLayers() [][]byte {
+	return p.Layers
+}
+
+func (p *Drawing) CountSetFieldsDrawing() int {
+	count := 0
+	if p.IsSetShape() {
+		count++
+	}
+	if p.IsSetPoint() {
+		count++
+	}
+	if p.IsSetLayers() {
+		count++
+	}
+	return count
+}
+
+func (p *Drawing) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if c := p.CountSetFieldsDrawing(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T read union: exactly one field must be set (%d set).", p, c))
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField1(iprot thrift.TProtocol) error {
+	p.Shape = NewShape()
+	if err := p.Shape.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Shape), err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField2(iprot thrift.TProtocol) error {
+	p.Point = NewPoint()
+	if err := p.Point.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Point), err)
+	}
+	return nil
+}
+
+func (p *Drawing) ReadField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	p.Layers = make([][]byte, 0, size)
+	for i := 0; i < size; i++ {
+		var elem93 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			elem93 = v
+		}
+		p.Layers = append(p.Layers, elem93)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) Write(oprot thrift.TProtocol) error {
+	if c := p.CountSetFieldsDrawing(); c != 1 {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("%T write union: exactly one field must be set (%d set).", p, c))
+	}
+	if err := oprot.WriteStructBegin("drawing"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *Drawing) writeField1(oprot thrift.TProtocol) error {
+	if p.IsSetShape() {
+		if err := oprot.WriteFieldBegin("shape", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:shape: ", p), err)
+		}
+		if err := p.Shape.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Shape), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:shape: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetPoint() {
+		if err := oprot.WriteFieldBegin("point", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:point: ", p), err)
+		}
+		if err := p.Point.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Point), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:point: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) writeField3(oprot thrift.TProtocol) error {
+	if p.IsSetLayers() {
+		if err := oprot.WriteFieldBegin("layers", thrift.LIST, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:layers: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.Layers)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.Layers {
+			if err := oprot.WriteBinary([]byte(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:layers: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *Drawing) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Drawing(%+v)", *p)
+}
+
+func (p *Drawing) Equals(other *Drawing) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if !p.Shape.Equals(other.Shape) {
+		return false
+	}
+	if !p.Point.Equals(other.Point) {
+		return false
+	}
+	if (p.Layers == nil) != (other.Layers == nil) {
+		return false
+	}
+	if len(p.Layers) != len(other.Layers) {
+		return false
+	}
+	for elem94, elem95 := range p.Layers {
+		elem96 := other.Layers[elem94]
+		if !bytes.Equal(elem95, elem96) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Drawing) DeepCopy() *Drawing {
+	if p == nil {
+		return nil
+	}
+	c := new(Drawing)
+	c.Shape = p.Shape.DeepCopy()
+	c.Point = p.Point.DeepCopy()
+	if p.Layers != nil {
+		c.Layers = make([][]byte, 0, len(p.Layers))
+		for _, elem97 := range p.Layers {
+			var elem98 []byte
+			if elem97 != nil {
+				elem98 = append([]byte{}, elem97...)
+			}
+			c.Layers = append(c.Layers, elem98)
+		}
+	}
+	return c
+}
+
+func (p *Drawing) Hash() uint64 {
+	if p == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	frugalHashInt(h, 1)
+	frugalHashInt(h, int64(p.Shape.Hash()))
+	frugalHashInt(h, 2)
+	frugalHashInt(h, int64(p.Point.Hash()))
+	frugalHashInt(h, 3)
+	frugalHashBool(h, p.Layers != nil)
+	if p.Layers != nil {
+		frugalHashInt(h, int64(len(p.Layers)))
+		for _, elem99 := range p.Layers {
+			frugalHashBytes(h, elem99)
+		}
+	}
+	return h.Sum64()
+}
+
+type DrawError struct {
+	Message string `thrift:"message,1" db:"message" json:"message"`
+	At      *Point `thrift:"at,2" db:"at" json:"at,omitempty"`
+}
+
+func NewDrawError() *DrawError {
+	return &DrawError{}
+}
+
+func (p *DrawError) GetMessage() string {
+	return p.Message
+}
+
+var DrawError_At_DEFAULT *Point
+
+func (p *DrawError) IsSetAt() bool {
+	return p.At != nil
+}
+
+func (p *DrawError) GetAt() *Point {
+	if !p.IsSetAt() {
+		return DrawError_At_DEFAULT
+	}
+	return p.At
+}
+
+func (p *DrawError) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DrawError) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Message = v
+	}
+	return nil
+}
+
+func (p *DrawError) ReadField2(iprot thrift.TProtocol) error {
+	p.At = NewPoint()
+	if err := p.At.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.At), err)
+	}
+	return nil
+}
+
+func (p *DrawError) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("draw_error"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DrawError) writeField1(oprot thrift.TProtocol) error {
+	if err := oprot.WriteFieldBegin("message", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:message: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Message)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.message (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:message: ", p), err)
+	}
+	return nil
+}
+
+func (p *DrawError) writeField2(oprot thrift.TProtocol) error {
+	if p.IsSetAt() {
+		if err := oprot.WriteFieldBegin("at", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:at: ", p), err)
+		}
+		if err := p.At.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.At), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:at: ", p), err)
+		}
+	}
+	return nil
+}
+
+func (p *DrawError) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DrawError(%+v)", *p)
+}
+
+func (p *DrawError) Equals(other *DrawError) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if p.Message != other.Message {
+		return false
+	}
+	if !p.At.Equals(other.At) {
+		return false
+	}
+	return true
+}
+
+func (p *DrawError) DeepCopy() *DrawError {
+	if p == nil {
+		return nil
+	}
+	c := new(DrawError)
+	c.Message = p.Message
+	c.At = p.At.DeepCopy()
+	return c
+}
+
+func (p *DrawError) Hash() uint64 {
+	if p == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	frugalHashInt(h, 1)
+	frugalHashString(h, string(p.Message))
+	frugalHashInt(h, 2)
+	frugalHashInt(h, int64(p.At.Hash()))
+	return h.Sum64()
+}
+
+func (p *DrawError) Error() string {
+	return p.String()
+}
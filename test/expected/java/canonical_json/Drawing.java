@@ -0,0 +1,2627 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+import com.workiva.frugal.util.CanonicalJson;
+import com.workiva.frugal.util.Pair;
+
+public class Drawing implements org.apache.thrift.TBase<Drawing, Drawing._Fields>, java.io.Serializable, Cloneable, Comparable<Drawing> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Drawing");
+
+	private static final org.apache.thrift.protocol.TField ID_FIELD_DESC = new org.apache.thrift.protocol.TField("id", org.apache.thrift.protocol.TType.I64, (short)1);
+	private static final org.apache.thrift.protocol.TField NAME_FIELD_DESC = new org.apache.thrift.protocol.TField("name", org.apache.thrift.protocol.TType.STRING, (short)2);
+	private static final org.apache.thrift.protocol.TField NOTE_FIELD_DESC = new org.apache.thrift.protocol.TField("note", org.apache.thrift.protocol.TType.STRING, (short)3);
+	private static final org.apache.thrift.protocol.TField VISIBLE_FIELD_DESC = new org.apache.thrift.protocol.TField("visible", org.apache.thrift.protocol.TType.BOOL, (short)4);
+	private static final org.apache.thrift.protocol.TField LAYER_FIELD_DESC = new org.apache.thrift.protocol.TField("layer", org.apache.thrift.protocol.TType.BYTE, (short)5);
+	private static final org.apache.thrift.protocol.TField DEPTH_FIELD_DESC = new org.apache.thrift.protocol.TField("depth", org.apache.thrift.protocol.TType.I16, (short)6);
+	private static final org.apache.thrift.protocol.TField SCALE_FIELD_DESC = new org.apache.thrift.protocol.TField("scale", org.apache.thrift.protocol.TType.DOUBLE, (short)7);
+	private static final org.apache.thrift.protocol.TField THUMBNAIL_FIELD_DESC = new org.apache.thrift.protocol.TField("thumbnail", org.apache.thrift.protocol.TType.STRING, (short)8);
+	private static final org.apache.thrift.protocol.TField KIND_FIELD_DESC = new org.apache.thrift.protocol.TField("kind", org.apache.thrift.protocol.TType.I32, (short)9);
+	private static final org.apache.thrift.protocol.TField SIZE_FIELD_DESC = new org.apache.thrift.protocol.TField("size", org.apache.thrift.protocol.TType.I32, (short)10);
+	private static final org.apache.thrift.protocol.TField SHAPES_FIELD_DESC = new org.apache.thrift.protocol.TField("shapes", org.apache.thrift.protocol.TType.LIST, (short)11);
+	private static final org.apache.thrift.protocol.TField TAGS_FIELD_DESC = new org.apache.thrift.protocol.TField("tags", org.apache.thrift.protocol.TType.SET, (short)12);
+	private static final org.apache.thrift.protocol.TField COUNTS_FIELD_DESC = new org.apache.thrift.protocol.TField("counts", org.apache.thrift.protocol.TType.MAP, (short)13);
+	private static final org.apache.thrift.protocol.TField NAMES_FIELD_DESC = new org.apache.thrift.protocol.TField("names", org.apache.thrift.protocol.TType.MAP, (short)14);
+	private static final org.apache.thrift.protocol.TField LABELS_FIELD_DESC = new org.apache.thrift.protocol.TField("labels", org.apache.thrift.protocol.TType.MAP, (short)15);
+	private static final org.apache.thrift.protocol.TField FLAGS_FIELD_DESC = new org.apache.thrift.protocol.TField("flags", org.apache.thrift.protocol.TType.MAP, (short)16);
+	private static final org.apache.thrift.protocol.TField ORIGIN_FIELD_DESC = new org.apache.thrift.protocol.TField("origin", org.apache.thrift.protocol.TType.STRUCT, (short)17);
+	private static final org.apache.thrift.protocol.TField VERSION_FIELD_DESC = new org.apache.thrift.protocol.TField("version", org.apache.thrift.protocol.TType.I64, (short)18);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new DrawingStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new DrawingTupleSchemeFactory());
+	}
+
+	public long id; // required
+	public String name;
+	public String note; // optional
+	public boolean visible;
+	public byte layer;
+	public short depth;
+	public double scale;
+	public java.nio.ByteBuffer thumbnail;
+	public Kind kind;
+	public Kind size; // optional
+	public java.util.List<Shape> shapes;
+	public java.util.Set<String> tags;
+	public java.util.Map<Kind, java.util.List<Long>> counts;
+	public java.util.Map<Long, String> names;
+	public java.util.Map<Point, String> labels;
+	public java.util.Map<Boolean, Double> flags; // optional
+	public Point origin;
+	public long version; // optional
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		ID((short)1, "id"),
+		NAME((short)2, "name"),
+		NOTE((short)3, "note"),
+		VISIBLE((short)4, "visible"),
+		LAYER((short)5, "layer"),
+		DEPTH((short)6, "depth"),
+		SCALE((short)7, "scale"),
+		THUMBNAIL((short)8, "thumbnail"),
+		KIND((short)9, "kind"),
+		SIZE((short)10, "size"),
+		SHAPES((short)11, "shapes"),
+		TAGS((short)12, "tags"),
+		COUNTS((short)13, "counts"),
+		NAMES((short)14, "names"),
+		LABELS((short)15, "labels"),
+		FLAGS((short)16, "flags"),
+		ORIGIN((short)17, "origin"),
+		VERSION((short)18, "version")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // ID
+					return ID;
+				case 2: // NAME
+					return NAME;
+				case 3: // NOTE
+					return NOTE;
+				case 4: // VISIBLE
+					return VISIBLE;
+				case 5: // LAYER
+					return LAYER;
+				case 6: // DEPTH
+					return DEPTH;
+				case 7: // SCALE
+					return SCALE;
+				case 8: // THUMBNAIL
+					return THUMBNAIL;
+				case 9: // KIND
+					return KIND;
+				case 10: // SIZE
+					return SIZE;
+				case 11: // SHAPES
+					return SHAPES;
+				case 12: // TAGS
+					return TAGS;
+				case 13: // COUNTS
+					return COUNTS;
+				case 14: // NAMES
+					return NAMES;
+				case 15: // LABELS
+					return LABELS;
+				case 16: // FLAGS
+					return FLAGS;
+				case 17: // ORIGIN
+					return ORIGIN;
+				case 18: // VERSION
+					return VERSION;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	private static final int __ID_ISSET_ID = 0;
+	private static final int __VISIBLE_ISSET_ID = 1;
+	private static final int __LAYER_ISSET_ID = 2;
+	private static final int __DEPTH_ISSET_ID = 3;
+	private static final int __SCALE_ISSET_ID = 4;
+	private static final int __VERSION_ISSET_ID = 5;
+	private byte __isset_bitfield = 0;
+	public Drawing() {
+		this.version = 3L;
+
+	}
+
+	public Drawing(
+		long id,
+		String name,
+		boolean visible,
+		byte layer,
+		short depth,
+		double scale,
+		java.nio.ByteBuffer thumbnail,
+		Kind kind,
+		java.util.List<Shape> shapes,
+		java.util.Set<String> tags,
+		java.util.Map<Kind, java.util.List<Long>> counts,
+		java.util.Map<Long, String> names,
+		java.util.Map<Point, String> labels,
+		Point origin) {
+		this();
+		this.id = id;
+		setIdIsSet(true);
+		this.name = name;
+		this.visible = visible;
+		setVisibleIsSet(true);
+		this.layer = layer;
+		setLayerIsSet(true);
+		this.depth = depth;
+		setDepthIsSet(true);
+		this.scale = scale;
+		setScaleIsSet(true);
+		this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+		this.kind = kind;
+		this.shapes = shapes;
+		this.tags = tags;
+		this.counts = counts;
+		this.names = names;
+		this.labels = labels;
+		this.origin = origin;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public Drawing(Drawing other) {
+		__isset_bitfield = other.__isset_bitfield;
+		this.id = other.id;
+		if (other.isSetName()) {
+			this.name = other.name;
+		}
+		if (other.isSetNote()) {
+			this.note = other.note;
+		}
+		this.visible = other.visible;
+		this.layer = other.layer;
+		this.depth = other.depth;
+		this.scale = other.scale;
+		if (other.isSetThumbnail()) {
+			this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(other.thumbnail);
+		}
+		if (other.isSetKind()) {
+			this.kind = other.kind;
+		}
+		if (other.isSetSize()) {
+			this.size = other.size;
+		}
+		if (other.isSetShapes()) {
+			this.shapes = new ArrayList<Shape>(other.shapes.size());
+			for (Shape elem6 : other.shapes) {
+				Shape elem7 = new Shape(elem6);
+				this.shapes.add(elem7);
+			}
+		}
+		if (other.isSetTags()) {
+			this.tags = new HashSet<String>(other.tags.size());
+			for (String elem8 : other.tags) {
+				String elem9 = elem8;
+				this.tags.add(elem9);
+			}
+		}
+		if (other.isSetCounts()) {
+			this.counts = new HashMap<Kind,java.util.List<Long>>(other.counts.size());
+			for (Map.Entry<Kind, java.util.List<Long>> elem10 : other.counts.entrySet()) {
+				Kind elem12 = elem10.getKey();
+				java.util.List<Long> elem11 = new ArrayList<Long>(elem10.getValue().size());
+				for (long elem13 : elem10.getValue()) {
+					long elem14 = elem13;
+					elem11.add(elem14);
+				}
+				this.counts.put(elem12, elem11);
+			}
+		}
+		if (other.isSetNames()) {
+			this.names = new HashMap<Long,String>(other.names);
+		}
+		if (other.isSetLabels()) {
+			this.labels = new HashMap<Point,String>(other.labels.size());
+			for (Map.Entry<Point, String> elem17 : other.labels.entrySet()) {
+				Point elem19 = new Point(elem17.getKey());
+				String elem18 = elem17.getValue();
+				this.labels.put(elem19, elem18);
+			}
+		}
+		if (other.isSetFlags()) {
+			this.flags = new HashMap<Boolean,Double>(other.flags);
+		}
+		if (other.isSetOrigin()) {
+			this.origin = new Point(other.origin);
+		}
+		this.version = other.version;
+	}
+
+	public Drawing deepCopy() {
+		return new Drawing(this);
+	}
+
+	@Override
+	public void clear() {
+		setIdIsSet(false);
+		this.id = 0L;
+
+		this.name = null;
+
+		this.note = null;
+
+		setVisibleIsSet(false);
+		this.visible = false;
+
+		setLayerIsSet(false);
+		this.layer = (byte)0;
+
+		setDepthIsSet(false);
+		this.depth = (short)0;
+
+		setScaleIsSet(false);
+		this.scale = 0.0;
+
+		this.thumbnail = null;
+
+		this.kind = null;
+
+		this.size = null;
+
+		this.shapes = null;
+
+		this.tags = null;
+
+		this.counts = null;
+
+		this.names = null;
+
+		this.labels = null;
+
+		this.flags = null;
+
+		this.origin = null;
+
+		this.version = 3L;
+
+	}
+
+	public long getId() {
+		return this.id;
+	}
+
+	public Drawing setId(long id) {
+		this.id = id;
+		setIdIsSet(true);
+		return this;
+	}
+
+	public void unsetId() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __ID_ISSET_ID);
+	}
+
+	/** Returns true if field id is set (has been assigned a value) and false otherwise */
+	public boolean isSetId() {
+		return EncodingUtils.testBit(__isset_bitfield, __ID_ISSET_ID);
+	}
+
+	public void setIdIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __ID_ISSET_ID, value);
+	}
+
+	public String getName() {
+		return this.name;
+	}
+
+	public Drawing setName(String name) {
+		this.name = name;
+		return this;
+	}
+
+	public void unsetName() {
+		this.name = null;
+	}
+
+	/** Returns true if field name is set (has been assigned a value) and false otherwise */
+	public boolean isSetName() {
+		return this.name != null;
+	}
+
+	public void setNameIsSet(boolean value) {
+		if (!value) {
+			this.name = null;
+		}
+	}
+
+	public String getNote() {
+		return this.note;
+	}
+
+	public Drawing setNote(String note) {
+		this.note = note;
+		return this;
+	}
+
+	public void unsetNote() {
+		this.note = null;
+	}
+
+	/** Returns true if field note is set (has been assigned a value) and false otherwise */
+	public boolean isSetNote() {
+		return this.note != null;
+	}
+
+	public void setNoteIsSet(boolean value) {
+		if (!value) {
+			this.note = null;
+		}
+	}
+
+	public boolean isVisible() {
+		return this.visible;
+	}
+
+	public Drawing setVisible(boolean visible) {
+		this.visible = visible;
+		setVisibleIsSet(true);
+		return this;
+	}
+
+	public void unsetVisible() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __VISIBLE_ISSET_ID);
+	}
+
+	/** Returns true if field visible is set (has been assigned a value) and false otherwise */
+	public boolean isSetVisible() {
+		return EncodingUtils.testBit(__isset_bitfield, __VISIBLE_ISSET_ID);
+	}
+
+	public void setVisibleIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __VISIBLE_ISSET_ID, value);
+	}
+
+	public byte getLayer() {
+		return this.layer;
+	}
+
+	public Drawing setLayer(byte layer) {
+		this.layer = layer;
+		setLayerIsSet(true);
+		return this;
+	}
+
+	public void unsetLayer() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __LAYER_ISSET_ID);
+	}
+
+	/** Returns true if field layer is set (has been assigned a value) and false otherwise */
+	public boolean isSetLayer() {
+		return EncodingUtils.testBit(__isset_bitfield, __LAYER_ISSET_ID);
+	}
+
+	public void setLayerIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __LAYER_ISSET_ID, value);
+	}
+
+	public short getDepth() {
+		return this.depth;
+	}
+
+	public Drawing setDepth(short depth) {
+		this.depth = depth;
+		setDepthIsSet(true);
+		return this;
+	}
+
+	public void unsetDepth() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __DEPTH_ISSET_ID);
+	}
+
+	/** Returns true if field depth is set (has been assigned a value) and false otherwise */
+	public boolean isSetDepth() {
+		return EncodingUtils.testBit(__isset_bitfield, __DEPTH_ISSET_ID);
+	}
+
+	public void setDepthIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __DEPTH_ISSET_ID, value);
+	}
+
+	public double getScale() {
+		return this.scale;
+	}
+
+	public Drawing setScale(double scale) {
+		this.scale = scale;
+		setScaleIsSet(true);
+		return this;
+	}
+
+	public void unsetScale() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __SCALE_ISSET_ID);
+	}
+
+	/** Returns true if field scale is set (has been assigned a value) and false otherwise */
+	public boolean isSetScale() {
+		return EncodingUtils.testBit(__isset_bitfield, __SCALE_ISSET_ID);
+	}
+
+	public void setScaleIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __SCALE_ISSET_ID, value);
+	}
+
+	public byte[] getThumbnail() {
+		setThumbnail(org.apache.thrift.TBaseHelper.rightSize(thumbnail));
+		return thumbnail == null ? null : thumbnail.array();
+	}
+
+	public java.nio.ByteBuffer bufferForThumbnail() {
+		return org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+	}
+
+	public Drawing setThumbnail(byte[] thumbnail) {
+		this.thumbnail = thumbnail == null ? (java.nio.ByteBuffer)null : java.nio.ByteBuffer.wrap(Arrays.copyOf(thumbnail, thumbnail.length));
+		return this;
+	}
+
+	public Drawing setThumbnail(java.nio.ByteBuffer thumbnail) {
+		this.thumbnail = org.apache.thrift.TBaseHelper.copyBinary(thumbnail);
+		return this;
+	}
+
+	public void unsetThumbnail() {
+		this.thumbnail = null;
+	}
+
+	/** Returns true if field thumbnail is set (has been assigned a value) and false otherwise */
+	public boolean isSetThumbnail() {
+		return this.thumbnail != null;
+	}
+
+	public void setThumbnailIsSet(boolean value) {
+		if (!value) {
+			this.thumbnail = null;
+		}
+	}
+
+	public Kind getKind() {
+		return this.kind;
+	}
+
+	public Drawing setKind(Kind kind) {
+		this.kind = kind;
+		return this;
+	}
+
+	public void unsetKind() {
+		this.kind = null;
+	}
+
+	/** Returns true if field kind is set (has been assigned a value) and false otherwise */
+	public boolean isSetKind() {
+		return this.kind != null;
+	}
+
+	public void setKindIsSet(boolean value) {
+		if (!value) {
+			this.kind = null;
+		}
+	}
+
+	public Kind getSize() {
+		return this.size;
+	}
+
+	public Drawing setSize(Kind size) {
+		this.size = size;
+		return this;
+	}
+
+	public void unsetSize() {
+		this.size = null;
+	}
+
+	/** Returns true if field size is set (has been assigned a value) and false otherwise */
+	public boolean isSetSize() {
+		return this.size != null;
+	}
+
+	public void setSizeIsSet(boolean value) {
+		if (!value) {
+			this.size = null;
+		}
+	}
+
+	public int getShapesSize() {
+		return (this.shapes == null) ? 0 : this.shapes.size();
+	}
+
+	public java.util.Iterator<Shape> getShapesIterator() {
+		return (this.shapes == null) ? null : this.shapes.iterator();
+	}
+
+	public void addToShapes(Shape elem) {
+		if (this.shapes == null) {
+			this.shapes = new ArrayList<Shape>();
+		}
+		this.shapes.add(elem);
+	}
+
+	public java.util.List<Shape> getShapes() {
+		return this.shapes;
+	}
+
+	public Drawing setShapes(java.util.List<Shape> shapes) {
+		this.shapes = shapes;
+		return this;
+	}
+
+	public void unsetShapes() {
+		this.shapes = null;
+	}
+
+	/** Returns true if field shapes is set (has been assigned a value) and false otherwise */
+	public boolean isSetShapes() {
+		return this.shapes != null;
+	}
+
+	public void setShapesIsSet(boolean value) {
+		if (!value) {
+			this.shapes = null;
+		}
+	}
+
+	public int getTagsSize() {
+		return (this.tags == null) ? 0 : this.tags.size();
+	}
+
+	public java.util.Iterator<String> getTagsIterator() {
+		return (this.tags == null) ? null : this.tags.iterator();
+	}
+
+	public void addToTags(String elem) {
+		if (this.tags == null) {
+			this.tags = new HashSet<String>();
+		}
+		this.tags.add(elem);
+	}
+
+	public java.util.Set<String> getTags() {
+		return this.tags;
+	}
+
+	public Drawing setTags(java.util.Set<String> tags) {
+		this.tags = tags;
+		return this;
+	}
+
+	public void unsetTags() {
+		this.tags = null;
+	}
+
+	/** Returns true if field tags is set (has been assigned a value) and false otherwise */
+	public boolean isSetTags() {
+		return this.tags != null;
+	}
+
+	public void setTagsIsSet(boolean value) {
+		if (!value) {
+			this.tags = null;
+		}
+	}
+
+	public int getCountsSize() {
+		return (this.counts == null) ? 0 : this.counts.size();
+	}
+
+	public void putToCounts(Kind key, java.util.List<Long> val) {
+		if (this.counts == null) {
+			this.counts = new HashMap<Kind,java.util.List<Long>>();
+		}
+		this.counts.put(key, val);
+	}
+
+	public java.util.Map<Kind, java.util.List<Long>> getCounts() {
+		return this.counts;
+	}
+
+	public Drawing setCounts(java.util.Map<Kind, java.util.List<Long>> counts) {
+		this.counts = counts;
+		return this;
+	}
+
+	public void unsetCounts() {
+		this.counts = null;
+	}
+
+	/** Returns true if field counts is set (has been assigned a value) and false otherwise */
+	public boolean isSetCounts() {
+		return this.counts != null;
+	}
+
+	public void setCountsIsSet(boolean value) {
+		if (!value) {
+			this.counts = null;
+		}
+	}
+
+	public int getNamesSize() {
+		return (this.names == null) ? 0 : this.names.size();
+	}
+
+	public void putToNames(long key, String val) {
+		if (this.names == null) {
+			this.names = new HashMap<Long,String>();
+		}
+		this.names.put(key, val);
+	}
+
+	public java.util.Map<Long, String> getNames() {
+		return this.names;
+	}
+
+	public Drawing setNames(java.util.Map<Long, String> names) {
+		this.names = names;
+		return this;
+	}
+
+	public void unsetNames() {
+		this.names = null;
+	}
+
+	/** Returns true if field names is set (has been assigned a value) and false otherwise */
+	public boolean isSetNames() {
+		return this.names != null;
+	}
+
+	public void setNamesIsSet(boolean value) {
+		if (!value) {
+			this.names = null;
+		}
+	}
+
+	public int getLabelsSize() {
+		return (this.labels == null) ? 0 : this.labels.size();
+	}
+
+	public void putToLabels(Point key, String val) {
+		if (this.labels == null) {
+			this.labels = new HashMap<Point,String>();
+		}
+		this.labels.put(key, val);
+	}
+
+	public java.util.Map<Point, String> getLabels() {
+		return this.labels;
+	}
+
+	public Drawing setLabels(java.util.Map<Point, String> labels) {
+		this.labels = labels;
+		return this;
+	}
+
+	public void unsetLabels() {
+		this.labels = null;
+	}
+
+	/** Returns true if field labels is set (has been assigned a value) and false otherwise */
+	public boolean isSetLabels() {
+		return this.labels != null;
+	}
+
+	public void setLabelsIsSet(boolean value) {
+		if (!value) {
+			this.labels = null;
+		}
+	}
+
+	public int getFlagsSize() {
+		return (this.flags == null) ? 0 : this.flags.size();
+	}
+
+	public void putToFlags(boolean key, double val) {
+		if (this.flags == null) {
+			this.flags = new HashMap<Boolean,Double>();
+		}
+		this.flags.put(key, val);
+	}
+
+	public java.util.Map<Boolean, Double> getFlags() {
+		return this.flags;
+	}
+
+	public Drawing setFlags(java.util.Map<Boolean, Double> flags) {
+		this.flags = flags;
+		return this;
+	}
+
+	public void unsetFlags() {
+		this.flags = null;
+	}
+
+	/** Returns true if field flags is set (has been assigned a value) and false otherwise */
+	public boolean isSetFlags() {
+		return this.flags != null;
+	}
+
+	public void setFlagsIsSet(boolean value) {
+		if (!value) {
+			this.flags = null;
+		}
+	}
+
+	public Point getOrigin() {
+		return this.origin;
+	}
+
+	public Drawing setOrigin(Point origin) {
+		this.origin = origin;
+		return this;
+	}
+
+	public void unsetOrigin() {
+		this.origin = null;
+	}
+
+	/** Returns true if field origin is set (has been assigned a value) and false otherwise */
+	public boolean isSetOrigin() {
+		return this.origin != null;
+	}
+
+	public void setOriginIsSet(boolean value) {
+		if (!value) {
+			this.origin = null;
+		}
+	}
+
+	public long getVersion() {
+		return this.version;
+	}
+
+	public Drawing setVersion(long version) {
+		this.version = version;
+		setVersionIsSet(true);
+		return this;
+	}
+
+	public void unsetVersion() {
+		__isset_bitfield = EncodingUtils.clearBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	/** Returns true if field version is set (has been assigned a value) and false otherwise */
+	public boolean isSetVersion() {
+		return EncodingUtils.testBit(__isset_bitfield, __VERSION_ISSET_ID);
+	}
+
+	public void setVersionIsSet(boolean value) {
+		__isset_bitfield = EncodingUtils.setBit(__isset_bitfield, __VERSION_ISSET_ID, value);
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case ID:
+			if (value == null) {
+				unsetId();
+			} else {
+				setId((Long)value);
+			}
+			break;
+
+		case NAME:
+			if (value == null) {
+				unsetName();
+			} else {
+				setName((String)value);
+			}
+			break;
+
+		case NOTE:
+			if (value == null) {
+				unsetNote();
+			} else {
+				setNote((String)value);
+			}
+			break;
+
+		case VISIBLE:
+			if (value == null) {
+				unsetVisible();
+			} else {
+				setVisible((Boolean)value);
+			}
+			break;
+
+		case LAYER:
+			if (value == null) {
+				unsetLayer();
+			} else {
+				setLayer((Byte)value);
+			}
+			break;
+
+		case DEPTH:
+			if (value == null) {
+				unsetDepth();
+			} else {
+				setDepth((Short)value);
+			}
+			break;
+
+		case SCALE:
+			if (value == null) {
+				unsetScale();
+			} else {
+				setScale((Double)value);
+			}
+			break;
+
+		case THUMBNAIL:
+			if (value == null) {
+				unsetThumbnail();
+			} else {
+				setThumbnail((java.nio.ByteBuffer)value);
+			}
+			break;
+
+		case KIND:
+			if (value == null) {
+				unsetKind();
+			} else {
+				setKind((Kind)value);
+			}
+			break;
+
+		case SIZE:
+			if (value == null) {
+				unsetSize();
+			} else {
+				setSize((Kind)value);
+			}
+			break;
+
+		case SHAPES:
+			if (value == null) {
+				unsetShapes();
+			} else {
+				setShapes((java.util.List<Shape>)value);
+			}
+			break;
+
+		case TAGS:
+			if (value == null) {
+				unsetTags();
+			} else {
+				setTags((java.util.Set<String>)value);
+			}
+			break;
+
+		case COUNTS:
+			if (value == null) {
+				unsetCounts();
+			} else {
+				setCounts((java.util.Map<Kind, java.util.List<Long>>)value);
+			}
+			break;
+
+		case NAMES:
+			if (value == null) {
+				unsetNames();
+			} else {
+				setNames((java.util.Map<Long, String>)value);
+			}
+			break;
+
+		case LABELS:
+			if (value == null) {
+				unsetLabels();
+			} else {
+				setLabels((java.util.Map<Point, String>)value);
+			}
+			break;
+
+		case FLAGS:
+			if (value == null) {
+				unsetFlags();
+			} else {
+				setFlags((java.util.Map<Boolean, Double>)value);
+			}
+			break;
+
+		case ORIGIN:
+			if (value == null) {
+				unsetOrigin();
+			} else {
+				setOrigin((Point)value);
+			}
+			break;
+
+		case VERSION:
+			if (value == null) {
+				unsetVersion();
+			} else {
+				setVersion((Long)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case ID:
+			return getId();
+
+		case NAME:
+			return getName();
+
+		case NOTE:
+			return getNote();
+
+		case VISIBLE:
+			return isVisible();
+
+		case LAYER:
+			return getLayer();
+
+		case DEPTH:
+			return getDepth();
+
+		case SCALE:
+			return getScale();
+
+		case THUMBNAIL:
+			return getThumbnail();
+
+		case KIND:
+			return getKind();
+
+		case SIZE:
+			return getSize();
+
+		case SHAPES:
+			return getShapes();
+
+		case TAGS:
+			return getTags();
+
+		case COUNTS:
+			return getCounts();
+
+		case NAMES:
+			return getNames();
+
+		case LABELS:
+			return getLabels();
+
+		case FLAGS:
+			return getFlags();
+
+		case ORIGIN:
+			return getOrigin();
+
+		case VERSION:
+			return getVersion();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case ID:
+			return isSetId();
+		case NAME:
+			return isSetName();
+		case NOTE:
+			return isSetNote();
+		case VISIBLE:
+			return isSetVisible();
+		case LAYER:
+			return isSetLayer();
+		case DEPTH:
+			return isSetDepth();
+		case SCALE:
+			return isSetScale();
+		case THUMBNAIL:
+			return isSetThumbnail();
+		case KIND:
+			return isSetKind();
+		case SIZE:
+			return isSetSize();
+		case SHAPES:
+			return isSetShapes();
+		case TAGS:
+			return isSetTags();
+		case COUNTS:
+			return isSetCounts();
+		case NAMES:
+			return isSetNames();
+		case LABELS:
+			return isSetLabels();
+		case FLAGS:
+			return isSetFlags();
+		case ORIGIN:
+			return isSetOrigin();
+		case VERSION:
+			return isSetVersion();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof Drawing)
+			return this.equals((Drawing)that);
+		return false;
+	}
+
+	public boolean equals(Drawing that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_id = true;
+		boolean that_present_id = true;
+		if (this_present_id || that_present_id) {
+			if (!(this_present_id && that_present_id))
+				return false;
+			if (this.id != that.id)
+				return false;
+		}
+
+		boolean this_present_name = true && this.isSetName();
+		boolean that_present_name = true && that.isSetName();
+		if (this_present_name || that_present_name) {
+			if (!(this_present_name && that_present_name))
+				return false;
+			if (!this.name.equals(that.name))
+				return false;
+		}
+
+		boolean this_present_note = true && this.isSetNote();
+		boolean that_present_note = true && that.isSetNote();
+		if (this_present_note || that_present_note) {
+			if (!(this_present_note && that_present_note))
+				return false;
+			if (!this.note.equals(that.note))
+				return false;
+		}
+
+		boolean this_present_visible = true;
+		boolean that_present_visible = true;
+		if (this_present_visible || that_present_visible) {
+			if (!(this_present_visible && that_present_visible))
+				return false;
+			if (this.visible != that.visible)
+				return false;
+		}
+
+		boolean this_present_layer = true;
+		boolean that_present_layer = true;
+		if (this_present_layer || that_present_layer) {
+			if (!(this_present_layer && that_present_layer))
+				return false;
+			if (this.layer != that.layer)
+				return false;
+		}
+
+		boolean this_present_depth = true;
+		boolean that_present_depth = true;
+		if (this_present_depth || that_present_depth) {
+			if (!(this_present_depth && that_present_depth))
+				return false;
+			if (this.depth != that.depth)
+				return false;
+		}
+
+		boolean this_present_scale = true;
+		boolean that_present_scale = true;
+		if (this_present_scale || that_present_scale) {
+			if (!(this_present_scale && that_present_scale))
+				return false;
+			if (this.scale != that.scale)
+				return false;
+		}
+
+		boolean this_present_thumbnail = true && this.isSetThumbnail();
+		boolean that_present_thumbnail = true && that.isSetThumbnail();
+		if (this_present_thumbnail || that_present_thumbnail) {
+			if (!(this_present_thumbnail && that_present_thumbnail))
+				return false;
+			if (!this.thumbnail.equals(that.thumbnail))
+				return false;
+		}
+
+		boolean this_present_kind = true && this.isSetKind();
+		boolean that_present_kind = true && that.isSetKind();
+		if (this_present_kind || that_present_kind) {
+			if (!(this_present_kind && that_present_kind))
+				return false;
+			if (!this.kind.equals(that.kind))
+				return false;
+		}
+
+		boolean this_present_size = true && this.isSetSize();
+		boolean that_present_size = true && that.isSetSize();
+		if (this_present_size || that_present_size) {
+			if (!(this_present_size && that_present_size))
+				return false;
+			if (!this.size.equals(that.size))
+				return false;
+		}
+
+		boolean this_present_shapes = true && this.isSetShapes();
+		boolean that_present_shapes = true && that.isSetShapes();
+		if (this_present_shapes || that_present_shapes) {
+			if (!(this_present_shapes && that_present_shapes))
+				return false;
+			if (!this.shapes.equals(that.shapes))
+				return false;
+		}
+
+		boolean this_present_tags = true && this.isSetTags();
+		boolean that_present_tags = true && that.isSetTags();
+		if (this_present_tags || that_present_tags) {
+			if (!(this_present_tags && that_present_tags))
+				return false;
+			if (!this.tags.equals(that.tags))
+				return false;
+		}
+
+		boolean this_present_counts = true && this.isSetCounts();
+		boolean that_present_counts = true && that.isSetCounts();
+		if (this_present_counts || that_present_counts) {
+			if (!(this_present_counts && that_present_counts))
+				return false;
+			if (!this.counts.equals(that.counts))
+				return false;
+		}
+
+		boolean this_present_names = true && this.isSetNames();
+		boolean that_present_names = true && that.isSetNames();
+		if (this_present_names || that_present_names) {
+			if (!(this_present_names && that_present_names))
+				return false;
+			if (!this.names.equals(that.names))
+				return false;
+		}
+
+		boolean this_present_labels = true && this.isSetLabels();
+		boolean that_present_labels = true && that.isSetLabels();
+		if (this_present_labels || that_present_labels) {
+			if (!(this_present_labels && that_present_labels))
+				return false;
+			if (!this.labels.equals(that.labels))
+				return false;
+		}
+
+		boolean this_present_flags = true && this.isSetFlags();
+		boolean that_present_flags = true && that.isSetFlags();
+		if (this_present_flags || that_present_flags) {
+			if (!(this_present_flags && that_present_flags))
+				return false;
+			if (!this.flags.equals(that.flags))
+				return false;
+		}
+
+		boolean this_present_origin = true && this.isSetOrigin();
+		boolean that_present_origin = true && that.isSetOrigin();
+		if (this_present_origin || that_present_origin) {
+			if (!(this_present_origin && that_present_origin))
+				return false;
+			if (!this.origin.equals(that.origin))
+				return false;
+		}
+
+		boolean this_present_version = true && this.isSetVersion();
+		boolean that_present_version = true && that.isSetVersion();
+		if (this_present_version || that_present_version) {
+			if (!(this_present_version && that_present_version))
+				return false;
+			if (this.version != that.version)
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_id = true;
+		list.add(present_id);
+		if (present_id)
+			list.add(id);
+
+		boolean present_name = true && (isSetName());
+		list.add(present_name);
+		if (present_name)
+			list.add(name);
+
+		boolean present_note = true && (isSetNote());
+		list.add(present_note);
+		if (present_note)
+			list.add(note);
+
+		boolean present_visible = true;
+		list.add(present_visible);
+		if (present_visible)
+			list.add(visible);
+
+		boolean present_layer = true;
+		list.add(present_layer);
+		if (present_layer)
+			list.add(layer);
+
+		boolean present_depth = true;
+		list.add(present_depth);
+		if (present_depth)
+			list.add(depth);
+
+		boolean present_scale = true;
+		list.add(present_scale);
+		if (present_scale)
+			list.add(scale);
+
+		boolean present_thumbnail = true && (isSetThumbnail());
+		list.add(present_thumbnail);
+		if (present_thumbnail)
+			list.add(thumbnail);
+
+		boolean present_kind = true && (isSetKind());
+		list.add(present_kind);
+		if (present_kind)
+			list.add(kind.getValue());
+
+		boolean present_size = true && (isSetSize());
+		list.add(present_size);
+		if (present_size)
+			list.add(size);
+
+		boolean present_shapes = true && (isSetShapes());
+		list.add(present_shapes);
+		if (present_shapes)
+			list.add(shapes);
+
+		boolean present_tags = true && (isSetTags());
+		list.add(present_tags);
+		if (present_tags)
+			list.add(tags);
+
+		boolean present_counts = true && (isSetCounts());
+		list.add(present_counts);
+		if (present_counts)
+			list.add(counts);
+
+		boolean present_names = true && (isSetNames());
+		list.add(present_names);
+		if (present_names)
+			list.add(names);
+
+		boolean present_labels = true && (isSetLabels());
+		list.add(present_labels);
+		if (present_labels)
+			list.add(labels);
+
+		boolean present_flags = true && (isSetFlags());
+		list.add(present_flags);
+		if (present_flags)
+			list.add(flags);
+
+		boolean present_origin = true && (isSetOrigin());
+		list.add(present_origin);
+		if (present_origin)
+			list.add(origin);
+
+		boolean present_version = true && (isSetVersion());
+		list.add(present_version);
+		if (present_version)
+			list.add(version);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(Drawing other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetId()).compareTo(other.isSetId());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetId()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.id, other.id);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetName()).compareTo(other.isSetName());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetName()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.name, other.name);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetNote()).compareTo(other.isSetNote());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetNote()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.note, other.note);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetVisible()).compareTo(other.isSetVisible());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetVisible()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.visible, other.visible);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLayer()).compareTo(other.isSetLayer());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLayer()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.layer, other.layer);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetDepth()).compareTo(other.isSetDepth());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetDepth()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.depth, other.depth);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetScale()).compareTo(other.isSetScale());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetScale()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.scale, other.scale);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetThumbnail()).compareTo(other.isSetThumbnail());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetThumbnail()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.thumbnail, other.thumbnail);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetKind()).compareTo(other.isSetKind());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetKind()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.kind, other.kind);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetSize()).compareTo(other.isSetSize());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetSize()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.size, other.size);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetShapes()).compareTo(other.isSetShapes());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetShapes()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.shapes, other.shapes);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetTags()).compareTo(other.isSetTags());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetTags()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.tags, other.tags);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetCounts()).compareTo(other.isSetCounts());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetCounts()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.counts, other.counts);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetNames()).compareTo(other.isSetNames());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetNames()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.names, other.names);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetLabels()).compareTo(other.isSetLabels());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetLabels()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.labels, other.labels);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetFlags()).compareTo(other.isSetFlags());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetFlags()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.flags, other.flags);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetOrigin()).compareTo(other.isSetOrigin());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetOrigin()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.origin, other.origin);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		lastComparison = Boolean.valueOf(isSetVersion()).compareTo(other.isSetVersion());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetVersion()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.version, other.version);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("Drawing(");
+		boolean first = true;
+
+		sb.append("id:");
+		sb.append(this.id);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("name:");
+		if (this.name == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.name);
+		}
+		first = false;
+		if (isSetNote()) {
+			if (!first) sb.append(", ");
+			sb.append("note:");
+			if (this.note == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.note);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("visible:");
+		sb.append(this.visible);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("layer:");
+		sb.append(this.layer);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("depth:");
+		sb.append(this.depth);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("scale:");
+		sb.append(this.scale);
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("thumbnail:");
+		if (this.thumbnail == null) {
+			sb.append("null");
+		} else {
+			org.apache.thrift.TBaseHelper.toString(this.thumbnail, sb);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("kind:");
+		if (this.kind == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.kind);
+		}
+		first = false;
+		if (isSetSize()) {
+			if (!first) sb.append(", ");
+			sb.append("size:");
+			if (this.size == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.size);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("shapes:");
+		if (this.shapes == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.shapes);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("tags:");
+		if (this.tags == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.tags);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("counts:");
+		if (this.counts == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.counts);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("names:");
+		if (this.names == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.names);
+		}
+		first = false;
+		if (!first) sb.append(", ");
+		sb.append("labels:");
+		if (this.labels == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.labels);
+		}
+		first = false;
+		if (isSetFlags()) {
+			if (!first) sb.append(", ");
+			sb.append("flags:");
+			if (this.flags == null) {
+				sb.append("null");
+			} else {
+				sb.append(this.flags);
+			}
+			first = false;
+		}
+		if (!first) sb.append(", ");
+		sb.append("origin:");
+		if (this.origin == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.origin);
+		}
+		first = false;
+		if (isSetVersion()) {
+			if (!first) sb.append(", ");
+			sb.append("version:");
+			sb.append(this.version);
+			first = false;
+		}
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		// check for sub-struct validity
+		if (origin != null) {
+			origin.validate();
+		}
+	}
+
+	public Map<String, Object> toJsonValue() {
+		Map<String, Object> value = new HashMap<String, Object>();
+		value.put("id", String.valueOf(this.id));
+		if (isSetName()) {
+			value.put("name", this.name);
+		} else {
+			value.put("name", "");
+		}
+		if (isSetNote()) {
+			value.put("note", this.note);
+		}
+		value.put("visible", this.visible);
+		value.put("layer", this.layer);
+		value.put("depth", this.depth);
+		value.put("scale", CanonicalJson.doubleValue(this.scale));
+		if (isSetThumbnail()) {
+			value.put("thumbnail", CanonicalJson.binaryValue(this.thumbnail));
+		} else {
+			value.put("thumbnail", "");
+		}
+		if (isSetKind()) {
+			value.put("kind", CanonicalJson.enumValue(this.kind, Kind.class));
+		} else {
+			value.put("kind", CanonicalJson.enumValue(null, Kind.class));
+		}
+		if (isSetSize()) {
+			value.put("size", CanonicalJson.enumValue(this.size, Kind.class));
+		}
+		if (isSetShapes()) {
+			List<Object> elem22 = new ArrayList<Object>();
+			for (Shape elem23 : this.shapes) {
+				elem22.add(elem23.toJsonValue());
+			}
+			value.put("shapes", elem22);
+		} else {
+			value.put("shapes", new ArrayList<Object>());
+		}
+		if (isSetTags()) {
+			List<Object> elem24 = new ArrayList<Object>();
+			for (String elem25 : this.tags) {
+				elem24.add(elem25);
+			}
+			value.put("tags", CanonicalJson.sorted(elem24));
+		} else {
+			value.put("tags", new ArrayList<Object>());
+		}
+		if (isSetCounts()) {
+			Map<String, Object> elem26 = new HashMap<String, Object>();
+			for (Map.Entry<Kind, java.util.List<Long>> elem27 : this.counts.entrySet()) {
+				List<Object> elem28 = new ArrayList<Object>();
+				for (Long elem29 : elem27.getValue()) {
+					elem28.add(String.valueOf(elem29));
+				}
+				elem26.put(CanonicalJson.key(CanonicalJson.enumValue(elem27.getKey(), Kind.class)), elem28);
+			}
+			value.put("counts", elem26);
+		} else {
+			value.put("counts", new HashMap<String, Object>());
+		}
+		if (isSetNames()) {
+			Map<String, Object> elem30 = new HashMap<String, Object>();
+			for (Map.Entry<Long, String> elem31 : this.names.entrySet()) {
+				elem30.put(CanonicalJson.key(String.valueOf(elem31.getKey())), elem31.getValue());
+			}
+			value.put("names", elem30);
+		} else {
+			value.put("names", new HashMap<String, Object>());
+		}
+		if (isSetLabels()) {
+			List<Object> elem32 = new ArrayList<Object>();
+			for (Map.Entry<Point, String> elem33 : this.labels.entrySet()) {
+				Map<String, Object> elem34 = new HashMap<String, Object>();
+				elem34.put("key", elem33.getKey().toJsonValue());
+				elem34.put("value", elem33.getValue());
+				elem32.add(elem34);
+			}
+			value.put("labels", CanonicalJson.sorted(elem32));
+		} else {
+			value.put("labels", new ArrayList<Object>());
+		}
+		if (isSetFlags()) {
+			Map<String, Object> elem35 = new HashMap<String, Object>();
+			for (Map.Entry<Boolean, Double> elem36 : this.flags.entrySet()) {
+				elem35.put(CanonicalJson.key(elem36.getKey()), CanonicalJson.doubleValue(elem36.getValue()));
+			}
+			value.put("flags", elem35);
+		}
+		if (isSetOrigin()) {
+			value.put("origin", this.origin.toJsonValue());
+		}
+		if (isSetVersion() && this.version != 3L) {
+			value.put("version", String.valueOf(this.version));
+		}
+		return value;
+	}
+
+	public Drawing fromJsonValue(Object value) throws TException {
+		Map<String, Object> object = CanonicalJson.parseObject(value);
+		Object elem37 = object.get("id");
+		if (elem37 != null) {
+			setId(CanonicalJson.parseInt(elem37, 64));
+		} else {
+			throw new TProtocolException("Required field 'id' is not present in struct 'Drawing'");
+		}
+		Object elem38 = object.get("name");
+		if (elem38 != null) {
+			setName(CanonicalJson.parseString(elem38));
+		}
+		Object elem39 = object.get("note");
+		if (elem39 != null) {
+			setNote(CanonicalJson.parseString(elem39));
+		}
+		Object elem40 = object.get("visible");
+		if (elem40 != null) {
+			setVisible(CanonicalJson.parseBool(elem40));
+		}
+		Object elem41 = object.get("layer");
+		if (elem41 != null) {
+			setLayer((byte) CanonicalJson.parseInt(elem41, 8));
+		}
+		Object elem42 = object.get("depth");
+		if (elem42 != null) {
+			setDepth((short) CanonicalJson.parseInt(elem42, 16));
+		}
+		Object elem43 = object.get("scale");
+		if (elem43 != null) {
+			setScale(CanonicalJson.parseDouble(elem43));
+		}
+		Object elem44 = object.get("thumbnail");
+		if (elem44 != null) {
+			setThumbnail(CanonicalJson.parseBinary(elem44));
+		}
+		Object elem45 = object.get("kind");
+		if (elem45 != null) {
+			setKind(CanonicalJson.parseEnum(elem45, Kind.class));
+		}
+		Object elem46 = object.get("size");
+		if (elem46 != null) {
+			setSize(CanonicalJson.parseEnum(elem46, Kind.class));
+		}
+		Object elem47 = object.get("shapes");
+		if (elem47 != null) {
+			java.util.List<Shape> elem48 = new ArrayList<Shape>();
+			for (Object elem49 : CanonicalJson.parseList(elem47)) {
+				elem48.add(new Shape().fromJsonValue(elem49));
+			}
+			setShapes(elem48);
+		}
+		Object elem50 = object.get("tags");
+		if (elem50 != null) {
+			java.util.Set<String> elem51 = new HashSet<String>();
+			for (Object elem52 : CanonicalJson.parseList(elem50)) {
+				elem51.add(CanonicalJson.parseString(elem52));
+			}
+			setTags(elem51);
+		}
+		Object elem53 = object.get("counts");
+		if (elem53 != null) {
+			java.util.Map<Kind, java.util.List<Long>> elem54 = new HashMap<Kind, java.util.List<Long>>();
+			for (Map.Entry<String, Object> elem55 : CanonicalJson.parseObject(elem53).entrySet()) {
+				java.util.List<Long> elem56 = new ArrayList<Long>();
+				for (Object elem57 : CanonicalJson.parseList(elem55.getValue())) {
+					elem56.add(CanonicalJson.parseInt(elem57, 64));
+				}
+				elem54.put(CanonicalJson.parseEnum(elem55.getKey(), Kind.class), elem56);
+			}
+			setCounts(elem54);
+		}
+		Object elem58 = object.get("names");
+		if (elem58 != null) {
+			java.util.Map<Long, String> elem59 = new HashMap<Long, String>();
+			for (Map.Entry<String, Object> elem60 : CanonicalJson.parseObject(elem58).entrySet()) {
+				elem59.put(CanonicalJson.parseInt(elem60.getKey(), 64), CanonicalJson.parseString(elem60.getValue()));
+			}
+			setNames(elem59);
+		}
+		Object elem61 = object.get("labels");
+		if (elem61 != null) {
+			java.util.Map<Point, String> elem62 = new HashMap<Point, String>();
+			for (Pair<Object, Object> elem63 : CanonicalJson.parseEntries(elem61)) {
+				elem62.put(new Point().fromJsonValue(elem63.getLeft()), CanonicalJson.parseString(elem63.getRight()));
+			}
+			setLabels(elem62);
+		}
+		Object elem64 = object.get("flags");
+		if (elem64 != null) {
+			java.util.Map<Boolean, Double> elem65 = new HashMap<Boolean, Double>();
+			for (Map.Entry<String, Object> elem66 : CanonicalJson.parseObject(elem64).entrySet()) {
+				elem65.put(CanonicalJson.parseBoolKey(elem66.getKey()), CanonicalJson.parseDouble(elem66.getValue()));
+			}
+			setFlags(elem65);
+		}
+		Object elem67 = object.get("origin");
+		if (elem67 != null) {
+			setOrigin(new Point().fromJsonValue(elem67));
+		}
+		Object elem68 = object.get("version");
+		if (elem68 != null) {
+			setVersion(CanonicalJson.parseInt(elem68, 64));
+		}
+		return this;
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			__isset_bitfield = 0;
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class DrawingStandardSchemeFactory implements SchemeFactory {
+		public DrawingStandardScheme getScheme() {
+			return new DrawingStandardScheme();
+		}
+	}
+
+	private static class DrawingStandardScheme extends StandardScheme<Drawing> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, Drawing struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // ID
+						if (schemeField.type == org.apache.thrift.protocol.TType.I64) {
+							struct.id = iprot.readI64();
+							struct.setIdIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 2: // NAME
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.name = iprot.readString();
+							struct.setNameIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 3: // NOTE
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.note = iprot.readString();
+							struct.setNoteIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 4: // VISIBLE
+						if (schemeField.type == org.apache.thrift.protocol.TType.BOOL) {
+							struct.visible = iprot.readBool();
+							struct.setVisibleIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 5: // LAYER
+						if (schemeField.type == org.apache.thrift.protocol.TType.BYTE) {
+							struct.layer = iprot.readByte();
+							struct.setLayerIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 6: // DEPTH
+						if (schemeField.type == org.apache.thrift.protocol.TType.I16) {
+							struct.depth = iprot.readI16();
+							struct.setDepthIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 7: // SCALE
+						if (schemeField.type == org.apache.thrift.protocol.TType.DOUBLE) {
+							struct.scale = iprot.readDouble();
+							struct.setScaleIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 8: // THUMBNAIL
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.thumbnail = iprot.readBinary();
+							struct.setThumbnailIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 9: // KIND
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.kind = Kind.findByValue(iprot.readI32());
+							struct.setKindIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 10: // SIZE
+						if (schemeField.type == org.apache.thrift.protocol.TType.I32) {
+							struct.size = Kind.findByValue(iprot.readI32());
+							struct.setSizeIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 11: // SHAPES
+						if (schemeField.type == org.apache.thrift.protocol.TType.LIST) {
+							org.apache.thrift.protocol.TList elem69 = iprot.readListBegin();
+							struct.shapes = new ArrayList<Shape>(elem69.size);
+							for (int elem70 = 0; elem70 < elem69.size; ++elem70) {
+								Shape elem71 = new Shape();
+								elem71.read(iprot);
+								struct.shapes.add(elem71);
+							}
+							iprot.readListEnd();
+							struct.setShapesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 12: // TAGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.SET) {
+							org.apache.thrift.protocol.TSet elem72 = iprot.readSetBegin();
+							struct.tags = new HashSet<String>(2*elem72.size);
+							for (int elem73 = 0; elem73 < elem72.size; ++elem73) {
+								String elem74 = iprot.readString();
+								struct.tags.add(elem74);
+							}
+							iprot.readSetEnd();
+							struct.setTagsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 13: // COUNTS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem75 = iprot.readMapBegin();
+							struct.counts = new HashMap<Kind,java.util.List<Long>>(2*elem75.size);
+							for (int elem76 = 0; elem76 < elem75.size; ++elem76) {
+								Kind elem81 = Kind.findByValue(iprot.readI32());
+								org.apache.thrift.protocol.TList elem78 = iprot.readListBegin();
+								java.util.List<Long> elem77 = new ArrayList<Long>(elem78.size);
+								for (int elem79 = 0; elem79 < elem78.size; ++elem79) {
+									long elem80 = iprot.readI64();
+									elem77.add(elem80);
+								}
+								iprot.readListEnd();
+								struct.counts.put(elem81, elem77);
+							}
+							iprot.readMapEnd();
+							struct.setCountsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 14: // NAMES
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem82 = iprot.readMapBegin();
+							struct.names = new HashMap<Long,String>(2*elem82.size);
+							for (int elem83 = 0; elem83 < elem82.size; ++elem83) {
+								long elem85 = iprot.readI64();
+								String elem84 = iprot.readString();
+								struct.names.put(elem85, elem84);
+							}
+							iprot.readMapEnd();
+							struct.setNamesIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 15: // LABELS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem86 = iprot.readMapBegin();
+							struct.labels = new HashMap<Point,String>(2*elem86.size);
+							for (int elem87 = 0; elem87 < elem86.size; ++elem87) {
+								Point elem89 = new Point();
+								elem89.read(iprot);
+								String elem88 = iprot.readString();
+								struct.labels.put(elem89, elem88);
+							}
+							iprot.readMapEnd();
+							struct.setLabelsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 16: // FLAGS
+						if (schemeField.type == org.apache.thrift.protocol.TType.MAP) {
+							org.apache.thrift.protocol.TMap elem90 = iprot.readMapBegin();
+							struct.flags = new HashMap<Boolean,Double>(2*elem90.size);
+							for (int elem91 = 0; elem91 < elem90.size; ++elem91) {
+								boolean elem93 = iprot.readBool();
+								double elem92 = iprot.readDouble();
+								struct.flags.put(elem93, elem92);
+							}
+							iprot.readMapEnd();
+							struct.setFlagsIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 17: // ORIGIN
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRUCT) {
+							struct.origin = new Point();
+							struct.origin.read(iprot);
+							struct.setOriginIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					case 18: // VERSION
+						if (schemeField.type == org.apache.thrift.protocol.TType.I64) {
+							struct.version = iprot.readI64();
+							struct.setVersionIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			if (!struct.isSetId()) {
+				throw new org.apache.thrift.protocol.TProtocolException("Required field 'id' was not found in serialized data for struct type 'Drawing'");
+			}
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, Drawing struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			oprot.writeFieldBegin(ID_FIELD_DESC);
+			long elem94 = struct.id;
+			oprot.writeI64(elem94);
+			oprot.writeFieldEnd();
+			if (struct.name != null) {
+				oprot.writeFieldBegin(NAME_FIELD_DESC);
+				String elem95 = struct.name;
+				oprot.writeString(elem95);
+				oprot.writeFieldEnd();
+			}
+			if (struct.note != null) {
+				if (struct.isSetNote()) {
+					oprot.writeFieldBegin(NOTE_FIELD_DESC);
+					String elem96 = struct.note;
+					oprot.writeString(elem96);
+					oprot.writeFieldEnd();
+				}
+			}
+			oprot.writeFieldBegin(VISIBLE_FIELD_DESC);
+			boolean elem97 = struct.visible;
+			oprot.writeBool(elem97);
+			oprot.writeFieldEnd();
+			oprot.writeFieldBegin(LAYER_FIELD_DESC);
+			byte elem98 = struct.layer;
+			oprot.writeByte(elem98);
+			oprot.writeFieldEnd();
+			oprot.writeFieldBegin(DEPTH_FIELD_DESC);
+			short elem99 = struct.depth;
+			oprot.writeI16(elem99);
+			oprot.writeFieldEnd();
+			oprot.writeFieldBegin(SCALE_FIELD_DESC);
+			double elem100 = struct.scale;
+			oprot.writeDouble(elem100);
+			oprot.writeFieldEnd();
+			if (struct.thumbnail != null) {
+				oprot.writeFieldBegin(THUMBNAIL_FIELD_DESC);
+				java.nio.ByteBuffer elem101 = struct.thumbnail;
+				oprot.writeBinary(elem101);
+				oprot.writeFieldEnd();
+			}
+			if (struct.kind != null) {
+				oprot.writeFieldBegin(KIND_FIELD_DESC);
+				Kind elem102 = struct.kind;
+				oprot.writeI32(elem102.getValue());
+				oprot.writeFieldEnd();
+			}
+			if (struct.size != null) {
+				if (struct.isSetSize()) {
+					oprot.writeFieldBegin(SIZE_FIELD_DESC);
+					Kind elem103 = struct.size;
+					oprot.writeI32(elem103.getValue());
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.shapes != null) {
+				oprot.writeFieldBegin(SHAPES_FIELD_DESC);
+				oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, struct.shapes.size()));
+				for (Shape elem104 : struct.shapes) {
+					elem104.write(oprot);
+				}
+				oprot.writeListEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.tags != null) {
+				oprot.writeFieldBegin(TAGS_FIELD_DESC);
+				oprot.writeSetBegin(new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, struct.tags.size()));
+				for (String elem105 : struct.tags) {
+					String elem106 = elem105;
+					oprot.writeString(elem106);
+				}
+				oprot.writeSetEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.counts != null) {
+				oprot.writeFieldBegin(COUNTS_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.LIST, struct.counts.size()));
+				for (Map.Entry<Kind, java.util.List<Long>> elem107 : struct.counts.entrySet()) {
+					Kind elem108 = elem107.getKey();
+					oprot.writeI32(elem108.getValue());
+					oprot.writeListBegin(new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I64, elem107.getValue().size()));
+					for (long elem109 : elem107.getValue()) {
+						long elem110 = elem109;
+						oprot.writeI64(elem110);
+					}
+					oprot.writeListEnd();
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.names != null) {
+				oprot.writeFieldBegin(NAMES_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I64, org.apache.thrift.protocol.TType.STRING, struct.names.size()));
+				for (Map.Entry<Long, String> elem111 : struct.names.entrySet()) {
+					long elem112 = elem111.getKey();
+					oprot.writeI64(elem112);
+					String elem113 = elem111.getValue();
+					oprot.writeString(elem113);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.labels != null) {
+				oprot.writeFieldBegin(LABELS_FIELD_DESC);
+				oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRUCT, org.apache.thrift.protocol.TType.STRING, struct.labels.size()));
+				for (Map.Entry<Point, String> elem114 : struct.labels.entrySet()) {
+					elem114.getKey().write(oprot);
+					String elem115 = elem114.getValue();
+					oprot.writeString(elem115);
+				}
+				oprot.writeMapEnd();
+				oprot.writeFieldEnd();
+			}
+			if (struct.flags != null) {
+				if (struct.isSetFlags()) {
+					oprot.writeFieldBegin(FLAGS_FIELD_DESC);
+					oprot.writeMapBegin(new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.BOOL, org.apache.thrift.protocol.TType.DOUBLE, struct.flags.size()));
+					for (Map.Entry<Boolean, Double> elem116 : struct.flags.entrySet()) {
+						boolean elem117 = elem116.getKey();
+						oprot.writeBool(elem117);
+						double elem118 = elem116.getValue();
+						oprot.writeDouble(elem118);
+					}
+					oprot.writeMapEnd();
+					oprot.writeFieldEnd();
+				}
+			}
+			if (struct.origin != null) {
+				oprot.writeFieldBegin(ORIGIN_FIELD_DESC);
+				struct.origin.write(oprot);
+				oprot.writeFieldEnd();
+			}
+			if (struct.isSetVersion()) {
+				oprot.writeFieldBegin(VERSION_FIELD_DESC);
+				long elem119 = struct.version;
+				oprot.writeI64(elem119);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class DrawingTupleSchemeFactory implements SchemeFactory {
+		public DrawingTupleScheme getScheme() {
+			return new DrawingTupleScheme();
+		}
+	}
+
+	private static class DrawingTupleScheme extends TupleScheme<Drawing> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, Drawing struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			long elem120 = struct.id;
+			oprot.writeI64(elem120);
+			BitSet optionals = new BitSet();
+			if (struct.isSetName()) {
+				optionals.set(0);
+			}
+			if (struct.isSetNote()) {
+				optionals.set(1);
+			}
+			if (struct.isSetVisible()) {
+				optionals.set(2);
+			}
+			if (struct.isSetLayer()) {
+				optionals.set(3);
+			}
+			if (struct.isSetDepth()) {
+				optionals.set(4);
+			}
+			if (struct.isSetScale()) {
+				optionals.set(5);
+			}
+			if (struct.isSetThumbnail()) {
+				optionals.set(6);
+			}
+			if (struct.isSetKind()) {
+				optionals.set(7);
+			}
+			if (struct.isSetSize()) {
+				optionals.set(8);
+			}
+			if (struct.isSetShapes()) {
+				optionals.set(9);
+			}
+			if (struct.isSetTags()) {
+				optionals.set(10);
+			}
+			if (struct.isSetCounts()) {
+				optionals.set(11);
+			}
+			if (struct.isSetNames()) {
+				optionals.set(12);
+			}
+			if (struct.isSetLabels()) {
+				optionals.set(13);
+			}
+			if (struct.isSetFlags()) {
+				optionals.set(14);
+			}
+			if (struct.isSetOrigin()) {
+				optionals.set(15);
+			}
+			if (struct.isSetVersion()) {
+				optionals.set(16);
+			}
+			oprot.writeBitSet(optionals, 17);
+			if (struct.isSetName()) {
+				String elem121 = struct.name;
+				oprot.writeString(elem121);
+			}
+			if (struct.isSetNote()) {
+				String elem122 = struct.note;
+				oprot.writeString(elem122);
+			}
+			if (struct.isSetVisible()) {
+				boolean elem123 = struct.visible;
+				oprot.writeBool(elem123);
+			}
+			if (struct.isSetLayer()) {
+				byte elem124 = struct.layer;
+				oprot.writeByte(elem124);
+			}
+			if (struct.isSetDepth()) {
+				short elem125 = struct.depth;
+				oprot.writeI16(elem125);
+			}
+			if (struct.isSetScale()) {
+				double elem126 = struct.scale;
+				oprot.writeDouble(elem126);
+			}
+			if (struct.isSetThumbnail()) {
+				java.nio.ByteBuffer elem127 = struct.thumbnail;
+				oprot.writeBinary(elem127);
+			}
+			if (struct.isSetKind()) {
+				Kind elem128 = struct.kind;
+				oprot.writeI32(elem128.getValue());
+			}
+			if (struct.isSetSize()) {
+				Kind elem129 = struct.size;
+				oprot.writeI32(elem129.getValue());
+			}
+			if (struct.isSetShapes()) {
+				oprot.writeI32(struct.shapes.size());
+				for (Shape elem130 : struct.shapes) {
+					elem130.write(oprot);
+				}
+			}
+			if (struct.isSetTags()) {
+				oprot.writeI32(struct.tags.size());
+				for (String elem131 : struct.tags) {
+					String elem132 = elem131;
+					oprot.writeString(elem132);
+				}
+			}
+			if (struct.isSetCounts()) {
+				oprot.writeI32(struct.counts.size());
+				for (Map.Entry<Kind, java.util.List<Long>> elem133 : struct.counts.entrySet()) {
+					Kind elem134 = elem133.getKey();
+					oprot.writeI32(elem134.getValue());
+					oprot.writeI32(elem133.getValue().size());
+					for (long elem135 : elem133.getValue()) {
+						long elem136 = elem135;
+						oprot.writeI64(elem136);
+					}
+				}
+			}
+			if (struct.isSetNames()) {
+				oprot.writeI32(struct.names.size());
+				for (Map.Entry<Long, String> elem137 : struct.names.entrySet()) {
+					long elem138 = elem137.getKey();
+					oprot.writeI64(elem138);
+					String elem139 = elem137.getValue();
+					oprot.writeString(elem139);
+				}
+			}
+			if (struct.isSetLabels()) {
+				oprot.writeI32(struct.labels.size());
+				for (Map.Entry<Point, String> elem140 : struct.labels.entrySet()) {
+					elem140.getKey().write(oprot);
+					String elem141 = elem140.getValue();
+					oprot.writeString(elem141);
+				}
+			}
+			if (struct.isSetFlags()) {
+				oprot.writeI32(struct.flags.size());
+				for (Map.Entry<Boolean, Double> elem142 : struct.flags.entrySet()) {
+					boolean elem143 = elem142.getKey();
+					oprot.writeBool(elem143);
+					double elem144 = elem142.getValue();
+					oprot.writeDouble(elem144);
+				}
+			}
+			if (struct.isSetOrigin()) {
+				struct.origin.write(oprot);
+			}
+			if (struct.isSetVersion()) {
+				long elem145 = struct.version;
+				oprot.writeI64(elem145);
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, Drawing struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			struct.id = iprot.readI64();
+			struct.setIdIsSet(true);
+			BitSet incoming = iprot.readBitSet(17);
+			if (incoming.get(0)) {
+				struct.name = iprot.readString();
+				struct.setNameIsSet(true);
+			}
+			if (incoming.get(1)) {
+				struct.note = iprot.readString();
+				struct.setNoteIsSet(true);
+			}
+			if (incoming.get(2)) {
+				struct.visible = iprot.readBool();
+				struct.setVisibleIsSet(true);
+			}
+			if (incoming.get(3)) {
+				struct.layer = iprot.readByte();
+				struct.setLayerIsSet(true);
+			}
+			if (incoming.get(4)) {
+				struct.depth = iprot.readI16();
+				struct.setDepthIsSet(true);
+			}
+			if (incoming.get(5)) {
+				struct.scale = iprot.readDouble();
+				struct.setScaleIsSet(true);
+			}
+			if (incoming.get(6)) {
+				struct.thumbnail = iprot.readBinary();
+				struct.setThumbnailIsSet(true);
+			}
+			if (incoming.get(7)) {
+				struct.kind = Kind.findByValue(iprot.readI32());
+				struct.setKindIsSet(true);
+			}
+			if (incoming.get(8)) {
+				struct.size = Kind.findByValue(iprot.readI32());
+				struct.setSizeIsSet(true);
+			}
+			if (incoming.get(9)) {
+				org.apache.thrift.protocol.TList elem146 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.STRUCT, iprot.readI32());
+				struct.shapes = new ArrayList<Shape>(elem146.size);
+				for (int elem147 = 0; elem147 < elem146.size; ++elem147) {
+					Shape elem148 = new Shape();
+					elem148.read(iprot);
+					struct.shapes.add(elem148);
+				}
+				struct.setShapesIsSet(true);
+			}
+			if (incoming.get(10)) {
+				org.apache.thrift.protocol.TSet elem149 = new org.apache.thrift.protocol.TSet(org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.tags = new HashSet<String>(2*elem149.size);
+				for (int elem150 = 0; elem150 < elem149.size; ++elem150) {
+					String elem151 = iprot.readString();
+					struct.tags.add(elem151);
+				}
+				struct.setTagsIsSet(true);
+			}
+			if (incoming.get(11)) {
+				org.apache.thrift.protocol.TMap elem152 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I32, org.apache.thrift.protocol.TType.LIST, iprot.readI32());
+				struct.counts = new HashMap<Kind,java.util.List<Long>>(2*elem152.size);
+				for (int elem153 = 0; elem153 < elem152.size; ++elem153) {
+					Kind elem158 = Kind.findByValue(iprot.readI32());
+					org.apache.thrift.protocol.TList elem155 = new org.apache.thrift.protocol.TList(org.apache.thrift.protocol.TType.I64, iprot.readI32());
+					java.util.List<Long> elem154 = new ArrayList<Long>(elem155.size);
+					for (int elem156 = 0; elem156 < elem155.size; ++elem156) {
+						long elem157 = iprot.readI64();
+						elem154.add(elem157);
+					}
+					struct.counts.put(elem158, elem154);
+				}
+				struct.setCountsIsSet(true);
+			}
+			if (incoming.get(12)) {
+				org.apache.thrift.protocol.TMap elem159 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.I64, org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.names = new HashMap<Long,String>(2*elem159.size);
+				for (int elem160 = 0; elem160 < elem159.size; ++elem160) {
+					long elem162 = iprot.readI64();
+					String elem161 = iprot.readString();
+					struct.names.put(elem162, elem161);
+				}
+				struct.setNamesIsSet(true);
+			}
+			if (incoming.get(13)) {
+				org.apache.thrift.protocol.TMap elem163 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.STRUCT, org.apache.thrift.protocol.TType.STRING, iprot.readI32());
+				struct.labels = new HashMap<Point,String>(2*elem163.size);
+				for (int elem164 = 0; elem164 < elem163.size; ++elem164) {
+					Point elem166 = new Point();
+					elem166.read(iprot);
+					String elem165 = iprot.readString();
+					struct.labels.put(elem166, elem165);
+				}
+				struct.setLabelsIsSet(true);
+			}
+			if (incoming.get(14)) {
+				org.apache.thrift.protocol.TMap elem167 = new org.apache.thrift.protocol.TMap(org.apache.thrift.protocol.TType.BOOL, org.apache.thrift.protocol.TType.DOUBLE, iprot.readI32());
+				struct.flags = new HashMap<Boolean,Double>(2*elem167.size);
+				for (int elem168 = 0; elem168 < elem167.size; ++elem168) {
+					boolean elem170 = iprot.readBool();
+					double elem169 = iprot.readDouble();
+					struct.flags.put(elem170, elem169);
+				}
+				struct.setFlagsIsSet(true);
+			}
+			if (incoming.get(15)) {
+				struct.origin = new Point();
+				struct.origin.read(iprot);
+				struct.setOriginIsSet(true);
+			}
+			if (incoming.get(16)) {
+				struct.version = iprot.readI64();
+				struct.setVersionIsSet(true);
+			}
+		}
+
+	}
+
+}
@@ -0,0 +1,411 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+import com.workiva.frugal.util.CanonicalJson;
+import com.workiva.frugal.util.Pair;
+
+public class DrawingError extends TException implements org.apache.thrift.TBase<DrawingError, DrawingError._Fields>, java.io.Serializable, Cloneable, Comparable<DrawingError> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("DrawingError");
+
+	private static final org.apache.thrift.protocol.TField MESSAGE_FIELD_DESC = new org.apache.thrift.protocol.TField("message", org.apache.thrift.protocol.TType.STRING, (short)1);
+
+	private static final Map<Class<? extends IScheme>, SchemeFactory> schemes = new HashMap<Class<? extends IScheme>, SchemeFactory>();
+	static {
+		schemes.put(StandardScheme.class, new DrawingErrorStandardSchemeFactory());
+		schemes.put(TupleScheme.class, new DrawingErrorTupleSchemeFactory());
+	}
+
+	public String message;
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		MESSAGE((short)1, "message")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // MESSAGE
+					return MESSAGE;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	// isset id assignments
+	public DrawingError() {
+	}
+
+	public DrawingError(
+		String message) {
+		this();
+		this.message = message;
+	}
+
+	/**
+	 * Performs a deep copy on <i>other</i>.
+	 */
+	public DrawingError(DrawingError other) {
+		if (other.isSetMessage()) {
+			this.message = other.message;
+		}
+	}
+
+	public DrawingError deepCopy() {
+		return new DrawingError(this);
+	}
+
+	@Override
+	public void clear() {
+		this.message = null;
+
+	}
+
+	public String getMessage() {
+		return this.message;
+	}
+
+	public DrawingError setMessage(String message) {
+		this.message = message;
+		return this;
+	}
+
+	public void unsetMessage() {
+		this.message = null;
+	}
+
+	/** Returns true if field message is set (has been assigned a value) and false otherwise */
+	public boolean isSetMessage() {
+		return this.message != null;
+	}
+
+	public void setMessageIsSet(boolean value) {
+		if (!value) {
+			this.message = null;
+		}
+	}
+
+	public void setFieldValue(_Fields field, Object value) {
+		switch (field) {
+		case MESSAGE:
+			if (value == null) {
+				unsetMessage();
+			} else {
+				setMessage((String)value);
+			}
+			break;
+
+		}
+	}
+
+	public Object getFieldValue(_Fields field) {
+		switch (field) {
+		case MESSAGE:
+			return getMessage();
+
+		}
+		throw new IllegalStateException();
+	}
+
+	/** Returns true if field corresponding to fieldID is set (has been assigned a value) and false otherwise */
+	public boolean isSet(_Fields field) {
+		if (field == null) {
+			throw new IllegalArgumentException();
+		}
+
+		switch (field) {
+		case MESSAGE:
+			return isSetMessage();
+		}
+		throw new IllegalStateException();
+	}
+
+	@Override
+	public boolean equals(Object that) {
+		if (that == null)
+			return false;
+		if (that instanceof DrawingError)
+			return this.equals((DrawingError)that);
+		return false;
+	}
+
+	public boolean equals(DrawingError that) {
+		if (that == null)
+			return false;
+
+		boolean this_present_message = true && this.isSetMessage();
+		boolean that_present_message = true && that.isSetMessage();
+		if (this_present_message || that_present_message) {
+			if (!(this_present_message && that_present_message))
+				return false;
+			if (!this.message.equals(that.message))
+				return false;
+		}
+
+		return true;
+	}
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+
+		boolean present_message = true && (isSetMessage());
+		list.add(present_message);
+		if (present_message)
+			list.add(message);
+
+		return list.hashCode();
+	}
+
+	@Override
+	public int compareTo(DrawingError other) {
+		if (!getClass().equals(other.getClass())) {
+			return getClass().getName().compareTo(other.getClass().getName());
+		}
+
+		int lastComparison = 0;
+
+		lastComparison = Boolean.valueOf(isSetMessage()).compareTo(other.isSetMessage());
+		if (lastComparison != 0) {
+			return lastComparison;
+		}
+		if (isSetMessage()) {
+			lastComparison = org.apache.thrift.TBaseHelper.compareTo(this.message, other.message);
+			if (lastComparison != 0) {
+				return lastComparison;
+			}
+		}
+		return 0;
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+	public void read(org.apache.thrift.protocol.TProtocol iprot) throws org.apache.thrift.TException {
+		schemes.get(iprot.getScheme()).getScheme().read(iprot, this);
+	}
+
+	public void write(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		schemes.get(oprot.getScheme()).getScheme().write(oprot, this);
+	}
+
+	@Override
+	public String toString() {
+		StringBuilder sb = new StringBuilder("DrawingError(");
+		boolean first = true;
+
+		sb.append("message:");
+		if (this.message == null) {
+			sb.append("null");
+		} else {
+			sb.append(this.message);
+		}
+		first = false;
+		sb.append(")");
+		return sb.toString();
+	}
+
+	public void validate() throws org.apache.thrift.TException {
+		// check for required fields
+		// check for sub-struct validity
+	}
+
+	public Map<String, Object> toJsonValue() {
+		Map<String, Object> value = new HashMap<String, Object>();
+		if (isSetMessage()) {
+			value.put("message", this.message);
+		} else {
+			value.put("message", "");
+		}
+		return value;
+	}
+
+	public DrawingError fromJsonValue(Object value) throws TException {
+		Map<String, Object> object = CanonicalJson.parseObject(value);
+		Object elem175 = object.get("message");
+		if (elem175 != null) {
+			setMessage(CanonicalJson.parseString(elem175));
+		}
+		return this;
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			// it doesn't seem like you should have to do this, but java serialization is wacky, and doesn't call the default constructor.
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private static class DrawingErrorStandardSchemeFactory implements SchemeFactory {
+		public DrawingErrorStandardScheme getScheme() {
+			return new DrawingErrorStandardScheme();
+		}
+	}
+
+	private static class DrawingErrorStandardScheme extends StandardScheme<DrawingError> {
+
+		public void read(org.apache.thrift.protocol.TProtocol iprot, DrawingError struct) throws org.apache.thrift.TException {
+			org.apache.thrift.protocol.TField schemeField;
+			iprot.readStructBegin();
+			while (true) {
+				schemeField = iprot.readFieldBegin();
+				if (schemeField.type == org.apache.thrift.protocol.TType.STOP) {
+					break;
+				}
+				switch (schemeField.id) {
+					case 1: // MESSAGE
+						if (schemeField.type == org.apache.thrift.protocol.TType.STRING) {
+							struct.message = iprot.readString();
+							struct.setMessageIsSet(true);
+						} else {
+							org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+						}
+						break;
+					default:
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, schemeField.type);
+				}
+				iprot.readFieldEnd();
+			}
+			iprot.readStructEnd();
+
+			// check for required fields of primitive type, which can't be checked in the validate method
+			struct.validate();
+		}
+
+		public void write(org.apache.thrift.protocol.TProtocol oprot, DrawingError struct) throws org.apache.thrift.TException {
+			struct.validate();
+
+			oprot.writeStructBegin(STRUCT_DESC);
+			if (struct.message != null) {
+				oprot.writeFieldBegin(MESSAGE_FIELD_DESC);
+				String elem176 = struct.message;
+				oprot.writeString(elem176);
+				oprot.writeFieldEnd();
+			}
+			oprot.writeFieldStop();
+			oprot.writeStructEnd();
+		}
+
+	}
+
+	private static class DrawingErrorTupleSchemeFactory implements SchemeFactory {
+		public DrawingErrorTupleScheme getScheme() {
+			return new DrawingErrorTupleScheme();
+		}
+	}
+
+	private static class DrawingErrorTupleScheme extends TupleScheme<DrawingError> {
+
+		@Override
+		public void write(org.apache.thrift.protocol.TProtocol prot, DrawingError struct) throws org.apache.thrift.TException {
+			TTupleProtocol oprot = (TTupleProtocol) prot;
+			BitSet optionals = new BitSet();
+			if (struct.isSetMessage()) {
+				optionals.set(0);
+			}
+			oprot.writeBitSet(optionals, 1);
+			if (struct.isSetMessage()) {
+				String elem177 = struct.message;
+				oprot.writeString(elem177);
+			}
+		}
+
+		@Override
+		public void read(org.apache.thrift.protocol.TProtocol prot, DrawingError struct) throws org.apache.thrift.TException {
+			TTupleProtocol iprot = (TTupleProtocol) prot;
+			BitSet incoming = iprot.readBitSet(1);
+			if (incoming.get(0)) {
+				struct.message = iprot.readString();
+				struct.setMessageIsSet(true);
+			}
+		}
+
+	}
+
+}
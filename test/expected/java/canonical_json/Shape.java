@@ -0,0 +1,384 @@
+/**
+ * Autogenerated by Frugal Compiler (2.23.0)
+ * DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+ *
+ * @generated
+ */
+
+
+import org.apache.thrift.scheme.IScheme;
+import org.apache.thrift.scheme.SchemeFactory;
+import org.apache.thrift.scheme.StandardScheme;
+
+import org.apache.thrift.scheme.TupleScheme;
+import org.apache.thrift.protocol.TTupleProtocol;
+import org.apache.thrift.protocol.TProtocolException;
+import org.apache.thrift.EncodingUtils;
+import org.apache.thrift.TException;
+import org.apache.thrift.async.AsyncMethodCallback;
+import org.apache.thrift.server.AbstractNonblockingServer.*;
+import java.util.List;
+import java.util.ArrayList;
+import java.util.Map;
+import java.util.HashMap;
+import java.util.EnumMap;
+import java.util.Set;
+import java.util.HashSet;
+import java.util.EnumSet;
+import java.util.Collections;
+import java.util.BitSet;
+import java.nio.ByteBuffer;
+import java.util.Arrays;
+import javax.annotation.Generated;
+import org.slf4j.Logger;
+import org.slf4j.LoggerFactory;
+
+import com.workiva.frugal.util.CanonicalJson;
+import com.workiva.frugal.util.Pair;
+
+public class Shape extends org.apache.thrift.TUnion<Shape, Shape._Fields> {
+	private static final org.apache.thrift.protocol.TStruct STRUCT_DESC = new org.apache.thrift.protocol.TStruct("Shape");
+
+	private static final org.apache.thrift.protocol.TField POINT_FIELD_DESC = new org.apache.thrift.protocol.TField("point", org.apache.thrift.protocol.TType.STRUCT, (short)1);
+	private static final org.apache.thrift.protocol.TField RADIUS_FIELD_DESC = new org.apache.thrift.protocol.TField("radius", org.apache.thrift.protocol.TType.DOUBLE, (short)2);
+
+	/** The set of fields this struct contains, along with convenience methods for finding and manipulating them. */
+	public enum _Fields implements org.apache.thrift.TFieldIdEnum {
+		POINT((short)1, "point"),
+		RADIUS((short)2, "radius")
+		;
+
+		private static final Map<String, _Fields> byName = new HashMap<String, _Fields>();
+
+		static {
+			for (_Fields field : EnumSet.allOf(_Fields.class)) {
+				byName.put(field.getFieldName(), field);
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, or null if its not found.
+		 */
+		public static _Fields findByThriftId(int fieldId) {
+			switch(fieldId) {
+				case 1: // POINT
+					return POINT;
+				case 2: // RADIUS
+					return RADIUS;
+				default:
+					return null;
+			}
+		}
+
+		/**
+		 * Find the _Fields constant that matches fieldId, throwing an exception
+		 * if it is not found.
+		 */
+		public static _Fields findByThriftIdOrThrow(int fieldId) {
+			_Fields fields = findByThriftId(fieldId);
+			if (fields == null) throw new IllegalArgumentException("Field " + fieldId + " doesn't exist!");
+			return fields;
+		}
+
+		/**
+		 * Find the _Fields constant that matches name, or null if its not found.
+		 */
+		public static _Fields findByName(String name) {
+			return byName.get(name);
+		}
+
+		private final short _thriftId;
+		private final String _fieldName;
+
+		_Fields(short thriftId, String fieldName) {
+			_thriftId = thriftId;
+			_fieldName = fieldName;
+		}
+
+		public short getThriftFieldId() {
+			return _thriftId;
+		}
+
+		public String getFieldName() {
+			return _fieldName;
+		}
+	}
+
+	public Shape() {
+		super();
+	}
+
+	public Shape(_Fields setField, Object value) {
+		super(setField, value);
+	}
+
+	public Shape(Shape other) {
+		super(other);
+	}
+	public Shape deepCopy() {
+		return new Shape(this);
+	}
+
+	public static Shape point(Point value) {
+		Shape x = new Shape();
+		x.setPoint(value);
+		return x;
+	}
+
+	public static Shape radius(double value) {
+		Shape x = new Shape();
+		x.setRadius(value);
+		return x;
+	}
+
+	@Override
+	protected void checkType(_Fields setField, Object value) throws ClassCastException {
+		switch (setField) {
+			case POINT:
+				if (value instanceof Point) {
+					break;
+				}
+				throw new ClassCastException("Was expecting value of type Point for field 'point', but got " + value.getClass().getSimpleName());
+			case RADIUS:
+				if (value instanceof Double) {
+					break;
+				}
+				throw new ClassCastException("Was expecting value of type Double for field 'radius', but got " + value.getClass().getSimpleName());
+			default:
+				throw new IllegalArgumentException("Unknown field id " + setField);
+		}
+	}
+
+	@Override
+	protected Object standardSchemeReadValue(org.apache.thrift.protocol.TProtocol iprot, org.apache.thrift.protocol.TField field) throws org.apache.thrift.TException {
+		_Fields setField = _Fields.findByThriftId(field.id);
+		if (setField != null) {
+			switch (setField) {
+				case POINT:
+					if (field.type == POINT_FIELD_DESC.type) {
+						Point point = new Point();
+						point.read(iprot);
+						return point;
+					} else {
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+						return null;
+					}
+				case RADIUS:
+					if (field.type == RADIUS_FIELD_DESC.type) {
+						Double radius = iprot.readDouble();
+						return radius;
+					} else {
+						org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+						return null;
+					}
+				default:
+					throw new IllegalStateException("setField wasn't null, but didn't match any of the case statements!");
+			}
+		} else {
+			org.apache.thrift.protocol.TProtocolUtil.skip(iprot, field.type);
+			return null;
+		}
+	}
+
+	@Override
+	protected void standardSchemeWriteValue(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		switch (setField_) {
+			case POINT:
+				Point point = (Point)value_;
+				point.write(oprot);
+				return;
+			case RADIUS:
+				Double radius = (Double)value_;
+				double elem171 = radius;
+				oprot.writeDouble(elem171);
+				return;
+			default:
+				throw new IllegalStateException("Cannot write union with unknown field " + setField_);
+		}
+	}
+
+	@Override
+	protected Object tupleSchemeReadValue(org.apache.thrift.protocol.TProtocol iprot, short fieldID) throws org.apache.thrift.TException {
+		_Fields setField = _Fields.findByThriftId(fieldID);
+		if (setField != null) {
+			switch (setField) {
+				case POINT:
+					Point point = new Point();
+					point.read(iprot);
+					return point;
+				case RADIUS:
+					Double radius = iprot.readDouble();
+					return radius;
+				default:
+					throw new IllegalStateException("setField wasn't null, but didn't match any of the case statements!");
+			}
+		} else {
+			throw new TProtocolException("Couldn't find a field with field id " + fieldID);
+		}
+	}
+
+	@Override
+	protected void tupleSchemeWriteValue(org.apache.thrift.protocol.TProtocol oprot) throws org.apache.thrift.TException {
+		switch (setField_) {
+			case POINT:
+				Point point = (Point)value_;
+				point.write(oprot);
+				return;
+			case RADIUS:
+				Double radius = (Double)value_;
+				double elem172 = radius;
+				oprot.writeDouble(elem172);
+				return;
+			default:
+				throw new IllegalStateException("Cannot write union with unknown field " + setField_);
+		}
+	}
+
+	@Override
+	protected org.apache.thrift.protocol.TField getFieldDesc(_Fields setField) {
+		switch (setField) {
+			case POINT:
+				return POINT_FIELD_DESC;
+			case RADIUS:
+				return RADIUS_FIELD_DESC;
+			default:
+				throw new IllegalArgumentException("Unknown field id " + setField);
+		}
+	}
+
+	@Override
+	protected org.apache.thrift.protocol.TStruct getStructDesc() {
+		return STRUCT_DESC;
+	}
+
+	@Override
+	protected _Fields enumForId(short id) {
+		return _Fields.findByThriftIdOrThrow(id);
+	}
+
+	public _Fields fieldForId(int fieldId) {
+		return _Fields.findByThriftId(fieldId);
+	}
+
+
+	public Point getPoint() {
+		if (getSetField() == _Fields.POINT) {
+			return (Point)getFieldValue();
+		} else {
+			throw new RuntimeException("Cannot get field 'point' because union is currently set to " + getFieldDesc(getSetField()).name);
+		}
+	}
+
+	public void setPoint(Point value) {
+		if (value == null) throw new NullPointerException();
+		setField_ = _Fields.POINT;
+		value_ = value;
+	}
+
+	public double getRadius() {
+		if (getSetField() == _Fields.RADIUS) {
+			return (Double)getFieldValue();
+		} else {
+			throw new RuntimeException("Cannot get field 'radius' because union is currently set to " + getFieldDesc(getSetField()).name);
+		}
+	}
+
+	public void setRadius(double value) {
+		setField_ = _Fields.RADIUS;
+		value_ = value;
+	}
+
+	public boolean isSetPoint() {
+		return setField_ == _Fields.POINT;
+	}
+
+	public boolean isSetRadius() {
+		return setField_ == _Fields.RADIUS;
+	}
+
+
+	public boolean equals(Object other) {
+		if (other instanceof Shape) {
+			return equals((Shape)other);
+		} else {
+			return false;
+		}
+	}
+
+	public boolean equals(Shape other) {
+		return other != null && getSetField() == other.getSetField() && getFieldValue().equals(other.getFieldValue());
+	}
+
+	@Override
+	public int compareTo(Shape other) {
+		int lastComparison = org.apache.thrift.TBaseHelper.compareTo(getSetField(), other.getSetField());
+		if (lastComparison == 0) {
+			return org.apache.thrift.TBaseHelper.compareTo(getFieldValue(), other.getFieldValue());
+		}
+		return lastComparison;
+	}
+
+
+	@Override
+	public int hashCode() {
+		List<Object> list = new ArrayList<Object>();
+		list.add(this.getClass().getName());
+		org.apache.thrift.TFieldIdEnum setField = getSetField();
+		if (setField != null) {
+			list.add(setField.getThriftFieldId());
+			Object value = getFieldValue();
+			if (value instanceof org.apache.thrift.TEnum) {
+				list.add(((org.apache.thrift.TEnum)getFieldValue()).getValue());
+			} else {
+				list.add(value);
+			}
+		}
+		return list.hashCode();
+	}
+	public Map<String, Object> toJsonValue() {
+		Map<String, Object> value = new HashMap<String, Object>();
+		if (isSetPoint()) {
+			value.put("point", getPoint().toJsonValue());
+		}
+		if (isSetRadius()) {
+			value.put("radius", CanonicalJson.doubleValue(getRadius()));
+		}
+		return value;
+	}
+
+	public Shape fromJsonValue(Object value) throws TException {
+		Map<String, Object> object = CanonicalJson.parseObject(value);
+		int setFields = 0;
+		Object elem173 = object.get("point");
+		if (elem173 != null) {
+			setPoint(new Point().fromJsonValue(elem173));
+			setFields++;
+		}
+		Object elem174 = object.get("radius");
+		if (elem174 != null) {
+			setRadius(CanonicalJson.parseDouble(elem174));
+			setFields++;
+		}
+		if (setFields > 1) {
+			throw new TProtocolException("The union had more than one field set, " + setFields + " were set");
+		}
+		return this;
+	}
+
+	private void writeObject(java.io.ObjectOutputStream out) throws java.io.IOException {
+		try {
+			write(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(out)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+	private void readObject(java.io.ObjectInputStream in) throws java.io.IOException, ClassNotFoundException {
+		try {
+			read(new org.apache.thrift.protocol.TCompactProtocol(new org.apache.thrift.transport.TIOStreamTransport(in)));
+		} catch (org.apache.thrift.TException te) {
+			throw new java.io.IOException(te);
+		}
+	}
+
+}
@@ -0,0 +1,709 @@
+#
+# Autogenerated by Frugal Compiler (2.23.0)
+#
+# DO NOT EDIT UNLESS YOU ARE SURE THAT YOU KNOW WHAT YOU ARE DOING
+#
+
+from thrift.Thrift import TType, TMessageType, TException, TApplicationException
+
+from frugal.util import make_hashable
+from frugal.util import canonical_json
+from thrift.transport import TTransport
+from thrift.protocol import TBinaryProtocol, TProtocol
+
+
+class Kind(int):
+    SMALL = 1
+    LARGE = 2
+
+    _VALUES_TO_NAMES = {
+        1: "SMALL",
+        2: "LARGE",
+    }
+
+    _NAMES_TO_VALUES = {
+        "SMALL": 1,
+        "LARGE": 2,
+    }
+
+class Point(object):
+    """
+    Attributes:
+     - x
+     - y
+    """
+    def __init__(self, x=None, y=None):
+        self.x = x
+        self.y = y
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.I32:
+                    self.x = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.I32:
+                    self.y = iprot.readI32()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Point')
+        if self.x is not None:
+            oprot.writeFieldBegin('x', TType.I32, 1)
+            oprot.writeI32(self.x)
+            oprot.writeFieldEnd()
+        if self.y is not None:
+            oprot.writeFieldBegin('y', TType.I32, 2)
+            oprot.writeI32(self.y)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.x))
+        value = (value * 31) ^ hash(make_hashable(self.y))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+    def to_json_value(self):
+        value = {}
+        elem0 = self.x if self.x is not None else 0
+        value['x'] = elem0
+        elem1 = self.y if self.y is not None else 0
+        value['y'] = elem1
+        return value
+
+    def from_json_value(self, value):
+        canonical_json.parse_object(value)
+        elem2 = value.get('x')
+        if elem2 is not None:
+            self.x = canonical_json.parse_int(elem2, 32)
+        elem3 = value.get('y')
+        if elem3 is not None:
+            self.y = canonical_json.parse_int(elem3, 32)
+        return self
+
+class Drawing(object):
+    """
+    Attributes:
+     - id
+     - name
+     - note
+     - visible
+     - layer
+     - depth
+     - scale
+     - thumbnail
+     - kind
+     - size
+     - shapes
+     - tags
+     - counts
+     - names
+     - labels
+     - flags
+     - origin
+     - version
+    """
+    _DEFAULT_version_MARKER = 3
+    def __init__(self, id=None, name=None, note=None, visible=None, layer=None, depth=None, scale=None, thumbnail=None, kind=None, size=None, shapes=None, tags=None, counts=None, names=None, labels=None, flags=None, origin=None, version=_DEFAULT_version_MARKER):
+        self.id = id
+        self.name = name
+        self.note = note
+        self.visible = visible
+        self.layer = layer
+        self.depth = depth
+        self.scale = scale
+        self.thumbnail = thumbnail
+        self.kind = kind
+        self.size = size
+        self.shapes = shapes
+        self.tags = tags
+        self.counts = counts
+        self.names = names
+        self.labels = labels
+        self.flags = flags
+        self.origin = origin
+        self.version = version
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.I64:
+                    self.id = iprot.readI64()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.STRING:
+                    self.name = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 3:
+                if ftype == TType.STRING:
+                    self.note = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 4:
+                if ftype == TType.BOOL:
+                    self.visible = iprot.readBool()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 5:
+                if ftype == TType.BYTE:
+                    self.layer = iprot.readByte()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 6:
+                if ftype == TType.I16:
+                    self.depth = iprot.readI16()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 7:
+                if ftype == TType.DOUBLE:
+                    self.scale = iprot.readDouble()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 8:
+                if ftype == TType.STRING:
+                    self.thumbnail = iprot.readBinary()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 9:
+                if ftype == TType.I32:
+                    self.kind = Kind(iprot.readI32())
+                else:
+                    iprot.skip(ftype)
+            elif fid == 10:
+                if ftype == TType.I32:
+                    self.size = Kind(iprot.readI32())
+                else:
+                    iprot.skip(ftype)
+            elif fid == 11:
+                if ftype == TType.LIST:
+                    self.shapes = []
+                    (_, elem4) = iprot.readListBegin()
+                    for _ in range(elem4):
+                        elem5 = Shape()
+                        elem5.read(iprot)
+                        self.shapes.append(elem5)
+                    iprot.readListEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 12:
+                if ftype == TType.SET:
+                    self.tags = set()
+                    (_, elem6) = iprot.readSetBegin()
+                    for _ in range(elem6):
+                        elem7 = iprot.readString()
+                        self.tags.add(elem7)
+                    iprot.readSetEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 13:
+                if ftype == TType.MAP:
+                    self.counts = {}
+                    (_, _, elem8) = iprot.readMapBegin()
+                    for _ in range(elem8):
+                        elem10 = Kind(iprot.readI32())
+                        elem9 = []
+                        (_, elem11) = iprot.readListBegin()
+                        for _ in range(elem11):
+                            elem12 = iprot.readI64()
+                            elem9.append(elem12)
+                        iprot.readListEnd()
+                        self.counts[elem10] = elem9
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 14:
+                if ftype == TType.MAP:
+                    self.names = {}
+                    (_, _, elem13) = iprot.readMapBegin()
+                    for _ in range(elem13):
+                        elem15 = iprot.readI64()
+                        elem14 = iprot.readString()
+                        self.names[elem15] = elem14
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 15:
+                if ftype == TType.MAP:
+                    self.labels = {}
+                    (_, _, elem16) = iprot.readMapBegin()
+                    for _ in range(elem16):
+                        elem18 = Point()
+                        elem18.read(iprot)
+                        elem17 = iprot.readString()
+                        self.labels[elem18] = elem17
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 16:
+                if ftype == TType.MAP:
+                    self.flags = {}
+                    (_, _, elem19) = iprot.readMapBegin()
+                    for _ in range(elem19):
+                        elem21 = iprot.readBool()
+                        elem20 = iprot.readDouble()
+                        self.flags[elem21] = elem20
+                    iprot.readMapEnd()
+                else:
+                    iprot.skip(ftype)
+            elif fid == 17:
+                if ftype == TType.STRUCT:
+                    self.origin = Point()
+                    self.origin.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 18:
+                if ftype == TType.I64:
+                    self.version = iprot.readI64()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Drawing')
+        if self.id is not None:
+            oprot.writeFieldBegin('id', TType.I64, 1)
+            oprot.writeI64(self.id)
+            oprot.writeFieldEnd()
+        if self.name is not None:
+            oprot.writeFieldBegin('name', TType.STRING, 2)
+            oprot.writeString(self.name)
+            oprot.writeFieldEnd()
+        if self.note is not None:
+            oprot.writeFieldBegin('note', TType.STRING, 3)
+            oprot.writeString(self.note)
+            oprot.writeFieldEnd()
+        if self.visible is not None:
+            oprot.writeFieldBegin('visible', TType.BOOL, 4)
+            oprot.writeBool(self.visible)
+            oprot.writeFieldEnd()
+        if self.layer is not None:
+            oprot.writeFieldBegin('layer', TType.BYTE, 5)
+            oprot.writeByte(self.layer)
+            oprot.writeFieldEnd()
+        if self.depth is not None:
+            oprot.writeFieldBegin('depth', TType.I16, 6)
+            oprot.writeI16(self.depth)
+            oprot.writeFieldEnd()
+        if self.scale is not None:
+            oprot.writeFieldBegin('scale', TType.DOUBLE, 7)
+            oprot.writeDouble(self.scale)
+            oprot.writeFieldEnd()
+        if self.thumbnail is not None:
+            oprot.writeFieldBegin('thumbnail', TType.STRING, 8)
+            oprot.writeBinary(self.thumbnail)
+            oprot.writeFieldEnd()
+        if self.kind is not None:
+            oprot.writeFieldBegin('kind', TType.I32, 9)
+            oprot.writeI32(self.kind)
+            oprot.writeFieldEnd()
+        if self.size is not None:
+            oprot.writeFieldBegin('size', TType.I32, 10)
+            oprot.writeI32(self.size)
+            oprot.writeFieldEnd()
+        if self.shapes is not None:
+            oprot.writeFieldBegin('shapes', TType.LIST, 11)
+            oprot.writeListBegin(TType.STRUCT, len(self.shapes))
+            for elem22 in self.shapes:
+                elem22.write(oprot)
+            oprot.writeListEnd()
+            oprot.writeFieldEnd()
+        if self.tags is not None:
+            oprot.writeFieldBegin('tags', TType.SET, 12)
+            oprot.writeSetBegin(TType.STRING, len(self.tags))
+            for elem23 in self.tags:
+                oprot.writeString(elem23)
+            oprot.writeSetEnd()
+            oprot.writeFieldEnd()
+        if self.counts is not None:
+            oprot.writeFieldBegin('counts', TType.MAP, 13)
+            oprot.writeMapBegin(TType.I32, TType.LIST, len(self.counts))
+            for elem25, elem24 in self.counts.items():
+                oprot.writeI32(elem25)
+                oprot.writeListBegin(TType.I64, len(elem24))
+                for elem26 in elem24:
+                    oprot.writeI64(elem26)
+                oprot.writeListEnd()
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.names is not None:
+            oprot.writeFieldBegin('names', TType.MAP, 14)
+            oprot.writeMapBegin(TType.I64, TType.STRING, len(self.names))
+            for elem28, elem27 in self.names.items():
+                oprot.writeI64(elem28)
+                oprot.writeString(elem27)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.labels is not None:
+            oprot.writeFieldBegin('labels', TType.MAP, 15)
+            oprot.writeMapBegin(TType.STRUCT, TType.STRING, len(self.labels))
+            for elem30, elem29 in self.labels.items():
+                elem30.write(oprot)
+                oprot.writeString(elem29)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.flags is not None:
+            oprot.writeFieldBegin('flags', TType.MAP, 16)
+            oprot.writeMapBegin(TType.BOOL, TType.DOUBLE, len(self.flags))
+            for elem32, elem31 in self.flags.items():
+                oprot.writeBool(elem32)
+                oprot.writeDouble(elem31)
+            oprot.writeMapEnd()
+            oprot.writeFieldEnd()
+        if self.origin is not None:
+            oprot.writeFieldBegin('origin', TType.STRUCT, 17)
+            self.origin.write(oprot)
+            oprot.writeFieldEnd()
+        if self.version is not None:
+            oprot.writeFieldBegin('version', TType.I64, 18)
+            oprot.writeI64(self.version)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        if self.id is None:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='Required field \'id\' is not present in struct \'Drawing\'')
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.id))
+        value = (value * 31) ^ hash(make_hashable(self.name))
+        value = (value * 31) ^ hash(make_hashable(self.note))
+        value = (value * 31) ^ hash(make_hashable(self.visible))
+        value = (value * 31) ^ hash(make_hashable(self.layer))
+        value = (value * 31) ^ hash(make_hashable(self.depth))
+        value = (value * 31) ^ hash(make_hashable(self.scale))
+        value = (value * 31) ^ hash(make_hashable(self.thumbnail))
+        value = (value * 31) ^ hash(make_hashable(self.kind))
+        value = (value * 31) ^ hash(make_hashable(self.size))
+        value = (value * 31) ^ hash(make_hashable(self.shapes))
+        value = (value * 31) ^ hash(make_hashable(self.tags))
+        value = (value * 31) ^ hash(make_hashable(self.counts))
+        value = (value * 31) ^ hash(make_hashable(self.names))
+        value = (value * 31) ^ hash(make_hashable(self.labels))
+        value = (value * 31) ^ hash(make_hashable(self.flags))
+        value = (value * 31) ^ hash(make_hashable(self.origin))
+        value = (value * 31) ^ hash(make_hashable(self.version))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+    def to_json_value(self):
+        value = {}
+        elem33 = self.id if self.id is not None else 0
+        value['id'] = str(elem33)
+        elem34 = self.name if self.name is not None else ''
+        value['name'] = elem34
+        if self.note is not None:
+            value['note'] = self.note
+        elem35 = self.visible if self.visible is not None else False
+        value['visible'] = elem35
+        elem36 = self.layer if self.layer is not None else 0
+        value['layer'] = elem36
+        elem37 = self.depth if self.depth is not None else 0
+        value['depth'] = elem37
+        elem38 = self.scale if self.scale is not None else 0.0
+        value['scale'] = canonical_json.double(elem38)
+        elem39 = self.thumbnail if self.thumbnail is not None else b''
+        value['thumbnail'] = canonical_json.binary(elem39)
+        elem40 = self.kind if self.kind is not None else 0
+        value['kind'] = canonical_json.enum(elem40, Kind)
+        if self.size is not None:
+            value['size'] = canonical_json.enum(self.size, Kind)
+        elem41 = self.shapes if self.shapes is not None else []
+        value['shapes'] = list(elem41)
+        elem43 = self.tags if self.tags is not None else set()
+        value['tags'] = canonical_json.sorted_values(list(elem43))
+        elem45 = self.counts if self.counts is not None else {}
+        value['counts'] = {canonical_json.key(canonical_json.enum(elem46, Kind)): [str(elem48) for elem48 in elem47] for elem46, elem47 in elem45.items()}
+        elem49 = self.names if self.names is not None else {}
+        value['names'] = {canonical_json.key(str(elem50)): elem51 for elem50, elem51 in elem49.items()}
+        elem52 = self.labels if self.labels is not None else {}
+        value['labels'] = canonical_json.sorted_values([{'key': elem53, 'value': elem54} for elem53, elem54 in elem52.items()])
+        if self.flags is not None:
+            value['flags'] = {canonical_json.key(elem55): canonical_json.double(elem56) for elem55, elem56 in self.flags.items()}
+        if self.origin is not None:
+            value['origin'] = self.origin
+        if self.version is not None and self.version != self._DEFAULT_version_MARKER:
+            value['version'] = str(self.version)
+        return value
+
+    def from_json_value(self, value):
+        canonical_json.parse_object(value)
+        elem57 = value.get('id')
+        if elem57 is not None:
+            self.id = canonical_json.parse_int(elem57, 64)
+        else:
+            raise ValueError('Required field \'id\' is not present in struct \'Drawing\'')
+        elem58 = value.get('name')
+        if elem58 is not None:
+            self.name = canonical_json.parse_string(elem58)
+        elem59 = value.get('note')
+        if elem59 is not None:
+            self.note = canonical_json.parse_string(elem59)
+        elem60 = value.get('visible')
+        if elem60 is not None:
+            self.visible = canonical_json.parse_bool(elem60)
+        elem61 = value.get('layer')
+        if elem61 is not None:
+            self.layer = canonical_json.parse_int(elem61, 8)
+        elem62 = value.get('depth')
+        if elem62 is not None:
+            self.depth = canonical_json.parse_int(elem62, 16)
+        elem63 = value.get('scale')
+        if elem63 is not None:
+            self.scale = canonical_json.parse_double(elem63)
+        elem64 = value.get('thumbnail')
+        if elem64 is not None:
+            self.thumbnail = canonical_json.parse_binary(elem64)
+        elem65 = value.get('kind')
+        if elem65 is not None:
+            self.kind = canonical_json.parse_enum(elem65, Kind)
+        elem66 = value.get('size')
+        if elem66 is not None:
+            self.size = canonical_json.parse_enum(elem66, Kind)
+        elem67 = value.get('shapes')
+        if elem67 is not None:
+            self.shapes = [Shape().from_json_value(elem68) for elem68 in canonical_json.parse_list(elem67)]
+        elem69 = value.get('tags')
+        if elem69 is not None:
+            self.tags = set(canonical_json.parse_string(elem70) for elem70 in canonical_json.parse_list(elem69))
+        elem71 = value.get('counts')
+        if elem71 is not None:
+            self.counts = {canonical_json.parse_enum(elem72, Kind): [canonical_json.parse_int(elem74, 64) for elem74 in canonical_json.parse_list(elem73)] for elem72, elem73 in canonical_json.parse_object(elem71).items()}
+        elem75 = value.get('names')
+        if elem75 is not None:
+            self.names = {canonical_json.parse_int(elem76, 64): canonical_json.parse_string(elem77) for elem76, elem77 in canonical_json.parse_object(elem75).items()}
+        elem78 = value.get('labels')
+        if elem78 is not None:
+            self.labels = {Point().from_json_value(elem79): canonical_json.parse_string(elem80) for elem79, elem80 in canonical_json.parse_entries(elem78)}
+        elem81 = value.get('flags')
+        if elem81 is not None:
+            self.flags = {canonical_json.parse_bool_key(elem82): canonical_json.parse_double(elem83) for elem82, elem83 in canonical_json.parse_object(elem81).items()}
+        elem84 = value.get('origin')
+        if elem84 is not None:
+            self.origin = Point().from_json_value(elem84)
+        elem85 = value.get('version')
+        if elem85 is not None:
+            self.version = canonical_json.parse_int(elem85, 64)
+        return self
+
+class Shape(object):
+    """
+    Attributes:
+     - point
+     - radius
+    """
+    def __init__(self, point=None, radius=None):
+        self.point = point
+        self.radius = radius
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRUCT:
+                    self.point = Point()
+                    self.point.read(iprot)
+                else:
+                    iprot.skip(ftype)
+            elif fid == 2:
+                if ftype == TType.DOUBLE:
+                    self.radius = iprot.readDouble()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('Shape')
+        if self.point is not None:
+            oprot.writeFieldBegin('point', TType.STRUCT, 1)
+            self.point.write(oprot)
+            oprot.writeFieldEnd()
+        if self.radius is not None:
+            oprot.writeFieldBegin('radius', TType.DOUBLE, 2)
+            oprot.writeDouble(self.radius)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        set_fields = 0
+        if self.point is not None:
+            set_fields += 1
+        if self.radius is not None:
+            set_fields += 1
+        if set_fields != 1:
+            raise TProtocol.TProtocolException(type=TProtocol.TProtocolException.INVALID_DATA, message='The union did not have exactly one field set, {} were set'.format(set_fields))
+        return
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.point))
+        value = (value * 31) ^ hash(make_hashable(self.radius))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+    def to_json_value(self):
+        value = {}
+        if self.point is not None:
+            value['point'] = self.point
+        if self.radius is not None:
+            value['radius'] = canonical_json.double(self.radius)
+        return value
+
+    def from_json_value(self, value):
+        canonical_json.parse_object(value)
+        elem86 = value.get('point')
+        if elem86 is not None:
+            self.point = Point().from_json_value(elem86)
+        elem87 = value.get('radius')
+        if elem87 is not None:
+            self.radius = canonical_json.parse_double(elem87)
+        set_fields = 0
+        if self.point is not None:
+            set_fields += 1
+        if self.radius is not None:
+            set_fields += 1
+        if set_fields > 1:
+            raise ValueError('The union had more than one field set, {} were set'.format(set_fields))
+        return self
+
+class DrawingError(TException):
+    """
+    Attributes:
+     - message
+    """
+    def __init__(self, message=None):
+        self.message = message
+
+    def read(self, iprot):
+        iprot.readStructBegin()
+        while True:
+            (fname, ftype, fid) = iprot.readFieldBegin()
+            if ftype == TType.STOP:
+                break
+            if fid == 1:
+                if ftype == TType.STRING:
+                    self.message = iprot.readString()
+                else:
+                    iprot.skip(ftype)
+            else:
+                iprot.skip(ftype)
+            iprot.readFieldEnd()
+        iprot.readStructEnd()
+        self.validate()
+
+    def write(self, oprot):
+        self.validate()
+        oprot.writeStructBegin('DrawingError')
+        if self.message is not None:
+            oprot.writeFieldBegin('message', TType.STRING, 1)
+            oprot.writeString(self.message)
+            oprot.writeFieldEnd()
+        oprot.writeFieldStop()
+        oprot.writeStructEnd()
+
+    def validate(self):
+        return
+
+    def __str__(self):
+        return repr(self)
+
+    def __hash__(self):
+        value = 17
+        value = (value * 31) ^ hash(make_hashable(self.message))
+        return value
+
+    def __repr__(self):
+        L = ['%s=%r' % (key, value)
+            for key, value in self.__dict__.items()]
+        return '%s(%s)' % (self.__class__.__name__, ', '.join(L))
+
+    def __eq__(self, other):
+        return isinstance(other, self.__class__) and self.__dict__ == other.__dict__
+
+    def __ne__(self, other):
+        return not (self == other)
+
+    def to_json_value(self):
+        value = {}
+        elem88 = self.message if self.message is not None else ''
+        value['message'] = elem88
+        return value
+
+    def from_json_value(self, value):
+        canonical_json.parse_object(value)
+        elem89 = value.get('message')
+        if elem89 is not None:
+            self.message = canonical_json.parse_string(elem89)
+        return self
+
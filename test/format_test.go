@@ -0,0 +1,71 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/format"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	unformattedFile       = "idl/format/unformatted.frugal"
+	formattedFile         = "expected/format/formatted.frugal"
+	formatCommentsFile    = "idl/format/comments.frugal"
+	formatCommentsOutFile = "expected/format/comments.frugal"
+)
+
+func TestFormat(t *testing.T) {
+	for file, expectedFile := range map[string]string{
+		unformattedFile:    formattedFile,
+		formatCommentsFile: formatCommentsOutFile,
+	} {
+		contents, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		expected, err := ioutil.ReadFile(expectedFile)
+		assert.Nil(t, err)
+
+		formatted, err := format.Format(file, contents)
+		assert.Nil(t, err)
+		assert.Equal(t, string(expected), string(formatted), file)
+	}
+}
+
+func TestFormatIdempotent(t *testing.T) {
+	for _, file := range []string{frugalGenFile, validFile, formatCommentsFile} {
+		contents, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		once, err := format.Format(file, contents)
+		assert.Nil(t, err)
+		twice, err := format.Format(file, once)
+		assert.Nil(t, err)
+		assert.Equal(t, string(once), string(twice), file)
+	}
+	for _, file := range []string{formattedFile, formatCommentsOutFile} {
+		contents, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		formatted, err := format.Format(file, contents)
+		assert.Nil(t, err)
+		assert.Equal(t, string(contents), string(formatted), file)
+	}
+}
+
+func TestFormatInvalid(t *testing.T) {
+	contents, err := ioutil.ReadFile(invalidFile)
+	assert.Nil(t, err)
+	_, err = format.Format(invalidFile, contents)
+	assert.Error(t, err)
+}
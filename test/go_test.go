@@ -38,6 +38,24 @@ func TestValidGoWithAsync(t *testing.T) {
 	compareAllFiles(t, files)
 }
 
+func TestValidGoWithContext(t *testing.T) {
+	options := compiler.Options{
+		File:  frugalGenFile,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/context/,context",
+		Out:   outputDir + "/context",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/variety_context/f_foo_service.txt", filepath.Join(outputDir, "context", "variety", "f_foo_service.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
 func TestValidGoFrugalCompiler(t *testing.T) {
 	options := compiler.Options{
 		File:    frugalGenFile,
@@ -84,6 +102,46 @@ func TestValidGoVendor(t *testing.T) {
 	compareAllFiles(t, files)
 }
 
+// Ensures enums from vendored includes are not checked with strict_enums,
+// since the vendored code may have been generated without IsValid.
+func TestValidGoVendorStrictEnums(t *testing.T) {
+	options := compiler.Options{
+		File:  includeVendor,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/vendor_strict_enums/,use_vendor,strict_enums",
+		Out:   outputDir + "/vendor_strict_enums",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/vendor_strict_enums/f_types.txt", filepath.Join(outputDir, "vendor_strict_enums", "include_vendor", "f_types.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
+// Ensures canonical JSON marshals enums from vendored includes without
+// IsValid, since the vendored code may have been generated without it.
+func TestValidGoVendorCanonicalJSON(t *testing.T) {
+	options := compiler.Options{
+		File:  includeVendor,
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/vendor_canonical_json/,use_vendor,canonical_json",
+		Out:   outputDir + "/vendor_canonical_json",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/vendor_canonical_json/f_types.txt", filepath.Join(outputDir, "vendor_canonical_json", "include_vendor", "f_types.go")},
+	}
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
+
 // Ensures an error is returned when -use-vendor is set and the vendored
 // include does not specify a path.
 func TestValidGoVendorPathNotSpecified(t *testing.T) {
@@ -165,3 +223,141 @@ func TestSlim(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+// Ensures Equals, DeepCopy and Hash methods are generated when enabled.
+func TestValidGoValueMethods(t *testing.T) {
+	options := compiler.Options{
+		File:    "idl/values.frugal",
+		Gen:     "go:package_prefix=github.com/Workiva/frugal/test/out/values/,equals,deep_copy,hash",
+		Out:     outputDir + "/values",
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/values/f_types.go", filepath.Join(outputDir, "values", "values", "f_types.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "values", "values"), "runtime/go/values_test.txt")
+}
+
+// Ensures Validate methods are generated for fields with validation
+// annotations and called by the processor.
+func TestValidGoConstraints(t *testing.T) {
+	options := compiler.Options{
+		File:    "idl/constraints.frugal",
+		Gen:     "go:package_prefix=github.com/Workiva/frugal/test/out/constraints/",
+		Out:     outputDir + "/constraints",
+		Delim:   delim,
+		Recurse: true,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/constraints/f_types.go", filepath.Join(outputDir, "constraints", "constraints", "f_types.go")},
+		{"expected/go/constraints/f_accounts_service.go", filepath.Join(outputDir, "constraints", "constraints", "f_accounts_service.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "constraints", "constraints"), "runtime/go/constraints_test.txt")
+}
+
+// Ensures mocks of services and fakes of scopes are generated with the mocks
+// option.
+func TestValidGoMocks(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/mocks.frugal",
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/mocks/,mocks",
+		Out:   outputDir + "/mocks",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/mocks/f_base_service_mock.go", filepath.Join(outputDir, "mocks", "mocks", "f_base_service_mock.go")},
+		{"expected/go/mocks/f_store_service_mock.go", filepath.Join(outputDir, "mocks", "mocks", "f_store_service_mock.go")},
+		{"expected/go/mocks/f_events_scope_fake.go", filepath.Join(outputDir, "mocks", "mocks", "f_events_scope_fake.go")},
+		{"expected/go/mocks/f_alerts_scope_fake.go", filepath.Join(outputDir, "mocks", "mocks", "f_alerts_scope_fake.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "mocks", "mocks"), "runtime/go/mocks_test.txt")
+}
+
+// Ensures generated clients include the annotations of their base service and
+// don't hide an annotations method of a base service.
+func TestValidGoClientAnnotations(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/client_annotations.frugal",
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/client_annotations/",
+		Out:   outputDir + "/client_annotations",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/client_annotations/f_child_service.go", filepath.Join(outputDir, "client_annotations", "client_annotations", "f_child_service.go")},
+		{"expected/go/client_annotations/f_derived_service.go", filepath.Join(outputDir, "client_annotations", "client_annotations", "f_derived_service.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "client_annotations", "client_annotations"), "runtime/go/client_annotations_test.txt")
+}
+
+// Ensures generated Read methods check enum values with the strict_enums
+// option.
+func TestValidGoStrictEnums(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/strict_enums.frugal",
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/strict_enums/,strict_enums",
+		Out:   outputDir + "/strict_enums",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/strict_enums/f_types.go", filepath.Join(outputDir, "strict_enums", "strict_enums", "f_types.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "strict_enums", "strict_enums"), "runtime/go/strict_enums_test.txt")
+}
+
+// Ensures MarshalJSON and UnmarshalJSON methods are generated with the
+// canonical_json option.
+func TestValidGoCanonicalJSON(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/canonical_json.frugal",
+		Gen:   "go:package_prefix=github.com/Workiva/frugal/test/out/canonical_json/,canonical_json",
+		Out:   outputDir + "/canonical_json",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/go/canonical_json/f_types.go", filepath.Join(outputDir, "canonical_json", "canonical_json", "f_types.go")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runGoTest(t, filepath.Join(outputDir, "canonical_json", "canonical_json"), "runtime/go/canonical_json_test.txt")
+}
@@ -0,0 +1,7 @@
+namespace go base (vendor="github.com/bar/base")
+
+typedef string Id
+
+struct Thing {
+    1: i32 count
+}
@@ -0,0 +1,13 @@
+include "base.thrift"
+
+typedef base.Id UserId
+
+struct User {
+    1: UserId id,
+    2: base.Thing thing,
+    3: string name (deprecated="use thing")
+}
+
+service Users {
+    User get(1: UserId id)
+}
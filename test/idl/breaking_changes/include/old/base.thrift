@@ -0,0 +1,7 @@
+namespace go base (vendor="github.com/foo/base")
+
+typedef i32 Id
+
+struct Thing {
+    1: i64 count
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	includePathsFile = "idl/include_paths/app/app.frugal"
+	includePathsDir  = "idl/include_paths/lib"
+)
+
+func TestIncludePaths(t *testing.T) {
+	options := compiler.Options{
+		File:   includePathsFile,
+		Gen:    "go",
+		Out:    outputDir,
+		Delim:  delim,
+		DryRun: true,
+	}
+	assert.Error(t, compiler.Compile(options))
+
+	options.IncludePaths = []string{"idl/include_paths/missing", includePathsDir}
+	assert.Nil(t, compiler.Compile(options))
+}
+
+func TestIncludePathEnv(t *testing.T) {
+	defer os.Unsetenv(parser.IncludePathEnv)
+	os.Setenv(parser.IncludePathEnv, "idl/include_paths/missing"+string(os.PathListSeparator)+includePathsDir)
+
+	frugal, err := parser.ParseFrugal(includePathsFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "idl/include_paths/lib/shared/common.frugal", frugal.ParsedIncludes["common"].File)
+}
+
+// Ensures include search paths are checked for the include without reading
+// the files which are not there.
+func TestIncludePathsExists(t *testing.T) {
+	var read []string
+	frugal, err := parser.ParseFrugalWithOptions(includePathsFile, parser.ParseOptions{
+		Read: func(filePath string) ([]byte, error) {
+			read = append(read, filePath)
+			return ioutil.ReadFile(filePath)
+		},
+		Exists: func(filePath string) bool {
+			_, err := os.Stat(filePath)
+			return err == nil
+		},
+		IncludePaths: []string{"idl/include_paths/missing", includePathsDir},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "idl/include_paths/lib/shared/common.frugal", frugal.ParsedIncludes["common"].File)
+	assert.Equal(t, []string{includePathsFile, "idl/include_paths/lib/shared/common.frugal"}, read)
+}
+
+func TestIncludePathsAuditAndLint(t *testing.T) {
+	auditor := parser.NewAuditorWithConfig(nil, nil)
+	assert.Error(t, auditor.Audit(includePathsFile, includePathsFile))
+	auditor.SetIncludePaths([]string{includePathsDir})
+	assert.Nil(t, auditor.Audit(includePathsFile, includePathsFile))
+
+	linter := parser.NewLinter(&MockValidationLogger{}, nil)
+	assert.Error(t, linter.Lint(includePathsFile))
+	linter.SetIncludePaths([]string{includePathsDir})
+	assert.Nil(t, linter.Lint(includePathsFile))
+}
+
+func TestIncludePathsLSP(t *testing.T) {
+	contents, err := ioutil.ReadFile(includePathsFile)
+	assert.Nil(t, err)
+
+	for _, includePaths := range [][]string{nil, {includePathsDir}} {
+		messages := runLSPWithIncludePaths(t, includePaths, lspOpen(t, includePathsFile, string(contents)))
+		var diagnostics []interface{}
+		for _, msg := range messages {
+			if msg.Method == "textDocument/publishDiagnostics" {
+				var params struct {
+					Diagnostics []interface{} `json:"diagnostics"`
+				}
+				assert.Nil(t, json.Unmarshal(msg.Params, &params))
+				diagnostics = append(diagnostics, params.Diagnostics...)
+			}
+		}
+		if includePaths == nil {
+			assert.NotEmpty(t, diagnostics)
+		} else {
+			assert.Empty(t, diagnostics)
+		}
+	}
+}
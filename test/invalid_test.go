@@ -14,9 +14,12 @@
 package test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Workiva/frugal/compiler"
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInvalid(t *testing.T) {
@@ -115,3 +118,47 @@ func TestWildcardNamespaceWithVendorAnnotation(t *testing.T) {
 		t.Fatal("Expected error")
 	}
 }
+
+// Ensures every validation error is reported with its position.
+func TestValidationErrorPositions(t *testing.T) {
+	_, err := parser.ParseFrugal(multipleErrors)
+	errs, ok := err.(parser.ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	assert.Equal(t, []string{
+		multipleErrors + ":13:5: Duplicate method name ping",
+		multipleErrors + ":3:5: Duplicate field id 1 in struct Foo",
+		multipleErrors + ":7:5: Invalid type Missing on struct Foo",
+		multipleErrors + ":12:5: Void method Bar.pong cannot return i32",
+	}, strings.Split(errs.Error(), "\n"))
+	assert.Equal(t, parser.Pos{Line: 13, Col: 5}, errs[0].Pos)
+	assert.Equal(t, multipleErrors, errs[0].File)
+}
+
+// Ensures validation annotations are checked against their field types.
+func TestInvalidConstraints(t *testing.T) {
+	_, err := parser.ParseFrugal(invalidConstraints)
+	errs, ok := err.(parser.ValidationErrors)
+	if !ok {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	assert.Equal(t, []string{
+		invalidConstraints + ":2:5: validate.min does not apply to field name of type string",
+		invalidConstraints + ":3:5: Invalid validate.max value '300' on field age",
+		invalidConstraints + ":4:5: validate.min is greater than validate.max on field score",
+		invalidConstraints + ":5:5: Invalid validate.pattern value '(' on field email: error parsing regexp: missing closing ): `(`",
+		invalidConstraints + ":6:5: validate.non_empty on field tags takes no value",
+		invalidConstraints + ":7:5: Unknown validation annotation validate.required on field active",
+		invalidConstraints + ":8:5: Invalid validate.min value 'NaN' on field ratio",
+		invalidConstraints + ":8:5: Invalid validate.max value 'Inf' on field ratio",
+		invalidConstraints + ":12:17: validate.max_len does not apply to field limit of type double",
+	}, strings.Split(errs.Error(), "\n"))
+}
+
+// Ensures an unknown_value annotation must name a value of its enum.
+func TestInvalidUnknownValue(t *testing.T) {
+	_, err := parser.ParseFrugal(invalidUnknownValue)
+	assert.EqualError(t, err, invalidUnknownValue+
+		":1:1: Invalid unknown_value annotation on enum Status, \"UNKNOWN\" is not a value of the enum")
+}
@@ -233,3 +233,24 @@ func TestValidJavaVendorNoPathUsesDefinedNamespace(t *testing.T) {
 
 	assertFilesNotExist(t, filesNotToGenerate)
 }
+
+func TestValidJavaCanonicalJSON(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/canonical_json.frugal",
+		Gen:   "java:generated_annotations=suppress,canonical_json",
+		Out:   outputDir + "/java.canonical_json",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/java/canonical_json/Drawing.java", filepath.Join(outputDir, "java.canonical_json", "Drawing.java")},
+		{"expected/java/canonical_json/Shape.java", filepath.Join(outputDir, "java.canonical_json", "Shape.java")},
+		{"expected/java/canonical_json/DrawingError.java", filepath.Join(outputDir, "java.canonical_json", "DrawingError.java")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	lintFile       = "idl/lint.frugal"
+	lintConfigFile = "idl/lint_config.yaml"
+)
+
+func TestLint(t *testing.T) {
+	logger := &MockValidationLogger{}
+	linter := parser.NewLinter(logger, nil)
+	assert.Nil(t, linter.Lint(lintFile))
+	assert.Empty(t, logger.errors)
+	assert.Equal(t, []string{
+		"idl/lint.frugal:1:1: Include base.frugal is not used [unused-include]",
+		"idl/lint.frugal:6:5: Enum value Color.GREEN has no explicit number [enum-value-implicit]",
+		"idl/lint.frugal:10:5: Field color of Paint has ID 0, IDs should be at least 1 [field-id]",
+		"idl/lint.frugal:11:5: Field name of Paint is required [required-field]",
+		"idl/lint.frugal:20:1: Service Undocumented has no doc string [service-docstring]",
+		"idl/lint.frugal:25:5: Operation Created of scope Events collides with prefix variable created [scope-prefix-collision]",
+	}, logger.warnings)
+}
+
+func TestLintConfig(t *testing.T) {
+	config, err := parser.LoadLintConfig(lintConfigFile)
+	assert.Nil(t, err)
+	logger := &MockValidationLogger{}
+	linter := parser.NewLinter(logger, config)
+	assert.Error(t, linter.Lint(lintFile))
+	assert.Equal(t, []string{
+		"idl/lint.frugal:11:5: Field name of Paint is required [required-field]",
+	}, logger.errors)
+	assert.Len(t, logger.warnings, 4)
+}
+
+func TestLoadLintConfigInvalid(t *testing.T) {
+	_, err := parser.LoadLintConfig("idl/lint_config_invalid.yaml")
+	assert.Error(t, err)
+}
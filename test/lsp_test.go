@@ -0,0 +1,302 @@
+/*
+ * Copyright 2017 Workiva
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Workiva/frugal/compiler/lsp"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	lspDir    = "idl/lsp"
+	lspMain   = "idl/lsp/main.frugal"
+	lspShapes = "idl/lsp/shapes.frugal"
+)
+
+type lspMessage struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code int `json:"code"`
+	} `json:"error"`
+}
+
+// runLSP sends the given requests to a language server and returns its
+// responses and notifications. Requests with a nil ID are notifications.
+func runLSP(t *testing.T, requests ...map[string]interface{}) []*lspMessage {
+	return runLSPWithIncludePaths(t, nil, requests...)
+}
+
+// runLSPWithIncludePaths is runLSP with a server which searches the given
+// directories for includes.
+func runLSPWithIncludePaths(t *testing.T, includePaths []string, requests ...map[string]interface{}) []*lspMessage {
+	var in bytes.Buffer
+	for _, req := range requests {
+		req["jsonrpc"] = "2.0"
+		body, err := json.Marshal(req)
+		assert.Nil(t, err)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	fmt.Fprintf(&in, "Content-Length: 33\r\n\r\n{\"jsonrpc\":\"2.0\",\"method\":\"exit\"}")
+
+	var out bytes.Buffer
+	server := lsp.NewServer(&in, &out)
+	server.SetIncludePaths(includePaths)
+	assert.Nil(t, server.Run())
+
+	messages := []*lspMessage{}
+	reader := bufio.NewReader(&out)
+	for {
+		header, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return messages
+		}
+		assert.Nil(t, err)
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+		assert.Nil(t, err)
+		reader.ReadString('\n')
+		body := make([]byte, length)
+		_, err = io.ReadFull(reader, body)
+		assert.Nil(t, err)
+		msg := &lspMessage{}
+		assert.Nil(t, json.Unmarshal(body, msg))
+		messages = append(messages, msg)
+	}
+}
+
+func lspResult(t *testing.T, messages []*lspMessage, id int, result interface{}) {
+	for _, msg := range messages {
+		if msg.ID != nil && *msg.ID == id {
+			assert.Nil(t, json.Unmarshal(msg.Result, result))
+			return
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+func lspURI(t *testing.T, file string) string {
+	abs, err := filepath.Abs(file)
+	assert.Nil(t, err)
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+}
+
+func lspOpen(t *testing.T, file, text string) map[string]interface{} {
+	return map[string]interface{}{
+		"method": "textDocument/didOpen",
+		"params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": lspURI(t, file), "text": text},
+		},
+	}
+}
+
+func lspRequest(t *testing.T, id int, method, file string, line, char int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     id,
+		"method": method,
+		"params": map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": lspURI(t, file)},
+			"position":     map[string]interface{}{"line": line, "character": char},
+			"context":      map[string]interface{}{"includeDeclaration": false},
+		},
+	}
+}
+
+func TestLSPDiagnostics(t *testing.T) {
+	messages := runLSP(t,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		lspOpen(t, lspMain, "struct Foo {\n    1: Missing bar\n}\n"),
+		lspOpen(t, lspShapes, "struct Foo {\n    1: i32 bar\n}\n"),
+		map[string]interface{}{"id": 2, "method": "unknown"},
+	)
+
+	var diagnostics []map[string]interface{}
+	for _, msg := range messages {
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var params struct {
+				URI         string                   `json:"uri"`
+				Diagnostics []map[string]interface{} `json:"diagnostics"`
+			}
+			assert.Nil(t, json.Unmarshal(msg.Params, &params))
+			if params.URI == lspURI(t, lspMain) {
+				diagnostics = params.Diagnostics
+			} else {
+				assert.Empty(t, params.Diagnostics)
+			}
+		}
+	}
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, "Invalid type Missing on struct Foo", diagnostics[0]["message"])
+	assert.Equal(t, map[string]interface{}{
+		"start": map[string]interface{}{"line": 1.0, "character": 4.0},
+		"end":   map[string]interface{}{"line": 1.0, "character": 5.0},
+	}, diagnostics[0]["range"])
+	assert.Equal(t, -32601, messages[len(messages)-1].Error.Code)
+}
+
+func TestLSPNavigation(t *testing.T) {
+	messages := runLSP(t,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{"rootPath": lspDir}},
+		lspRequest(t, 2, "textDocument/definition", lspMain, 4, 20),
+		lspRequest(t, 3, "textDocument/definition", lspMain, 4, 13),
+		lspRequest(t, 4, "textDocument/definition", lspMain, 5, 47),
+		lspRequest(t, 5, "textDocument/hover", lspMain, 14, 5),
+		lspRequest(t, 6, "textDocument/references", lspMain, 4, 20),
+		lspRequest(t, 7, "textDocument/references", lspMain, 5, 15),
+	)
+
+	var location struct {
+		URI   string
+		Range struct{ Start struct{ Line, Character int } }
+	}
+	lspResult(t, messages, 2, &location)
+	assert.Equal(t, lspURI(t, lspShapes), location.URI)
+	assert.Equal(t, 1, location.Range.Start.Line)
+
+	lspResult(t, messages, 3, &location)
+	assert.Equal(t, lspURI(t, lspShapes), location.URI)
+	assert.Equal(t, 0, location.Range.Start.Line)
+
+	lspResult(t, messages, 4, &location)
+	assert.Equal(t, lspURI(t, lspShapes), location.URI)
+	assert.Equal(t, 6, location.Range.Start.Line)
+
+	var hover struct{ Contents struct{ Value string } }
+	lspResult(t, messages, 5, &hover)
+	assert.Equal(t, "```frugal\nstruct Drawing\n```\n\nA drawing of shapes.", hover.Contents.Value)
+
+	var locations []struct {
+		URI   string
+		Range struct{ Start, End struct{ Line, Character int } }
+	}
+	lspResult(t, messages, 6, &locations)
+	assert.Len(t, locations, 2)
+	for _, loc := range locations {
+		assert.Equal(t, lspURI(t, lspMain), loc.URI)
+	}
+	assert.Equal(t, 4, locations[0].Range.Start.Line)
+	assert.Equal(t, 12, locations[0].Range.Start.Character)
+	assert.Equal(t, 24, locations[0].Range.End.Character)
+	assert.Equal(t, 14, locations[1].Range.Start.Line)
+	assert.Equal(t, 21, locations[1].Range.Start.Character)
+
+	lspResult(t, messages, 7, &locations)
+	assert.Len(t, locations, 2)
+	assert.Equal(t, 7, locations[0].Range.Start.Character)
+	assert.Equal(t, 33, locations[1].Range.Start.Character)
+	assert.Equal(t, 45, locations[1].Range.End.Character)
+}
+
+func TestLSPCompletion(t *testing.T) {
+	messages := runLSP(t,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		lspRequest(t, 2, "textDocument/completion", lspMain, 6, 7),
+		lspRequest(t, 3, "textDocument/completion", lspMain, 14, 43),
+	)
+
+	labels := func(id int) []string {
+		var items []struct{ Label string }
+		lspResult(t, messages, id, &items)
+		labels := []string{}
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+		return labels
+	}
+	types := labels(2)
+	assert.Contains(t, types, "i32")
+	assert.Contains(t, types, "Canvas")
+	assert.Contains(t, types, "shapes.Shape")
+	assert.Contains(t, types, "shapes.Color")
+	assert.NotContains(t, types, "deprecated")
+	assert.Equal(t, []string{"vendor", "deprecated", "audit.ignore"}, labels(3))
+}
+
+// Ensures positions are converted between the UTF-16 characters used by LSP
+// and the text, and that positions outside a line or names which can't be
+// resolved don't cause errors.
+func TestLSPPositions(t *testing.T) {
+	text := "include \"shapes.frugal\"\n\nstruct Card {\n" +
+		"    1: /* \U0001F600é */ shapes.Color color = shapes.Color.WHITE,\n}\n\n// See nope.Size.BIG and Card.\n"
+	messages := runLSP(t,
+		map[string]interface{}{"id": 1, "method": "initialize", "params": map[string]interface{}{}},
+		lspOpen(t, lspMain, text),
+		lspRequest(t, 2, "textDocument/definition", lspMain, 3, 25),
+		lspRequest(t, 3, "textDocument/references", lspMain, 3, 25),
+		lspRequest(t, 4, "textDocument/hover", lspMain, 3, 52),
+		lspRequest(t, 5, "textDocument/hover", lspMain, 6, 9),
+		lspRequest(t, 6, "textDocument/hover", lspMain, 6, -3),
+		lspRequest(t, 7, "textDocument/hover", lspMain, 6, 1000),
+		lspRequest(t, 8, "textDocument/completion", lspMain, 3, -1),
+		lspRequest(t, 9, "textDocument/completion", lspMain, 3, 1000),
+	)
+	for _, msg := range messages {
+		assert.Nil(t, msg.Error)
+	}
+
+	type position struct{ Line, Character int }
+	var location struct {
+		URI   string
+		Range struct{ Start position }
+	}
+	lspResult(t, messages, 2, &location)
+	assert.Equal(t, lspURI(t, lspShapes), location.URI)
+	assert.Equal(t, position{5, 0}, location.Range.Start)
+
+	var locations []struct {
+		Range struct{ Start, End position }
+	}
+	lspResult(t, messages, 3, &locations)
+	assert.Len(t, locations, 2)
+	assert.Equal(t, position{3, 17}, locations[0].Range.Start)
+	assert.Equal(t, position{3, 29}, locations[0].Range.End)
+	assert.Equal(t, position{3, 38}, locations[1].Range.Start)
+	assert.Equal(t, position{3, 50}, locations[1].Range.End)
+
+	var hover struct {
+		Contents struct{ Value string }
+		Range    struct{ Start, End position }
+	}
+	lspResult(t, messages, 4, &hover)
+	assert.Equal(t, "```frugal\nColor.WHITE = 1\n```", hover.Contents.Value)
+	assert.Equal(t, position{3, 38}, hover.Range.Start)
+	assert.Equal(t, position{3, 56}, hover.Range.End)
+
+	for _, id := range []int{5, 6} {
+		var result interface{}
+		lspResult(t, messages, id, &result)
+		assert.Nil(t, result)
+	}
+	hover.Contents.Value = ""
+	lspResult(t, messages, 7, &hover)
+	assert.Equal(t, "```frugal\nstruct Card\n```", hover.Contents.Value)
+
+	for _, id := range []int{8, 9} {
+		var items []struct{ Label string }
+		lspResult(t, messages, id, &items)
+		assert.NotEmpty(t, items)
+	}
+}
@@ -186,3 +186,25 @@ func TestPythonAbsoluteOutputPath(t *testing.T) {
 	copyAllFiles(t, files)
 	compareAllFiles(t, files)
 }
+
+// Ensures to_json_value and from_json_value methods are generated with the
+// canonical_json option.
+func TestValidPythonCanonicalJSON(t *testing.T) {
+	options := compiler.Options{
+		File:  "idl/canonical_json.frugal",
+		Gen:   "py:canonical_json",
+		Out:   outputDir + "/python.canonical_json",
+		Delim: delim,
+	}
+	if err := compiler.Compile(options); err != nil {
+		t.Fatal("unexpected error", err)
+	}
+
+	files := []FileComparisonPair{
+		{"expected/python/canonical_json/ttypes.py", filepath.Join(outputDir, "python.canonical_json", "canonical_json", "ttypes.py")},
+	}
+
+	copyAllFiles(t, files)
+	compareAllFiles(t, files)
+	runPythonTest(t, filepath.Join(outputDir, "python.canonical_json"), "runtime/python/canonical_json_test.py")
+}
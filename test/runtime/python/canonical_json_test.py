@@ -0,0 +1,70 @@
+import unittest
+
+from frugal.util import canonical_json
+
+from canonical_json.ttypes import Drawing, Kind, Point, Shape
+
+EXPECTED_JSON = (
+    u'{"counts":{"SMALL":["1","-2"]},"depth":300,"flags":{"true":0.1},"id":"9007199254740993",'
+    u'"kind":7,"labels":[{"key":{"x":0,"y":1},"value":"up"},{"key":{"x":2,"y":0},"value":"right"}],"layer":-1,'
+    u'"name":"sketch","names":{"10":"ten","2":"two"},"note":"\\u003cb\\u003e\\u0026","origin":{"x":0,"y":0},'
+    u'"scale":"Infinity","shapes":[{"radius":2.5},{"point":{"x":1,"y":2}},{}],"size":"LARGE",'
+    u'"tags":["a","b"],"thumbnail":"AAEC","version":"4","visible":true}'
+)
+
+
+def new_test_drawing():
+    return Drawing(
+        id=9007199254740993,
+        name=u'sketch',
+        note=u'<b>&',
+        visible=True,
+        layer=-1,
+        depth=300,
+        scale=float('inf'),
+        thumbnail=b'\x00\x01\x02',
+        kind=7,
+        size=Kind.LARGE,
+        shapes=[Shape(radius=2.5), Shape(point=Point(x=1, y=2)), Shape()],
+        tags={u'b', u'a'},
+        counts={Kind.SMALL: [1, -2]},
+        names={10: u'ten', 2: u'two'},
+        labels={Point(x=2, y=0): u'right', Point(x=0, y=1): u'up'},
+        flags={True: 0.1},
+        origin=Point(x=0, y=0),
+        version=4,
+    )
+
+
+class TestCanonicalJSON(unittest.TestCase):
+
+    def test_round_trip(self):
+        data = canonical_json.dumps(new_test_drawing())
+        self.assertEqual(EXPECTED_JSON, data)
+
+        decoded = canonical_json.loads(Drawing(), data)
+        self.assertEqual(EXPECTED_JSON, canonical_json.dumps(decoded))
+
+    def test_decode_lenient(self):
+        decoded = canonical_json.loads(Drawing(), (
+            u'{"id":12,"depth":"3","kind":"LARGE","scale":"1.5",'
+            u'"note":null,"other":1}'))
+        self.assertEqual(12, decoded.id)
+        self.assertEqual(3, decoded.depth)
+        self.assertEqual(Kind.LARGE, decoded.kind)
+        self.assertEqual(1.5, decoded.scale)
+        self.assertIsNone(decoded.note)
+
+    def test_decode_errors(self):
+        for data in [
+            u'{}',
+            u'{"id":"1","layer":300}',
+            u'{"id":"1","kind":"MEDIUM"}',
+            u'{"id":"1","shapes":[{"radius":1,"point":{}}]}',
+        ]:
+            with self.assertRaises(ValueError):
+                canonical_json.loads(Drawing(), data)
+
+
+if __name__ == '__main__':
+    unittest.main()